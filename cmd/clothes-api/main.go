@@ -2,20 +2,19 @@ package main
 
 import (
 	"clothes_management/internal/api"
+	"clothes_management/internal/domain"
 	"clothes_management/internal/repository"
 	"context"
-	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"time"
 
-	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
-	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
-	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/gorilla/mux"
 	"github.com/joho/godotenv"
 )
@@ -30,29 +29,38 @@ func main() {
 	if awsRegion == "" {
 		log.Fatal("ERROR: AWS_REGION environment variable not set. Please set it in .env or your shell.")
 	}
-	dynamoTableName := os.Getenv("DYNAMODB_TABLE_NAME")
-	if dynamoTableName == "" {
-		log.Fatal("ERROR: DYNAMODB_TABLE_NAME environment variable not set. Please set it in .env or your shell.")
-	}
-
 	cfg, err := config.LoadDefaultConfig(context.TODO(), config.WithRegion(awsRegion))
 	if err != nil {
 		log.Fatalf("ERROR: Failed to load AWS SDK config: %v", err)
 	}
-	dynamoClient := dynamodb.NewFromConfig(cfg)
 
-	_, err = dynamoClient.DescribeTable(context.TODO(), &dynamodb.DescribeTableInput{
-		TableName: aws.String(dynamoTableName),
-	})
-	if err != nil {
-		var notFoundEx *types.ResourceNotFoundException
-		if errors.As(err, &notFoundEx) {
-			log.Fatalf("ERROR: DynamoDB table '%s' not found in region '%s'. Please create it. Error: %v", dynamoTableName, awsRegion, err)
-		} else {
-			log.Fatalf("ERROR: Failed to describe DynamoDB table '%s'. Check region, credentials, and permissions: %v", dynamoTableName, err)
+	// STORAGE_BACKEND picks where clothing, the catalog, the history, outfits and settings are kept:
+	// dynamodb (the default), sqlite, postgres or file. The other backends need no AWS resources beyond Cognito.
+	storageBackend := os.Getenv("STORAGE_BACKEND")
+	if storageBackend == "" {
+		storageBackend = "dynamodb"
+	}
+
+	var stores repositories
+
+	switch storageBackend {
+	case "dynamodb":
+		stores = newDynamoDBRepositories(cfg, awsRegion)
+	case "file":
+		stores = newBoltRepositories()
+	default:
+		dialect, ok := repository.ParseSQLDialect(storageBackend)
+		if !ok {
+			log.Fatalf("ERROR: STORAGE_BACKEND '%s' is not supported. Use dynamodb, sqlite, postgres or file.", storageBackend)
 		}
+		stores = newSQLRepositories(dialect)
+	}
+
+	// Every write goes through the history decorator, so each backend records changes the same way.
+	repo, err := repository.NewHistoryClothingRepository(stores.clothing, stores.history)
+	if err != nil {
+		log.Fatalf("ERROR: Failed to create instance of HistoryClothingRepository %v", err)
 	}
-	log.Printf("SUCCESS: Successfully connected to DynamoDB table '%s' in region '%s'.", dynamoTableName, awsRegion)
 
 	cognitoUserPoolId := os.Getenv("COGNITO_USER_POOL_ID")
 	if cognitoUserPoolId == "" {
@@ -71,19 +79,58 @@ func main() {
 	port := 8080
 	portStr := fmt.Sprintf(":%d", port)
 
-	repo, err := repository.NewDynamoDBClothingRepository(dynamoClient, dynamoTableName)
+	// Images go to S3 when a bucket is configured, otherwise to the local filesystem.
+	var images repository.ImageStore
+
+	if imageBucketName := os.Getenv("IMAGE_BUCKET_NAME"); imageBucketName != "" {
+		images, err = repository.NewS3ImageStore(s3.NewFromConfig(cfg), imageBucketName)
+	} else {
+		imageDirectory := os.Getenv("IMAGE_DIRECTORY")
+		if imageDirectory == "" {
+			imageDirectory = "images"
+		}
+		images, err = repository.NewFileSystemImageStore(imageDirectory)
+	}
 
 	if err != nil {
-		log.Fatalf("ERROR: Failed to create instance of DynamoDBClothingRepository %v", err)
+		log.Fatalf("ERROR: Failed to create image store %v", err)
+	}
+
+	// Statistics convert prices into the requested currency with the rates in EXCHANGE_RATES_FILE. Without
+	// it, only items already in the requested currency can be reported.
+	var rates domain.ExchangeRateProvider
+
+	if exchangeRatesFile := os.Getenv("EXCHANGE_RATES_FILE"); exchangeRatesFile != "" {
+		rates, err = repository.NewFileExchangeRates(exchangeRatesFile)
+		if err != nil {
+			log.Fatalf("ERROR: Failed to load exchange rates %v", err)
+		}
 	}
 
 	apiHandler := &api.API{
 		Repo:               repo,
+		Catalog:            stores.catalog,
+		History:            stores.history,
+		Outfits:            stores.outfits,
+		Settings:           stores.settings,
+		Rates:              rates,
+		Images:             images,
 		CognitoClient:      cognitoClient,
 		CognitoAppClientID: cognitoAppClientId,
 		CognitoUserPoolID:  cognitoUserPoolId,
 	}
 
+	// Trashed items are purged once they have been in the trash for TRASH_RETENTION_DAYS.
+	trashRetentionDays := 30
+	if raw := os.Getenv("TRASH_RETENTION_DAYS"); raw != "" {
+		trashRetentionDays, err = strconv.Atoi(raw)
+		if err != nil || trashRetentionDays <= 0 {
+			log.Fatalf("ERROR: TRASH_RETENTION_DAYS '%s' must be a positive whole number of days.", raw)
+		}
+	}
+
+	go purgeExpiredTrash(apiHandler, time.Duration(trashRetentionDays)*24*time.Hour)
+
 	authMiddleware, err := api.NewAuthMiddleware(cognitoAppClientId, cognitoUserPoolId, jwksUrl, awsRegion)
 
 	if err != nil {
@@ -102,13 +149,62 @@ func main() {
 
 	protectedRouter.HandleFunc("", apiHandler.GetClothing).Methods(http.MethodGet)
 	protectedRouter.HandleFunc("", apiHandler.CreateClothing).Methods(http.MethodPost)
+	protectedRouter.HandleFunc("", apiHandler.BatchDeleteClothing).Methods(http.MethodDelete)
+	// Registered before /{id} so "stats", "import", "batch", "trash" and "seasonal" are not treated as item ids.
+	protectedRouter.HandleFunc("/stats", apiHandler.GetClothingStats).Methods(http.MethodGet)
+	protectedRouter.HandleFunc("/import", apiHandler.ImportClothing).Methods(http.MethodPost)
+	protectedRouter.HandleFunc("/batch", apiHandler.BatchCreateClothing).Methods(http.MethodPost)
+	protectedRouter.HandleFunc("/trash", apiHandler.GetTrash).Methods(http.MethodGet)
+	protectedRouter.HandleFunc("/trash", apiHandler.EmptyTrash).Methods(http.MethodDelete)
+	protectedRouter.HandleFunc("/seasonal", apiHandler.GetSeasonalClothing).Methods(http.MethodGet)
 	protectedRouter.HandleFunc("/{id}", apiHandler.GetClothingById).Methods(http.MethodGet)
 	protectedRouter.HandleFunc("/{id}", apiHandler.UpdateClothing).Methods(http.MethodPost, http.MethodPut, http.MethodPatch)
 	protectedRouter.HandleFunc("/{id}", apiHandler.DeleteClothing).Methods(http.MethodDelete)
+	protectedRouter.HandleFunc("/{id}/restore", apiHandler.RestoreClothing).Methods(http.MethodPost)
+	protectedRouter.HandleFunc("/{id}/history", apiHandler.GetClothingHistory).Methods(http.MethodGet)
+	protectedRouter.HandleFunc("/{id}/wear", apiHandler.RecordClothingWear).Methods(http.MethodPost)
+	protectedRouter.HandleFunc("/{id}/wears", apiHandler.GetClothingWears).Methods(http.MethodGet)
+	protectedRouter.HandleFunc("/{id}/image", apiHandler.UploadClothingImage).Methods(http.MethodPost)
+	protectedRouter.HandleFunc("/{id}/image", apiHandler.GetClothingImage).Methods(http.MethodGet)
+
+	catalogRouter := router.PathPrefix("/catalog").Subrouter()
+	catalogRouter.Use(authMiddleware.Authenticate)
+
+	catalogRouter.HandleFunc("/{kind}", apiHandler.GetCatalog).Methods(http.MethodGet)
+
+	tagRouter := router.PathPrefix("/tags").Subrouter()
+	tagRouter.Use(authMiddleware.Authenticate)
+
+	tagRouter.HandleFunc("", apiHandler.GetTags).Methods(http.MethodGet)
+
+	outfitRouter := router.PathPrefix("/outfits").Subrouter()
+	outfitRouter.Use(authMiddleware.Authenticate)
+
+	outfitRouter.HandleFunc("", apiHandler.GetOutfits).Methods(http.MethodGet)
+	outfitRouter.HandleFunc("", apiHandler.CreateOutfit).Methods(http.MethodPost)
+	outfitRouter.HandleFunc("/{id}", apiHandler.GetOutfitById).Methods(http.MethodGet)
+	outfitRouter.HandleFunc("/{id}", apiHandler.UpdateOutfit).Methods(http.MethodPut)
+	outfitRouter.HandleFunc("/{id}", apiHandler.DeleteOutfit).Methods(http.MethodDelete)
+
+	settingsRouter := router.PathPrefix("/settings").Subrouter()
+	settingsRouter.Use(authMiddleware.Authenticate)
+
+	settingsRouter.HandleFunc("", apiHandler.GetSettings).Methods(http.MethodGet)
+	settingsRouter.HandleFunc("", apiHandler.UpdateSettings).Methods(http.MethodPut)
+
+	// Handlers pass the request context down to the repository, so a request that outlives
+	// requestTimeout has its DynamoDB calls cancelled rather than running on after WriteTimeout.
+	requestTimeout := 9 * time.Second
+
+	// TimeoutHandler buffers the whole response, so exports are routed around it to stream a page at a
+	// time. ExportClothing pushes its own write deadline back as each page is written instead.
+	rootRouter := mux.NewRouter()
+	rootRouter.Handle("/clothes/export", authMiddleware.Authenticate(http.HandlerFunc(apiHandler.ExportClothing))).Methods(http.MethodGet)
+	rootRouter.PathPrefix("/").Handler(http.TimeoutHandler(router, requestTimeout, "Request timed out"))
 
 	srv := &http.Server{
 		Addr:         portStr,
-		Handler:      router,
+		Handler:      rootRouter,
 		ReadTimeout:  10 * time.Second,
 		WriteTimeout: 10 * time.Second,
 		IdleTimeout:  10 * time.Second,
@@ -120,3 +216,28 @@ func main() {
 	}
 	log.Println("INFO: Server gracefully stopped.")
 }
+
+// trashPurgeInterval is how often expired trash is purged. Items may outlive the retention period by up
+// to this long.
+const trashPurgeInterval = time.Hour
+
+// purgeExpiredTrash purges expired trash on start up and then every trashPurgeInterval. A failed purge
+// is logged and retried on the next tick.
+func purgeExpiredTrash(apiHandler *api.API, retention time.Duration) {
+	ticker := time.NewTicker(trashPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), trashPurgeInterval/2)
+		purged, err := apiHandler.PurgeExpiredTrash(ctx, retention)
+		cancel()
+
+		if err != nil {
+			log.Printf("ERROR: Failed to purge expired trash: %v", err)
+		} else if purged > 0 {
+			log.Printf("INFO: Purged %d items from the trash", purged)
+		}
+
+		<-ticker.C
+	}
+}
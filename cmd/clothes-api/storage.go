@@ -0,0 +1,196 @@
+package main
+
+import (
+	"clothes_management/internal/repository"
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"go.etcd.io/bbolt"
+	_ "modernc.org/sqlite"
+)
+
+// repositories are the stores a storage backend provides, all kept in the same database.
+type repositories struct {
+	clothing repository.ClothingRepository
+	catalog  repository.CatalogRepository
+	history  repository.HistoryRepository
+	outfits  repository.OutfitRepository
+	settings repository.SettingsRepository
+}
+
+func newDynamoDBRepositories(cfg aws.Config, awsRegion string) repositories {
+	dynamoTableName := os.Getenv("DYNAMODB_TABLE_NAME")
+	if dynamoTableName == "" {
+		log.Fatal("ERROR: DYNAMODB_TABLE_NAME environment variable not set. Please set it in .env or your shell.")
+	}
+
+	dynamoClient := dynamodb.NewFromConfig(cfg)
+
+	_, err := dynamoClient.DescribeTable(context.TODO(), &dynamodb.DescribeTableInput{
+		TableName: aws.String(dynamoTableName),
+	})
+	if err != nil {
+		var notFoundEx *types.ResourceNotFoundException
+		if errors.As(err, &notFoundEx) {
+			log.Fatalf("ERROR: DynamoDB table '%s' not found in region '%s'. Please create it. Error: %v", dynamoTableName, awsRegion, err)
+		} else {
+			log.Fatalf("ERROR: Failed to describe DynamoDB table '%s'. Check region, credentials, and permissions: %v", dynamoTableName, err)
+		}
+	}
+	log.Printf("SUCCESS: Successfully connected to DynamoDB table '%s' in region '%s'.", dynamoTableName, awsRegion)
+
+	repo, err := repository.NewDynamoDBClothingRepository(dynamoClient, dynamoTableName)
+
+	if err != nil {
+		log.Fatalf("ERROR: Failed to create instance of DynamoDBClothingRepository %v", err)
+	}
+
+	catalog, err := repository.NewDynamoDBCatalogRepository(dynamoClient, dynamoTableName)
+
+	if err != nil {
+		log.Fatalf("ERROR: Failed to create instance of DynamoDBCatalogRepository %v", err)
+	}
+
+	history, err := repository.NewDynamoDBHistoryRepository(dynamoClient, dynamoTableName)
+
+	if err != nil {
+		log.Fatalf("ERROR: Failed to create instance of DynamoDBHistoryRepository %v", err)
+	}
+
+	outfits, err := repository.NewDynamoDBOutfitRepository(dynamoClient, dynamoTableName)
+
+	if err != nil {
+		log.Fatalf("ERROR: Failed to create instance of DynamoDBOutfitRepository %v", err)
+	}
+
+	settings, err := repository.NewDynamoDBSettingsRepository(dynamoClient, dynamoTableName)
+
+	if err != nil {
+		log.Fatalf("ERROR: Failed to create instance of DynamoDBSettingsRepository %v", err)
+	}
+
+	return repositories{clothing: repo, catalog: catalog, history: history, outfits: outfits, settings: settings}
+}
+
+// newSQLRepositories opens DATABASE_URL, a file path for SQLite or a connection string for PostgreSQL,
+// and applies any outstanding migrations.
+func newSQLRepositories(dialect repository.SQLDialect) repositories {
+	databaseUrl := os.Getenv("DATABASE_URL")
+
+	if databaseUrl == "" && dialect == repository.SQLiteDialect {
+		databaseUrl = "clothes.db"
+	}
+
+	if databaseUrl == "" {
+		log.Fatal("ERROR: DATABASE_URL environment variable not set. Please set it in .env or your shell.")
+	}
+
+	driverName := "pgx"
+
+	if dialect == repository.SQLiteDialect {
+		driverName = "sqlite"
+	}
+
+	db, err := sql.Open(driverName, databaseUrl)
+	if err != nil {
+		log.Fatalf("ERROR: Failed to open %s database: %v", dialect, err)
+	}
+
+	// SQLite allows a single writer, so queue requests on one connection rather than fail with SQLITE_BUSY.
+	if dialect == repository.SQLiteDialect {
+		db.SetMaxOpenConns(1)
+	}
+
+	if err := repository.MigrateSQLDatabase(context.TODO(), db, dialect); err != nil {
+		log.Fatalf("ERROR: Failed to migrate %s database: %v", dialect, err)
+	}
+	log.Printf("SUCCESS: Successfully connected to %s database.", dialect)
+
+	repo, err := repository.NewSQLClothingRepository(db, dialect)
+
+	if err != nil {
+		log.Fatalf("ERROR: Failed to create instance of SQLClothingRepository %v", err)
+	}
+
+	catalog, err := repository.NewSQLCatalogRepository(db, dialect)
+
+	if err != nil {
+		log.Fatalf("ERROR: Failed to create instance of SQLCatalogRepository %v", err)
+	}
+
+	history, err := repository.NewSQLHistoryRepository(db, dialect)
+
+	if err != nil {
+		log.Fatalf("ERROR: Failed to create instance of SQLHistoryRepository %v", err)
+	}
+
+	outfits, err := repository.NewSQLOutfitRepository(db, dialect)
+
+	if err != nil {
+		log.Fatalf("ERROR: Failed to create instance of SQLOutfitRepository %v", err)
+	}
+
+	settings, err := repository.NewSQLSettingsRepository(db, dialect)
+
+	if err != nil {
+		log.Fatalf("ERROR: Failed to create instance of SQLSettingsRepository %v", err)
+	}
+
+	return repositories{clothing: repo, catalog: catalog, history: history, outfits: outfits, settings: settings}
+}
+
+// newBoltRepositories opens the single file at DATABASE_URL (default clothes.bolt) for clothing, the
+// catalog, the history, outfits and settings. Only one process can have the file open at a time.
+func newBoltRepositories() repositories {
+	path := os.Getenv("DATABASE_URL")
+
+	if path == "" {
+		path = "clothes.bolt"
+	}
+
+	db, err := bbolt.Open(path, 0o600, &bbolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		log.Fatalf("ERROR: Failed to open data file '%s': %v", path, err)
+	}
+	log.Printf("SUCCESS: Successfully opened data file '%s'.", path)
+
+	repo, err := repository.NewBoltClothingRepository(db)
+
+	if err != nil {
+		log.Fatalf("ERROR: Failed to create instance of BoltClothingRepository %v", err)
+	}
+
+	catalog, err := repository.NewBoltCatalogRepository(db)
+
+	if err != nil {
+		log.Fatalf("ERROR: Failed to create instance of BoltCatalogRepository %v", err)
+	}
+
+	history, err := repository.NewBoltHistoryRepository(db)
+
+	if err != nil {
+		log.Fatalf("ERROR: Failed to create instance of BoltHistoryRepository %v", err)
+	}
+
+	outfits, err := repository.NewBoltOutfitRepository(db)
+
+	if err != nil {
+		log.Fatalf("ERROR: Failed to create instance of BoltOutfitRepository %v", err)
+	}
+
+	settings, err := repository.NewBoltSettingsRepository(db)
+
+	if err != nil {
+		log.Fatalf("ERROR: Failed to create instance of BoltSettingsRepository %v", err)
+	}
+
+	return repositories{clothing: repo, catalog: catalog, history: history, outfits: outfits, settings: settings}
+}
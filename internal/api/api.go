@@ -1,6 +1,7 @@
 package api
 
 import (
+	"clothes_management/internal/domain"
 	"clothes_management/internal/repository"
 	"context"
 
@@ -14,6 +15,12 @@ type CognitoAPI interface {
 
 type API struct {
 	Repo               repository.ClothingRepository
+	Catalog            repository.CatalogRepository
+	History            repository.HistoryRepository
+	Outfits            repository.OutfitRepository
+	Settings           repository.SettingsRepository
+	Rates              domain.ExchangeRateProvider
+	Images             repository.ImageStore
 	CognitoClient      CognitoAPI
 	CognitoAppClientID string
 	CognitoUserPoolID  string
@@ -0,0 +1,254 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// MaxBatchItems is the most items BatchCreateClothing creates, or ids BatchDeleteClothing trashes, in
+// one request.
+const MaxBatchItems = 100
+
+// BatchDeleteRequest is the body of BatchDeleteClothing.
+type BatchDeleteRequest struct {
+	Ids []string `json:"ids"`
+}
+
+// BatchDeleteResult reports whether one of the requested ids was moved to the trash.
+type BatchDeleteResult struct {
+	Id      string `json:"id"`
+	Deleted bool   `json:"deleted"`
+	Error   string `json:"error,omitempty"`
+}
+
+type BatchDeleteReport struct {
+	Deleted int                 `json:"deleted"`
+	Failed  int                 `json:"failed"`
+	Results []BatchDeleteResult `json:"results"`
+}
+
+// BatchCreateClothing creates an item for each object in a JSON array, reporting each one as
+// ImportClothing does, including its 'allOrNothing' parameter.
+func (a *API) BatchCreateClothing(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, fmt.Sprintf("Unauthorised method %s.", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	userId, ok := r.Context().Value(UserIDContextKey).(string)
+
+	if !ok {
+		http.Error(w, "UserID not provided", http.StatusUnauthorized)
+		return
+	}
+
+	if strings.TrimSpace(userId) == "" {
+		http.Error(w, "UserID not provided", http.StatusUnauthorized)
+		return
+	}
+
+	allOrNothing, err := parseAllOrNothing(r.URL.Query())
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if r.Body == nil || r.Body == http.NoBody {
+		http.Error(w, "Request body must not be empty or missing", http.StatusBadRequest)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxImportBytes))
+
+	var maxBytesErr *http.MaxBytesError
+
+	if errors.As(err, &maxBytesErr) {
+		http.Error(w, fmt.Sprintf("Batch must not be larger than %d bytes", MaxImportBytes), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	rows, err := parseJSONImport(body)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if len(rows) == 0 {
+		http.Error(w, "Batch must contain at least one item", http.StatusBadRequest)
+		return
+	}
+
+	if len(rows) > MaxBatchItems {
+		http.Error(w, fmt.Sprintf("Batch must not contain more than %d items", MaxBatchItems), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	a.createRows(w, r, userId, rows, allOrNothing)
+}
+
+// BatchDeleteClothing moves each of the ids in the body to the trash with a single TrashMany, reporting
+// which were trashed and which were not found or are part of an outfit. As in DeleteClothing, items an
+// outfit took up while they were being trashed are restored. Unlike DeleteClothing it takes no If-Match,
+// as there is no single version to check.
+func (a *API) BatchDeleteClothing(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, fmt.Sprintf("Unauthorised method %s.", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	userId, ok := r.Context().Value(UserIDContextKey).(string)
+
+	if !ok {
+		http.Error(w, "UserID not provided", http.StatusUnauthorized)
+		return
+	}
+
+	if strings.TrimSpace(userId) == "" {
+		http.Error(w, "UserID not provided", http.StatusUnauthorized)
+		return
+	}
+
+	if r.Body == nil || r.Body == http.NoBody {
+		http.Error(w, "Request body must not be empty or missing", http.StatusBadRequest)
+		return
+	}
+
+	bodyBytes, err := io.ReadAll(r.Body)
+
+	if err != nil {
+		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	var req BatchDeleteRequest
+
+	dec := json.NewDecoder(bytes.NewReader(bodyBytes))
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(&req); err != nil {
+		http.Error(w, "Request body must be JSON with an 'ids' array", http.StatusBadRequest)
+		return
+	}
+
+	// Report each id once, in the order it was first given.
+	var ids []string
+	seen := map[string]bool{}
+
+	for _, id := range req.Ids {
+		id = strings.TrimSpace(id)
+
+		if id == "" {
+			http.Error(w, "'ids' must not contain an empty id", http.StatusBadRequest)
+			return
+		}
+
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	if len(ids) == 0 {
+		http.Error(w, "'ids' must contain at least one id", http.StatusBadRequest)
+		return
+	}
+
+	if len(ids) > MaxBatchItems {
+		http.Error(w, fmt.Sprintf("'ids' must not contain more than %d ids", MaxBatchItems), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	// Outfits are read once for the whole batch rather than asked about each id.
+	outfits, err := a.userOutfits(r.Context(), userId)
+
+	if err != nil {
+		writeRepositoryError(w, err, "", "Error deleting clothing items")
+		return
+	}
+
+	report := BatchDeleteReport{Results: make([]BatchDeleteResult, len(ids))}
+	unused := make([]string, 0, len(ids))
+	inUse := 0
+
+	for i, id := range ids {
+		report.Results[i] = BatchDeleteResult{Id: id}
+
+		if names := outfitNames(outfits, id); len(names) > 0 {
+			report.Results[i].Error = inUseMessage(id, names)
+			report.Failed++
+			inUse++
+			continue
+		}
+
+		unused = append(unused, id)
+	}
+
+	trashed := map[string]bool{}
+
+	if len(unused) > 0 {
+		items, err := a.Repo.TrashMany(r.Context(), userId, unused)
+
+		if err != nil {
+			writeRepositoryError(w, err, "", "Error deleting clothing items")
+			return
+		}
+
+		restored, err := a.restoreUsed(r.Context(), userId, items)
+
+		if err != nil {
+			writeRepositoryError(w, err, "", "Error deleting clothing items")
+			return
+		}
+
+		for _, item := range items {
+			if names, used := restored[item.Id]; used {
+				i := slices.IndexFunc(report.Results, func(result BatchDeleteResult) bool { return result.Id == item.Id })
+				report.Results[i].Error = inUseMessage(item.Id, names)
+				report.Failed++
+				inUse++
+				continue
+			}
+
+			trashed[item.Id] = true
+		}
+	}
+
+	for i, result := range report.Results {
+		switch {
+		case result.Error != "":
+		case trashed[result.Id]:
+			report.Results[i].Deleted = true
+			report.Deleted++
+		default:
+			report.Results[i].Error = fmt.Sprintf("Clothing item not found for ID %s", result.Id)
+			report.Failed++
+		}
+	}
+
+	status := http.StatusOK
+
+	if report.Deleted == 0 && inUse > 0 {
+		status = http.StatusConflict
+	} else if report.Deleted == 0 {
+		status = http.StatusNotFound
+	}
+
+	resp := map[string]any{"success": report.Deleted > 0, "data": report}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,302 @@
+package api
+
+import (
+	"clothes_management/internal/domain"
+	"clothes_management/internal/repository"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// countingOutfitRepo counts the reads BatchDeleteClothing makes of the user's outfits.
+type countingOutfitRepo struct {
+	repository.OutfitRepository
+	reads int
+}
+
+func (c *countingOutfitRepo) GetAll(ctx context.Context, userId string) ([]domain.Outfit, error) {
+	c.reads++
+	return c.OutfitRepository.GetAll(ctx, userId)
+}
+
+func (c *countingOutfitRepo) ListUsing(ctx context.Context, userId, itemId string) ([]domain.Outfit, error) {
+	c.reads++
+	return c.OutfitRepository.ListUsing(ctx, userId, itemId)
+}
+
+func newBatchDeleteRequest(body string) *http.Request {
+	ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+	return httptest.NewRequestWithContext(ctx, http.MethodDelete, "/clothes", strings.NewReader(body))
+}
+
+func decodeBatchDeleteReport(t *testing.T, resp *http.Response) (bool, BatchDeleteReport) {
+	t.Helper()
+
+	var body struct {
+		Success bool              `json:"success"`
+		Data    BatchDeleteReport `json:"data"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	return body.Success, body.Data
+}
+
+func TestBatchCreateClothing(t *testing.T) {
+	validJSON := `{"clothingType": "Jumper", "description": "This Jumper", "brand": "XYZ", "store": "This Store", "size": "L", "pricePence": 2000}`
+
+	t.Run("Given request method is not allowed, should return error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := newImportRequest(t, "/clothes/batch", "application/json", "["+validJSON+"]")
+		r.Method = http.MethodPut
+
+		apiHandler := &API{Repo: &DummyClothingRepo{}}
+		apiHandler.BatchCreateClothing(w, r)
+
+		if w.Result().StatusCode != http.StatusMethodNotAllowed {
+			t.Errorf("Expected %d got %d", http.StatusMethodNotAllowed, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given no UserID in context, should return 401", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/clothes/batch", strings.NewReader("["+validJSON+"]"))
+
+		apiHandler := &API{Repo: &DummyClothingRepo{}}
+		apiHandler.BatchCreateClothing(w, r)
+
+		if w.Result().StatusCode != http.StatusUnauthorized {
+			t.Errorf("Expected %d got %d", http.StatusUnauthorized, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given items with one invalid, should create the others and report each item", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := newImportRequest(t, "/clothes/batch", "application/json", "["+validJSON+`, {"description": "No type"}, `+validJSON+"]")
+
+		repo := &DummyClothingRepo{}
+		apiHandler := &API{Repo: repo}
+		apiHandler.BatchCreateClothing(w, r)
+
+		resp := w.Result()
+
+		if resp.StatusCode != http.StatusCreated {
+			t.Fatalf("Expected %d got %d", http.StatusCreated, resp.StatusCode)
+		}
+
+		success, report := decodeImportReport(t, resp)
+
+		if !success || report.Created != 2 || report.Failed != 1 || report.Rows[1].Error == "" || report.Rows[2].Id != "dummy-id-1" {
+			t.Errorf("Expected 2 created and item 2 reported, got %v and %+v", success, report)
+		}
+
+		if len(repo.SaveManyItems) != 2 {
+			t.Errorf("Expected a single SaveMany of 2 items, got %+v", repo.SaveManyItems)
+		}
+	})
+
+	t.Run("Given a body that is not a JSON array or has no items, should return 400", func(t *testing.T) {
+		for _, body := range []string{validJSON, "[]"} {
+			w := httptest.NewRecorder()
+			r := newImportRequest(t, "/clothes/batch", "application/json", body)
+
+			apiHandler := &API{Repo: &DummyClothingRepo{}}
+			apiHandler.BatchCreateClothing(w, r)
+
+			if w.Result().StatusCode != http.StatusBadRequest {
+				t.Errorf("Body %q: Expected %d got %d", body, http.StatusBadRequest, w.Result().StatusCode)
+			}
+		}
+	})
+
+	t.Run("Given more than MaxBatchItems items, should return 413", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := "[" + strings.Repeat(validJSON+",", MaxBatchItems) + validJSON + "]"
+		r := newImportRequest(t, "/clothes/batch", "application/json", body)
+
+		apiHandler := &API{Repo: &DummyClothingRepo{}}
+		apiHandler.BatchCreateClothing(w, r)
+
+		if w.Result().StatusCode != http.StatusRequestEntityTooLarge {
+			t.Errorf("Expected %d got %d", http.StatusRequestEntityTooLarge, w.Result().StatusCode)
+		}
+	})
+}
+
+func TestBatchDeleteClothing(t *testing.T) {
+	t.Run("Given request method is not allowed, should return error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := newBatchDeleteRequest(`{"ids": ["1"]}`)
+		r.Method = http.MethodPost
+
+		apiHandler := &API{Repo: &DummyClothingRepo{}}
+		apiHandler.BatchDeleteClothing(w, r)
+
+		if w.Result().StatusCode != http.StatusMethodNotAllowed {
+			t.Errorf("Expected %d got %d", http.StatusMethodNotAllowed, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given no UserID in context, should return 401", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodDelete, "/clothes", strings.NewReader(`{"ids": ["1"]}`))
+
+		apiHandler := &API{Repo: &DummyClothingRepo{}}
+		apiHandler.BatchDeleteClothing(w, r)
+
+		if w.Result().StatusCode != http.StatusUnauthorized {
+			t.Errorf("Expected %d got %d", http.StatusUnauthorized, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given found, missing and repeated ids, should trash each id once and keep their images", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := newBatchDeleteRequest(`{"ids": ["1", " 2 ", "1", "3"]}`)
+
+		repo := &DummyClothingRepo{AllItems: []domain.Clothing{{Id: "1"}, {Id: "3"}}}
+		images := &DummyImageStore{}
+		apiHandler := &API{Repo: repo, Images: images}
+		apiHandler.BatchDeleteClothing(w, r)
+
+		resp := w.Result()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Expected %d got %d", http.StatusOK, resp.StatusCode)
+		}
+
+		if !slices.Equal(repo.TrashedIds, []string{"1", "2", "3"}) || repo.TrashManyCalls != 1 {
+			t.Errorf("Expected one TrashMany of 1, 2 and 3, got %v in %d calls", repo.TrashedIds, repo.TrashManyCalls)
+		}
+
+		success, report := decodeBatchDeleteReport(t, resp)
+
+		if !success || report.Deleted != 2 || report.Failed != 1 || len(report.Results) != 3 {
+			t.Fatalf("Expected 2 deleted and 1 failed, got %v and %+v", success, report)
+		}
+
+		if !report.Results[0].Deleted || report.Results[1].Deleted || report.Results[1].Error == "" || !report.Results[2].Deleted {
+			t.Errorf("Expected 1 and 3 deleted and 2 not found, got %+v", report.Results)
+		}
+
+		if images.DeletedID != "" {
+			t.Errorf("Expected no image to be deleted, got %q", images.DeletedID)
+		}
+	})
+
+	t.Run("Given no id was found, should return 404 with the report", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		apiHandler := &API{Repo: &DummyClothingRepo{}}
+		apiHandler.BatchDeleteClothing(w, newBatchDeleteRequest(`{"ids": ["1"]}`))
+
+		resp := w.Result()
+
+		if resp.StatusCode != http.StatusNotFound {
+			t.Fatalf("Expected %d got %d", http.StatusNotFound, resp.StatusCode)
+		}
+
+		if success, report := decodeBatchDeleteReport(t, resp); success || report.Failed != 1 {
+			t.Errorf("Expected 1 failure, got %v and %+v", success, report)
+		}
+	})
+
+	t.Run("Given an id used by an outfit, should read the outfits once either side of trashing, not trash it and report the outfit", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		outfits := &countingOutfitRepo{OutfitRepository: repository.NewInMemoryOutfitRepository()}
+		outfits.Save(context.Background(), "test-user-id", domain.Outfit{Name: "Office", ItemIds: []string{"1"}})
+
+		repo := &DummyClothingRepo{AllItems: []domain.Clothing{{Id: "1"}, {Id: "2"}, {Id: "3"}}}
+		apiHandler := &API{Repo: repo, Outfits: outfits}
+		apiHandler.BatchDeleteClothing(w, newBatchDeleteRequest(`{"ids": ["1", "2", "3"]}`))
+
+		resp := w.Result()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Expected %d got %d", http.StatusOK, resp.StatusCode)
+		}
+
+		if !slices.Equal(repo.TrashedIds, []string{"2", "3"}) {
+			t.Errorf("Expected only 2 and 3 to be trashed, got %v", repo.TrashedIds)
+		}
+
+		if outfits.reads != 2 {
+			t.Errorf("Expected the outfits to be read before and after trashing, got %d reads", outfits.reads)
+		}
+
+		_, report := decodeBatchDeleteReport(t, resp)
+
+		if report.Deleted != 2 || report.Results[0].Deleted || !strings.Contains(report.Results[0].Error, "Office") {
+			t.Errorf("Expected 1 to be reported as used by Office, got %+v", report)
+		}
+	})
+
+	t.Run("Given only ids used by outfits, should return 409 with the report", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		outfits := repository.NewInMemoryOutfitRepository()
+		outfits.Save(context.Background(), "test-user-id", domain.Outfit{Name: "Office", ItemIds: []string{"1"}})
+
+		apiHandler := &API{Repo: &DummyClothingRepo{ShouldExist: true}, Outfits: outfits}
+		apiHandler.BatchDeleteClothing(w, newBatchDeleteRequest(`{"ids": ["1"]}`))
+
+		if w.Result().StatusCode != http.StatusConflict {
+			t.Errorf("Expected %d got %d", http.StatusConflict, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given an invalid body, should return 400", func(t *testing.T) {
+		for _, body := range []string{`["1"]`, `{"ids": []}`, `{"ids": ["1", " "]}`, `{"ids": ["1"], "force": true}`} {
+			w := httptest.NewRecorder()
+
+			repo := &DummyClothingRepo{}
+			apiHandler := &API{Repo: repo}
+			apiHandler.BatchDeleteClothing(w, newBatchDeleteRequest(body))
+
+			if w.Result().StatusCode != http.StatusBadRequest {
+				t.Errorf("Body %s: Expected %d got %d", body, http.StatusBadRequest, w.Result().StatusCode)
+			}
+
+			if repo.TrashedIds != nil {
+				t.Errorf("Body %s: Expected Trash not to be called", body)
+			}
+		}
+	})
+
+	t.Run("Given more than MaxBatchItems ids, should return 413", func(t *testing.T) {
+		ids := make([]string, MaxBatchItems+1)
+
+		for i := range ids {
+			ids[i] = fmt.Sprintf("id-%d", i)
+		}
+
+		body, _ := json.Marshal(BatchDeleteRequest{Ids: ids})
+		w := httptest.NewRecorder()
+
+		apiHandler := &API{Repo: &DummyClothingRepo{}}
+		apiHandler.BatchDeleteClothing(w, newBatchDeleteRequest(string(body)))
+
+		if w.Result().StatusCode != http.StatusRequestEntityTooLarge {
+			t.Errorf("Expected %d got %d", http.StatusRequestEntityTooLarge, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given the repository fails, should return 500", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		apiHandler := &API{Repo: &DummyClothingRepo{TrashError: errors.New("boom")}}
+		apiHandler.BatchDeleteClothing(w, newBatchDeleteRequest(`{"ids": ["1"]}`))
+
+		if w.Result().StatusCode != http.StatusInternalServerError {
+			t.Errorf("Expected %d got %d", http.StatusInternalServerError, w.Result().StatusCode)
+		}
+	})
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"clothes_management/internal/domain"
+	"clothes_management/internal/repository"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// GetCatalog lists one of the global catalogs named by the 'kind' path parameter: types, brands or stores.
+// Without a catalog configured nothing has been registered, so every catalog is empty.
+func (a *API) GetCatalog(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, fmt.Sprintf("Unauthorised method %s.", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	kind, ok := repository.ParseCatalogKind(mux.Vars(r)["kind"])
+
+	if !ok {
+		http.Error(w, fmt.Sprintf("Catalog %s not found", mux.Vars(r)["kind"]), http.StatusNotFound)
+		return
+	}
+
+	values := []string{}
+
+	if a.Catalog != nil {
+		var err error
+		values, err = a.Catalog.List(r.Context(), kind)
+
+		if err != nil {
+			log.Print(err)
+			http.Error(w, fmt.Sprintf("Unable to get catalog %s", kind), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	resp := map[string]any{"success": true, "data": values}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(resp)
+}
+
+// registerCatalogValues adds each item's type, brand and store to the global catalogs, registering a
+// value repeated across items only once. The items have already been stored, so a failure here is
+// logged rather than failing the request.
+func (a *API) registerCatalogValues(ctx context.Context, clothing ...domain.Clothing) {
+	if a.Catalog == nil {
+		return
+	}
+
+	type entry struct {
+		kind  repository.CatalogKind
+		value string
+	}
+
+	registered := map[entry]bool{}
+
+	for _, item := range clothing {
+		for _, e := range []entry{
+			{repository.CatalogTypes, item.ClothingType},
+			{repository.CatalogBrands, item.Brand},
+			{repository.CatalogStores, item.Store},
+		} {
+			if registered[e] {
+				continue
+			}
+
+			registered[e] = true
+
+			if err := a.Catalog.Register(ctx, e.kind, e.value); err != nil {
+				log.Printf("WARN: Failed to register %q in catalog %s: %v", e.value, e.kind, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package api
+
+import (
+	"bytes"
+	"clothes_management/internal/repository"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+type DummyCatalogRepo struct {
+	RegisterError error
+	ListError     error
+}
+
+func (d *DummyCatalogRepo) Register(ctx context.Context, kind repository.CatalogKind, value string) error {
+	return d.RegisterError
+}
+
+func (d *DummyCatalogRepo) List(ctx context.Context, kind repository.CatalogKind) ([]string, error) {
+	return nil, d.ListError
+}
+
+func TestGetCatalog(t *testing.T) {
+	t.Run("Given registered values, should return them for the requested catalog", func(t *testing.T) {
+		catalog := repository.NewInMemoryCatalogRepository()
+		catalog.Register(context.Background(), repository.CatalogBrands, "XYZ")
+		catalog.Register(context.Background(), repository.CatalogBrands, "Abc")
+		catalog.Register(context.Background(), repository.CatalogStores, "This Store")
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/catalog/brands", nil)
+		r = mux.SetURLVars(r, map[string]string{"kind": "brands"})
+
+		apiHandler := &API{
+			Catalog: catalog,
+		}
+		apiHandler.GetCatalog(w, r)
+
+		resp := w.Result()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Expected %d got %d", http.StatusOK, resp.StatusCode)
+		}
+
+		var body struct {
+			Success bool     `json:"success"`
+			Data    []string `json:"data"`
+		}
+
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+
+		if !body.Success || !slices.Equal(body.Data, []string{"Abc", "XYZ"}) {
+			t.Errorf("Expected success with [Abc XYZ], got %+v", body)
+		}
+	})
+
+	t.Run("Given an unknown catalog, should return 404", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/catalog/colours", nil)
+		r = mux.SetURLVars(r, map[string]string{"kind": "colours"})
+
+		apiHandler := &API{
+			Catalog: repository.NewInMemoryCatalogRepository(),
+		}
+		apiHandler.GetCatalog(w, r)
+
+		if w.Result().StatusCode != http.StatusNotFound {
+			t.Errorf("Expected %d got %d", http.StatusNotFound, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given no catalog is configured, should return an empty list", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/catalog/brands", nil)
+		r = mux.SetURLVars(r, map[string]string{"kind": "brands"})
+
+		apiHandler := &API{}
+		apiHandler.GetCatalog(w, r)
+
+		if w.Result().StatusCode != http.StatusOK {
+			t.Fatalf("Expected %d got %d", http.StatusOK, w.Result().StatusCode)
+		}
+
+		if expected := `{"data":[],"success":true}`; strings.TrimSpace(w.Body.String()) != expected {
+			t.Errorf("Expected %s, got %s", expected, w.Body.String())
+		}
+	})
+
+	t.Run("Given the repository fails, should return 500", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/catalog/types", nil)
+		r = mux.SetURLVars(r, map[string]string{"kind": "types"})
+
+		apiHandler := &API{
+			Catalog: &DummyCatalogRepo{ListError: errors.New("boom")},
+		}
+		apiHandler.GetCatalog(w, r)
+
+		if w.Result().StatusCode != http.StatusInternalServerError {
+			t.Errorf("Expected %d got %d", http.StatusInternalServerError, w.Result().StatusCode)
+		}
+	})
+}
+
+func TestCatalogRegistration(t *testing.T) {
+	t.Run("Given a created item, should register its type, brand and store", func(t *testing.T) {
+		body, err := json.Marshal(map[string]any{
+			"pricePence":   2000,
+			"clothingType": "Jumper",
+			"description":  "Red loosefit jumper",
+			"brand":        "A&B",
+			"store":        "Totlly Real Store",
+			"size":         "Medium",
+		})
+		if err != nil {
+			t.Fatalf("failed to marshal json: %v", err)
+		}
+
+		w := httptest.NewRecorder()
+		ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+		r := httptest.NewRequestWithContext(ctx, http.MethodPost, "/clothes", bytes.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+
+		catalog := repository.NewInMemoryCatalogRepository()
+		apiHandler := &API{
+			Repo:    &DummyClothingRepo{},
+			Catalog: catalog,
+		}
+		apiHandler.CreateClothing(w, r)
+
+		if w.Result().StatusCode != http.StatusCreated {
+			t.Fatalf("Expected %d got %d", http.StatusCreated, w.Result().StatusCode)
+		}
+
+		expected := map[repository.CatalogKind]string{
+			repository.CatalogTypes:  "Jumper",
+			repository.CatalogBrands: "A&B",
+			repository.CatalogStores: "Totlly Real Store",
+		}
+
+		for kind, value := range expected {
+			values, _ := catalog.List(context.Background(), kind)
+
+			if !slices.Equal(values, []string{value}) {
+				t.Errorf("Expected catalog %s to be [%s], got %v", kind, value, values)
+			}
+		}
+	})
+
+	t.Run("Given registration fails, should still report the update as successful", func(t *testing.T) {
+		body, err := json.Marshal(map[string]any{
+			"pricePence":   2000,
+			"clothingType": "Jumper",
+			"description":  "Red loosefit jumper",
+			"brand":        "A&B",
+			"store":        "Totlly Real Store",
+			"size":         "Medium",
+		})
+		if err != nil {
+			t.Fatalf("failed to marshal json: %v", err)
+		}
+
+		w := httptest.NewRecorder()
+		ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+		r := httptest.NewRequestWithContext(ctx, http.MethodPut, "/clothes/test-id", bytes.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		r = mux.SetURLVars(r, map[string]string{"id": "test-id"})
+
+		apiHandler := &API{
+			Repo:    &DummyClothingRepo{ShouldExist: true},
+			Catalog: &DummyCatalogRepo{RegisterError: errors.New("boom")},
+		}
+		apiHandler.UpdateClothing(w, r)
+
+		if w.Result().StatusCode != http.StatusOK {
+			t.Errorf("Expected %d got %d", http.StatusOK, w.Result().StatusCode)
+		}
+	})
+}
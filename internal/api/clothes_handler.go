@@ -3,13 +3,20 @@ package api
 import (
 	"bytes"
 	"clothes_management/internal/domain"
+	"clothes_management/internal/repository"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"maps"
+	"mime"
 	"net/http"
+	"net/url"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 )
@@ -67,6 +74,7 @@ func (a *API) CreateClothing(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	normaliseClothing(&clothing)
 	err = clothing.Validate()
 
 	if err != nil {
@@ -76,15 +84,16 @@ func (a *API) CreateClothing(w http.ResponseWriter, r *http.Request) {
 
 	clothing.UserId = userId
 
-	clothing, err = a.Repo.Save(userId, clothing)
+	saved, err := a.Repo.Save(r.Context(), userId, clothing)
 
 	if err != nil {
-		log.Print(err)
-		http.Error(w, "Error saving clothing item", http.StatusInternalServerError)
+		writeRepositoryError(w, err, clothing.Id, "Error saving clothing item")
 		return
 	}
 
-	resp := map[string]any{"success": true, "data": clothing}
+	a.registerCatalogValues(r.Context(), saved)
+
+	resp := map[string]any{"success": true, "data": saved}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 
@@ -93,7 +102,16 @@ func (a *API) CreateClothing(w http.ResponseWriter, r *http.Request) {
 }
 
 func (a *API) GetClothing(w http.ResponseWriter, r *http.Request) {
+	a.listClothing(w, r, false, nil)
+}
 
+// listScope narrows a listing's filter once its query parameters are read, returning fields to add to the
+// response. It writes its own error response and returns false when the listing cannot be served.
+type listScope func(w http.ResponseWriter, r *http.Request, userId string, filter *repository.ClothingFilter) (map[string]any, bool)
+
+// listClothing serves a page of the user's live items, or of their trash when trashed is true, taking
+// the same paging, filter and sort parameters for both. A scope, when given, narrows the filter further.
+func (a *API) listClothing(w http.ResponseWriter, r *http.Request, trashed bool, scope listScope) {
 	if r.Method != http.MethodGet {
 		http.Error(w, fmt.Sprintf("Unauthorised method %s.", r.Method), http.StatusMethodNotAllowed)
 		return
@@ -111,15 +129,51 @@ func (a *API) GetClothing(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	clothingItems, err := a.Repo.GetAll(userId)
+	pageRequest, err := ParsePageRequest(r.URL.Query())
 
 	if err != nil {
-		log.Print(err)
-		http.Error(w, "Error getting clothing items", http.StatusInternalServerError)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	pageRequest.Filter, err = ParseClothingFilter(r.URL.Query())
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	pageRequest.Filter.Trashed = trashed
+
+	var extra map[string]any
+
+	if scope != nil {
+		if extra, ok = scope(w, r, userId, &pageRequest.Filter); !ok {
+			return
+		}
+	}
+
+	pageRequest.Sort, err = ParseSort(r.URL.Query().Get("sort"))
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	page, err := a.Repo.GetPage(r.Context(), userId, pageRequest)
+
+	if errors.Is(err, repository.ErrInvalidCursor) {
+		http.Error(w, "Invalid 'cursor' parameter", http.StatusBadRequest)
+		return
+	}
+
+	if err != nil {
+		writeRepositoryError(w, err, "", "Error getting clothing items")
 		return
 	}
 
-	resp := map[string]any{"success": true, "data": clothingItems}
+	resp := map[string]any{"success": true, "data": page.Items, "nextCursor": page.NextCursor}
+	maps.Copy(resp, extra)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 
@@ -159,28 +213,15 @@ func (a *API) GetClothingById(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	exists, err := a.Repo.Exists(userId, id)
-
-	if err != nil {
-		log.Print(err)
-		http.Error(w, fmt.Sprintf("Unable to get clothing for ID %s", id), http.StatusInternalServerError)
-		return
-	}
-
-	if !exists {
-		http.Error(w, fmt.Sprintf("Clothing item not found for ID %s", id), http.StatusNotFound)
-		return
-	}
-
-	item, err := a.Repo.GetById(userId, id)
+	item, err := a.Repo.GetById(r.Context(), userId, id)
 
 	if err != nil {
-		log.Print(err)
-		http.Error(w, fmt.Sprintf("Unable to get clothing for ID %s", id), http.StatusInternalServerError)
+		writeRepositoryError(w, err, id, fmt.Sprintf("Unable to get clothing for ID %s", id))
 		return
 	}
 
 	resp := map[string]any{"success": true, "data": item}
+	w.Header().Set("ETag", VersionETag(item.Version))
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 
@@ -237,6 +278,16 @@ func (a *API) UpdateClothing(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// PATCH with a patch media type is a partial update; any other body is a full replacement.
+	if r.Method == http.MethodPatch {
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+
+		if mediaType == MergePatchContentType || mediaType == JSONPatchContentType {
+			a.patchClothing(w, r, userId, id, mediaType, bodyBytes)
+			return
+		}
+	}
+
 	var req map[string]any
 
 	if err := json.Unmarshal(bodyBytes, &req); err != nil {
@@ -261,6 +312,75 @@ func (a *API) UpdateClothing(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	a.replaceClothing(w, r, userId, id, clothing)
+}
+
+// patchClothing applies a JSON Merge Patch or JSON Patch to the stored item and persists the result.
+// The update is conditional on the version that was patched, so a concurrent write results in a 412
+// rather than being silently overwritten.
+func (a *API) patchClothing(w http.ResponseWriter, r *http.Request, userId, id, mediaType string, patch []byte) {
+	expectedVersion, ok := ParseIfMatch(r.Header.Get("If-Match"))
+
+	if !ok {
+		http.Error(w, "If-Match header must be a single ETag previously returned for this item", http.StatusPreconditionFailed)
+		return
+	}
+
+	stored, err := a.Repo.GetById(r.Context(), userId, id)
+
+	if err != nil {
+		writeRepositoryError(w, err, id, fmt.Sprintf("Unable to get clothing for ID %s", id))
+		return
+	}
+
+	if expectedVersion != 0 && expectedVersion != stored.Version {
+		http.Error(w, fmt.Sprintf("Clothing item %s has been modified since it was retrieved", id), http.StatusPreconditionFailed)
+		return
+	}
+
+	document, err := json.Marshal(stored)
+
+	if err != nil {
+		log.Print(err)
+		http.Error(w, "Error updating clothing item", http.StatusInternalServerError)
+		return
+	}
+
+	if mediaType == MergePatchContentType {
+		document, err = ApplyMergePatch(document, patch)
+	} else {
+		document, err = ApplyJSONPatch(document, patch)
+	}
+
+	switch {
+	case errors.Is(err, ErrPatchConflict):
+		http.Error(w, fmt.Sprintf("Patch cannot be applied: %s", err.Error()), http.StatusConflict)
+		return
+	case err != nil:
+		http.Error(w, fmt.Sprintf("Invalid patch document: %s", err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	var clothing domain.Clothing
+
+	dec := json.NewDecoder(bytes.NewReader(document))
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(&clothing); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request body, superfluous fields %s", err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	if clothing.Version != stored.Version {
+		http.Error(w, "Patch must not change 'version'", http.StatusBadRequest)
+		return
+	}
+
+	a.replaceClothing(w, r, userId, id, clothing)
+}
+
+// replaceClothing checks the identity fields and validation rules of a complete item, then persists it.
+func (a *API) replaceClothing(w http.ResponseWriter, r *http.Request, userId, id string, clothing domain.Clothing) {
 	if clothing.Id != "" && clothing.Id != id {
 		http.Error(w, fmt.Sprintf("Body has Id = %s, but id = %s, resulting is mismatch", clothing.Id, id), http.StatusBadRequest)
 		return
@@ -279,35 +399,36 @@ func (a *API) UpdateClothing(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = clothing.Validate()
+	normaliseClothing(&clothing)
 
-	if err != nil {
+	if err := clothing.Validate(); err != nil {
 		http.Error(w, fmt.Sprintf("Invalid request body, breaks validation rule: %s", err.Error()), http.StatusBadRequest)
 		return
 	}
 
-	exists, err = a.Repo.Exists(userId, clothing.Id)
+	// If-Match takes precedence over any version echoed back in the body.
+	if ifMatch := r.Header.Get("If-Match"); ifMatch != "" {
+		expectedVersion, ok := ParseIfMatch(ifMatch)
 
-	if err != nil {
-		log.Print(err)
-		http.Error(w, fmt.Sprintf("Error updating clothing item %s", id), http.StatusInternalServerError)
-		return
-	}
+		if !ok {
+			http.Error(w, "If-Match header must be a single ETag previously returned for this item", http.StatusPreconditionFailed)
+			return
+		}
 
-	if !exists {
-		http.Error(w, fmt.Sprintf("Clothing item not found for ID %s", id), http.StatusNotFound)
-		return
+		clothing.Version = expectedVersion
 	}
 
-	clothing, err = a.Repo.Update(userId, clothing)
+	clothing, err := a.Repo.Update(r.Context(), userId, clothing)
 
 	if err != nil {
-		log.Print(err)
-		http.Error(w, "Error updating clothing item", http.StatusInternalServerError)
+		writeRepositoryError(w, err, id, "Error updating clothing item")
 		return
 	}
 
+	a.registerCatalogValues(r.Context(), clothing)
+
 	resp := map[string]any{"success": true, "data": clothing}
+	w.Header().Set("ETag", VersionETag(clothing.Version))
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 
@@ -315,6 +436,9 @@ func (a *API) UpdateClothing(w http.ResponseWriter, r *http.Request) {
 
 }
 
+// DeleteClothing moves the item to the trash, where it can be restored until it is purged. Its image is
+// kept until then. An item that is part of an outfit is not deleted, with a 409 naming the outfits. The
+// outfits are checked again after trashing, and the item restored, in case one was saved in between.
 func (a *API) DeleteClothing(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodDelete {
 		http.Error(w, fmt.Sprintf("Unauthorised method %s.", r.Method), http.StatusMethodNotAllowed)
@@ -348,30 +472,82 @@ func (a *API) DeleteClothing(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	exists, err := a.Repo.Exists(userId, id)
+	expectedVersion, ok := ParseIfMatch(r.Header.Get("If-Match"))
+
+	if !ok {
+		http.Error(w, "If-Match header must be a single ETag previously returned for this item", http.StatusPreconditionFailed)
+		return
+	}
+
+	outfitNames, err := a.outfitNamesUsing(r.Context(), userId, id)
 
 	if err != nil {
-		log.Print(err)
-		http.Error(w, fmt.Sprintf("Error deleting clothing item %s", id), http.StatusInternalServerError)
+		writeRepositoryError(w, err, id, fmt.Sprintf("Unable to delete clothing for ID %s", id))
 		return
 	}
 
-	if !exists {
-		http.Error(w, fmt.Sprintf("Clothing item not found for ID %s", id), http.StatusNotFound)
+	if len(outfitNames) > 0 {
+		http.Error(w, inUseMessage(id, outfitNames), http.StatusConflict)
 		return
 	}
 
-	err = a.Repo.Delete(userId, id)
+	trashed, err := a.Repo.Trash(r.Context(), userId, id, expectedVersion)
 
 	if err != nil {
-		log.Print(err)
-		http.Error(w, fmt.Sprintf("Unable to delete clothing for ID %s", id), http.StatusInternalServerError)
+		writeRepositoryError(w, err, id, fmt.Sprintf("Unable to delete clothing for ID %s", id))
+		return
+	}
+
+	restored, err := a.restoreUsed(r.Context(), userId, []domain.Clothing{trashed})
+
+	if err != nil {
+		writeRepositoryError(w, err, id, fmt.Sprintf("Unable to delete clothing for ID %s", id))
+		return
+	}
+
+	if names, used := restored[id]; used {
+		http.Error(w, inUseMessage(id, names), http.StatusConflict)
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// writeRepositoryError maps the repository's typed errors onto HTTP statuses. Anything it does not
+// recognise is logged and reported as a 500 with fallbackMessage, so storage details are not leaked.
+func writeRepositoryError(w http.ResponseWriter, err error, id, fallbackMessage string) {
+	var validationErr *repository.ValidationError
+
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		http.Error(w, fmt.Sprintf("Clothing item not found for ID %s", id), http.StatusNotFound)
+	case errors.As(err, &validationErr):
+		http.Error(w, fmt.Sprintf("Invalid request, breaks validation rule: %s", validationErr.Error()), http.StatusBadRequest)
+	case errors.Is(err, repository.ErrConflict):
+		http.Error(w, fmt.Sprintf("Clothing item already exists for ID %s", id), http.StatusConflict)
+	case errors.Is(err, repository.ErrPreconditionFailed):
+		http.Error(w, fmt.Sprintf("Clothing item %s has been modified since it was retrieved", id), http.StatusPreconditionFailed)
+	default:
+		log.Print(err)
+		http.Error(w, fallbackMessage, http.StatusInternalServerError)
+	}
+}
+
+// normaliseClothing prices an item that was given without a currency in domain.DefaultCurrency,
+// truncates its purchase time to the second the repositories store, and normalises its tags.
+func normaliseClothing(clothing *domain.Clothing) {
+	if clothing.Currency == "" {
+		clothing.Currency = domain.DefaultCurrency
+	}
+
+	clothing.Tags = domain.NormaliseTags(clothing.Tags)
+
+	if clothing.PurchasedAt != nil {
+		purchasedAt := clothing.PurchasedAt.UTC().Truncate(time.Second)
+		clothing.PurchasedAt = &purchasedAt
+	}
+}
+
 func MissingMandatoryClothingField(req map[string]any) (bool, string) {
 
 	var requiredFields []string = []string{
@@ -393,3 +569,215 @@ func MissingMandatoryClothingField(req map[string]any) (bool, string) {
 
 	return false, ""
 }
+
+// ParsePageRequest reads the optional 'limit' and 'cursor' query parameters for list endpoints.
+func ParsePageRequest(query url.Values) (repository.PageRequest, error) {
+	var pageRequest repository.PageRequest
+
+	if rawLimit := query.Get("limit"); rawLimit != "" {
+		limit, err := strconv.Atoi(rawLimit)
+
+		if err != nil || limit <= 0 {
+			return repository.PageRequest{}, fmt.Errorf("Invalid 'limit' parameter, must be a positive integer")
+		}
+
+		if limit > repository.MaxPageLimit {
+			return repository.PageRequest{}, fmt.Errorf("Invalid 'limit' parameter, must not exceed %d", repository.MaxPageLimit)
+		}
+
+		pageRequest.Limit = limit
+	}
+
+	pageRequest.Cursor = strings.TrimSpace(query.Get("cursor"))
+
+	return pageRequest, nil
+}
+
+// ParseClothingFilter reads the optional attribute, price range, tag and colour query parameters for
+// GET /clothes. Each 'tag' is normalised, and items must have all of them unless 'tagMatch' is "any".
+// Each 'colour' is mapped to the palette as ParseColour does, and items must have one of them, as their
+// primary colour if 'colourMatch' is "primary". Items must suit one of the given 'season' and one of the
+// given 'occasion' values. Items must be priced in 'currency' when it is given, and it must be given with
+// 'minPricePence' or 'maxPricePence', which are in its minor unit.
+func ParseClothingFilter(query url.Values) (repository.ClothingFilter, error) {
+	filter := repository.ClothingFilter{
+		ClothingType: strings.TrimSpace(query.Get("clothingType")),
+		Brand:        strings.TrimSpace(query.Get("brand")),
+		Store:        strings.TrimSpace(query.Get("store")),
+		Size:         strings.TrimSpace(query.Get("size")),
+		Tags:         domain.NormaliseTags(query["tag"]),
+	}
+
+	switch tagMatch := strings.TrimSpace(query.Get("tagMatch")); tagMatch {
+	case "", "all":
+	case "any":
+		filter.AnyTag = true
+	default:
+		return repository.ClothingFilter{}, fmt.Errorf("Invalid 'tagMatch' parameter %q, must be all or any", tagMatch)
+	}
+
+	if len(filter.Tags) > domain.MaxTags {
+		return repository.ClothingFilter{}, fmt.Errorf("Must not give more than %d 'tag' parameters", domain.MaxTags)
+	}
+
+	for _, raw := range query["colour"] {
+		colour, ok := domain.ParseColour(raw)
+
+		if !ok {
+			return repository.ClothingFilter{}, fmt.Errorf("Invalid 'colour' parameter %q, must be a palette colour or a hex value such as #1f2a44", raw)
+		}
+
+		if !slices.Contains(filter.Colours, colour) {
+			filter.Colours = append(filter.Colours, colour)
+		}
+	}
+
+	for _, raw := range query["season"] {
+		season, ok := domain.ParseSeason(raw)
+
+		if !ok {
+			return repository.ClothingFilter{}, fmt.Errorf("Invalid 'season' parameter %q, must be spring, summer, autumn or winter", raw)
+		}
+
+		if !slices.Contains(filter.Seasons, season) {
+			filter.Seasons = append(filter.Seasons, season)
+		}
+	}
+
+	for _, raw := range query["occasion"] {
+		occasion, ok := domain.ParseOccasion(raw)
+
+		if !ok {
+			return repository.ClothingFilter{}, fmt.Errorf("Invalid 'occasion' parameter %q, must be casual, work, formal, party, sport, lounge or outdoor", raw)
+		}
+
+		if !slices.Contains(filter.Occasions, occasion) {
+			filter.Occasions = append(filter.Occasions, occasion)
+		}
+	}
+
+	switch colourMatch := strings.TrimSpace(query.Get("colourMatch")); colourMatch {
+	case "", "any":
+	case "primary":
+		filter.PrimaryColour = true
+	default:
+		return repository.ClothingFilter{}, fmt.Errorf("Invalid 'colourMatch' parameter %q, must be any or primary", colourMatch)
+	}
+
+	parsePence := func(name string) (*domain.Pence, error) {
+		raw := strings.TrimSpace(query.Get(name))
+
+		if raw == "" {
+			return nil, nil
+		}
+
+		value, err := strconv.ParseInt(raw, 10, 64)
+
+		if err != nil {
+			return nil, fmt.Errorf("Invalid '%s' parameter, must be an integer", name)
+		}
+
+		pence := domain.Pence(value)
+		return &pence, nil
+	}
+
+	var err error
+
+	if filter.MinPricePence, err = parsePence("minPricePence"); err != nil {
+		return repository.ClothingFilter{}, err
+	}
+
+	if filter.MaxPricePence, err = parsePence("maxPricePence"); err != nil {
+		return repository.ClothingFilter{}, err
+	}
+
+	if raw := strings.TrimSpace(query.Get("currency")); raw != "" {
+		currency, ok := domain.ParseCurrency(raw)
+
+		if !ok {
+			return repository.ClothingFilter{}, fmt.Errorf("Invalid 'currency' parameter %q, must be a supported ISO 4217 code", raw)
+		}
+
+		filter.Currency = currency
+	}
+
+	if err := filter.Validate(); err != nil {
+		return repository.ClothingFilter{}, fmt.Errorf("Invalid filter: %s", err.Error())
+	}
+
+	return filter, nil
+}
+
+// ParseSort reads a sort parameter such as "price,-brand,description", where a leading '-' sorts
+// that field in descending order.
+func ParseSort(raw string) ([]repository.SortKey, error) {
+	raw = strings.TrimSpace(raw)
+
+	if raw == "" {
+		return nil, nil
+	}
+
+	var keys []repository.SortKey
+	seen := map[repository.SortField]bool{}
+
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+
+		key := repository.SortKey{}
+
+		if strings.HasPrefix(part, "-") {
+			key.Descending = true
+			part = strings.TrimPrefix(part, "-")
+		}
+
+		key.Field = repository.SortField(part)
+
+		if err := key.Validate(); err != nil {
+			return nil, fmt.Errorf("Invalid 'sort' parameter, %s", err.Error())
+		}
+
+		if seen[key.Field] {
+			return nil, fmt.Errorf("Invalid 'sort' parameter, '%s' appears more than once", key.Field)
+		}
+
+		seen[key.Field] = true
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// VersionETag renders an item's Version as a strong ETag.
+func VersionETag(version int64) string {
+	return fmt.Sprintf("\"%d\"", version)
+}
+
+// ParseIfMatch turns an If-Match header back into the version it was issued for. An absent header or
+// "*" gives 0, meaning no version check. ok is false when the header cannot name a single version.
+func ParseIfMatch(header string) (int64, bool) {
+	header = strings.TrimSpace(header)
+
+	if header == "" || header == "*" {
+		return 0, true
+	}
+
+	unquoted, found := strings.CutPrefix(header, "\"")
+
+	if !found {
+		return 0, false
+	}
+
+	unquoted, found = strings.CutSuffix(unquoted, "\"")
+
+	if !found {
+		return 0, false
+	}
+
+	version, err := strconv.ParseInt(unquoted, 10, 64)
+
+	if err != nil || version <= 0 {
+		return 0, false
+	}
+
+	return version, true
+}
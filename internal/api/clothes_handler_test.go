@@ -3,14 +3,18 @@ package api
 import (
 	"bytes"
 	"clothes_management/internal/domain"
+	"clothes_management/internal/repository"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
@@ -19,22 +23,37 @@ import (
 type DummyClothingRepo struct {
 	ExpectedSaveItem *domain.Clothing
 	SaveError        error
+	SaveManyItems    []domain.Clothing
+	SaveManyError    error
 	NextId           string
 	AllItems         []domain.Clothing
 	GetAllError      error
+	NextCursor       string
+	GetPageRequest   *repository.PageRequest
 	GetByIdItem      *domain.Clothing
 	GetByIdError     error
 	GetByIdCalledId  string
 	UpdateError      error
 	UpdatedClothing  *domain.Clothing
 	UpdateReturnItem *domain.Clothing
-	DeleteError      error
-	DeletedID        string
 	ExistsError      error
+	ExistsCalled     bool
 	ShouldExist      bool
+	TrashError       error
+	TrashedIds       []string
+	TrashedVersion   int64
+	TrashManyCalls   int
+	RestoreError     error
+	RestoredID       string
+	RestoredVersion  int64
+	PurgeResult      []domain.Clothing
+	PurgeError       error
+	PurgedBefore     time.Time
+	WornAt           time.Time
+	WearError        error
 }
 
-func (d *DummyClothingRepo) Save(userId string, clothing domain.Clothing) (domain.Clothing, error) {
+func (d *DummyClothingRepo) Save(ctx context.Context, userId string, clothing domain.Clothing) (domain.Clothing, error) {
 	if d.SaveError != nil {
 		return domain.Clothing{}, d.SaveError
 	}
@@ -50,7 +69,30 @@ func (d *DummyClothingRepo) Save(userId string, clothing domain.Clothing) (domai
 	return clothing, nil
 }
 
-func (d *DummyClothingRepo) GetAll(userId string) ([]domain.Clothing, error) {
+func (d *DummyClothingRepo) SaveMany(ctx context.Context, userId string, clothing []domain.Clothing) ([]domain.Clothing, error) {
+	d.SaveManyItems = clothing
+
+	if d.SaveManyError != nil {
+		return nil, d.SaveManyError
+	}
+
+	saved := make([]domain.Clothing, len(clothing))
+
+	for i, item := range clothing {
+		item.Id = fmt.Sprintf("dummy-id-%d", i)
+		item.UserId = userId
+		item.Version = 1
+		saved[i] = item
+	}
+
+	return saved, nil
+}
+
+func (d *DummyClothingRepo) GetAll(ctx context.Context, userId string) ([]domain.Clothing, error) {
+	if err := ctx.Err(); err != nil {
+		return []domain.Clothing{}, err
+	}
+
 	if d.GetAllError != nil {
 		return []domain.Clothing{}, d.GetAllError
 	}
@@ -64,25 +106,41 @@ func (d *DummyClothingRepo) GetAll(userId string) ([]domain.Clothing, error) {
 	return items, nil
 }
 
-func (d *DummyClothingRepo) GetById(userId, id string) (domain.Clothing, error) {
+func (d *DummyClothingRepo) GetPage(ctx context.Context, userId string, page repository.PageRequest) (repository.Page, error) {
+	d.GetPageRequest = &page
+
+	items, err := d.GetAll(ctx, userId)
+
+	if err != nil {
+		return repository.Page{Items: []domain.Clothing{}}, err
+	}
+
+	return repository.Page{Items: items, NextCursor: d.NextCursor}, nil
+}
+
+func (d *DummyClothingRepo) GetById(ctx context.Context, userId, id string) (domain.Clothing, error) {
 	d.GetByIdCalledId = id
 
 	if d.GetByIdError != nil {
 		return domain.Clothing{}, d.GetByIdError
 	}
 	if d.GetByIdItem == nil {
-		return domain.Clothing{}, fmt.Errorf("item with id %s not found (dummy)", id)
+		return domain.Clothing{}, fmt.Errorf("item with id %s not found (dummy): %w", id, repository.ErrNotFound)
 	}
 	itemCopy := *d.GetByIdItem
 	return itemCopy, nil
 }
 
-func (d *DummyClothingRepo) Update(userId string, clothing domain.Clothing) (domain.Clothing, error) {
+func (d *DummyClothingRepo) Update(ctx context.Context, userId string, clothing domain.Clothing) (domain.Clothing, error) {
 	d.UpdatedClothing = &clothing
 	if d.UpdateError != nil {
 		return domain.Clothing{}, d.UpdateError
 	}
 
+	if !d.ShouldExist {
+		return domain.Clothing{}, fmt.Errorf("item with id %s not found (dummy): %w", clothing.Id, repository.ErrNotFound)
+	}
+
 	if d.UpdateReturnItem != nil {
 		itemCopy := *d.UpdateReturnItem
 		return itemCopy, nil
@@ -92,22 +150,117 @@ func (d *DummyClothingRepo) Update(userId string, clothing domain.Clothing) (dom
 	return itemCopy, nil
 }
 
-func (d *DummyClothingRepo) Delete(userId, id string) error {
-	d.DeletedID = id
+func (d *DummyClothingRepo) Exists(ctx context.Context, userId, id string) (bool, error) {
+	d.ExistsCalled = true
 
-	if d.DeleteError != nil {
-		return d.DeleteError
-	}
-	return nil
-}
-
-func (d *DummyClothingRepo) Exists(userId, id string) (bool, error) {
 	if d.ExistsError != nil {
 		return false, d.ExistsError
 	}
 	return d.ShouldExist, nil
 }
 
+// Trash succeeds for any id in AllItems, or for every id when ShouldExist is set.
+func (d *DummyClothingRepo) Trash(ctx context.Context, userId, id string, expectedVersion int64) (domain.Clothing, error) {
+	d.TrashedIds = append(d.TrashedIds, id)
+	d.TrashedVersion = expectedVersion
+
+	if d.TrashError != nil {
+		return domain.Clothing{}, d.TrashError
+	}
+
+	if !d.ShouldExist && !slices.ContainsFunc(d.AllItems, func(item domain.Clothing) bool { return item.Id == id }) {
+		return domain.Clothing{}, fmt.Errorf("item with id %s not found (dummy): %w", id, repository.ErrNotFound)
+	}
+
+	deletedAt := time.Now()
+	return domain.Clothing{Id: id, UserId: userId, Version: expectedVersion + 1, DeletedAt: &deletedAt}, nil
+}
+
+// TrashMany trashes the ids as Trash would, skipping those Trash would not find.
+func (d *DummyClothingRepo) TrashMany(ctx context.Context, userId string, ids []string) ([]domain.Clothing, error) {
+	d.TrashManyCalls++
+	trashed := []domain.Clothing{}
+
+	for _, id := range ids {
+		item, err := d.Trash(ctx, userId, id, 0)
+
+		if errors.Is(err, repository.ErrNotFound) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		trashed = append(trashed, item)
+	}
+
+	return trashed, nil
+}
+
+func (d *DummyClothingRepo) Restore(ctx context.Context, userId, id string, expectedVersion int64) (domain.Clothing, error) {
+	d.RestoredID = id
+	d.RestoredVersion = expectedVersion
+
+	if d.RestoreError != nil {
+		return domain.Clothing{}, d.RestoreError
+	}
+
+	if !d.ShouldExist {
+		return domain.Clothing{}, fmt.Errorf("item with id %s not found (dummy): %w", id, repository.ErrNotFound)
+	}
+
+	return domain.Clothing{Id: id, UserId: userId, Version: expectedVersion + 1}, nil
+}
+
+func (d *DummyClothingRepo) PurgeTrash(ctx context.Context, userId string, trashedBefore time.Time) ([]string, error) {
+	d.PurgedBefore = trashedBefore
+
+	if d.PurgeError != nil {
+		return nil, d.PurgeError
+	}
+
+	purged := []string{}
+
+	for _, item := range d.PurgeResult {
+		purged = append(purged, item.Id)
+	}
+
+	return purged, nil
+}
+
+func (d *DummyClothingRepo) PurgeExpiredTrash(ctx context.Context, trashedBefore time.Time) ([]domain.Clothing, error) {
+	d.PurgedBefore = trashedBefore
+
+	if d.PurgeError != nil {
+		return nil, d.PurgeError
+	}
+
+	return d.PurgeResult, nil
+}
+
+func (d *DummyClothingRepo) RecordWear(ctx context.Context, userId, id string, wornAt time.Time) (domain.Clothing, error) {
+	d.WornAt = wornAt
+
+	if d.WearError != nil {
+		return domain.Clothing{}, d.WearError
+	}
+
+	if !d.ShouldExist {
+		return domain.Clothing{}, fmt.Errorf("item with id %s not found (dummy): %w", id, repository.ErrNotFound)
+	}
+
+	return domain.Clothing{Id: id, UserId: userId, Version: 2, WearCount: 1, LastWornAt: &wornAt}, nil
+}
+
+func (d *DummyClothingRepo) ListWears(ctx context.Context, userId, id string) ([]domain.Wear, error) {
+	if d.WearError != nil {
+		return nil, d.WearError
+	}
+
+	return []domain.Wear{}, nil
+}
+
 func TestMissingMandatoryClothingField(t *testing.T) {
 	t.Run("Given no pricePence field, should return true and appropriate message", func(t *testing.T) {
 		var req map[string]any = map[string]any{}
@@ -514,6 +667,51 @@ func TestCreateClothing(t *testing.T) {
 
 	})
 
+	t.Run("Given POST request, where the repository reports a typed error, should map it to a status code", func(t *testing.T) {
+		cases := []struct {
+			err    error
+			status int
+		}{
+			{err: &repository.ValidationError{Err: errors.New("User ID must not be empty or whitespace")}, status: http.StatusBadRequest},
+			{err: fmt.Errorf("wrapped: %w", repository.ErrConflict), status: http.StatusConflict},
+		}
+
+		for _, c := range cases {
+			w := httptest.NewRecorder()
+
+			var jsonMap map[string]any = map[string]any{
+				"pricePence":   2000,
+				"clothingType": "Jumper",
+				"description":  "Red loosefit jumper",
+				"brand":        "A&B",
+				"store":        "Totlly Real Store",
+				"size":         "Medium",
+			}
+
+			body, err := json.Marshal(jsonMap)
+			if err != nil {
+				t.Fatalf("failed to marshal json: %v", err)
+			}
+
+			ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+			r := httptest.NewRequestWithContext(ctx, http.MethodPost, "/clothes", bytes.NewReader(body))
+
+			dummyRepo := &DummyClothingRepo{
+				SaveError: c.err,
+			}
+			apiHandler := &API{
+				Repo: dummyRepo,
+			}
+			apiHandler.CreateClothing(w, r)
+
+			resp := w.Result()
+
+			if resp.StatusCode != c.status {
+				t.Errorf("%v: Expected %d got %d", c.err, c.status, resp.StatusCode)
+			}
+		}
+	})
+
 	t.Run("Given POST request, with valid data and no issue on save, should have success", func(t *testing.T) {
 		w := httptest.NewRecorder()
 
@@ -580,6 +778,116 @@ func TestCreateClothing(t *testing.T) {
 			t.Errorf("Expected returned clothingType %s, got %v", jsonMap["clothingType"], data["clothingType"])
 		}
 
+		if data["currency"] != "GBP" {
+			t.Errorf("Expected an item without a currency to be priced in GBP, got %v", data["currency"])
+		}
+
+	})
+
+	t.Run("Given POST request, with a currency, should save it normalised or reject it when unsupported", func(t *testing.T) {
+		for currency, expectedStatus := range map[string]int{" jpy": http.StatusCreated, "XXX": http.StatusBadRequest} {
+			w := httptest.NewRecorder()
+
+			body := fmt.Sprintf(`{"pricePence": 2000, "currency": %q, "clothingType": "Jumper", "description": "Red", "brand": "A&B", "store": "Store", "size": "M"}`, currency)
+			ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+			r := httptest.NewRequestWithContext(ctx, http.MethodPost, "/clothes", strings.NewReader(body))
+
+			dummyRepo := &DummyClothingRepo{}
+			apiHandler := &API{Repo: dummyRepo}
+			apiHandler.CreateClothing(w, r)
+
+			if w.Result().StatusCode != expectedStatus {
+				t.Fatalf("Currency %q: Expected %d got %d", currency, expectedStatus, w.Result().StatusCode)
+			}
+
+			if expectedStatus == http.StatusCreated && dummyRepo.ExpectedSaveItem.Currency != "JPY" {
+				t.Errorf("Expected JPY to be saved, got %+v", dummyRepo.ExpectedSaveItem)
+			}
+		}
+	})
+
+	t.Run("Given POST request, with tags, should save them normalised or reject too many", func(t *testing.T) {
+		tooMany := `"tag 0"`
+
+		for i := 1; i <= domain.MaxTags; i++ {
+			tooMany += fmt.Sprintf(`, "tag %d"`, i)
+		}
+
+		for tags, expectedStatus := range map[string]int{`"Work", " winter ", "WORK", ""`: http.StatusCreated, tooMany: http.StatusBadRequest} {
+			w := httptest.NewRecorder()
+
+			body := `{"pricePence": 2000, "clothingType": "Jumper", "description": "Red", "brand": "A&B", "store": "Store", "size": "M", "tags": [` + tags + `]}`
+			ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+			r := httptest.NewRequestWithContext(ctx, http.MethodPost, "/clothes", strings.NewReader(body))
+
+			dummyRepo := &DummyClothingRepo{}
+			apiHandler := &API{Repo: dummyRepo}
+			apiHandler.CreateClothing(w, r)
+
+			if w.Result().StatusCode != expectedStatus {
+				t.Fatalf("Tags %s: Expected %d got %d", tags, expectedStatus, w.Result().StatusCode)
+			}
+
+			if expectedStatus == http.StatusCreated && !slices.Equal(dummyRepo.ExpectedSaveItem.Tags, []string{"winter", "work"}) {
+				t.Errorf("Expected tags [winter work] to be saved, got %q", dummyRepo.ExpectedSaveItem.Tags)
+			}
+		}
+	})
+
+	t.Run("Given POST request, with seasons and occasions, should save them or reject unknown ones", func(t *testing.T) {
+		for classification, expectedStatus := range map[string]int{
+			`"seasons": ["Autumn", "winter"], "occasions": ["work"]`: http.StatusCreated,
+			`"seasons": ["monsoon"]`:                                 http.StatusBadRequest,
+			`"occasions": ["work", "work"]`:                          http.StatusBadRequest,
+		} {
+			w := httptest.NewRecorder()
+
+			body := `{"pricePence": 2000, "clothingType": "Coat", "description": "Wool", "brand": "A&B", "store": "Store", "size": "M", ` + classification + `}`
+			ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+			r := httptest.NewRequestWithContext(ctx, http.MethodPost, "/clothes", strings.NewReader(body))
+
+			dummyRepo := &DummyClothingRepo{}
+			apiHandler := &API{Repo: dummyRepo}
+			apiHandler.CreateClothing(w, r)
+
+			if w.Result().StatusCode != expectedStatus {
+				t.Fatalf("%s: Expected %d got %d", classification, expectedStatus, w.Result().StatusCode)
+			}
+
+			saved := dummyRepo.ExpectedSaveItem
+
+			if expectedStatus == http.StatusCreated && (!slices.Equal(saved.Seasons, []domain.Season{"autumn", "winter"}) || !slices.Equal(saved.Occasions, []domain.Occasion{"work"})) {
+				t.Errorf("Expected autumn and winter for work to be saved, got %q %q", saved.Seasons, saved.Occasions)
+			}
+		}
+	})
+
+	t.Run("Given POST request, with free-text colours, should save the nearest palette colours or reject unknown ones", func(t *testing.T) {
+		for colours, expectedStatus := range map[string]int{
+			`"colour": "#1F2A44", "secondaryColours": ["Gray", "white"]`: http.StatusCreated,
+			`"colour": "sparkly"`:           http.StatusBadRequest,
+			`"secondaryColours": ["white"]`: http.StatusBadRequest,
+		} {
+			w := httptest.NewRecorder()
+
+			body := `{"pricePence": 2000, "clothingType": "Jumper", "description": "Striped", "brand": "A&B", "store": "Store", "size": "M", ` + colours + `}`
+			ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+			r := httptest.NewRequestWithContext(ctx, http.MethodPost, "/clothes", strings.NewReader(body))
+
+			dummyRepo := &DummyClothingRepo{}
+			apiHandler := &API{Repo: dummyRepo}
+			apiHandler.CreateClothing(w, r)
+
+			if w.Result().StatusCode != expectedStatus {
+				t.Fatalf("Colours %s: Expected %d got %d", colours, expectedStatus, w.Result().StatusCode)
+			}
+
+			saved := dummyRepo.ExpectedSaveItem
+
+			if expectedStatus == http.StatusCreated && (saved.Colour != "navy" || !slices.Equal(saved.SecondaryColours, []domain.Colour{"grey", "white"})) {
+				t.Errorf("Expected navy with grey and white to be saved, got %q %q", saved.Colour, saved.SecondaryColours)
+			}
+		}
 	})
 }
 
@@ -666,6 +974,31 @@ func TestGetClothing(t *testing.T) {
 
 	})
 
+	t.Run("Given GET request, with a cancelled request context, should return error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		ctx, cancel := context.WithCancel(context.WithValue(context.TODO(), UserIDContextKey, "test-user-id"))
+		cancel()
+		r := httptest.NewRequestWithContext(ctx, http.MethodGet, "/clothes", nil)
+
+		dummyRepo := &DummyClothingRepo{
+			AllItems: []domain.Clothing{
+				{Id: "id-1", ClothingType: "Shirt", Description: "Blue Shirt", Brand: "X", Store: "Shop", Price: 1000, Size: "M"},
+			},
+		}
+		apiHandler := &API{
+			Repo: dummyRepo,
+		}
+
+		apiHandler.GetClothing(w, r)
+
+		resp := w.Result()
+
+		if resp.StatusCode != http.StatusInternalServerError {
+			t.Errorf("Expeted %d got %d", http.StatusInternalServerError, resp.StatusCode)
+		}
+	})
+
 	t.Run("Given GET request, with no issues with retrieval, should return success", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
@@ -676,7 +1009,379 @@ func TestGetClothing(t *testing.T) {
 			{Id: "id-2", ClothingType: "Trousers", Description: "Black Jeans", Brand: "Y", Store: "Store", Price: 2500, Size: "L"},
 		}
 		dummyRepo := &DummyClothingRepo{
-			AllItems: expectedItems,
+			AllItems: expectedItems,
+		}
+		apiHandler := &API{
+			Repo: dummyRepo,
+		}
+
+		apiHandler.GetClothing(w, r)
+
+		resp := w.Result()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("Expeted %d got %d", http.StatusOK, resp.StatusCode)
+		}
+
+		var responseBody map[string]any
+		err := json.Unmarshal(w.Body.Bytes(), &responseBody)
+
+		if err != nil {
+			t.Fatalf("Failed to unmarshal response body: %v", err)
+		}
+
+		if responseBody["success"] != true {
+			t.Errorf("Expected success: true, got %v", responseBody["success"])
+		}
+
+		data, ok := responseBody["data"].([]any)
+		if !ok {
+			t.Fatalf("Expected 'data' field in response, got %v", responseBody["data"])
+		}
+
+		if len(data) != len(expectedItems) {
+			t.Errorf("Expected %d items, got %d", len(expectedItems), len(data))
+		}
+
+		if responseBody["nextCursor"] != "" {
+			t.Errorf("Expected empty nextCursor, got %v", responseBody["nextCursor"])
+		}
+	})
+
+	t.Run("Given GET request, with limit and cursor, should pass them to the repository and return nextCursor", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+		r := httptest.NewRequestWithContext(ctx, http.MethodGet, "/clothes?limit=1&cursor=abc", nil)
+
+		dummyRepo := &DummyClothingRepo{
+			AllItems: []domain.Clothing{
+				{Id: "id-1", ClothingType: "Shirt", Description: "Blue Shirt", Brand: "X", Store: "Shop", Price: 1000, Size: "M"},
+			},
+			NextCursor: "next-page",
+		}
+		apiHandler := &API{
+			Repo: dummyRepo,
+		}
+
+		apiHandler.GetClothing(w, r)
+
+		resp := w.Result()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Expeted %d got %d", http.StatusOK, resp.StatusCode)
+		}
+
+		if dummyRepo.GetPageRequest == nil {
+			t.Fatal("Expected GetPage to be called")
+		}
+
+		if dummyRepo.GetPageRequest.Limit != 1 || dummyRepo.GetPageRequest.Cursor != "abc" {
+			t.Errorf("Expected limit 1 and cursor abc, got %+v", *dummyRepo.GetPageRequest)
+		}
+
+		var responseBody map[string]any
+		if err := json.Unmarshal(w.Body.Bytes(), &responseBody); err != nil {
+			t.Fatalf("Failed to unmarshal response body: %v", err)
+		}
+
+		if responseBody["nextCursor"] != "next-page" {
+			t.Errorf("Expected nextCursor next-page, got %v", responseBody["nextCursor"])
+		}
+	})
+
+	t.Run("Given GET request, with invalid limit, should return error", func(t *testing.T) {
+		for _, limit := range []string{"abc", "0", "-1", "101"} {
+			w := httptest.NewRecorder()
+			ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+			r := httptest.NewRequestWithContext(ctx, http.MethodGet, "/clothes?limit="+limit, nil)
+
+			dummyRepo := &DummyClothingRepo{}
+			apiHandler := &API{
+				Repo: dummyRepo,
+			}
+
+			apiHandler.GetClothing(w, r)
+
+			resp := w.Result()
+
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Errorf("limit=%s: Expected %d got %d", limit, http.StatusBadRequest, resp.StatusCode)
+			}
+
+			if dummyRepo.GetPageRequest != nil {
+				t.Errorf("limit=%s: Expected GetPage not to be called", limit)
+			}
+		}
+	})
+
+	t.Run("Given GET request, with filter parameters, should pass the filter to the repository", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+		r := httptest.NewRequestWithContext(ctx, http.MethodGet, "/clothes?clothingType=Shirt&brand=X&store=Shop&size=M&minPricePence=500&maxPricePence=1500&currency=jpy", nil)
+
+		dummyRepo := &DummyClothingRepo{}
+		apiHandler := &API{
+			Repo: dummyRepo,
+		}
+
+		apiHandler.GetClothing(w, r)
+
+		resp := w.Result()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Expected %d got %d", http.StatusOK, resp.StatusCode)
+		}
+
+		filter := dummyRepo.GetPageRequest.Filter
+
+		if filter.ClothingType != "Shirt" || filter.Brand != "X" || filter.Store != "Shop" || filter.Size != "M" {
+			t.Errorf("Expected attribute filters to be passed through, got %+v", filter)
+		}
+
+		if filter.MinPricePence == nil || *filter.MinPricePence != 500 {
+			t.Errorf("Expected minPricePence 500, got %v", filter.MinPricePence)
+		}
+
+		if filter.MaxPricePence == nil || *filter.MaxPricePence != 1500 {
+			t.Errorf("Expected maxPricePence 1500, got %v", filter.MaxPricePence)
+		}
+
+		if filter.Currency != "JPY" {
+			t.Errorf("Expected currency JPY, got %q", filter.Currency)
+		}
+	})
+
+	t.Run("Given GET request, with tag parameters, should pass them normalised to the repository", func(t *testing.T) {
+		for query, anyTag := range map[string]bool{"tag=Work&tag=%20winter": false, "tag=Work&tag=%20winter&tagMatch=any": true} {
+			w := httptest.NewRecorder()
+			ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+			r := httptest.NewRequestWithContext(ctx, http.MethodGet, "/clothes?"+query, nil)
+
+			dummyRepo := &DummyClothingRepo{}
+			apiHandler := &API{
+				Repo: dummyRepo,
+			}
+
+			apiHandler.GetClothing(w, r)
+
+			if w.Result().StatusCode != http.StatusOK {
+				t.Fatalf("%s: Expected %d got %d", query, http.StatusOK, w.Result().StatusCode)
+			}
+
+			filter := dummyRepo.GetPageRequest.Filter
+
+			if !slices.Equal(filter.Tags, []string{"winter", "work"}) || filter.AnyTag != anyTag {
+				t.Errorf("%s: Expected tags [winter work] with AnyTag %v, got %+v", query, anyTag, filter)
+			}
+		}
+	})
+
+	t.Run("Given GET request, with colour parameters, should pass them mapped to the palette to the repository", func(t *testing.T) {
+		for query, primary := range map[string]bool{"colour=Navy&colour=%23ffffff&colour=navy": false, "colour=Navy&colour=%23ffffff&colourMatch=primary": true} {
+			w := httptest.NewRecorder()
+			ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+			r := httptest.NewRequestWithContext(ctx, http.MethodGet, "/clothes?"+query, nil)
+
+			dummyRepo := &DummyClothingRepo{}
+			apiHandler := &API{
+				Repo: dummyRepo,
+			}
+
+			apiHandler.GetClothing(w, r)
+
+			if w.Result().StatusCode != http.StatusOK {
+				t.Fatalf("%s: Expected %d got %d", query, http.StatusOK, w.Result().StatusCode)
+			}
+
+			filter := dummyRepo.GetPageRequest.Filter
+
+			if !slices.Equal(filter.Colours, []domain.Colour{"navy", "white"}) || filter.PrimaryColour != primary {
+				t.Errorf("%s: Expected colours [navy white] with PrimaryColour %v, got %+v", query, primary, filter)
+			}
+		}
+	})
+
+	t.Run("Given GET request, with an unknown colour or colourMatch, should return error", func(t *testing.T) {
+		for _, query := range []string{"colour=sparkly", "colour=navy&colourMatch=secondary"} {
+			w := httptest.NewRecorder()
+			ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+			r := httptest.NewRequestWithContext(ctx, http.MethodGet, "/clothes?"+query, nil)
+
+			dummyRepo := &DummyClothingRepo{}
+			apiHandler := &API{
+				Repo: dummyRepo,
+			}
+
+			apiHandler.GetClothing(w, r)
+
+			if w.Result().StatusCode != http.StatusBadRequest {
+				t.Errorf("%s: Expected %d got %d", query, http.StatusBadRequest, w.Result().StatusCode)
+			}
+		}
+	})
+
+	t.Run("Given GET request, with season and occasion parameters, should pass them once each to the repository", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+		r := httptest.NewRequestWithContext(ctx, http.MethodGet, "/clothes?season=Winter&season=fall&season=winter&occasion=work", nil)
+
+		dummyRepo := &DummyClothingRepo{}
+		apiHandler := &API{
+			Repo: dummyRepo,
+		}
+
+		apiHandler.GetClothing(w, r)
+
+		if w.Result().StatusCode != http.StatusOK {
+			t.Fatalf("Expected %d got %d", http.StatusOK, w.Result().StatusCode)
+		}
+
+		filter := dummyRepo.GetPageRequest.Filter
+
+		if !slices.Equal(filter.Seasons, []domain.Season{"winter", "autumn"}) || !slices.Equal(filter.Occasions, []domain.Occasion{"work"}) {
+			t.Errorf("Expected seasons [winter autumn] and occasions [work], got %+v", filter)
+		}
+	})
+
+	t.Run("Given GET request, with an unknown season or occasion, should return error", func(t *testing.T) {
+		for _, query := range []string{"season=monsoon", "occasion=wedding"} {
+			w := httptest.NewRecorder()
+			ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+			r := httptest.NewRequestWithContext(ctx, http.MethodGet, "/clothes?"+query, nil)
+
+			apiHandler := &API{
+				Repo: &DummyClothingRepo{},
+			}
+
+			apiHandler.GetClothing(w, r)
+
+			if w.Result().StatusCode != http.StatusBadRequest {
+				t.Errorf("%s: Expected %d got %d", query, http.StatusBadRequest, w.Result().StatusCode)
+			}
+		}
+	})
+
+	t.Run("Given GET request, with an invalid tagMatch, should return error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+		r := httptest.NewRequestWithContext(ctx, http.MethodGet, "/clothes?tag=work&tagMatch=some", nil)
+
+		dummyRepo := &DummyClothingRepo{}
+		apiHandler := &API{
+			Repo: dummyRepo,
+		}
+
+		apiHandler.GetClothing(w, r)
+
+		if w.Result().StatusCode != http.StatusBadRequest {
+			t.Errorf("Expected %d got %d", http.StatusBadRequest, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given GET request, with an invalid price range, should return error", func(t *testing.T) {
+		for _, query := range []string{"minPricePence=abc&currency=GBP", "maxPricePence=1.5&currency=GBP", "minPricePence=-1&currency=GBP", "minPricePence=2000&maxPricePence=1000&currency=GBP", "minPricePence=500", "maxPricePence=500&currency=XXX"} {
+			w := httptest.NewRecorder()
+			ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+			r := httptest.NewRequestWithContext(ctx, http.MethodGet, "/clothes?"+query, nil)
+
+			dummyRepo := &DummyClothingRepo{}
+			apiHandler := &API{
+				Repo: dummyRepo,
+			}
+
+			apiHandler.GetClothing(w, r)
+
+			resp := w.Result()
+
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Errorf("%s: Expected %d got %d", query, http.StatusBadRequest, resp.StatusCode)
+			}
+
+			if dummyRepo.GetPageRequest != nil {
+				t.Errorf("%s: Expected GetPage not to be called", query)
+			}
+		}
+	})
+
+	t.Run("Given GET request, with a filter the repository would reject, should return its own message", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+		r := httptest.NewRequestWithContext(ctx, http.MethodGet, "/clothes?minPricePence=2000&maxPricePence=1000&currency=GBP", nil)
+
+		apiHandler := &API{
+			Repo: &DummyClothingRepo{},
+		}
+
+		apiHandler.GetClothing(w, r)
+
+		expected := "Invalid filter: Minimum price must not be greater than maximum price"
+
+		if body := strings.TrimSpace(w.Body.String()); body != expected {
+			t.Errorf("Expected %q got %q", expected, body)
+		}
+	})
+
+	t.Run("Given GET request, with a sort parameter, should pass the sort keys to the repository", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+		r := httptest.NewRequestWithContext(ctx, http.MethodGet, "/clothes?sort=price,-brand,description", nil)
+
+		dummyRepo := &DummyClothingRepo{}
+		apiHandler := &API{
+			Repo: dummyRepo,
+		}
+
+		apiHandler.GetClothing(w, r)
+
+		resp := w.Result()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Expected %d got %d", http.StatusOK, resp.StatusCode)
+		}
+
+		expected := []repository.SortKey{
+			{Field: repository.SortByPrice},
+			{Field: repository.SortByBrand, Descending: true},
+			{Field: repository.SortByDescription},
+		}
+
+		if !slices.Equal(dummyRepo.GetPageRequest.Sort, expected) {
+			t.Errorf("Expected %v, got %v", expected, dummyRepo.GetPageRequest.Sort)
+		}
+	})
+
+	t.Run("Given GET request, with an invalid sort parameter, should return error", func(t *testing.T) {
+		for _, sort := range []string{"colour", "price,,brand", "-", "price,-price"} {
+			w := httptest.NewRecorder()
+			ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+			r := httptest.NewRequestWithContext(ctx, http.MethodGet, "/clothes?sort="+sort, nil)
+
+			dummyRepo := &DummyClothingRepo{}
+			apiHandler := &API{
+				Repo: dummyRepo,
+			}
+
+			apiHandler.GetClothing(w, r)
+
+			resp := w.Result()
+
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Errorf("%s: Expected %d got %d", sort, http.StatusBadRequest, resp.StatusCode)
+			}
+
+			if dummyRepo.GetPageRequest != nil {
+				t.Errorf("%s: Expected GetPage not to be called", sort)
+			}
+		}
+	})
+
+	t.Run("Given GET request, with a cursor the repository rejects, should return error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+		r := httptest.NewRequestWithContext(ctx, http.MethodGet, "/clothes?cursor=nonsense", nil)
+
+		dummyRepo := &DummyClothingRepo{
+			GetAllError: fmt.Errorf("%w: bad", repository.ErrInvalidCursor),
 		}
 		apiHandler := &API{
 			Repo: dummyRepo,
@@ -686,28 +1391,14 @@ func TestGetClothing(t *testing.T) {
 
 		resp := w.Result()
 
-		if resp.StatusCode != http.StatusOK {
-			t.Errorf("Expeted %d got %d", http.StatusOK, resp.StatusCode)
-		}
-
-		var responseBody map[string]any
-		err := json.Unmarshal(w.Body.Bytes(), &responseBody)
-
-		if err != nil {
-			t.Fatalf("Failed to unmarshal response body: %v", err)
-		}
-
-		if responseBody["success"] != true {
-			t.Errorf("Expected success: true, got %v", responseBody["success"])
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("Expected %d got %d", http.StatusBadRequest, resp.StatusCode)
 		}
 
-		data, ok := responseBody["data"].([]any)
-		if !ok {
-			t.Fatalf("Expected 'data' field in response, got %v", responseBody["data"])
-		}
+		expected := "Invalid 'cursor' parameter"
 
-		if len(data) != len(expectedItems) {
-			t.Errorf("Expected %d items, got %d", len(expectedItems), len(data))
+		if !strings.Contains(w.Body.String(), expected) {
+			t.Errorf("Expected %s got %s", expected, w.Body.String())
 		}
 	})
 }
@@ -911,6 +1602,14 @@ func TestGetClothingById(t *testing.T) {
 			t.Errorf("Expeted %d got %d", http.StatusOK, resp.StatusCode)
 		}
 
+		if dummyRepo.ExistsCalled {
+			t.Error("Expected GetById alone to be used, but Exists was called")
+		}
+
+		if etag := resp.Header.Get("ETag"); etag != `"0"` {
+			t.Errorf("Expected ETag \"0\", got %s", etag)
+		}
+
 		var responseBody map[string]any
 		err := json.Unmarshal(w.Body.Bytes(), &responseBody)
 
@@ -1618,6 +2317,281 @@ func TestUpdateClothing(t *testing.T) {
 		}
 
 	})
+
+	t.Run("Given PUT request, with If-Match, should update against that version and return the new ETag", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		id := "test-id"
+		var jsonMap map[string]any = map[string]any{
+			"pricePence":   2000,
+			"clothingType": "Jumper",
+			"description":  "Red loosefit jumper",
+			"brand":        "A&B",
+			"store":        "Totlly Real Store",
+			"size":         "Medium",
+			"version":      1,
+		}
+
+		body, err := json.Marshal(jsonMap)
+		if err != nil {
+			t.Fatalf("failed to marshal json: %v", err)
+		}
+		ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+		r := httptest.NewRequestWithContext(ctx, http.MethodPut, "/clothes/"+id, bytes.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		r.Header.Set("If-Match", `"3"`)
+		r = mux.SetURLVars(r, map[string]string{"id": id})
+
+		returned := domain.Clothing{Id: id, Version: 4}
+		dummyRepo := &DummyClothingRepo{
+			UpdateReturnItem: &returned,
+			ShouldExist:      true,
+		}
+		apiHandler := &API{
+			Repo: dummyRepo,
+		}
+		apiHandler.UpdateClothing(w, r)
+
+		resp := w.Result()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Expected %d got %d", http.StatusOK, resp.StatusCode)
+		}
+
+		if dummyRepo.UpdatedClothing.Version != 3 {
+			t.Errorf("Expected Update to be called with version 3, got %d", dummyRepo.UpdatedClothing.Version)
+		}
+
+		if etag := resp.Header.Get("ETag"); etag != `"4"` {
+			t.Errorf("Expected ETag \"4\", got %s", etag)
+		}
+	})
+
+	t.Run("Given PUT request, with a stale or malformed If-Match, should return 412", func(t *testing.T) {
+		cases := []struct {
+			ifMatch     string
+			updateError error
+		}{
+			{ifMatch: `"3"`, updateError: fmt.Errorf("stale: %w", repository.ErrPreconditionFailed)},
+			{ifMatch: `W/"3"`},
+			{ifMatch: `3`},
+			{ifMatch: `"abc"`},
+		}
+
+		for _, c := range cases {
+			w := httptest.NewRecorder()
+
+			id := "test-id"
+			var jsonMap map[string]any = map[string]any{
+				"pricePence":   2000,
+				"clothingType": "Jumper",
+				"description":  "Red loosefit jumper",
+				"brand":        "A&B",
+				"store":        "Totlly Real Store",
+				"size":         "Medium",
+			}
+
+			body, err := json.Marshal(jsonMap)
+			if err != nil {
+				t.Fatalf("failed to marshal json: %v", err)
+			}
+			ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+			r := httptest.NewRequestWithContext(ctx, http.MethodPut, "/clothes/"+id, bytes.NewReader(body))
+			r.Header.Set("Content-Type", "application/json")
+			r.Header.Set("If-Match", c.ifMatch)
+			r = mux.SetURLVars(r, map[string]string{"id": id})
+
+			dummyRepo := &DummyClothingRepo{
+				UpdateError: c.updateError,
+				ShouldExist: true,
+			}
+			apiHandler := &API{
+				Repo: dummyRepo,
+			}
+			apiHandler.UpdateClothing(w, r)
+
+			resp := w.Result()
+
+			if resp.StatusCode != http.StatusPreconditionFailed {
+				t.Errorf("If-Match %s: Expected %d got %d", c.ifMatch, http.StatusPreconditionFailed, resp.StatusCode)
+			}
+		}
+	})
+
+	t.Run("Given PATCH request, with a merge patch of only the price, should keep the other stored fields", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		id := "test-id"
+		stored := domain.Clothing{
+			Id:           id,
+			UserId:       "test-user-id",
+			Price:        2000,
+			ClothingType: "Jumper",
+			Description:  "Red loosefit jumper",
+			Brand:        "A&B",
+			Store:        "Totlly Real Store",
+			Size:         "Medium",
+			Version:      2,
+		}
+
+		ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+		r := httptest.NewRequestWithContext(ctx, http.MethodPatch, "/clothes/"+id, strings.NewReader(`{"pricePence": 1500}`))
+		r.Header.Set("Content-Type", "application/merge-patch+json")
+		r = mux.SetURLVars(r, map[string]string{"id": id})
+
+		dummyRepo := &DummyClothingRepo{
+			GetByIdItem: &stored,
+			ShouldExist: true,
+		}
+		apiHandler := &API{
+			Repo: dummyRepo,
+		}
+		apiHandler.UpdateClothing(w, r)
+
+		resp := w.Result()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Expected %d got %d: %s", http.StatusOK, resp.StatusCode, w.Body.String())
+		}
+
+		expected := stored
+		expected.Price = 1500
+		expected.Currency = domain.DefaultCurrency
+
+		if !reflect.DeepEqual(*dummyRepo.UpdatedClothing, expected) {
+			t.Errorf("Expected Update to be called with %+v, got %+v", expected, *dummyRepo.UpdatedClothing)
+		}
+	})
+
+	t.Run("Given PATCH request, with a JSON patch, should apply the operations to the stored item", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		id := "test-id"
+		stored := domain.Clothing{
+			Id:           id,
+			UserId:       "test-user-id",
+			Price:        2000,
+			ClothingType: "Jumper",
+			Description:  "Red loosefit jumper",
+			Brand:        "A&B",
+			Store:        "Totlly Real Store",
+			Size:         "Medium",
+			Version:      2,
+		}
+
+		patch := `[{"op": "test", "path": "/size", "value": "Medium"}, {"op": "replace", "path": "/size", "value": "Large"}]`
+		ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+		r := httptest.NewRequestWithContext(ctx, http.MethodPatch, "/clothes/"+id, strings.NewReader(patch))
+		r.Header.Set("Content-Type", "application/json-patch+json")
+		r = mux.SetURLVars(r, map[string]string{"id": id})
+
+		dummyRepo := &DummyClothingRepo{
+			GetByIdItem: &stored,
+			ShouldExist: true,
+		}
+		apiHandler := &API{
+			Repo: dummyRepo,
+		}
+		apiHandler.UpdateClothing(w, r)
+
+		resp := w.Result()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Expected %d got %d: %s", http.StatusOK, resp.StatusCode, w.Body.String())
+		}
+
+		if dummyRepo.UpdatedClothing.Size != "Large" || dummyRepo.UpdatedClothing.Brand != "A&B" {
+			t.Errorf("Expected size Large with the stored brand, got %+v", *dummyRepo.UpdatedClothing)
+		}
+
+		if dummyRepo.UpdatedClothing.Version != 2 {
+			t.Errorf("Expected Update to be conditional on the stored version 2, got %d", dummyRepo.UpdatedClothing.Version)
+		}
+	})
+
+	t.Run("Given PATCH request, with a patch that fails, should not update and return an error status", func(t *testing.T) {
+		cases := []struct {
+			contentType string
+			patch       string
+			ifMatch     string
+			expected    int
+		}{
+			{contentType: MergePatchContentType, patch: `{"pricePence": -1}`, expected: http.StatusBadRequest},
+			{contentType: MergePatchContentType, patch: `{"brand": null}`, expected: http.StatusBadRequest},
+			{contentType: MergePatchContentType, patch: `{"pattern": "Striped"}`, expected: http.StatusBadRequest},
+			{contentType: MergePatchContentType, patch: `{"id": "other-id"}`, expected: http.StatusBadRequest},
+			{contentType: MergePatchContentType, patch: `{"version": 7}`, expected: http.StatusBadRequest},
+			{contentType: MergePatchContentType, patch: `{`, expected: http.StatusBadRequest},
+			{contentType: MergePatchContentType, patch: `{"pricePence": 1500}`, ifMatch: `"1"`, expected: http.StatusPreconditionFailed},
+			{contentType: JSONPatchContentType, patch: `{"op": "remove"}`, expected: http.StatusBadRequest},
+			{contentType: JSONPatchContentType, patch: `[{"op": "test", "path": "/size", "value": "Small"}]`, expected: http.StatusConflict},
+			{contentType: JSONPatchContentType, patch: `[{"op": "remove", "path": "/missing"}]`, expected: http.StatusConflict},
+		}
+
+		for _, c := range cases {
+			w := httptest.NewRecorder()
+
+			id := "test-id"
+			stored := domain.Clothing{
+				Id:           id,
+				UserId:       "test-user-id",
+				Price:        2000,
+				ClothingType: "Jumper",
+				Description:  "Red loosefit jumper",
+				Brand:        "A&B",
+				Store:        "Totlly Real Store",
+				Size:         "Medium",
+				Version:      2,
+			}
+
+			ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+			r := httptest.NewRequestWithContext(ctx, http.MethodPatch, "/clothes/"+id, strings.NewReader(c.patch))
+			r.Header.Set("Content-Type", c.contentType)
+			if c.ifMatch != "" {
+				r.Header.Set("If-Match", c.ifMatch)
+			}
+			r = mux.SetURLVars(r, map[string]string{"id": id})
+
+			dummyRepo := &DummyClothingRepo{
+				GetByIdItem: &stored,
+				ShouldExist: true,
+			}
+			apiHandler := &API{
+				Repo: dummyRepo,
+			}
+			apiHandler.UpdateClothing(w, r)
+
+			resp := w.Result()
+
+			if resp.StatusCode != c.expected {
+				t.Errorf("%s %s: Expected %d got %d", c.contentType, c.patch, c.expected, resp.StatusCode)
+			}
+
+			if dummyRepo.UpdatedClothing != nil {
+				t.Errorf("%s %s: Expected Update not to be called", c.contentType, c.patch)
+			}
+		}
+	})
+
+	t.Run("Given PATCH request, with a merge patch for an item that does not exist, should return 404", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+		r := httptest.NewRequestWithContext(ctx, http.MethodPatch, "/clothes/missing-id", strings.NewReader(`{"pricePence": 1500}`))
+		r.Header.Set("Content-Type", "application/merge-patch+json; charset=utf-8")
+		r = mux.SetURLVars(r, map[string]string{"id": "missing-id"})
+
+		apiHandler := &API{
+			Repo: &DummyClothingRepo{},
+		}
+		apiHandler.UpdateClothing(w, r)
+
+		resp := w.Result()
+
+		if resp.StatusCode != http.StatusNotFound {
+			t.Errorf("Expected %d got %d", http.StatusNotFound, resp.StatusCode)
+		}
+	})
 }
 
 func TestDeleteClothing(t *testing.T) {
@@ -1730,7 +2704,7 @@ func TestDeleteClothing(t *testing.T) {
 		r = mux.SetURLVars(r, map[string]string{"id": "legit-id"})
 
 		dummyRepo := &DummyClothingRepo{
-			DeleteError: errors.New("Some Type of Error"),
+			TrashError:  errors.New("Some Type of Error"),
 			ShouldExist: true,
 		}
 		apiHandler := &API{
@@ -1791,7 +2765,6 @@ func TestDeleteClothing(t *testing.T) {
 		r = mux.SetURLVars(r, map[string]string{"id": "legit-id"})
 
 		dummyRepo := &DummyClothingRepo{
-			DeletedID:   "legit-id",
 			ShouldExist: true,
 		}
 		apiHandler := &API{
@@ -1805,5 +2778,123 @@ func TestDeleteClothing(t *testing.T) {
 		if resp.StatusCode != http.StatusNoContent {
 			t.Errorf("Expeted %d got %d", http.StatusOK, resp.StatusCode)
 		}
+
+		if !slices.Equal(dummyRepo.TrashedIds, []string{"legit-id"}) {
+			t.Errorf("Expected legit-id to be moved to the trash, got %v", dummyRepo.TrashedIds)
+		}
+	})
+
+	t.Run("Given DELETE request, with If-Match, should pass the version to the repository", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+		r := httptest.NewRequestWithContext(ctx, http.MethodDelete, "/clothes/legit-id", nil)
+		r.Header.Set("If-Match", `"2"`)
+		r = mux.SetURLVars(r, map[string]string{"id": "legit-id"})
+
+		dummyRepo := &DummyClothingRepo{
+			ShouldExist: true,
+		}
+		apiHandler := &API{
+			Repo: dummyRepo,
+		}
+
+		apiHandler.DeleteClothing(w, r)
+
+		resp := w.Result()
+
+		if resp.StatusCode != http.StatusNoContent {
+			t.Errorf("Expected %d got %d", http.StatusNoContent, resp.StatusCode)
+		}
+
+		if dummyRepo.TrashedVersion != 2 {
+			t.Errorf("Expected Trash to be called with version 2, got %d", dummyRepo.TrashedVersion)
+		}
+	})
+
+	t.Run("Given DELETE request, with a stale If-Match, should return 412", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+		r := httptest.NewRequestWithContext(ctx, http.MethodDelete, "/clothes/legit-id", nil)
+		r.Header.Set("If-Match", `"1"`)
+		r = mux.SetURLVars(r, map[string]string{"id": "legit-id"})
+
+		dummyRepo := &DummyClothingRepo{
+			TrashError:  fmt.Errorf("stale: %w", repository.ErrPreconditionFailed),
+			ShouldExist: true,
+		}
+		apiHandler := &API{
+			Repo: dummyRepo,
+		}
+
+		apiHandler.DeleteClothing(w, r)
+
+		resp := w.Result()
+
+		if resp.StatusCode != http.StatusPreconditionFailed {
+			t.Errorf("Expected %d got %d", http.StatusPreconditionFailed, resp.StatusCode)
+		}
+	})
+
+	t.Run("Given DELETE request, for an item used by an outfit, should return 409 and not trash it", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+		r := httptest.NewRequestWithContext(ctx, http.MethodDelete, "/clothes/legit-id", nil)
+		r = mux.SetURLVars(r, map[string]string{"id": "legit-id"})
+
+		outfits := repository.NewInMemoryOutfitRepository()
+		outfits.Save(context.Background(), "test-user-id", domain.Outfit{Name: "Office", ItemIds: []string{"legit-id"}})
+
+		dummyRepo := &DummyClothingRepo{
+			ShouldExist: true,
+		}
+		apiHandler := &API{
+			Repo:    dummyRepo,
+			Outfits: outfits,
+		}
+
+		apiHandler.DeleteClothing(w, r)
+
+		resp := w.Result()
+
+		if resp.StatusCode != http.StatusConflict {
+			t.Errorf("Expected %d got %d", http.StatusConflict, resp.StatusCode)
+		}
+
+		if !strings.Contains(w.Body.String(), "Office") {
+			t.Errorf("Expected the outfit to be named, got %s", w.Body.String())
+		}
+
+		if len(dummyRepo.TrashedIds) != 0 {
+			t.Errorf("Expected nothing to be trashed, got %v", dummyRepo.TrashedIds)
+		}
+	})
+
+}
+
+func TestParseIfMatch(t *testing.T) {
+	t.Run("Given no header or a wildcard, should return 0 and ok", func(t *testing.T) {
+		for _, header := range []string{"", "*"} {
+			version, ok := ParseIfMatch(header)
+
+			if !ok || version != 0 {
+				t.Errorf("%q: Expected 0 and ok, got %d and %v", header, version, ok)
+			}
+		}
+	})
+
+	t.Run("Given an ETag from VersionETag, should return its version", func(t *testing.T) {
+		version, ok := ParseIfMatch(VersionETag(42))
+
+		if !ok || version != 42 {
+			t.Errorf("Expected 42 and ok, got %d and %v", version, ok)
+		}
+	})
+
+	t.Run("Given a weak, unquoted or non-numeric ETag, should not be ok", func(t *testing.T) {
+		for _, header := range []string{`W/"1"`, `1`, `"abc"`, `"0"`, `"1", "2"`} {
+			if _, ok := ParseIfMatch(header); ok {
+				t.Errorf("%q: Expected not ok", header)
+			}
+		}
 	})
 }
@@ -0,0 +1,259 @@
+package api
+
+import (
+	"clothes_management/internal/domain"
+	"clothes_management/internal/repository"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const NDJSONContentType = "application/x-ndjson"
+
+// exportPageTimeout is how long writing each page of an export may take. Exports are not bound by the
+// server's request timeout, so the write deadline is pushed back by this much before every page.
+const exportPageTimeout = 10 * time.Second
+
+// exportCSVHeader lists the CSV columns in order. They are the domain.Clothing JSON names, so an export
+// can be imported again, plus 'price' carrying the price as text. Times are RFC 3339 in UTC, and blank when
+// unset. Lists such as tags are joined by csvListSeparator in a single column.
+var exportCSVHeader = []string{"id", "userId", "clothingType", "description", "brand", "store", "size", "pricePence", "price", "imageUrl", "version", "currency", "purchasedAt", "tags", "colour", "secondaryColours", "seasons", "occasions"}
+
+// csvListSeparator separates the values of a list held in one CSV column.
+const csvListSeparator = ";"
+
+// exportWriter writes one export format. Flush pushes any buffered items to the underlying writer and
+// Close finishes the document, but neither closes the underlying writer.
+type exportWriter interface {
+	Write(item domain.Clothing) error
+	Flush() error
+	Close() error
+}
+
+type csvExportWriter struct {
+	w *csv.Writer
+}
+
+func newCSVExportWriter(w io.Writer) (*csvExportWriter, error) {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(exportCSVHeader); err != nil {
+		return nil, err
+	}
+
+	return &csvExportWriter{w: writer}, nil
+}
+
+func (c *csvExportWriter) Write(item domain.Clothing) error {
+	return c.w.Write([]string{
+		item.Id,
+		item.UserId,
+		item.ClothingType,
+		item.Description,
+		item.Brand,
+		item.Store,
+		item.Size,
+		strconv.FormatInt(int64(item.Price), 10),
+		item.PriceMoney().Format(),
+		item.ImageUrl,
+		strconv.FormatInt(item.Version, 10),
+		string(item.PriceMoney().Currency),
+		formatCSVTime(item.PurchasedAt),
+		strings.Join(item.Tags, csvListSeparator),
+		string(item.Colour),
+		joinCSVList(item.SecondaryColours),
+		joinCSVList(item.Seasons),
+		joinCSVList(item.Occasions),
+	})
+}
+
+// joinCSVList writes values as splitCSVList reads them.
+func joinCSVList[T ~string](values []T) string {
+	text := make([]string, len(values))
+
+	for i, value := range values {
+		text[i] = string(value)
+	}
+
+	return strings.Join(text, csvListSeparator)
+}
+
+// formatCSVTime writes t as parseCSVTime reads it.
+func formatCSVTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
+
+func (c *csvExportWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvExportWriter) Close() error {
+	return c.Flush()
+}
+
+// jsonExportWriter writes a single JSON array, which ImportClothing accepts as it is.
+type jsonExportWriter struct {
+	w       io.Writer
+	written bool
+}
+
+func (j *jsonExportWriter) Write(item domain.Clothing) error {
+	separator := ","
+
+	if !j.written {
+		separator = "["
+		j.written = true
+	}
+
+	raw, err := json.Marshal(item)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(j.w, "%s%s", separator, raw)
+	return err
+}
+
+func (j *jsonExportWriter) Flush() error {
+	return nil
+}
+
+func (j *jsonExportWriter) Close() error {
+	if !j.written {
+		_, err := io.WriteString(j.w, "[]\n")
+		return err
+	}
+
+	_, err := io.WriteString(j.w, "]\n")
+	return err
+}
+
+// ndjsonExportWriter writes one JSON object per line.
+type ndjsonExportWriter struct {
+	enc *json.Encoder
+}
+
+func (n *ndjsonExportWriter) Write(item domain.Clothing) error {
+	return n.enc.Encode(item)
+}
+
+func (n *ndjsonExportWriter) Flush() error {
+	return nil
+}
+
+func (n *ndjsonExportWriter) Close() error {
+	return nil
+}
+
+// ExportClothing streams the user's whole inventory as an attachment in the 'format' given: csv, json or
+// ndjson. Items are read and written a page at a time, so an export never holds the inventory in memory.
+func (a *API) ExportClothing(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, fmt.Sprintf("Unauthorised method %s.", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	userId, ok := r.Context().Value(UserIDContextKey).(string)
+
+	if !ok {
+		http.Error(w, "UserID not provided", http.StatusUnauthorized)
+		return
+	}
+
+	if strings.TrimSpace(userId) == "" {
+		http.Error(w, "UserID not provided", http.StatusUnauthorized)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+
+	var contentType string
+
+	switch format {
+	case "csv":
+		contentType = CSVContentType + "; charset=utf-8"
+	case "json":
+		contentType = "application/json"
+	case "ndjson":
+		contentType = NDJSONContentType
+	default:
+		http.Error(w, "Invalid 'format' parameter, must be csv, json or ndjson", http.StatusBadRequest)
+		return
+	}
+
+	controller := http.NewResponseController(w)
+	controller.SetWriteDeadline(time.Now().Add(exportPageTimeout))
+
+	// The first page is read before anything is written, so a failure here can still be reported.
+	pageRequest := repository.PageRequest{Limit: repository.MaxPageLimit}
+	page, err := a.Repo.GetPage(r.Context(), userId, pageRequest)
+
+	if err != nil {
+		writeRepositoryError(w, err, "", "Error exporting clothing items")
+		return
+	}
+
+	filename := fmt.Sprintf("clothes-%s.%s", time.Now().UTC().Format(time.DateOnly), format)
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+
+	var writer exportWriter
+
+	switch format {
+	case "csv":
+		writer, err = newCSVExportWriter(w)
+	case "json":
+		writer = &jsonExportWriter{w: w}
+	case "ndjson":
+		writer = &ndjsonExportWriter{enc: json.NewEncoder(w)}
+	}
+
+	for err == nil {
+		for _, item := range page.Items {
+			if err = writer.Write(item); err != nil {
+				break
+			}
+		}
+
+		if err != nil || page.NextCursor == "" {
+			break
+		}
+
+		if err = writer.Flush(); err != nil {
+			break
+		}
+
+		controller.Flush()
+		controller.SetWriteDeadline(time.Now().Add(exportPageTimeout))
+
+		pageRequest.Cursor = page.NextCursor
+		page, err = a.Repo.GetPage(r.Context(), userId, pageRequest)
+	}
+
+	if err == nil {
+		err = writer.Close()
+	}
+
+	if err != nil {
+		// The status has already been sent, so abort the response rather than let a truncated export
+		// look complete.
+		log.Printf("ERROR: Export for user %s failed part way through: %v", userId, err)
+		panic(http.ErrAbortHandler)
+	}
+}
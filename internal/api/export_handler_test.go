@@ -0,0 +1,341 @@
+package api
+
+import (
+	"bufio"
+	"clothes_management/internal/domain"
+	"clothes_management/internal/repository"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// failingPageRepo fails every GetPage after the first FailAfter calls.
+type failingPageRepo struct {
+	repository.ClothingRepository
+	FailAfter int
+	calls     int
+}
+
+func (f *failingPageRepo) GetPage(ctx context.Context, userId string, page repository.PageRequest) (repository.Page, error) {
+	f.calls++
+
+	if f.calls > f.FailAfter {
+		return repository.Page{}, errors.New("boom")
+	}
+
+	return f.ClothingRepository.GetPage(ctx, userId, page)
+}
+
+// newExportRepo returns an in-memory repository holding count items for test-user-id, enough to span
+// several pages when count is over repository.MaxPageLimit.
+func newExportRepo(t *testing.T, count int) *repository.InMemoryClothingRepository {
+	t.Helper()
+
+	repo := repository.NewInMemoryClothingRepository()
+
+	for i := 0; i < count; i++ {
+		_, err := repo.Save(context.Background(), "test-user-id", domain.Clothing{
+			ClothingType: "Jumper",
+			Description:  fmt.Sprintf("Jumper %d, knitted", i),
+			Brand:        "XYZ",
+			Store:        "This Store",
+			Size:         "L",
+			Price:        domain.Pence(100000 + i),
+		})
+
+		if err != nil {
+			t.Fatalf("Expected no error on Save, got %v", err)
+		}
+	}
+
+	return repo
+}
+
+// roundTripCSV saves item, exports it as CSV and imports the export again, returning the item the
+// import would create.
+func roundTripCSV(t *testing.T, item domain.Clothing) domain.Clothing {
+	t.Helper()
+
+	source := repository.NewInMemoryClothingRepository()
+
+	if _, err := source.Save(context.Background(), "test-user-id", item); err != nil {
+		t.Fatalf("Expected no error on Save, got %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	apiHandler := &API{Repo: source}
+	apiHandler.ExportClothing(w, newExportRequest("csv"))
+
+	exported, _ := io.ReadAll(w.Result().Body)
+
+	w = httptest.NewRecorder()
+	repo := &DummyClothingRepo{}
+	apiHandler = &API{Repo: repo}
+	apiHandler.ImportClothing(w, newImportRequest(t, "/clothes/import?allOrNothing=true", CSVContentType, string(exported)))
+
+	if w.Result().StatusCode != http.StatusCreated || len(repo.SaveManyItems) != 1 {
+		t.Fatalf("Expected the exported item to be imported, got %d and %d items", w.Result().StatusCode, len(repo.SaveManyItems))
+	}
+
+	return repo.SaveManyItems[0]
+}
+
+func newExportRequest(format string) *http.Request {
+	ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+	return httptest.NewRequestWithContext(ctx, http.MethodGet, "/clothes/export?format="+format, nil)
+}
+
+func TestExportClothing(t *testing.T) {
+	const itemCount = repository.MaxPageLimit + 30
+
+	t.Run("Given request method is not allowed, should return error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := newExportRequest("csv")
+		r.Method = http.MethodPost
+
+		apiHandler := &API{Repo: &DummyClothingRepo{}}
+		apiHandler.ExportClothing(w, r)
+
+		if w.Result().StatusCode != http.StatusMethodNotAllowed {
+			t.Errorf("Expected %d got %d", http.StatusMethodNotAllowed, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given no UserID in context, should return 401", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/clothes/export?format=csv", nil)
+
+		apiHandler := &API{Repo: &DummyClothingRepo{}}
+		apiHandler.ExportClothing(w, r)
+
+		if w.Result().StatusCode != http.StatusUnauthorized {
+			t.Errorf("Expected %d got %d", http.StatusUnauthorized, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given a missing or unknown format, should return 400", func(t *testing.T) {
+		for _, format := range []string{"", "xml"} {
+			w := httptest.NewRecorder()
+
+			apiHandler := &API{Repo: &DummyClothingRepo{}}
+			apiHandler.ExportClothing(w, newExportRequest(format))
+
+			if w.Result().StatusCode != http.StatusBadRequest {
+				t.Errorf("Format %q: Expected %d got %d", format, http.StatusBadRequest, w.Result().StatusCode)
+			}
+		}
+	})
+
+	t.Run("Given the first page fails, should return 500", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		apiHandler := &API{Repo: &failingPageRepo{ClothingRepository: newExportRepo(t, 1)}}
+		apiHandler.ExportClothing(w, newExportRequest("json"))
+
+		if w.Result().StatusCode != http.StatusInternalServerError {
+			t.Errorf("Expected %d got %d", http.StatusInternalServerError, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given csv, should stream every item as an attachment with pence and pounds columns", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		apiHandler := &API{Repo: newExportRepo(t, itemCount)}
+		apiHandler.ExportClothing(w, newExportRequest("csv"))
+
+		resp := w.Result()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Expected %d got %d", http.StatusOK, resp.StatusCode)
+		}
+
+		if !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/csv") {
+			t.Errorf("Expected a text/csv Content-Type, got %s", resp.Header.Get("Content-Type"))
+		}
+
+		if disposition := resp.Header.Get("Content-Disposition"); !strings.HasPrefix(disposition, "attachment;") || !strings.Contains(disposition, ".csv") {
+			t.Errorf("Expected a .csv attachment, got %s", disposition)
+		}
+
+		records, err := csv.NewReader(resp.Body).ReadAll()
+
+		if err != nil {
+			t.Fatalf("Expected valid CSV, got %v", err)
+		}
+
+		if len(records) != itemCount+1 {
+			t.Fatalf("Expected a header and %d rows, got %d records", itemCount, len(records))
+		}
+
+		if strings.Join(records[0], ",") != strings.Join(exportCSVHeader, ",") {
+			t.Errorf("Expected header %v, got %v", exportCSVHeader, records[0])
+		}
+
+		for _, record := range records[1:] {
+			pence, err := strconv.ParseInt(record[7], 10, 64)
+
+			if err != nil || record[8] != domain.Pence(pence).AsPounds() {
+				t.Fatalf("Expected a pricePence and the matching price, got %s and %s", record[7], record[8])
+			}
+
+			if record[11] != "GBP" {
+				t.Fatalf("Expected items without a currency to be exported in GBP, got %s", record[11])
+			}
+		}
+	})
+
+	t.Run("Given json, should stream a single array of every item", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		apiHandler := &API{Repo: newExportRepo(t, itemCount)}
+		apiHandler.ExportClothing(w, newExportRequest("json"))
+
+		var items []domain.Clothing
+
+		if err := json.NewDecoder(w.Result().Body).Decode(&items); err != nil {
+			t.Fatalf("Expected a JSON array, got %v", err)
+		}
+
+		if len(items) != itemCount || items[0].UserId != "test-user-id" {
+			t.Errorf("Expected %d items for test-user-id, got %d", itemCount, len(items))
+		}
+	})
+
+	t.Run("Given json and no items, should return an empty array", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		apiHandler := &API{Repo: newExportRepo(t, 0)}
+		apiHandler.ExportClothing(w, newExportRequest("json"))
+
+		body, _ := io.ReadAll(w.Result().Body)
+
+		if strings.TrimSpace(string(body)) != "[]" {
+			t.Errorf("Expected [], got %s", body)
+		}
+	})
+
+	t.Run("Given ndjson, should write one item per line", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		apiHandler := &API{Repo: newExportRepo(t, itemCount)}
+		apiHandler.ExportClothing(w, newExportRequest("ndjson"))
+
+		resp := w.Result()
+
+		if resp.Header.Get("Content-Type") != NDJSONContentType {
+			t.Errorf("Expected %s, got %s", NDJSONContentType, resp.Header.Get("Content-Type"))
+		}
+
+		lines := 0
+		scanner := bufio.NewScanner(resp.Body)
+
+		for scanner.Scan() {
+			var item domain.Clothing
+
+			if err := json.Unmarshal(scanner.Bytes(), &item); err != nil {
+				t.Fatalf("Expected line %d to be a JSON object, got %v", lines+1, err)
+			}
+
+			lines++
+		}
+
+		if lines != itemCount {
+			t.Errorf("Expected %d lines, got %d", itemCount, lines)
+		}
+	})
+
+	t.Run("Given a later page fails, should abort the response rather than end it cleanly", func(t *testing.T) {
+		apiHandler := &API{Repo: &failingPageRepo{ClothingRepository: newExportRepo(t, itemCount), FailAfter: 1}}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			apiHandler.ExportClothing(w, r.WithContext(context.WithValue(r.Context(), UserIDContextKey, "test-user-id")))
+		}))
+		defer server.Close()
+
+		resp, err := http.Get(server.URL + "/clothes/export?format=json")
+
+		if err != nil {
+			t.Fatalf("Expected the response to start, got %v", err)
+		}
+
+		defer resp.Body.Close()
+
+		if _, err := io.ReadAll(resp.Body); err == nil {
+			t.Errorf("Expected reading the aborted body to fail")
+		}
+	})
+
+	t.Run("Given a csv export, should be accepted by the import", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		apiHandler := &API{Repo: newExportRepo(t, 3)}
+		apiHandler.ExportClothing(w, newExportRequest("csv"))
+
+		exported, _ := io.ReadAll(w.Result().Body)
+
+		w = httptest.NewRecorder()
+		repo := &DummyClothingRepo{}
+		apiHandler = &API{Repo: repo}
+		apiHandler.ImportClothing(w, newImportRequest(t, "/clothes/import?allOrNothing=true", CSVContentType, string(exported)))
+
+		if w.Result().StatusCode != http.StatusCreated || len(repo.SaveManyItems) != 3 {
+			t.Errorf("Expected the 3 exported items to be imported, got %d and %d items", w.Result().StatusCode, len(repo.SaveManyItems))
+		}
+	})
+	t.Run("Given a purchase time, should export it and import it again", func(t *testing.T) {
+		purchasedAt := time.Date(2024, 12, 25, 9, 30, 0, 0, time.UTC)
+
+		item := roundTripCSV(t, domain.Clothing{ClothingType: "Jumper", Description: "Gift", Brand: "XYZ", Store: "This Store", Size: "L", Price: 2000, PurchasedAt: &purchasedAt})
+
+		if item.PurchasedAt == nil || !item.PurchasedAt.Equal(purchasedAt) {
+			t.Errorf("Expected purchased at %v, got %v", purchasedAt, item.PurchasedAt)
+		}
+
+		if item = roundTripCSV(t, domain.Clothing{ClothingType: "Jumper", Description: "Unknown", Brand: "XYZ", Store: "This Store", Size: "L", Price: 2000}); item.PurchasedAt != nil {
+			t.Errorf("Expected no purchase time, got %v", item.PurchasedAt)
+		}
+	})
+	t.Run("Given tags, should export them in one column and import them again", func(t *testing.T) {
+		item := roundTripCSV(t, domain.Clothing{ClothingType: "Jumper", Description: "Tagged", Brand: "XYZ", Store: "This Store", Size: "L", Price: 2000, Tags: []string{"smart casual", "winter", "work"}})
+
+		if !slices.Equal(item.Tags, []string{"smart casual", "winter", "work"}) {
+			t.Errorf("Expected the three tags, got %q", item.Tags)
+		}
+
+		if item = roundTripCSV(t, domain.Clothing{ClothingType: "Jumper", Description: "Untagged", Brand: "XYZ", Store: "This Store", Size: "L", Price: 2000}); item.Tags != nil {
+			t.Errorf("Expected no tags, got %q", item.Tags)
+		}
+	})
+	t.Run("Given colours, should export them and import them again", func(t *testing.T) {
+		item := roundTripCSV(t, domain.Clothing{ClothingType: "Jumper", Description: "Striped", Brand: "XYZ", Store: "This Store", Size: "L", Price: 2000, Colour: "navy", SecondaryColours: []domain.Colour{"white", "red"}})
+
+		if item.Colour != "navy" || !slices.Equal(item.SecondaryColours, []domain.Colour{"white", "red"}) {
+			t.Errorf("Expected navy with white and red, got %q and %q", item.Colour, item.SecondaryColours)
+		}
+
+		if item = roundTripCSV(t, domain.Clothing{ClothingType: "Jumper", Description: "Plain", Brand: "XYZ", Store: "This Store", Size: "L", Price: 2000}); item.Colour != "" || item.SecondaryColours != nil {
+			t.Errorf("Expected no colours, got %q and %q", item.Colour, item.SecondaryColours)
+		}
+	})
+	t.Run("Given seasons and occasions, should export them and import them again", func(t *testing.T) {
+		item := roundTripCSV(t, domain.Clothing{ClothingType: "Jumper", Description: "Knit", Brand: "XYZ", Store: "This Store", Size: "L", Price: 2000, Seasons: []domain.Season{domain.Autumn, domain.Winter}, Occasions: []domain.Occasion{domain.Work}})
+
+		if !slices.Equal(item.Seasons, []domain.Season{domain.Autumn, domain.Winter}) || !slices.Equal(item.Occasions, []domain.Occasion{domain.Work}) {
+			t.Errorf("Expected autumn and winter for work, got %q and %q", item.Seasons, item.Occasions)
+		}
+
+		if item = roundTripCSV(t, domain.Clothing{ClothingType: "Jumper", Description: "Any time", Brand: "XYZ", Store: "This Store", Size: "L", Price: 2000}); item.Seasons != nil || item.Occasions != nil {
+			t.Errorf("Expected no seasons or occasions, got %q and %q", item.Seasons, item.Occasions)
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// GetClothingHistory lists every recorded change to an item, oldest first. The history outlives the
+// item, so it is still returned once the item is trashed or purged.
+func (a *API) GetClothingHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, fmt.Sprintf("Unauthorised method %s.", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	userId, ok := r.Context().Value(UserIDContextKey).(string)
+
+	if !ok {
+		http.Error(w, "UserID not provided", http.StatusUnauthorized)
+		return
+	}
+
+	if strings.TrimSpace(userId) == "" {
+		http.Error(w, "UserID not provided", http.StatusUnauthorized)
+		return
+	}
+
+	vars := mux.Vars(r)
+	id, exists := vars["id"]
+
+	if !exists {
+		http.Error(w, "Missing 'id' parameter", http.StatusBadRequest)
+		return
+	}
+
+	id = strings.TrimSpace(id)
+
+	if len(id) == 0 {
+		http.Error(w, "Missing 'id' parameter", http.StatusBadRequest)
+		return
+	}
+
+	entries, err := a.History.List(r.Context(), userId, id)
+
+	if err != nil {
+		writeRepositoryError(w, err, id, fmt.Sprintf("Unable to get history for ID %s", id))
+		return
+	}
+
+	// An item created before history was recorded has none, which is not the same as not existing.
+	if len(entries) == 0 {
+		found, err := a.Repo.Exists(r.Context(), userId, id)
+
+		if err != nil {
+			writeRepositoryError(w, err, id, fmt.Sprintf("Unable to get history for ID %s", id))
+			return
+		}
+
+		if !found {
+			http.Error(w, fmt.Sprintf("Clothing item not found for ID %s", id), http.StatusNotFound)
+			return
+		}
+	}
+
+	resp := map[string]any{"success": true, "data": entries}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"clothes_management/internal/domain"
+	"clothes_management/internal/repository"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// failingHistoryRepo fails every List.
+type failingHistoryRepo struct {
+	repository.InMemoryHistoryRepository
+}
+
+func (f *failingHistoryRepo) List(ctx context.Context, userId, itemId string) ([]domain.HistoryEntry, error) {
+	return nil, errors.New("boom")
+}
+
+func newHistoryRequest(id string) *http.Request {
+	ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+	r := httptest.NewRequestWithContext(ctx, http.MethodGet, "/clothes/"+id+"/history", nil)
+	return mux.SetURLVars(r, map[string]string{"id": id})
+}
+
+func TestGetClothingHistory(t *testing.T) {
+	t.Run("Given request method is not allowed, should return error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := newHistoryRequest("1")
+		r.Method = http.MethodPost
+
+		apiHandler := &API{Repo: &DummyClothingRepo{}, History: repository.NewInMemoryHistoryRepository()}
+		apiHandler.GetClothingHistory(w, r)
+
+		if w.Result().StatusCode != http.StatusMethodNotAllowed {
+			t.Errorf("Expected %d got %d", http.StatusMethodNotAllowed, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given no UserID in context, should return 401", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/clothes/1/history", nil), map[string]string{"id": "1"})
+
+		apiHandler := &API{Repo: &DummyClothingRepo{}, History: repository.NewInMemoryHistoryRepository()}
+		apiHandler.GetClothingHistory(w, r)
+
+		if w.Result().StatusCode != http.StatusUnauthorized {
+			t.Errorf("Expected %d got %d", http.StatusUnauthorized, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given an item with history, should return its entries oldest first", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		history := repository.NewInMemoryHistoryRepository()
+		now := time.Now()
+		history.Append(context.Background(),
+			domain.HistoryEntry{UserId: "test-user-id", ItemId: "1", Action: domain.HistoryUpdated, ActedBy: "test-user-id", Timestamp: now.Add(time.Second),
+				Changes: []domain.FieldChange{{Field: "description", Old: json.RawMessage(`"Blue"`), New: json.RawMessage(`"Red"`)}}},
+			domain.HistoryEntry{UserId: "test-user-id", ItemId: "1", Action: domain.HistoryCreated, ActedBy: "test-user-id", Timestamp: now},
+			domain.HistoryEntry{UserId: "other-user-id", ItemId: "1", Action: domain.HistoryCreated, ActedBy: "other-user-id", Timestamp: now})
+
+		repo := &DummyClothingRepo{}
+		apiHandler := &API{Repo: repo, History: history}
+		apiHandler.GetClothingHistory(w, newHistoryRequest("1"))
+
+		if w.Result().StatusCode != http.StatusOK {
+			t.Fatalf("Expected %d got %d", http.StatusOK, w.Result().StatusCode)
+		}
+
+		var body struct {
+			Success bool                  `json:"success"`
+			Data    []domain.HistoryEntry `json:"data"`
+		}
+
+		if err := json.NewDecoder(w.Result().Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+
+		if !body.Success || len(body.Data) != 2 || body.Data[0].Action != domain.HistoryCreated || body.Data[1].Action != domain.HistoryUpdated {
+			t.Fatalf("Expected the user's create then update, got %+v", body)
+		}
+
+		if changes := body.Data[1].Changes; len(changes) != 1 || string(changes[0].Old) != `"Blue"` || string(changes[0].New) != `"Red"` {
+			t.Errorf("Expected the description change, got %+v", changes)
+		}
+
+		if repo.ExistsCalled {
+			t.Errorf("Expected Exists not to be called for an item with history")
+		}
+	})
+
+	t.Run("Given an existing item without history, should return an empty list", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		apiHandler := &API{Repo: &DummyClothingRepo{ShouldExist: true}, History: repository.NewInMemoryHistoryRepository()}
+		apiHandler.GetClothingHistory(w, newHistoryRequest("1"))
+
+		var body struct {
+			Data []domain.HistoryEntry `json:"data"`
+		}
+
+		if w.Result().StatusCode != http.StatusOK {
+			t.Fatalf("Expected %d got %d", http.StatusOK, w.Result().StatusCode)
+		}
+
+		if err := json.NewDecoder(w.Result().Body).Decode(&body); err != nil || body.Data == nil || len(body.Data) != 0 {
+			t.Errorf("Expected an empty list, got %+v and %v", body.Data, err)
+		}
+	})
+
+	t.Run("Given a missing item without history, should return 404", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		apiHandler := &API{Repo: &DummyClothingRepo{}, History: repository.NewInMemoryHistoryRepository()}
+		apiHandler.GetClothingHistory(w, newHistoryRequest("1"))
+
+		if w.Result().StatusCode != http.StatusNotFound {
+			t.Errorf("Expected %d got %d", http.StatusNotFound, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given the history fails, should return 500", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		apiHandler := &API{Repo: &DummyClothingRepo{ShouldExist: true}, History: &failingHistoryRepo{}}
+		apiHandler.GetClothingHistory(w, newHistoryRequest("1"))
+
+		if w.Result().StatusCode != http.StatusInternalServerError {
+			t.Errorf("Expected %d got %d", http.StatusInternalServerError, w.Result().StatusCode)
+		}
+	})
+}
@@ -0,0 +1,200 @@
+package api
+
+import (
+	"clothes_management/internal/repository"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// MaxImageBytes is the largest image UploadClothingImage accepts.
+const MaxImageBytes = 5 << 20
+
+// allowedImageTypes are checked against the sniffed content, not the type the client claims.
+var allowedImageTypes = []string{"image/jpeg", "image/png", "image/gif", "image/webp"}
+
+// ClothingImageUrl is the ImageUrl set on an item once an image has been uploaded for it.
+func ClothingImageUrl(id string) string {
+	return fmt.Sprintf("/clothes/%s/image", url.PathEscape(id))
+}
+
+// UploadClothingImage stores the 'image' file of a multipart form as the item's image and points the
+// item's ImageUrl at it. The image is only stored once the item has been updated.
+func (a *API) UploadClothingImage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, fmt.Sprintf("Unauthorised method %s.", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	userId, ok := r.Context().Value(UserIDContextKey).(string)
+
+	if !ok {
+		http.Error(w, "UserID not provided", http.StatusUnauthorized)
+		return
+	}
+
+	if strings.TrimSpace(userId) == "" {
+		http.Error(w, "UserID not provided", http.StatusUnauthorized)
+		return
+	}
+
+	id := strings.TrimSpace(mux.Vars(r)["id"])
+
+	if len(id) == 0 {
+		http.Error(w, "Missing 'id' parameter", http.StatusBadRequest)
+		return
+	}
+
+	expectedVersion, ok := ParseIfMatch(r.Header.Get("If-Match"))
+
+	if !ok {
+		http.Error(w, "If-Match header must be a single ETag previously returned for this item", http.StatusPreconditionFailed)
+		return
+	}
+
+	// Leave room for the multipart boundaries and headers around the file itself.
+	r.Body = http.MaxBytesReader(w, r.Body, MaxImageBytes+(64<<10))
+
+	file, _, err := r.FormFile("image")
+
+	var maxBytesErr *http.MaxBytesError
+
+	if errors.As(err, &maxBytesErr) {
+		http.Error(w, fmt.Sprintf("Image must not be larger than %d bytes", MaxImageBytes), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, "Request body must be multipart/form-data with an 'image' file", http.StatusBadRequest)
+		return
+	}
+
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, MaxImageBytes+1))
+
+	if err != nil {
+		http.Error(w, "Failed to read image", http.StatusBadRequest)
+		return
+	}
+
+	if len(data) > MaxImageBytes {
+		http.Error(w, fmt.Sprintf("Image must not be larger than %d bytes", MaxImageBytes), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	contentType := http.DetectContentType(data)
+
+	if !slices.Contains(allowedImageTypes, contentType) {
+		http.Error(w, fmt.Sprintf("Image must be one of %s", strings.Join(allowedImageTypes, ", ")), http.StatusUnsupportedMediaType)
+		return
+	}
+
+	item, err := a.Repo.GetById(r.Context(), userId, id)
+
+	if err != nil {
+		writeRepositoryError(w, err, id, fmt.Sprintf("Unable to get clothing for ID %s", id))
+		return
+	}
+
+	if expectedVersion != 0 && expectedVersion != item.Version {
+		http.Error(w, fmt.Sprintf("Clothing item %s has been modified since it was retrieved", id), http.StatusPreconditionFailed)
+		return
+	}
+
+	previousUrl := item.ImageUrl
+	item.ImageUrl = ClothingImageUrl(id)
+
+	// The version-checked update goes first, so an upload that loses a race to another write leaves the
+	// stored image as it was.
+	item, err = a.Repo.Update(r.Context(), userId, item)
+
+	if err != nil {
+		writeRepositoryError(w, err, id, "Error updating clothing item")
+		return
+	}
+
+	if err := a.Images.Put(r.Context(), userId, id, contentType, data); err != nil {
+		if previousUrl != item.ImageUrl {
+			item.ImageUrl = previousUrl
+
+			if _, revertErr := a.Repo.Update(r.Context(), userId, item); revertErr != nil {
+				log.Printf("WARN: Failed to clear ImageUrl of ID %s after the image was not stored: %v", id, revertErr)
+			}
+		}
+
+		writeRepositoryError(w, err, id, "Error storing image")
+		return
+	}
+
+	resp := map[string]any{"success": true, "data": item}
+	w.Header().Set("ETag", VersionETag(item.Version))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(resp)
+}
+
+// GetClothingImage streams the uploaded image of a live item, with a 404 once the item is trashed.
+func (a *API) GetClothingImage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, fmt.Sprintf("Unauthorised method %s.", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	userId, ok := r.Context().Value(UserIDContextKey).(string)
+
+	if !ok {
+		http.Error(w, "UserID not provided", http.StatusUnauthorized)
+		return
+	}
+
+	if strings.TrimSpace(userId) == "" {
+		http.Error(w, "UserID not provided", http.StatusUnauthorized)
+		return
+	}
+
+	id := strings.TrimSpace(mux.Vars(r)["id"])
+
+	if len(id) == 0 {
+		http.Error(w, "Missing 'id' parameter", http.StatusBadRequest)
+		return
+	}
+
+	// Images outlive their items in the trash until purged, but are only served while the item is live.
+	if _, err := a.Repo.GetById(r.Context(), userId, id); err != nil {
+		writeRepositoryError(w, err, id, fmt.Sprintf("Unable to get image for ID %s", id))
+		return
+	}
+
+	image, err := a.Images.Get(r.Context(), userId, id)
+
+	if errors.Is(err, repository.ErrNotFound) {
+		http.Error(w, fmt.Sprintf("Image not found for ID %s", id), http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		writeRepositoryError(w, err, id, fmt.Sprintf("Unable to get image for ID %s", id))
+		return
+	}
+
+	defer image.Body.Close()
+
+	w.Header().Set("Content-Type", image.ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "private")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := io.Copy(w, image.Body); err != nil {
+		log.Printf("WARN: Failed to stream image for ID %s: %v", id, err)
+	}
+}
@@ -0,0 +1,318 @@
+package api
+
+import (
+	"bytes"
+	"clothes_management/internal/domain"
+	"clothes_management/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+var pngBytes = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+type DummyImageStore struct {
+	Images      map[string][]byte
+	PutError    error
+	DeleteError error
+	DeletedID   string
+}
+
+func (d *DummyImageStore) Put(ctx context.Context, userId, id, contentType string, data []byte) error {
+	if d.PutError != nil {
+		return d.PutError
+	}
+
+	if d.Images == nil {
+		d.Images = map[string][]byte{}
+	}
+
+	d.Images[userId+"/"+id] = data
+	return nil
+}
+
+func (d *DummyImageStore) Get(ctx context.Context, userId, id string) (repository.Image, error) {
+	data, exists := d.Images[userId+"/"+id]
+
+	if !exists {
+		return repository.Image{}, fmt.Errorf("image %s not found (dummy): %w", id, repository.ErrNotFound)
+	}
+
+	return repository.Image{ContentType: "image/png", Body: io.NopCloser(bytes.NewReader(data))}, nil
+}
+
+func (d *DummyImageStore) Delete(ctx context.Context, userId, id string) error {
+	d.DeletedID = id
+
+	if d.DeleteError != nil {
+		return d.DeleteError
+	}
+
+	delete(d.Images, userId+"/"+id)
+	return nil
+}
+
+func newImageUploadRequest(t *testing.T, id string, field string, data []byte) *http.Request {
+	t.Helper()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	part, err := writer.CreateFormFile(field, "image.png")
+	if err != nil {
+		t.Fatalf("failed to create form file: %v", err)
+	}
+
+	part.Write(data)
+	writer.Close()
+
+	ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+	r := httptest.NewRequestWithContext(ctx, http.MethodPost, "/clothes/"+id+"/image", &body)
+	r.Header.Set("Content-Type", writer.FormDataContentType())
+	return mux.SetURLVars(r, map[string]string{"id": id})
+}
+
+func TestUploadClothingImage(t *testing.T) {
+	t.Run("Given a PNG for an existing item, should store it and set the item's ImageUrl", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := newImageUploadRequest(t, "test-id", "image", pngBytes)
+
+		stored := domain.Clothing{Id: "test-id", UserId: "test-user-id", Version: 2}
+		dummyRepo := &DummyClothingRepo{GetByIdItem: &stored, ShouldExist: true}
+		images := &DummyImageStore{}
+		apiHandler := &API{
+			Repo:   dummyRepo,
+			Images: images,
+		}
+		apiHandler.UploadClothingImage(w, r)
+
+		if w.Result().StatusCode != http.StatusOK {
+			t.Fatalf("Expected %d got %d: %s", http.StatusOK, w.Result().StatusCode, w.Body.String())
+		}
+
+		if !bytes.Equal(images.Images["test-user-id/test-id"], pngBytes) {
+			t.Errorf("Expected the image to be stored")
+		}
+
+		if dummyRepo.UpdatedClothing == nil || dummyRepo.UpdatedClothing.ImageUrl != "/clothes/test-id/image" {
+			t.Fatalf("Expected Update to set ImageUrl /clothes/test-id/image, got %+v", dummyRepo.UpdatedClothing)
+		}
+
+		if dummyRepo.UpdatedClothing.Version != 2 {
+			t.Errorf("Expected Update to be conditional on version 2, got %d", dummyRepo.UpdatedClothing.Version)
+		}
+	})
+
+	t.Run("Given a file that is not an image, should return 415", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := newImageUploadRequest(t, "test-id", "image", []byte("<html><script>alert(1)</script></html>"))
+
+		stored := domain.Clothing{Id: "test-id", Version: 1}
+		apiHandler := &API{
+			Repo:   &DummyClothingRepo{GetByIdItem: &stored, ShouldExist: true},
+			Images: &DummyImageStore{},
+		}
+		apiHandler.UploadClothingImage(w, r)
+
+		if w.Result().StatusCode != http.StatusUnsupportedMediaType {
+			t.Errorf("Expected %d got %d", http.StatusUnsupportedMediaType, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given an image over the size limit, should return 413", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := newImageUploadRequest(t, "test-id", "image", append(append([]byte{}, pngBytes...), make([]byte, MaxImageBytes)...))
+
+		stored := domain.Clothing{Id: "test-id", Version: 1}
+		apiHandler := &API{
+			Repo:   &DummyClothingRepo{GetByIdItem: &stored, ShouldExist: true},
+			Images: &DummyImageStore{},
+		}
+		apiHandler.UploadClothingImage(w, r)
+
+		if w.Result().StatusCode != http.StatusRequestEntityTooLarge {
+			t.Errorf("Expected %d got %d", http.StatusRequestEntityTooLarge, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given no 'image' field, should return 400", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := newImageUploadRequest(t, "test-id", "photo", pngBytes)
+
+		apiHandler := &API{
+			Repo:   &DummyClothingRepo{},
+			Images: &DummyImageStore{},
+		}
+		apiHandler.UploadClothingImage(w, r)
+
+		if w.Result().StatusCode != http.StatusBadRequest {
+			t.Errorf("Expected %d got %d", http.StatusBadRequest, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given the item does not exist, should return 404 and not store the image", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := newImageUploadRequest(t, "missing-id", "image", pngBytes)
+
+		images := &DummyImageStore{}
+		apiHandler := &API{
+			Repo:   &DummyClothingRepo{},
+			Images: images,
+		}
+		apiHandler.UploadClothingImage(w, r)
+
+		if w.Result().StatusCode != http.StatusNotFound {
+			t.Errorf("Expected %d got %d", http.StatusNotFound, w.Result().StatusCode)
+		}
+
+		if len(images.Images) != 0 {
+			t.Errorf("Expected no image to be stored")
+		}
+	})
+
+	t.Run("Given the image store fails, should return 500 and put back the item's ImageUrl", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := newImageUploadRequest(t, "test-id", "image", pngBytes)
+
+		stored := domain.Clothing{Id: "test-id", Version: 1}
+		dummyRepo := &DummyClothingRepo{GetByIdItem: &stored, ShouldExist: true}
+		apiHandler := &API{
+			Repo:   dummyRepo,
+			Images: &DummyImageStore{PutError: errors.New("boom")},
+		}
+		apiHandler.UploadClothingImage(w, r)
+
+		if w.Result().StatusCode != http.StatusInternalServerError {
+			t.Errorf("Expected %d got %d", http.StatusInternalServerError, w.Result().StatusCode)
+		}
+
+		if dummyRepo.UpdatedClothing == nil || dummyRepo.UpdatedClothing.ImageUrl != "" {
+			t.Errorf("Expected the ImageUrl to be cleared again, got %+v", dummyRepo.UpdatedClothing)
+		}
+	})
+
+	t.Run("Given the item changes before it is updated, should return 412 and keep the stored image", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := newImageUploadRequest(t, "test-id", "image", pngBytes)
+
+		oldImage := []byte("\x89PNG\r\n\x1a\nold")
+		stored := domain.Clothing{Id: "test-id", Version: 1, ImageUrl: "/clothes/test-id/image"}
+		images := &DummyImageStore{Images: map[string][]byte{"test-user-id/test-id": oldImage}}
+		apiHandler := &API{
+			Repo:   &DummyClothingRepo{GetByIdItem: &stored, ShouldExist: true, UpdateError: fmt.Errorf("stale (dummy): %w", repository.ErrPreconditionFailed)},
+			Images: images,
+		}
+		apiHandler.UploadClothingImage(w, r)
+
+		if w.Result().StatusCode != http.StatusPreconditionFailed {
+			t.Errorf("Expected %d got %d", http.StatusPreconditionFailed, w.Result().StatusCode)
+		}
+
+		if !bytes.Equal(images.Images["test-user-id/test-id"], oldImage) {
+			t.Errorf("Expected the stored image to be unchanged")
+		}
+	})
+}
+
+func TestGetClothingImage(t *testing.T) {
+	t.Run("Given a stored image, should stream it with its content type", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+		r := httptest.NewRequestWithContext(ctx, http.MethodGet, "/clothes/test-id/image", nil)
+		r = mux.SetURLVars(r, map[string]string{"id": "test-id"})
+
+		stored := domain.Clothing{Id: "test-id", ImageUrl: "/clothes/test-id/image"}
+		apiHandler := &API{
+			Repo:   &DummyClothingRepo{GetByIdItem: &stored},
+			Images: &DummyImageStore{Images: map[string][]byte{"test-user-id/test-id": pngBytes}},
+		}
+		apiHandler.GetClothingImage(w, r)
+
+		resp := w.Result()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Expected %d got %d", http.StatusOK, resp.StatusCode)
+		}
+
+		if resp.Header.Get("Content-Type") != "image/png" {
+			t.Errorf("Expected image/png, got %s", resp.Header.Get("Content-Type"))
+		}
+
+		if !bytes.Equal(w.Body.Bytes(), pngBytes) {
+			t.Errorf("Expected the image bytes, got %v", w.Body.Bytes())
+		}
+	})
+
+	t.Run("Given no image, should return 404", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+		r := httptest.NewRequestWithContext(ctx, http.MethodGet, "/clothes/test-id/image", nil)
+		r = mux.SetURLVars(r, map[string]string{"id": "test-id"})
+
+		stored := domain.Clothing{Id: "test-id"}
+		apiHandler := &API{
+			Repo:   &DummyClothingRepo{GetByIdItem: &stored},
+			Images: &DummyImageStore{},
+		}
+		apiHandler.GetClothingImage(w, r)
+
+		if w.Result().StatusCode != http.StatusNotFound {
+			t.Errorf("Expected %d got %d", http.StatusNotFound, w.Result().StatusCode)
+		}
+	})
+	t.Run("Given a trashed item with a stored image, should return 404", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+
+		repo := repository.NewInMemoryClothingRepository()
+		saved, _ := repo.Save(context.Background(), "test-user-id", domain.Clothing{ClothingType: "Jumper", Description: "This Jumper", Brand: "XYZ", Store: "This Store", Size: "L", Price: 2000})
+		repo.Trash(context.Background(), "test-user-id", saved.Id, 0)
+
+		r := httptest.NewRequestWithContext(ctx, http.MethodGet, "/clothes/"+saved.Id+"/image", nil)
+		r = mux.SetURLVars(r, map[string]string{"id": saved.Id})
+		apiHandler := &API{
+			Repo:   repo,
+			Images: &DummyImageStore{Images: map[string][]byte{"test-user-id/" + saved.Id: pngBytes}},
+		}
+		apiHandler.GetClothingImage(w, r)
+
+		if w.Result().StatusCode != http.StatusNotFound {
+			t.Errorf("Expected %d got %d", http.StatusNotFound, w.Result().StatusCode)
+		}
+
+		if bytes.Contains(w.Body.Bytes(), pngBytes) {
+			t.Errorf("Expected the image not to be served")
+		}
+	})
+}
+
+func TestDeleteClothingImage(t *testing.T) {
+	t.Run("Given a trashed item, should keep its image so it can be restored", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+		r := httptest.NewRequestWithContext(ctx, http.MethodDelete, "/clothes/test-id", nil)
+		r = mux.SetURLVars(r, map[string]string{"id": "test-id"})
+
+		images := &DummyImageStore{}
+		apiHandler := &API{
+			Repo:   &DummyClothingRepo{ShouldExist: true},
+			Images: images,
+		}
+		apiHandler.DeleteClothing(w, r)
+
+		if w.Result().StatusCode != http.StatusNoContent {
+			t.Fatalf("Expected %d got %d", http.StatusNoContent, w.Result().StatusCode)
+		}
+
+		if images.DeletedID != "" {
+			t.Errorf("Expected no image to be deleted, got %q", images.DeletedID)
+		}
+	})
+}
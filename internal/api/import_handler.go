@@ -0,0 +1,417 @@
+package api
+
+import (
+	"bytes"
+	"clothes_management/internal/domain"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	CSVContentType = "text/csv"
+	// MaxImportBytes is the largest body ImportClothing accepts.
+	MaxImportBytes = 5 << 20
+	// MaxImportRows is the most rows a single import may contain.
+	MaxImportRows = 1000
+)
+
+// ImportRowResult reports what happened to one row of an import. Rows are numbered from 1 in the order
+// they were given, not counting a CSV header.
+type ImportRowResult struct {
+	Row   int    `json:"row"`
+	Id    string `json:"id,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+type ImportReport struct {
+	Created int               `json:"created"`
+	Failed  int               `json:"failed"`
+	Rows    []ImportRowResult `json:"rows"`
+}
+
+// importRow is a parsed row, or the reason it could not be parsed.
+type importRow struct {
+	clothing domain.Clothing
+	err      error
+}
+
+// csvColumns maps the CSV header names, which are the domain.Clothing JSON names, to the field they set.
+// The 'price' text column written by ExportClothing is accepted and ignored, so exports can be re-imported.
+var csvColumns = map[string]func(c *domain.Clothing, value string) error{
+	"price":        func(c *domain.Clothing, value string) error { return nil },
+	"id":           func(c *domain.Clothing, value string) error { c.Id = value; return nil },
+	"userId":       func(c *domain.Clothing, value string) error { c.UserId = value; return nil },
+	"clothingType": func(c *domain.Clothing, value string) error { c.ClothingType = value; return nil },
+	"description":  func(c *domain.Clothing, value string) error { c.Description = value; return nil },
+	"brand":        func(c *domain.Clothing, value string) error { c.Brand = value; return nil },
+	"store":        func(c *domain.Clothing, value string) error { c.Store = value; return nil },
+	"imageUrl":     func(c *domain.Clothing, value string) error { c.ImageUrl = value; return nil },
+	"size":         func(c *domain.Clothing, value string) error { c.Size = value; return nil },
+	"currency": func(c *domain.Clothing, value string) error {
+		c.Currency = domain.Currency(strings.ToUpper(strings.TrimSpace(value)))
+		return nil
+	},
+	"pricePence": func(c *domain.Clothing, value string) error {
+		pence, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+
+		if err != nil {
+			return fmt.Errorf("Invalid 'pricePence' %q, must be a whole number of pence", value)
+		}
+
+		c.Price = domain.Pence(pence)
+		return nil
+	},
+	"purchasedAt": func(c *domain.Clothing, value string) error {
+		purchasedAt, err := parseCSVTime("purchasedAt", value)
+		c.PurchasedAt = purchasedAt
+		return err
+	},
+	"tags":   func(c *domain.Clothing, value string) error { c.Tags = splitCSVList(value); return nil },
+	"colour": func(c *domain.Clothing, value string) error { c.Colour = parseCSVColour(value); return nil },
+	"secondaryColours": func(c *domain.Clothing, value string) error {
+		c.SecondaryColours = nil
+
+		for _, colour := range splitCSVList(value) {
+			c.SecondaryColours = append(c.SecondaryColours, parseCSVColour(colour))
+		}
+
+		return nil
+	},
+	"seasons": func(c *domain.Clothing, value string) error {
+		c.Seasons = splitCSVChoices(value, domain.ParseSeason)
+		return nil
+	},
+	"occasions": func(c *domain.Clothing, value string) error {
+		c.Occasions = splitCSVChoices(value, domain.ParseOccasion)
+		return nil
+	},
+	"version": func(c *domain.Clothing, value string) error {
+		if strings.TrimSpace(value) == "" {
+			return nil
+		}
+
+		version, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+
+		if err != nil {
+			return fmt.Errorf("Invalid 'version' %q, must be a whole number", value)
+		}
+
+		c.Version = version
+		return nil
+	},
+}
+
+// splitCSVList reads a list written with csvListSeparator, dropping blank values.
+func splitCSVList(value string) []string {
+	var values []string
+
+	for _, v := range strings.Split(value, csvListSeparator) {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
+
+// splitCSVChoices reads a list of choices such as seasons with parse, keeping text it cannot parse so
+// Validate rejects the row, as decoding JSON does.
+func splitCSVChoices[T ~string](value string, parse func(string) (T, bool)) []T {
+	var choices []T
+
+	for _, text := range splitCSVList(value) {
+		choice, ok := parse(text)
+
+		if !ok {
+			choice = T(text)
+		}
+
+		choices = append(choices, choice)
+	}
+
+	return choices
+}
+
+// parseCSVColour maps a colour as domain.ParseColour does, keeping text it cannot map so Validate rejects
+// the row, as decoding JSON does.
+func parseCSVColour(value string) domain.Colour {
+	if colour, ok := domain.ParseColour(value); ok {
+		return colour
+	}
+
+	return domain.Colour(strings.TrimSpace(value))
+}
+
+// parseCSVTime reads an RFC 3339 time from the named column, or nil when the value is blank.
+func parseCSVTime(column, value string) (*time.Time, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, strings.TrimSpace(value))
+
+	if err != nil {
+		return nil, fmt.Errorf("Invalid '%s' %q, must be an RFC 3339 time such as 2024-12-25T09:30:00Z", column, value)
+	}
+
+	return &t, nil
+}
+
+// ImportClothing creates an item for every valid row of a CSV file or JSON array and reports the
+// outcome of each row. Invalid rows are skipped unless 'allOrNothing=true', in which case a single
+// invalid row means nothing is created.
+func (a *API) ImportClothing(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, fmt.Sprintf("Unauthorised method %s.", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	userId, ok := r.Context().Value(UserIDContextKey).(string)
+
+	if !ok {
+		http.Error(w, "UserID not provided", http.StatusUnauthorized)
+		return
+	}
+
+	if strings.TrimSpace(userId) == "" {
+		http.Error(w, "UserID not provided", http.StatusUnauthorized)
+		return
+	}
+
+	allOrNothing, err := parseAllOrNothing(r.URL.Query())
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if r.Body == nil || r.Body == http.NoBody {
+		http.Error(w, "Request body must not be empty or missing", http.StatusBadRequest)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxImportBytes))
+
+	var maxBytesErr *http.MaxBytesError
+
+	if errors.As(err, &maxBytesErr) {
+		http.Error(w, fmt.Sprintf("Import must not be larger than %d bytes", MaxImportBytes), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	mediaType := "application/json"
+
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		mediaType, _, _ = mime.ParseMediaType(contentType)
+	}
+
+	var rows []importRow
+
+	switch mediaType {
+	case "application/json":
+		rows, err = parseJSONImport(body)
+	case CSVContentType:
+		rows, err = parseCSVImport(body)
+	default:
+		http.Error(w, fmt.Sprintf("Content-Type must be application/json or %s", CSVContentType), http.StatusUnsupportedMediaType)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if len(rows) == 0 {
+		http.Error(w, "Import must contain at least one row", http.StatusBadRequest)
+		return
+	}
+
+	if len(rows) > MaxImportRows {
+		http.Error(w, fmt.Sprintf("Import must not contain more than %d rows", MaxImportRows), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	a.createRows(w, r, userId, rows, allOrNothing)
+}
+
+// parseAllOrNothing reads the optional 'allOrNothing' query parameter, which defaults to false.
+func parseAllOrNothing(query url.Values) (bool, error) {
+	raw := query.Get("allOrNothing")
+
+	if raw == "" {
+		return false, nil
+	}
+
+	allOrNothing, err := strconv.ParseBool(raw)
+
+	if err != nil {
+		return false, errors.New("Invalid 'allOrNothing' parameter, must be true or false")
+	}
+
+	return allOrNothing, nil
+}
+
+// createRows validates the parsed rows, saves the valid ones in one SaveMany and writes the report. With
+// allOrNothing, any invalid row means nothing is saved.
+func (a *API) createRows(w http.ResponseWriter, r *http.Request, userId string, rows []importRow, allOrNothing bool) {
+	report := ImportReport{Rows: make([]ImportRowResult, len(rows))}
+
+	var valid []domain.Clothing
+	// validRows holds the index into rows of each item in valid.
+	var validRows []int
+
+	for i, row := range rows {
+		report.Rows[i].Row = i + 1
+
+		if row.err == nil {
+			normaliseClothing(&row.clothing)
+
+			if err := row.clothing.Validate(); err != nil {
+				row.err = fmt.Errorf("Invalid row, breaks validation rule: %w", err)
+			}
+		}
+
+		if row.err != nil {
+			report.Rows[i].Error = row.err.Error()
+			report.Failed++
+			continue
+		}
+
+		valid = append(valid, row.clothing)
+		validRows = append(validRows, i)
+	}
+
+	if len(valid) == 0 || (allOrNothing && report.Failed > 0) {
+		writeImportReport(w, http.StatusBadRequest, report)
+		return
+	}
+
+	saved, err := a.Repo.SaveMany(r.Context(), userId, valid)
+
+	if err != nil {
+		writeRepositoryError(w, err, "", "Error importing clothing items")
+		return
+	}
+
+	for i, item := range saved {
+		report.Rows[validRows[i]].Id = item.Id
+	}
+
+	report.Created = len(saved)
+
+	a.registerCatalogValues(r.Context(), saved...)
+
+	writeImportReport(w, http.StatusCreated, report)
+}
+
+// writeImportReport marks the response successful only when items were created.
+func writeImportReport(w http.ResponseWriter, status int, report ImportReport) {
+	resp := map[string]any{"success": report.Created > 0, "data": report}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	json.NewEncoder(w).Encode(resp)
+}
+
+// parseJSONImport reads an array of clothing objects, decoding each one as CreateClothing would.
+func parseJSONImport(body []byte) ([]importRow, error) {
+	var elements []json.RawMessage
+
+	if err := json.Unmarshal(body, &elements); err != nil {
+		return nil, errors.New("Request body must be a JSON array of clothing items")
+	}
+
+	rows := make([]importRow, len(elements))
+
+	for i, element := range elements {
+		var fields map[string]any
+
+		if err := json.Unmarshal(element, &fields); err != nil || fields == nil {
+			rows[i].err = errors.New("Invalid row, must be a JSON object")
+			continue
+		}
+
+		if missingField, message := MissingMandatoryClothingField(fields); missingField {
+			rows[i].err = errors.New(message)
+			continue
+		}
+
+		dec := json.NewDecoder(bytes.NewReader(element))
+		dec.DisallowUnknownFields()
+
+		if err := dec.Decode(&rows[i].clothing); err != nil {
+			rows[i].err = fmt.Errorf("Invalid row %s", err.Error())
+		}
+	}
+
+	return rows, nil
+}
+
+// parseCSVImport reads a header of domain.Clothing JSON names followed by one item per record. Problems
+// with the header fail the whole import, while a bad value only fails its own row.
+func parseCSVImport(body []byte) ([]importRow, error) {
+	reader := csv.NewReader(bytes.NewReader(body))
+
+	records, err := reader.ReadAll()
+
+	if err != nil {
+		return nil, fmt.Errorf("Request body must be valid CSV: %s", err.Error())
+	}
+
+	if len(records) == 0 {
+		return nil, errors.New("CSV must start with a header row")
+	}
+
+	header := records[0]
+	// Spreadsheet exports often start with a UTF-8 byte order mark.
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+
+	columns := map[string]any{}
+
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		header[i] = name
+
+		if _, known := csvColumns[name]; !known {
+			return nil, fmt.Errorf("Unknown CSV column '%s'", name)
+		}
+
+		if _, duplicate := columns[name]; duplicate {
+			return nil, fmt.Errorf("Duplicate CSV column '%s'", name)
+		}
+
+		columns[name] = true
+	}
+
+	if missingField, message := MissingMandatoryClothingField(columns); missingField {
+		return nil, errors.New(message)
+	}
+
+	rows := make([]importRow, len(records)-1)
+
+	for i, record := range records[1:] {
+		for column, value := range record {
+			if err := csvColumns[header[column]](&rows[i].clothing, value); err != nil {
+				rows[i].err = err
+				break
+			}
+		}
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,386 @@
+package api
+
+import (
+	"bytes"
+	"clothes_management/internal/domain"
+	"clothes_management/internal/repository"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newImportRequest(t *testing.T, target, contentType, body string) *http.Request {
+	t.Helper()
+
+	ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+	r := httptest.NewRequestWithContext(ctx, http.MethodPost, target, strings.NewReader(body))
+	r.Header.Set("Content-Type", contentType)
+
+	return r
+}
+
+func decodeImportReport(t *testing.T, resp *http.Response) (bool, ImportReport) {
+	t.Helper()
+
+	var body struct {
+		Success bool         `json:"success"`
+		Data    ImportReport `json:"data"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	return body.Success, body.Data
+}
+
+func TestImportClothing(t *testing.T) {
+	validJSON := `{"clothingType": "Jumper", "description": "This Jumper", "brand": "XYZ", "store": "This Store", "size": "L", "pricePence": 2000}`
+
+	t.Run("Given request method is not allowed, should return error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := newImportRequest(t, "/clothes/import", "application/json", "["+validJSON+"]")
+		r.Method = http.MethodGet
+
+		apiHandler := &API{Repo: &DummyClothingRepo{}}
+		apiHandler.ImportClothing(w, r)
+
+		if w.Result().StatusCode != http.StatusMethodNotAllowed {
+			t.Errorf("Expected %d got %d", http.StatusMethodNotAllowed, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given no UserID in context, should return 401", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/clothes/import", strings.NewReader("["+validJSON+"]"))
+
+		apiHandler := &API{Repo: &DummyClothingRepo{}}
+		apiHandler.ImportClothing(w, r)
+
+		if w.Result().StatusCode != http.StatusUnauthorized {
+			t.Errorf("Expected %d got %d", http.StatusUnauthorized, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given a JSON array with an invalid row, should create the valid rows and report each row", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := "[" + validJSON + `, {"clothingType": "Coat"}, {"clothingType": "Coat", "description": "Coat", "brand": "XYZ", "store": "This Store", "size": "M", "pricePence": -1}, ` + validJSON + "]"
+		r := newImportRequest(t, "/clothes/import", "application/json", body)
+
+		repo := &DummyClothingRepo{}
+		apiHandler := &API{Repo: repo}
+		apiHandler.ImportClothing(w, r)
+
+		resp := w.Result()
+
+		if resp.StatusCode != http.StatusCreated {
+			t.Fatalf("Expected %d got %d", http.StatusCreated, resp.StatusCode)
+		}
+
+		success, report := decodeImportReport(t, resp)
+
+		if !success || report.Created != 2 || report.Failed != 2 || len(report.Rows) != 4 {
+			t.Fatalf("Expected success with 2 created and 2 failed of 4 rows, got %v and %+v", success, report)
+		}
+
+		if report.Rows[0].Id != "dummy-id-0" || report.Rows[3].Id != "dummy-id-1" {
+			t.Errorf("Expected rows 1 and 4 to carry the created ids, got %+v", report.Rows)
+		}
+
+		if !strings.Contains(report.Rows[1].Error, "missing") || !strings.Contains(report.Rows[2].Error, "validation rule") {
+			t.Errorf("Expected rows 2 and 3 to report why they failed, got %+v", report.Rows)
+		}
+
+		if report.Rows[2].Row != 3 || report.Rows[2].Id != "" {
+			t.Errorf("Expected row 3 to be numbered from 1 and have no id, got %+v", report.Rows[2])
+		}
+
+		if len(repo.SaveManyItems) != 2 {
+			t.Errorf("Expected only the 2 valid rows to be saved, got %+v", repo.SaveManyItems)
+		}
+	})
+
+	t.Run("Given allOrNothing and an invalid row, should create nothing and return 400 with the report", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := "[" + validJSON + `, {"clothingType": "Coat", "colour": "red"}]`
+		r := newImportRequest(t, "/clothes/import?allOrNothing=true", "application/json", body)
+
+		repo := &DummyClothingRepo{}
+		apiHandler := &API{Repo: repo}
+		apiHandler.ImportClothing(w, r)
+
+		resp := w.Result()
+
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("Expected %d got %d", http.StatusBadRequest, resp.StatusCode)
+		}
+
+		success, report := decodeImportReport(t, resp)
+
+		if success || report.Created != 0 || report.Failed != 1 || report.Rows[1].Error == "" {
+			t.Errorf("Expected nothing created and row 2 reported, got %v and %+v", success, report)
+		}
+
+		if repo.SaveManyItems != nil {
+			t.Errorf("Expected SaveMany not to be called, got %+v", repo.SaveManyItems)
+		}
+	})
+
+	t.Run("Given a CSV currency column, should import each row's currency and reject unsupported ones", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := "description,clothingType,brand,store,size,pricePence,currency\n" +
+			"Yukata,Robe,XYZ,This Store,L,8000,jpy\n" +
+			"Raincoat,Coat,ABC,That Store,M,4999,\n" +
+			"Scarf,Scarf,ABC,That Store,M,1000,XXX\n"
+
+		repo := &DummyClothingRepo{}
+		apiHandler := &API{Repo: repo}
+		apiHandler.ImportClothing(w, newImportRequest(t, "/clothes/import", CSVContentType, body))
+
+		_, report := decodeImportReport(t, w.Result())
+
+		if report.Created != 2 || report.Failed != 1 || !strings.Contains(report.Rows[2].Error, "Currency") {
+			t.Fatalf("Expected 2 created and the bad currency reported, got %+v", report)
+		}
+
+		if repo.SaveManyItems[0].Currency != "JPY" || repo.SaveManyItems[1].Currency != domain.DefaultCurrency {
+			t.Errorf("Expected JPY and GBP, got %+v", repo.SaveManyItems)
+		}
+	})
+
+	t.Run("Given a CSV purchasedAt column, should import the time and reject one that is not RFC 3339", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := "description,clothingType,brand,store,size,pricePence,purchasedAt\n" +
+			"Yukata,Robe,XYZ,This Store,L,8000,2024-12-25T09:30:00+09:00\n" +
+			"Raincoat,Coat,ABC,That Store,M,4999,\n" +
+			"Scarf,Scarf,ABC,That Store,M,1000,25/12/2024\n"
+
+		repo := &DummyClothingRepo{}
+		apiHandler := &API{Repo: repo}
+		apiHandler.ImportClothing(w, newImportRequest(t, "/clothes/import", CSVContentType, body))
+
+		_, report := decodeImportReport(t, w.Result())
+
+		if report.Created != 2 || report.Failed != 1 || !strings.Contains(report.Rows[2].Error, "purchasedAt") {
+			t.Fatalf("Expected 2 created and the bad time reported, got %+v", report)
+		}
+
+		expected := time.Date(2024, 12, 25, 0, 30, 0, 0, time.UTC)
+
+		if purchasedAt := repo.SaveManyItems[0].PurchasedAt; purchasedAt == nil || !purchasedAt.Equal(expected) || repo.SaveManyItems[1].PurchasedAt != nil {
+			t.Errorf("Expected a purchase at %v and none, got %+v", expected, repo.SaveManyItems)
+		}
+	})
+
+	t.Run("Given a CSV tags column, should split and normalise each row's tags", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := "description,clothingType,brand,store,size,pricePence,tags\n" +
+			"Yukata,Robe,XYZ,This Store,L,8000,Summer; festival;;summer\n" +
+			"Raincoat,Coat,ABC,That Store,M,4999,\n"
+
+		repo := &DummyClothingRepo{}
+		apiHandler := &API{Repo: repo}
+		apiHandler.ImportClothing(w, newImportRequest(t, "/clothes/import", CSVContentType, body))
+
+		if w.Result().StatusCode != http.StatusCreated || len(repo.SaveManyItems) != 2 {
+			t.Fatalf("Expected both rows to be created, got %d and %+v", w.Result().StatusCode, repo.SaveManyItems)
+		}
+
+		if tags := repo.SaveManyItems[0].Tags; !slices.Equal(tags, []string{"festival", "summer"}) || repo.SaveManyItems[1].Tags != nil {
+			t.Errorf("Expected festival and summer, then no tags, got %+v", repo.SaveManyItems)
+		}
+	})
+
+	t.Run("Given CSV colour columns, should map each colour to the palette and reject unknown ones", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := "description,clothingType,brand,store,size,pricePence,colour,secondaryColours\n" +
+			"Yukata,Robe,XYZ,This Store,L,8000,Navy,White; #ff0000\n" +
+			"Raincoat,Coat,ABC,That Store,M,4999,,\n" +
+			"Scarf,Scarf,ABC,That Store,M,1000,red,plaid\n"
+
+		repo := &DummyClothingRepo{}
+		apiHandler := &API{Repo: repo}
+		apiHandler.ImportClothing(w, newImportRequest(t, "/clothes/import", CSVContentType, body))
+
+		_, report := decodeImportReport(t, w.Result())
+
+		if report.Created != 2 || report.Failed != 1 || !strings.Contains(report.Rows[2].Error, "plaid") {
+			t.Fatalf("Expected 2 created and the unknown colour reported, got %+v", report)
+		}
+
+		if first := repo.SaveManyItems[0]; first.Colour != "navy" || !slices.Equal(first.SecondaryColours, []domain.Colour{"white", "red"}) {
+			t.Errorf("Expected navy with white and red, got %+v", first)
+		}
+
+		if second := repo.SaveManyItems[1]; second.Colour != "" || second.SecondaryColours != nil {
+			t.Errorf("Expected no colours, got %+v", second)
+		}
+	})
+
+	t.Run("Given CSV seasons and occasions columns, should parse each and reject unknown ones", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := "description,clothingType,brand,store,size,pricePence,seasons,occasions\n" +
+			"Yukata,Robe,XYZ,This Store,L,8000,Summer; fall,Party\n" +
+			"Raincoat,Coat,ABC,That Store,M,4999,,\n" +
+			"Scarf,Scarf,ABC,That Store,M,1000,monsoon,\n"
+
+		repo := &DummyClothingRepo{}
+		apiHandler := &API{Repo: repo}
+		apiHandler.ImportClothing(w, newImportRequest(t, "/clothes/import", CSVContentType, body))
+
+		_, report := decodeImportReport(t, w.Result())
+
+		if report.Created != 2 || report.Failed != 1 || !strings.Contains(report.Rows[2].Error, "monsoon") {
+			t.Fatalf("Expected 2 created and the unknown season reported, got %+v", report)
+		}
+
+		if first := repo.SaveManyItems[0]; !slices.Equal(first.Seasons, []domain.Season{domain.Summer, domain.Autumn}) || !slices.Equal(first.Occasions, []domain.Occasion{domain.Party}) {
+			t.Errorf("Expected summer and autumn for parties, got %+v", first)
+		}
+
+		if second := repo.SaveManyItems[1]; second.Seasons != nil || second.Occasions != nil {
+			t.Errorf("Expected no seasons or occasions, got %+v", second)
+		}
+	})
+
+	t.Run("Given a CSV file with a header, should map the columns and create each row", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := "\ufeffdescription,clothingType,brand,store,size,pricePence\n" +
+			"\"Cable knit, navy\",Jumper,XYZ,This Store,L,2000\n" +
+			"Raincoat,Coat,ABC,That Store,M,4999\n" +
+			"Bad price,Coat,ABC,That Store,M,£10\n"
+		r := newImportRequest(t, "/clothes/import", "text/csv; charset=utf-8", body)
+
+		repo := &DummyClothingRepo{}
+		apiHandler := &API{Repo: repo}
+		apiHandler.ImportClothing(w, r)
+
+		resp := w.Result()
+
+		if resp.StatusCode != http.StatusCreated {
+			t.Fatalf("Expected %d got %d", http.StatusCreated, resp.StatusCode)
+		}
+
+		_, report := decodeImportReport(t, resp)
+
+		if report.Created != 2 || report.Failed != 1 || !strings.Contains(report.Rows[2].Error, "pricePence") {
+			t.Errorf("Expected 2 created and the bad price reported, got %+v", report)
+		}
+
+		expected := domain.Clothing{ClothingType: "Jumper", Description: "Cable knit, navy", Brand: "XYZ", Store: "This Store", Size: "L", Price: 2000, Currency: domain.DefaultCurrency}
+
+		if len(repo.SaveManyItems) != 2 || !reflect.DeepEqual(repo.SaveManyItems[0], expected) || repo.SaveManyItems[1].Price != 4999 {
+			t.Errorf("Expected %+v and a 4999 pence coat, got %+v", expected, repo.SaveManyItems)
+		}
+	})
+
+	t.Run("Given a CSV header that is unknown, duplicated or missing a mandatory column, should return 400", func(t *testing.T) {
+		headers := []string{
+			"description,clothingType,brand,store,size,pricePence,colour",
+			"description,clothingType,brand,store,size,pricePence,size",
+			"description,clothingType,brand,store,pricePence",
+		}
+
+		for _, header := range headers {
+			w := httptest.NewRecorder()
+			r := newImportRequest(t, "/clothes/import", "text/csv", header+"\n")
+
+			apiHandler := &API{Repo: &DummyClothingRepo{}}
+			apiHandler.ImportClothing(w, r)
+
+			if w.Result().StatusCode != http.StatusBadRequest {
+				t.Errorf("Header %q: Expected %d got %d", header, http.StatusBadRequest, w.Result().StatusCode)
+			}
+		}
+	})
+
+	t.Run("Given a body that is not a JSON array or has no rows, should return 400", func(t *testing.T) {
+		for _, body := range []string{validJSON, "[]", "not json"} {
+			w := httptest.NewRecorder()
+			r := newImportRequest(t, "/clothes/import", "application/json", body)
+
+			apiHandler := &API{Repo: &DummyClothingRepo{}}
+			apiHandler.ImportClothing(w, r)
+
+			if w.Result().StatusCode != http.StatusBadRequest {
+				t.Errorf("Body %q: Expected %d got %d", body, http.StatusBadRequest, w.Result().StatusCode)
+			}
+		}
+	})
+
+	t.Run("Given an invalid allOrNothing parameter, should return 400", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := newImportRequest(t, "/clothes/import?allOrNothing=maybe", "application/json", "["+validJSON+"]")
+
+		apiHandler := &API{Repo: &DummyClothingRepo{}}
+		apiHandler.ImportClothing(w, r)
+
+		if w.Result().StatusCode != http.StatusBadRequest {
+			t.Errorf("Expected %d got %d", http.StatusBadRequest, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given an unsupported content type, should return 415", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := newImportRequest(t, "/clothes/import", "application/xml", "<clothes/>")
+
+		apiHandler := &API{Repo: &DummyClothingRepo{}}
+		apiHandler.ImportClothing(w, r)
+
+		if w.Result().StatusCode != http.StatusUnsupportedMediaType {
+			t.Errorf("Expected %d got %d", http.StatusUnsupportedMediaType, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given too many rows or too large a body, should return 413", func(t *testing.T) {
+		rows := strings.Repeat(validJSON+",", MaxImportRows) + validJSON
+		large := bytes.Repeat([]byte(" "), MaxImportBytes+1)
+
+		for _, body := range []string{"[" + rows + "]", "[" + string(large) + "]"} {
+			w := httptest.NewRecorder()
+			r := newImportRequest(t, "/clothes/import", "application/json", body)
+
+			apiHandler := &API{Repo: &DummyClothingRepo{}}
+			apiHandler.ImportClothing(w, r)
+
+			if w.Result().StatusCode != http.StatusRequestEntityTooLarge {
+				t.Errorf("Expected %d got %d", http.StatusRequestEntityTooLarge, w.Result().StatusCode)
+			}
+		}
+	})
+
+	t.Run("Given the repository fails, should return 500", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := newImportRequest(t, "/clothes/import", "application/json", "["+validJSON+"]")
+
+		apiHandler := &API{Repo: &DummyClothingRepo{SaveManyError: errors.New("boom")}}
+		apiHandler.ImportClothing(w, r)
+
+		if w.Result().StatusCode != http.StatusInternalServerError {
+			t.Errorf("Expected %d got %d", http.StatusInternalServerError, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given rows sharing catalog values, should register each value once", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := newImportRequest(t, "/clothes/import", "application/json", fmt.Sprintf("[%s, %s]", validJSON, validJSON))
+
+		catalog := repository.NewInMemoryCatalogRepository()
+		apiHandler := &API{Repo: &DummyClothingRepo{}, Catalog: catalog}
+		apiHandler.ImportClothing(w, r)
+
+		brands, _ := catalog.List(context.Background(), repository.CatalogBrands)
+
+		if w.Result().StatusCode != http.StatusCreated || len(brands) != 1 || brands[0] != "XYZ" {
+			t.Errorf("Expected 201 and the brand XYZ, got %d and %v", w.Result().StatusCode, brands)
+		}
+	})
+}
@@ -0,0 +1,466 @@
+package api
+
+import (
+	"bytes"
+	"clothes_management/internal/domain"
+	"clothes_management/internal/repository"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+func (a *API) CreateOutfit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, fmt.Sprintf("Unauthorised method %s.", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	userId, ok := r.Context().Value(UserIDContextKey).(string)
+
+	if !ok {
+		http.Error(w, "UserID not provided", http.StatusUnauthorized)
+		return
+	}
+
+	if strings.TrimSpace(userId) == "" {
+		http.Error(w, "UserID not provided", http.StatusUnauthorized)
+		return
+	}
+
+	outfit, ok := decodeOutfit(w, r)
+
+	if !ok {
+		return
+	}
+
+	if outfit.Id != "" {
+		http.Error(w, "Body must not have an Id, as one is assigned when the outfit is created", http.StatusBadRequest)
+		return
+	}
+
+	if !a.validateOutfit(w, r, userId, outfit) {
+		return
+	}
+
+	saved, err := a.Outfits.Save(r.Context(), userId, outfit)
+
+	if err != nil {
+		writeOutfitError(w, err, outfit.Id, "Error saving outfit")
+		return
+	}
+
+	undo := func() error { return a.Outfits.Delete(r.Context(), userId, saved.Id) }
+
+	if !a.confirmOutfitItems(w, r, userId, saved, undo) {
+		return
+	}
+
+	resp := map[string]any{"success": true, "data": saved}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	json.NewEncoder(w).Encode(resp)
+}
+
+// GetOutfits lists the user's outfits by name.
+func (a *API) GetOutfits(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, fmt.Sprintf("Unauthorised method %s.", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	userId, ok := r.Context().Value(UserIDContextKey).(string)
+
+	if !ok {
+		http.Error(w, "UserID not provided", http.StatusUnauthorized)
+		return
+	}
+
+	if strings.TrimSpace(userId) == "" {
+		http.Error(w, "UserID not provided", http.StatusUnauthorized)
+		return
+	}
+
+	outfits, err := a.Outfits.GetAll(r.Context(), userId)
+
+	if err != nil {
+		writeOutfitError(w, err, "", "Error getting outfits")
+		return
+	}
+
+	resp := map[string]any{"success": true, "data": outfits}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (a *API) GetOutfitById(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, fmt.Sprintf("Unauthorised method %s.", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	userId, ok := r.Context().Value(UserIDContextKey).(string)
+
+	if !ok {
+		http.Error(w, "UserID not provided", http.StatusUnauthorized)
+		return
+	}
+
+	if strings.TrimSpace(userId) == "" {
+		http.Error(w, "UserID not provided", http.StatusUnauthorized)
+		return
+	}
+
+	vars := mux.Vars(r)
+	id, exists := vars["id"]
+
+	if !exists {
+		http.Error(w, "Missing 'id' parameter", http.StatusBadRequest)
+		return
+	}
+
+	id = strings.TrimSpace(id)
+
+	if len(id) == 0 {
+		http.Error(w, "Missing 'id' parameter", http.StatusBadRequest)
+		return
+	}
+
+	outfit, err := a.Outfits.GetById(r.Context(), userId, id)
+
+	if err != nil {
+		writeOutfitError(w, err, id, fmt.Sprintf("Unable to get outfit for ID %s", id))
+		return
+	}
+
+	resp := map[string]any{"success": true, "data": outfit}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(resp)
+}
+
+// UpdateOutfit replaces the outfit with the body, which is checked as CreateOutfit checks a new one.
+func (a *API) UpdateOutfit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, fmt.Sprintf("Unauthorised method %s.", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	userId, ok := r.Context().Value(UserIDContextKey).(string)
+
+	if !ok {
+		http.Error(w, "UserID not provided", http.StatusUnauthorized)
+		return
+	}
+
+	if strings.TrimSpace(userId) == "" {
+		http.Error(w, "UserID not provided", http.StatusUnauthorized)
+		return
+	}
+
+	vars := mux.Vars(r)
+	id, exists := vars["id"]
+
+	if !exists {
+		http.Error(w, "Missing 'id' parameter", http.StatusBadRequest)
+		return
+	}
+
+	id = strings.TrimSpace(id)
+
+	if len(id) == 0 {
+		http.Error(w, "Missing 'id' parameter", http.StatusBadRequest)
+		return
+	}
+
+	outfit, ok := decodeOutfit(w, r)
+
+	if !ok {
+		return
+	}
+
+	if outfit.Id != "" && outfit.Id != id {
+		http.Error(w, fmt.Sprintf("Body has Id = %s, but id = %s, resulting is mismatch", outfit.Id, id), http.StatusBadRequest)
+		return
+	}
+
+	outfit.Id = id
+
+	if !a.validateOutfit(w, r, userId, outfit) {
+		return
+	}
+
+	// Kept to put back should one of the new items be trashed while the update is saved.
+	previous, err := a.Outfits.GetById(r.Context(), userId, id)
+
+	if err != nil {
+		writeOutfitError(w, err, id, "Error updating outfit")
+		return
+	}
+
+	updated, err := a.Outfits.Update(r.Context(), userId, outfit)
+
+	if err != nil {
+		writeOutfitError(w, err, id, "Error updating outfit")
+		return
+	}
+
+	undo := func() error {
+		_, err := a.Outfits.Update(r.Context(), userId, previous)
+		return err
+	}
+
+	if !a.confirmOutfitItems(w, r, userId, updated, undo) {
+		return
+	}
+
+	resp := map[string]any{"success": true, "data": updated}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(resp)
+}
+
+// DeleteOutfit deletes the outfit permanently. Its items are left as they are.
+func (a *API) DeleteOutfit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, fmt.Sprintf("Unauthorised method %s.", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	userId, ok := r.Context().Value(UserIDContextKey).(string)
+
+	if !ok {
+		http.Error(w, "UserID not provided", http.StatusUnauthorized)
+		return
+	}
+
+	if strings.TrimSpace(userId) == "" {
+		http.Error(w, "UserID not provided", http.StatusUnauthorized)
+		return
+	}
+
+	vars := mux.Vars(r)
+	id, exists := vars["id"]
+
+	if !exists {
+		http.Error(w, "Missing 'id' parameter", http.StatusBadRequest)
+		return
+	}
+
+	id = strings.TrimSpace(id)
+
+	if len(id) == 0 {
+		http.Error(w, "Missing 'id' parameter", http.StatusBadRequest)
+		return
+	}
+
+	if err := a.Outfits.Delete(r.Context(), userId, id); err != nil {
+		writeOutfitError(w, err, id, fmt.Sprintf("Unable to delete outfit for ID %s", id))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decodeOutfit reads an outfit from the request body, writing a 400 and returning false if it cannot.
+func decodeOutfit(w http.ResponseWriter, r *http.Request) (domain.Outfit, bool) {
+	if r.Body == nil || r.Body == http.NoBody {
+		http.Error(w, "Request body must not be empty or missing", http.StatusBadRequest)
+		return domain.Outfit{}, false
+	}
+
+	bodyBytes, err := io.ReadAll(r.Body)
+
+	if err != nil {
+		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+		return domain.Outfit{}, false
+	}
+
+	var outfit domain.Outfit
+
+	dec := json.NewDecoder(bytes.NewReader(bodyBytes))
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(&outfit); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request body, superfluous fields %s", err.Error()), http.StatusBadRequest)
+		return domain.Outfit{}, false
+	}
+
+	return outfit, true
+}
+
+// validateOutfit checks the outfit belongs to the user, is valid, and lists only the user's live items,
+// writing a 400 naming the problem and returning false if not.
+func (a *API) validateOutfit(w http.ResponseWriter, r *http.Request, userId string, outfit domain.Outfit) bool {
+	if outfit.UserId != "" && outfit.UserId != userId {
+		http.Error(w, fmt.Sprintf("Body has UserId = %s, but UserId = %s, resulting is mismatch", outfit.UserId, userId), http.StatusBadRequest)
+		return false
+	}
+
+	if err := outfit.Validate(); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request body, breaks validation rule: %s", err.Error()), http.StatusBadRequest)
+		return false
+	}
+
+	missing, err := a.missingItems(r.Context(), userId, outfit.ItemIds)
+
+	if err != nil {
+		writeRepositoryError(w, err, "", "Error checking outfit items")
+		return false
+	}
+
+	if len(missing) > 0 {
+		http.Error(w, fmt.Sprintf("Outfit contains clothing items that do not exist: %s", strings.Join(missing, ", ")), http.StatusBadRequest)
+		return false
+	}
+
+	return true
+}
+
+// confirmOutfitItems checks the items of a saved outfit again, as DeleteClothing may have trashed one
+// since validateOutfit looked. DeleteClothing checks the outfits again after trashing, so whichever write
+// lands second, one side sees the other. If an item has gone, undo reverts the save and a 409 is written.
+func (a *API) confirmOutfitItems(w http.ResponseWriter, r *http.Request, userId string, outfit domain.Outfit, undo func() error) bool {
+	missing, err := a.missingItems(r.Context(), userId, outfit.ItemIds)
+
+	if err != nil {
+		writeRepositoryError(w, err, "", "Error checking outfit items")
+		return false
+	}
+
+	if len(missing) == 0 {
+		return true
+	}
+
+	if err := undo(); err != nil {
+		writeOutfitError(w, err, outfit.Id, "Error saving outfit")
+		return false
+	}
+
+	http.Error(w, fmt.Sprintf("Outfit clothing items were deleted while it was saved: %s", strings.Join(missing, ", ")), http.StatusConflict)
+	return false
+}
+
+// missingItems returns the ids that are not the user's live items, reading the items once rather than
+// asking after each id.
+func (a *API) missingItems(ctx context.Context, userId string, itemIds []string) ([]string, error) {
+	items, err := a.Repo.GetAll(ctx, userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	live := make(map[string]bool, len(items))
+
+	for _, item := range items {
+		live[item.Id] = true
+	}
+
+	var missing []string
+
+	for _, itemId := range itemIds {
+		if !live[itemId] {
+			missing = append(missing, itemId)
+		}
+	}
+
+	return missing, nil
+}
+
+// outfitNamesUsing names the user's outfits that contain the item, which must not be deleted from under
+// them. It names none when the API has no outfit repository.
+func (a *API) outfitNamesUsing(ctx context.Context, userId, itemId string) ([]string, error) {
+	if a.Outfits == nil {
+		return nil, nil
+	}
+
+	outfits, err := a.Outfits.ListUsing(ctx, userId, itemId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(outfits))
+
+	for i, outfit := range outfits {
+		names[i] = outfit.Name
+	}
+
+	return names, nil
+}
+
+// userOutfits returns all of the user's outfits, or none when the API has no outfit repository.
+func (a *API) userOutfits(ctx context.Context, userId string) ([]domain.Outfit, error) {
+	if a.Outfits == nil {
+		return nil, nil
+	}
+
+	return a.Outfits.GetAll(ctx, userId)
+}
+
+// outfitNames names the outfits that contain the item.
+func outfitNames(outfits []domain.Outfit, itemId string) []string {
+	var names []string
+
+	for _, outfit := range outfits {
+		if outfit.Uses(itemId) {
+			names = append(names, outfit.Name)
+		}
+	}
+
+	return names
+}
+
+// restoreUsed restores those of the just trashed items that an outfit now uses, returning the names of
+// the outfits using each restored item by its id. It covers an outfit being saved between the check for
+// outfits using an item and the item being trashed.
+func (a *API) restoreUsed(ctx context.Context, userId string, trashed []domain.Clothing) (map[string][]string, error) {
+	outfits, err := a.userOutfits(ctx, userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	restored := map[string][]string{}
+
+	for _, item := range trashed {
+		names := outfitNames(outfits, item.Id)
+
+		if len(names) == 0 {
+			continue
+		}
+
+		if _, err := a.Repo.Restore(ctx, userId, item.Id, item.Version); err != nil {
+			return nil, err
+		}
+
+		restored[item.Id] = names
+	}
+
+	return restored, nil
+}
+
+// inUseMessage explains why an item used by the named outfits was not deleted.
+func inUseMessage(itemId string, outfitNames []string) string {
+	return fmt.Sprintf("Clothing item %s is used by outfits %s and must be removed from them first", itemId, strings.Join(outfitNames, ", "))
+}
+
+// writeOutfitError reports a missing outfit as such, and anything else as writeRepositoryError does.
+func writeOutfitError(w http.ResponseWriter, err error, id, fallbackMessage string) {
+	if errors.Is(err, repository.ErrNotFound) {
+		http.Error(w, fmt.Sprintf("Outfit not found for ID %s", id), http.StatusNotFound)
+		return
+	}
+
+	writeRepositoryError(w, err, id, fallbackMessage)
+}
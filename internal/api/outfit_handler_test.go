@@ -0,0 +1,493 @@
+package api
+
+import (
+	"clothes_management/internal/domain"
+	"clothes_management/internal/repository"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+func newOutfitRequest(method, id, body string) *http.Request {
+	ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+	r := httptest.NewRequestWithContext(ctx, method, "/outfits/"+id, strings.NewReader(body))
+
+	if id == "" {
+		return r
+	}
+
+	return mux.SetURLVars(r, map[string]string{"id": id})
+}
+
+// newOutfitAPI returns an API with in-memory clothing holding an item per description, and no outfits.
+func newOutfitAPI(t *testing.T, descriptions ...string) (*API, []string) {
+	t.Helper()
+
+	repo := repository.NewInMemoryClothingRepository()
+	var ids []string
+
+	for _, description := range descriptions {
+		item := domain.Clothing{ClothingType: "Jumper", Description: description, Brand: "XYZ", Store: "This Store", Size: "L", Price: 2000}
+		saved, err := repo.Save(context.Background(), "test-user-id", item)
+
+		if err != nil {
+			t.Fatalf("failed to save item: %v", err)
+		}
+
+		ids = append(ids, saved.Id)
+	}
+
+	return &API{Repo: repo, Outfits: repository.NewInMemoryOutfitRepository()}, ids
+}
+
+func decodeOutfitResponse(t *testing.T, resp *http.Response) domain.Outfit {
+	t.Helper()
+
+	var body struct {
+		Success bool          `json:"success"`
+		Data    domain.Outfit `json:"data"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	return body.Data
+}
+
+func TestCreateOutfit(t *testing.T) {
+	t.Run("Given request method is not allowed, should return error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		apiHandler, _ := newOutfitAPI(t)
+		apiHandler.CreateOutfit(w, newOutfitRequest(http.MethodPut, "", `{}`))
+
+		if w.Result().StatusCode != http.StatusMethodNotAllowed {
+			t.Errorf("Expected %d got %d", http.StatusMethodNotAllowed, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given no UserID in context, should return 401", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/outfits", strings.NewReader(`{}`))
+
+		apiHandler, _ := newOutfitAPI(t)
+		apiHandler.CreateOutfit(w, r)
+
+		if w.Result().StatusCode != http.StatusUnauthorized {
+			t.Errorf("Expected %d got %d", http.StatusUnauthorized, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given an outfit of the user's items, should create it", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		apiHandler, ids := newOutfitAPI(t, "Shirt", "Trousers")
+		body := `{"name": "Office", "occasion": "Work", "itemIds": ["` + ids[1] + `", "` + ids[0] + `"]}`
+		apiHandler.CreateOutfit(w, newOutfitRequest(http.MethodPost, "", body))
+
+		resp := w.Result()
+
+		if resp.StatusCode != http.StatusCreated {
+			t.Fatalf("Expected %d got %d: %s", http.StatusCreated, resp.StatusCode, w.Body.String())
+		}
+
+		outfit := decodeOutfitResponse(t, resp)
+
+		if outfit.Id == "" || outfit.UserId != "test-user-id" || outfit.Name != "Office" || outfit.Occasion != domain.Work || !slices.Equal(outfit.ItemIds, []string{ids[1], ids[0]}) {
+			t.Errorf("Expected the outfit with an id, got %+v", outfit)
+		}
+	})
+
+	t.Run("Given an outfit at the item limit, should read the items once for each check", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		descriptions := make([]string, domain.MaxOutfitItems)
+
+		for i := range descriptions {
+			descriptions[i] = fmt.Sprintf("Item %d", i)
+		}
+
+		apiHandler, ids := newOutfitAPI(t, descriptions...)
+		repo := &countingClothingRepo{ClothingRepository: apiHandler.Repo}
+		apiHandler.Repo = repo
+
+		body, _ := json.Marshal(domain.Outfit{Name: "Everything", ItemIds: ids})
+		apiHandler.CreateOutfit(w, newOutfitRequest(http.MethodPost, "", string(body)))
+
+		if w.Result().StatusCode != http.StatusCreated {
+			t.Fatalf("Expected %d got %d: %s", http.StatusCreated, w.Result().StatusCode, w.Body.String())
+		}
+
+		if repo.reads != 2 {
+			t.Errorf("Expected the items to be read before and after saving, got %d reads", repo.reads)
+		}
+	})
+
+	t.Run("Given items that do not exist or are trashed, should return 400 naming them", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		apiHandler, ids := newOutfitAPI(t, "Shirt", "Trashed")
+		apiHandler.Repo.Trash(context.Background(), "test-user-id", ids[1], 0)
+
+		body := `{"name": "Office", "itemIds": ["` + ids[0] + `", "` + ids[1] + `", "missing-id"]}`
+		apiHandler.CreateOutfit(w, newOutfitRequest(http.MethodPost, "", body))
+
+		if w.Result().StatusCode != http.StatusBadRequest {
+			t.Fatalf("Expected %d got %d", http.StatusBadRequest, w.Result().StatusCode)
+		}
+
+		if !strings.Contains(w.Body.String(), ids[1]+", missing-id") || strings.Contains(w.Body.String(), ids[0]) {
+			t.Errorf("Expected only the missing items to be named, got %s", w.Body.String())
+		}
+
+		if outfits, _ := apiHandler.Outfits.GetAll(context.Background(), "test-user-id"); len(outfits) != 0 {
+			t.Errorf("Expected no outfit to be saved, got %+v", outfits)
+		}
+	})
+
+	t.Run("Given an invalid body, should return 400", func(t *testing.T) {
+		for _, body := range []string{
+			`{"name": " ", "itemIds": ["1"]}`,
+			`{"name": "Office", "itemIds": []}`,
+			`{"name": "Office", "itemIds": ["1", "1"]}`,
+			`{"name": "Office", "occasion": "wedding", "itemIds": ["1"]}`,
+			`{"id": "chosen-id", "name": "Office", "itemIds": ["1"]}`,
+			`{"name": "Office", "itemIds": ["1"], "colour": "blue"}`,
+		} {
+			w := httptest.NewRecorder()
+
+			apiHandler, _ := newOutfitAPI(t)
+			apiHandler.CreateOutfit(w, newOutfitRequest(http.MethodPost, "", body))
+
+			if w.Result().StatusCode != http.StatusBadRequest {
+				t.Errorf("Body %s: Expected %d got %d", body, http.StatusBadRequest, w.Result().StatusCode)
+			}
+		}
+	})
+}
+
+func TestGetOutfits(t *testing.T) {
+	t.Run("Given outfits, should list the user's by name", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		apiHandler, ids := newOutfitAPI(t, "Shirt")
+		apiHandler.Outfits.Save(context.Background(), "test-user-id", domain.Outfit{Name: "Office", ItemIds: ids})
+		apiHandler.Outfits.Save(context.Background(), "test-user-id", domain.Outfit{Name: "Dinner", ItemIds: ids})
+		apiHandler.Outfits.Save(context.Background(), "other-user-id", domain.Outfit{Name: "Theirs", ItemIds: ids})
+
+		apiHandler.GetOutfits(w, newOutfitRequest(http.MethodGet, "", ""))
+
+		if w.Result().StatusCode != http.StatusOK {
+			t.Fatalf("Expected %d got %d", http.StatusOK, w.Result().StatusCode)
+		}
+
+		var body struct {
+			Data []domain.Outfit `json:"data"`
+		}
+
+		if err := json.NewDecoder(w.Result().Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+
+		if len(body.Data) != 2 || body.Data[0].Name != "Dinner" || body.Data[1].Name != "Office" {
+			t.Errorf("Expected Dinner then Office, got %+v", body.Data)
+		}
+	})
+}
+
+func TestGetOutfitById(t *testing.T) {
+	t.Run("Given an outfit, should return it", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		apiHandler, ids := newOutfitAPI(t, "Shirt")
+		saved, _ := apiHandler.Outfits.Save(context.Background(), "test-user-id", domain.Outfit{Name: "Office", ItemIds: ids})
+
+		apiHandler.GetOutfitById(w, newOutfitRequest(http.MethodGet, saved.Id, ""))
+
+		if w.Result().StatusCode != http.StatusOK {
+			t.Fatalf("Expected %d got %d", http.StatusOK, w.Result().StatusCode)
+		}
+
+		if outfit := decodeOutfitResponse(t, w.Result()); outfit.Id != saved.Id || outfit.Name != "Office" {
+			t.Errorf("Expected %+v, got %+v", saved, outfit)
+		}
+	})
+
+	t.Run("Given an outfit that does not exist, should return 404", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		apiHandler, _ := newOutfitAPI(t)
+		apiHandler.GetOutfitById(w, newOutfitRequest(http.MethodGet, "missing-id", ""))
+
+		if w.Result().StatusCode != http.StatusNotFound {
+			t.Fatalf("Expected %d got %d", http.StatusNotFound, w.Result().StatusCode)
+		}
+
+		if !strings.Contains(w.Body.String(), "Outfit not found for ID missing-id") {
+			t.Errorf("Expected the outfit to be reported missing, got %s", w.Body.String())
+		}
+	})
+}
+
+func TestUpdateOutfit(t *testing.T) {
+	t.Run("Given a new name and items, should replace the outfit", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		apiHandler, ids := newOutfitAPI(t, "Shirt", "Blazer")
+		saved, _ := apiHandler.Outfits.Save(context.Background(), "test-user-id", domain.Outfit{Name: "Office", ItemIds: ids[:1]})
+
+		body := `{"name": "Smart office", "itemIds": ["` + ids[0] + `", "` + ids[1] + `"]}`
+		apiHandler.UpdateOutfit(w, newOutfitRequest(http.MethodPut, saved.Id, body))
+
+		if w.Result().StatusCode != http.StatusOK {
+			t.Fatalf("Expected %d got %d: %s", http.StatusOK, w.Result().StatusCode, w.Body.String())
+		}
+
+		got, _ := apiHandler.Outfits.GetById(context.Background(), "test-user-id", saved.Id)
+
+		if got.Name != "Smart office" || !slices.Equal(got.ItemIds, ids) {
+			t.Errorf("Expected the outfit to be replaced, got %+v", got)
+		}
+	})
+
+	t.Run("Given a body with another id, should return 400", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		apiHandler, ids := newOutfitAPI(t, "Shirt")
+		body := `{"id": "other-id", "name": "Office", "itemIds": ["` + ids[0] + `"]}`
+		apiHandler.UpdateOutfit(w, newOutfitRequest(http.MethodPut, "outfit-id", body))
+
+		if w.Result().StatusCode != http.StatusBadRequest {
+			t.Errorf("Expected %d got %d", http.StatusBadRequest, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given an item that does not exist, should return 400", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		apiHandler, ids := newOutfitAPI(t, "Shirt")
+		saved, _ := apiHandler.Outfits.Save(context.Background(), "test-user-id", domain.Outfit{Name: "Office", ItemIds: ids})
+
+		apiHandler.UpdateOutfit(w, newOutfitRequest(http.MethodPut, saved.Id, `{"name": "Office", "itemIds": ["missing-id"]}`))
+
+		if w.Result().StatusCode != http.StatusBadRequest {
+			t.Errorf("Expected %d got %d", http.StatusBadRequest, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given an outfit that does not exist, should return 404", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		apiHandler, ids := newOutfitAPI(t, "Shirt")
+		apiHandler.UpdateOutfit(w, newOutfitRequest(http.MethodPut, "missing-id", `{"name": "Office", "itemIds": ["`+ids[0]+`"]}`))
+
+		if w.Result().StatusCode != http.StatusNotFound {
+			t.Errorf("Expected %d got %d", http.StatusNotFound, w.Result().StatusCode)
+		}
+	})
+}
+
+func TestDeleteOutfit(t *testing.T) {
+	t.Run("Given an outfit, should delete it and free its items to be deleted", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		apiHandler, ids := newOutfitAPI(t, "Shirt")
+		saved, _ := apiHandler.Outfits.Save(context.Background(), "test-user-id", domain.Outfit{Name: "Office", ItemIds: ids})
+
+		apiHandler.DeleteOutfit(w, newOutfitRequest(http.MethodDelete, saved.Id, ""))
+
+		if w.Result().StatusCode != http.StatusNoContent {
+			t.Fatalf("Expected %d got %d", http.StatusNoContent, w.Result().StatusCode)
+		}
+
+		w = httptest.NewRecorder()
+		r := mux.SetURLVars(newOutfitRequest(http.MethodDelete, "", ""), map[string]string{"id": ids[0]})
+		apiHandler.DeleteClothing(w, r)
+
+		if w.Result().StatusCode != http.StatusNoContent {
+			t.Errorf("Expected the item to be deleted with %d, got %d", http.StatusNoContent, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given an outfit that does not exist, should return 404", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		apiHandler, _ := newOutfitAPI(t)
+		apiHandler.DeleteOutfit(w, newOutfitRequest(http.MethodDelete, "missing-id", ""))
+
+		if w.Result().StatusCode != http.StatusNotFound {
+			t.Errorf("Expected %d got %d", http.StatusNotFound, w.Result().StatusCode)
+		}
+	})
+}
+
+// countingClothingRepo counts the reads made to check that items exist.
+type countingClothingRepo struct {
+	repository.ClothingRepository
+	reads int
+}
+
+func (c *countingClothingRepo) GetAll(ctx context.Context, userId string) ([]domain.Clothing, error) {
+	c.reads++
+	return c.ClothingRepository.GetAll(ctx, userId)
+}
+
+func (c *countingClothingRepo) Exists(ctx context.Context, userId, id string) (bool, error) {
+	c.reads++
+	return c.ClothingRepository.Exists(ctx, userId, id)
+}
+
+// trashHookRepo runs afterTrash once, straight after the first successful trash, to stand in for a
+// request that lands between the handler's checks.
+type trashHookRepo struct {
+	repository.ClothingRepository
+	afterTrash func()
+}
+
+func (h *trashHookRepo) runHook() {
+	if hook := h.afterTrash; hook != nil {
+		h.afterTrash = nil
+		hook()
+	}
+}
+
+func (h *trashHookRepo) Trash(ctx context.Context, userId, id string, expectedVersion int64) (domain.Clothing, error) {
+	item, err := h.ClothingRepository.Trash(ctx, userId, id, expectedVersion)
+
+	if err == nil {
+		h.runHook()
+	}
+
+	return item, err
+}
+
+func (h *trashHookRepo) TrashMany(ctx context.Context, userId string, ids []string) ([]domain.Clothing, error) {
+	items, err := h.ClothingRepository.TrashMany(ctx, userId, ids)
+
+	if err == nil {
+		h.runHook()
+	}
+
+	return items, err
+}
+
+// saveHookOutfitRepo runs afterSave once, straight after the first outfit is saved or updated.
+type saveHookOutfitRepo struct {
+	repository.OutfitRepository
+	afterSave func()
+}
+
+func (h *saveHookOutfitRepo) runHook() {
+	if hook := h.afterSave; hook != nil {
+		h.afterSave = nil
+		hook()
+	}
+}
+
+func (h *saveHookOutfitRepo) Save(ctx context.Context, userId string, outfit domain.Outfit) (domain.Outfit, error) {
+	saved, err := h.OutfitRepository.Save(ctx, userId, outfit)
+	h.runHook()
+	return saved, err
+}
+
+func (h *saveHookOutfitRepo) Update(ctx context.Context, userId string, outfit domain.Outfit) (domain.Outfit, error) {
+	updated, err := h.OutfitRepository.Update(ctx, userId, outfit)
+	h.runHook()
+	return updated, err
+}
+
+func TestOutfitTrashRace(t *testing.T) {
+	t.Run("Given an outfit saved as the item is trashed, DeleteClothing should restore it and return 409", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		apiHandler, ids := newOutfitAPI(t, "Shirt")
+		apiHandler.Repo = &trashHookRepo{ClothingRepository: apiHandler.Repo, afterTrash: func() {
+			apiHandler.Outfits.Save(context.Background(), "test-user-id", domain.Outfit{Name: "Office", ItemIds: ids})
+		}}
+
+		r := mux.SetURLVars(newOutfitRequest(http.MethodDelete, "", ""), map[string]string{"id": ids[0]})
+		apiHandler.DeleteClothing(w, r)
+
+		if w.Result().StatusCode != http.StatusConflict || !strings.Contains(w.Body.String(), "Office") {
+			t.Errorf("Expected %d naming Office, got %d: %s", http.StatusConflict, w.Result().StatusCode, w.Body.String())
+		}
+
+		if exists, _ := apiHandler.Repo.Exists(context.Background(), "test-user-id", ids[0]); !exists {
+			t.Errorf("Expected the item to be restored")
+		}
+	})
+
+	t.Run("Given an outfit saved as the items are trashed, BatchDeleteClothing should restore its item and report it", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		apiHandler, ids := newOutfitAPI(t, "Shirt", "Scarf")
+		apiHandler.Repo = &trashHookRepo{ClothingRepository: apiHandler.Repo, afterTrash: func() {
+			apiHandler.Outfits.Save(context.Background(), "test-user-id", domain.Outfit{Name: "Office", ItemIds: ids[:1]})
+		}}
+
+		apiHandler.BatchDeleteClothing(w, newBatchDeleteRequest(`{"ids": ["`+ids[0]+`", "`+ids[1]+`"]}`))
+
+		if w.Result().StatusCode != http.StatusOK {
+			t.Fatalf("Expected %d got %d", http.StatusOK, w.Result().StatusCode)
+		}
+
+		_, report := decodeBatchDeleteReport(t, w.Result())
+
+		if report.Deleted != 1 || report.Results[0].Deleted || !strings.Contains(report.Results[0].Error, "Office") || !report.Results[1].Deleted {
+			t.Errorf("Expected the shirt to be reported as used by Office and the scarf deleted, got %+v", report)
+		}
+
+		if exists, _ := apiHandler.Repo.Exists(context.Background(), "test-user-id", ids[0]); !exists {
+			t.Errorf("Expected the shirt to be restored")
+		}
+	})
+
+	t.Run("Given an item trashed as the outfit is created, should remove the outfit and return 409", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		apiHandler, ids := newOutfitAPI(t, "Shirt", "Scarf")
+		apiHandler.Outfits = &saveHookOutfitRepo{OutfitRepository: apiHandler.Outfits, afterSave: func() {
+			apiHandler.Repo.Trash(context.Background(), "test-user-id", ids[1], 0)
+		}}
+
+		body := `{"name": "Office", "itemIds": ["` + ids[0] + `", "` + ids[1] + `"]}`
+		apiHandler.CreateOutfit(w, newOutfitRequest(http.MethodPost, "", body))
+
+		if w.Result().StatusCode != http.StatusConflict || !strings.Contains(w.Body.String(), ids[1]) {
+			t.Errorf("Expected %d naming the scarf, got %d: %s", http.StatusConflict, w.Result().StatusCode, w.Body.String())
+		}
+
+		if outfits, _ := apiHandler.Outfits.GetAll(context.Background(), "test-user-id"); len(outfits) != 0 {
+			t.Errorf("Expected no outfits, got %+v", outfits)
+		}
+	})
+
+	t.Run("Given an item trashed as the outfit is updated, should put the outfit back and return 409", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		apiHandler, ids := newOutfitAPI(t, "Shirt", "Scarf")
+		saved, _ := apiHandler.Outfits.Save(context.Background(), "test-user-id", domain.Outfit{Name: "Office", ItemIds: ids[:1]})
+		apiHandler.Outfits = &saveHookOutfitRepo{OutfitRepository: apiHandler.Outfits, afterSave: func() {
+			apiHandler.Repo.Trash(context.Background(), "test-user-id", ids[1], 0)
+		}}
+
+		body := `{"name": "Smart office", "itemIds": ["` + ids[0] + `", "` + ids[1] + `"]}`
+		apiHandler.UpdateOutfit(w, newOutfitRequest(http.MethodPut, saved.Id, body))
+
+		if w.Result().StatusCode != http.StatusConflict {
+			t.Errorf("Expected %d got %d: %s", http.StatusConflict, w.Result().StatusCode, w.Body.String())
+		}
+
+		if got, _ := apiHandler.Outfits.GetById(context.Background(), "test-user-id", saved.Id); got.Name != "Office" || !slices.Equal(got.ItemIds, ids[:1]) {
+			t.Errorf("Expected the outfit to be put back, got %+v", got)
+		}
+	})
+}
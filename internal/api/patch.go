@@ -0,0 +1,443 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	MergePatchContentType = "application/merge-patch+json"
+	JSONPatchContentType  = "application/json-patch+json"
+)
+
+var (
+	// ErrInvalidPatch means the patch document itself is malformed.
+	ErrInvalidPatch = errors.New("invalid patch")
+	// ErrPatchConflict means the patch is well formed but cannot be applied to the current document,
+	// such as a failed "test" operation or a path that does not exist.
+	ErrPatchConflict = errors.New("patch cannot be applied")
+)
+
+// ApplyMergePatch applies an RFC 7396 JSON Merge Patch to a JSON document.
+func ApplyMergePatch(document, patch []byte) ([]byte, error) {
+	target, err := decodeJSONValue(document)
+
+	if err != nil {
+		return nil, err
+	}
+
+	patchValue, err := decodeJSONValue(patch)
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+
+	return json.Marshal(mergePatch(target, patchValue))
+}
+
+func mergePatch(target, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]any)
+
+	if !ok {
+		targetObject = map[string]any{}
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+
+		targetObject[key] = mergePatch(targetObject[key], value)
+	}
+
+	return targetObject
+}
+
+type patchOperation struct {
+	Op    string          `json:"op"`
+	Path  *string         `json:"path"`
+	From  *string         `json:"from"`
+	Value json.RawMessage `json:"value"`
+}
+
+// ApplyJSONPatch applies an RFC 6902 JSON Patch to a JSON document. Operations are applied in order and
+// the whole patch fails if any one of them does.
+func ApplyJSONPatch(document, patch []byte) ([]byte, error) {
+	target, err := decodeJSONValue(document)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var operations []patchOperation
+
+	if err := json.Unmarshal(patch, &operations); err != nil {
+		return nil, fmt.Errorf("%w: patch must be an array of operations: %v", ErrInvalidPatch, err)
+	}
+
+	for i, operation := range operations {
+		target, err = applyPatchOperation(target, operation)
+
+		if err != nil {
+			return nil, fmt.Errorf("operation %d (%s): %w", i, operation.Op, err)
+		}
+	}
+
+	return json.Marshal(target)
+}
+
+func applyPatchOperation(document any, operation patchOperation) (any, error) {
+	if operation.Path == nil {
+		return nil, fmt.Errorf("%w: missing 'path'", ErrInvalidPatch)
+	}
+
+	path, err := parseJSONPointer(*operation.Path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch operation.Op {
+	case "add", "replace", "test":
+		if operation.Value == nil {
+			return nil, fmt.Errorf("%w: missing 'value'", ErrInvalidPatch)
+		}
+
+		value, err := decodeJSONValue(operation.Value)
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+		}
+
+		switch operation.Op {
+		case "add":
+			return addJSONValue(document, path, value)
+		case "replace":
+			if _, err := getJSONValue(document, path); err != nil {
+				return nil, err
+			}
+
+			document, err = removeJSONValue(document, path)
+
+			if err != nil {
+				return nil, err
+			}
+
+			return addJSONValue(document, path, value)
+		default:
+			current, err := getJSONValue(document, path)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if !jsonValuesEqual(current, value) {
+				return nil, fmt.Errorf("%w: value at '%s' does not match", ErrPatchConflict, *operation.Path)
+			}
+
+			return document, nil
+		}
+	case "remove":
+		return removeJSONValue(document, path)
+	case "move", "copy":
+		if operation.From == nil {
+			return nil, fmt.Errorf("%w: missing 'from'", ErrInvalidPatch)
+		}
+
+		from, err := parseJSONPointer(*operation.From)
+
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := getJSONValue(document, from)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if operation.Op == "move" {
+			if len(path) > len(from) && strings.HasPrefix(*operation.Path, *operation.From+"/") {
+				return nil, fmt.Errorf("%w: cannot move a value into itself", ErrInvalidPatch)
+			}
+
+			document, err = removeJSONValue(document, from)
+
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			// Round trip through JSON so the copy does not share maps or slices with the original.
+			raw, err := json.Marshal(value)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if value, err = decodeJSONValue(raw); err != nil {
+				return nil, err
+			}
+		}
+
+		return addJSONValue(document, path, value)
+	default:
+		return nil, fmt.Errorf("%w: unsupported op '%s'", ErrInvalidPatch, operation.Op)
+	}
+}
+
+// parseJSONPointer splits an RFC 6901 JSON Pointer into unescaped reference tokens.
+func parseJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return []string{}, nil
+	}
+
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: path '%s' must start with '/'", ErrInvalidPatch, pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+
+	return tokens, nil
+}
+
+func getJSONValue(document any, path []string) (any, error) {
+	current := document
+
+	for _, token := range path {
+		switch node := current.(type) {
+		case map[string]any:
+			child, ok := node[token]
+
+			if !ok {
+				return nil, fmt.Errorf("%w: '%s' does not exist", ErrPatchConflict, token)
+			}
+
+			current = child
+		case []any:
+			index, err := arrayIndex(token, len(node)-1)
+
+			if err != nil {
+				return nil, err
+			}
+
+			current = node[index]
+		default:
+			return nil, fmt.Errorf("%w: '%s' does not exist", ErrPatchConflict, token)
+		}
+	}
+
+	return current, nil
+}
+
+func addJSONValue(document any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	token := path[0]
+
+	switch node := document.(type) {
+	case map[string]any:
+		if len(path) == 1 {
+			node[token] = value
+			return node, nil
+		}
+
+		child, ok := node[token]
+
+		if !ok {
+			return nil, fmt.Errorf("%w: '%s' does not exist", ErrPatchConflict, token)
+		}
+
+		updated, err := addJSONValue(child, path[1:], value)
+
+		if err != nil {
+			return nil, err
+		}
+
+		node[token] = updated
+		return node, nil
+	case []any:
+		if len(path) == 1 {
+			index := len(node)
+
+			if token != "-" {
+				var err error
+
+				if index, err = arrayIndex(token, len(node)); err != nil {
+					return nil, err
+				}
+			}
+
+			node = append(node, nil)
+			copy(node[index+1:], node[index:])
+			node[index] = value
+			return node, nil
+		}
+
+		index, err := arrayIndex(token, len(node)-1)
+
+		if err != nil {
+			return nil, err
+		}
+
+		updated, err := addJSONValue(node[index], path[1:], value)
+
+		if err != nil {
+			return nil, err
+		}
+
+		node[index] = updated
+		return node, nil
+	default:
+		return nil, fmt.Errorf("%w: cannot add '%s' to a scalar value", ErrPatchConflict, token)
+	}
+}
+
+func removeJSONValue(document any, path []string) (any, error) {
+	if len(path) == 0 {
+		return nil, fmt.Errorf("%w: cannot remove the whole document", ErrInvalidPatch)
+	}
+
+	token := path[0]
+
+	switch node := document.(type) {
+	case map[string]any:
+		child, ok := node[token]
+
+		if !ok {
+			return nil, fmt.Errorf("%w: '%s' does not exist", ErrPatchConflict, token)
+		}
+
+		if len(path) == 1 {
+			delete(node, token)
+			return node, nil
+		}
+
+		updated, err := removeJSONValue(child, path[1:])
+
+		if err != nil {
+			return nil, err
+		}
+
+		node[token] = updated
+		return node, nil
+	case []any:
+		index, err := arrayIndex(token, len(node)-1)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if len(path) == 1 {
+			return append(node[:index], node[index+1:]...), nil
+		}
+
+		updated, err := removeJSONValue(node[index], path[1:])
+
+		if err != nil {
+			return nil, err
+		}
+
+		node[index] = updated
+		return node, nil
+	default:
+		return nil, fmt.Errorf("%w: '%s' does not exist", ErrPatchConflict, token)
+	}
+}
+
+// arrayIndex parses an array reference token, which must be a plain decimal no greater than max.
+func arrayIndex(token string, max int) (int, error) {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("%w: '%s' is not a valid array index", ErrInvalidPatch, token)
+	}
+
+	index, err := strconv.Atoi(token)
+
+	if err != nil || index < 0 {
+		return 0, fmt.Errorf("%w: '%s' is not a valid array index", ErrInvalidPatch, token)
+	}
+
+	if index > max {
+		return 0, fmt.Errorf("%w: array index %d is out of range", ErrPatchConflict, index)
+	}
+
+	return index, nil
+}
+
+// decodeJSONValue decodes with UseNumber so whole pence values survive the round trip exactly.
+func decodeJSONValue(raw []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+
+	var value any
+
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+func jsonValuesEqual(a, b any) bool {
+	switch left := a.(type) {
+	case map[string]any:
+		right, ok := b.(map[string]any)
+
+		if !ok || len(left) != len(right) {
+			return false
+		}
+
+		for key, value := range left {
+			other, ok := right[key]
+
+			if !ok || !jsonValuesEqual(value, other) {
+				return false
+			}
+		}
+
+		return true
+	case []any:
+		right, ok := b.([]any)
+
+		if !ok || len(left) != len(right) {
+			return false
+		}
+
+		for i := range left {
+			if !jsonValuesEqual(left[i], right[i]) {
+				return false
+			}
+		}
+
+		return true
+	case json.Number:
+		right, ok := b.(json.Number)
+
+		if !ok {
+			return false
+		}
+
+		leftFloat, leftErr := left.Float64()
+		rightFloat, rightErr := right.Float64()
+
+		return leftErr == nil && rightErr == nil && leftFloat == rightFloat
+	default:
+		return a == b
+	}
+}
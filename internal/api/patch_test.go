@@ -0,0 +1,161 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func assertJSONEqual(t *testing.T, expected string, actual []byte) {
+	t.Helper()
+
+	want, err := decodeJSONValue([]byte(expected))
+	if err != nil {
+		t.Fatalf("failed to decode expected json: %v", err)
+	}
+
+	got, err := decodeJSONValue(actual)
+	if err != nil {
+		t.Fatalf("failed to decode actual json: %v", err)
+	}
+
+	if !jsonValuesEqual(want, got) {
+		t.Errorf("Expected %s got %s", expected, actual)
+	}
+}
+
+func TestApplyMergePatch(t *testing.T) {
+	t.Run("Given RFC 7396 examples, should produce the documented results", func(t *testing.T) {
+		cases := []struct {
+			document string
+			patch    string
+			expected string
+		}{
+			{document: `{"a":"b"}`, patch: `{"a":"c"}`, expected: `{"a":"c"}`},
+			{document: `{"a":"b"}`, patch: `{"b":"c"}`, expected: `{"a":"b","b":"c"}`},
+			{document: `{"a":"b"}`, patch: `{"a":null}`, expected: `{}`},
+			{document: `{"a":"b","b":"c"}`, patch: `{"a":null}`, expected: `{"b":"c"}`},
+			{document: `{"a":["b"]}`, patch: `{"a":"c"}`, expected: `{"a":"c"}`},
+			{document: `{"a":"c"}`, patch: `{"a":["b"]}`, expected: `{"a":["b"]}`},
+			{document: `{"a":{"b":"c"}}`, patch: `{"a":{"b":"d","c":null}}`, expected: `{"a":{"b":"d"}}`},
+			{document: `{"a":[{"b":"c"}]}`, patch: `{"a":[1]}`, expected: `{"a":[1]}`},
+			{document: `{"e":null}`, patch: `{"a":1}`, expected: `{"e":null,"a":1}`},
+			{document: `[1,2]`, patch: `{"a":"b","c":null}`, expected: `{"a":"b"}`},
+			{document: `{}`, patch: `{"a":{"bb":{"ccc":null}}}`, expected: `{"a":{"bb":{}}}`},
+		}
+
+		for _, c := range cases {
+			result, err := ApplyMergePatch([]byte(c.document), []byte(c.patch))
+
+			if err != nil {
+				t.Fatalf("%s + %s: unexpected error %v", c.document, c.patch, err)
+			}
+
+			assertJSONEqual(t, c.expected, result)
+		}
+	})
+
+	t.Run("Given a large whole number, should not lose precision", func(t *testing.T) {
+		result, err := ApplyMergePatch([]byte(`{"pricePence":1}`), []byte(`{"pricePence":9007199254740993}`))
+
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+
+		if string(result) != `{"pricePence":9007199254740993}` {
+			t.Errorf("Expected the exact number to be kept, got %s", result)
+		}
+	})
+
+	t.Run("Given malformed patch JSON, should return ErrInvalidPatch", func(t *testing.T) {
+		_, err := ApplyMergePatch([]byte(`{}`), []byte(`{`))
+
+		if !errors.Is(err, ErrInvalidPatch) {
+			t.Errorf("Expected ErrInvalidPatch, got %v", err)
+		}
+	})
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	t.Run("Given each operation, should produce the RFC 6902 result", func(t *testing.T) {
+		cases := []struct {
+			document string
+			patch    string
+			expected string
+		}{
+			{document: `{"foo":"bar"}`, patch: `[{"op":"add","path":"/baz","value":"qux"}]`, expected: `{"foo":"bar","baz":"qux"}`},
+			{document: `{"foo":["bar","baz"]}`, patch: `[{"op":"add","path":"/foo/1","value":"qux"}]`, expected: `{"foo":["bar","qux","baz"]}`},
+			{document: `{"foo":["bar"]}`, patch: `[{"op":"add","path":"/foo/-","value":"baz"}]`, expected: `{"foo":["bar","baz"]}`},
+			{document: `{"baz":"qux","foo":"bar"}`, patch: `[{"op":"remove","path":"/baz"}]`, expected: `{"foo":"bar"}`},
+			{document: `{"foo":["bar","qux","baz"]}`, patch: `[{"op":"remove","path":"/foo/1"}]`, expected: `{"foo":["bar","baz"]}`},
+			{document: `{"baz":"qux","foo":"bar"}`, patch: `[{"op":"replace","path":"/baz","value":"boo"}]`, expected: `{"baz":"boo","foo":"bar"}`},
+			{document: `{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`, patch: `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`, expected: `{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`},
+			{document: `{"foo":["all","grass","cows","eat"]}`, patch: `[{"op":"move","from":"/foo/1","path":"/foo/3"}]`, expected: `{"foo":["all","cows","eat","grass"]}`},
+			{document: `{"foo":{"bar":1}}`, patch: `[{"op":"copy","from":"/foo","path":"/baz"},{"op":"replace","path":"/baz/bar","value":2}]`, expected: `{"foo":{"bar":1},"baz":{"bar":2}}`},
+			{document: `{"/":1,"~":2}`, patch: `[{"op":"test","path":"/~1","value":1.0},{"op":"remove","path":"/~0"}]`, expected: `{"/":1}`},
+			{document: `{"foo":"bar"}`, patch: `[{"op":"add","path":"/child","value":{"grandchild":{}}}]`, expected: `{"foo":"bar","child":{"grandchild":{}}}`},
+		}
+
+		for _, c := range cases {
+			result, err := ApplyJSONPatch([]byte(c.document), []byte(c.patch))
+
+			if err != nil {
+				t.Fatalf("%s + %s: unexpected error %v", c.document, c.patch, err)
+			}
+
+			assertJSONEqual(t, c.expected, result)
+		}
+	})
+
+	t.Run("Given an operation that cannot apply to the document, should return ErrPatchConflict", func(t *testing.T) {
+		cases := []string{
+			`[{"op":"test","path":"/foo","value":"baz"}]`,
+			`[{"op":"remove","path":"/missing"}]`,
+			`[{"op":"replace","path":"/missing","value":1}]`,
+			`[{"op":"add","path":"/missing/child","value":1}]`,
+			`[{"op":"add","path":"/list/5","value":1}]`,
+		}
+
+		for _, patch := range cases {
+			_, err := ApplyJSONPatch([]byte(`{"foo":"bar","list":[]}`), []byte(patch))
+
+			if !errors.Is(err, ErrPatchConflict) {
+				t.Errorf("%s: Expected ErrPatchConflict, got %v", patch, err)
+			}
+		}
+	})
+
+	t.Run("Given a malformed patch, should return ErrInvalidPatch", func(t *testing.T) {
+		cases := []string{
+			`{"op":"add","path":"/a","value":1}`,
+			`[{"op":"frobnicate","path":"/a"}]`,
+			`[{"op":"add","value":1}]`,
+			`[{"op":"add","path":"/a"}]`,
+			`[{"op":"add","path":"a","value":1}]`,
+			`[{"op":"move","path":"/a"}]`,
+			`[{"op":"add","path":"/list/01","value":1}]`,
+			`[{"op":"move","from":"/foo","path":"/foo/bar"}]`,
+		}
+
+		for _, patch := range cases {
+			_, err := ApplyJSONPatch([]byte(`{"foo":{},"list":[1]}`), []byte(patch))
+
+			if !errors.Is(err, ErrInvalidPatch) {
+				t.Errorf("%s: Expected ErrInvalidPatch, got %v", patch, err)
+			}
+		}
+	})
+
+	t.Run("Given a later operation fails, should not return a partially patched document", func(t *testing.T) {
+		result, err := ApplyJSONPatch([]byte(`{"foo":"bar"}`), []byte(`[{"op":"add","path":"/baz","value":1},{"op":"test","path":"/foo","value":"nope"}]`))
+
+		if err == nil || result != nil {
+			t.Errorf("Expected an error and no document, got %s and %v", result, err)
+		}
+
+		var document map[string]any
+		if json.Unmarshal(result, &document) == nil {
+			t.Errorf("Expected no document to be returned")
+		}
+	})
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"clothes_management/internal/domain"
+	"clothes_management/internal/repository"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// GetSeasonalClothing lists a page of the user's items that suit the season of the 'date' parameter, or
+// of today in UTC, in the hemisphere of the user's settings. It takes the same parameters as GetClothing
+// other than 'season', and the response says which season and hemisphere were used.
+func (a *API) GetSeasonalClothing(w http.ResponseWriter, r *http.Request) {
+	a.listClothing(w, r, false, a.seasonalScope)
+}
+
+func (a *API) seasonalScope(w http.ResponseWriter, r *http.Request, userId string, filter *repository.ClothingFilter) (map[string]any, bool) {
+	query := r.URL.Query()
+
+	if query.Has("season") {
+		http.Error(w, "Must not give a 'season' parameter, the season is that of 'date'", http.StatusBadRequest)
+		return nil, false
+	}
+
+	date := time.Now().UTC()
+
+	if raw := strings.TrimSpace(query.Get("date")); raw != "" {
+		var err error
+
+		if date, err = time.Parse(time.DateOnly, raw); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid 'date' parameter %q, must be a date such as 2024-12-25", raw), http.StatusBadRequest)
+			return nil, false
+		}
+	}
+
+	settings, err := a.userSettings(r.Context(), userId)
+
+	if err != nil {
+		writeRepositoryError(w, err, "", "Error getting settings")
+		return nil, false
+	}
+
+	season := domain.SeasonOf(date, settings.Hemisphere)
+	filter.Seasons = []domain.Season{season}
+
+	return map[string]any{"season": season, "hemisphere": settings.Hemisphere}, true
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"clothes_management/internal/domain"
+	"clothes_management/internal/repository"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestGetSeasonalClothing(t *testing.T) {
+	t.Run("Given no UserID in context, should return 401", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/clothes/seasonal", nil)
+
+		apiHandler := &API{Repo: &DummyClothingRepo{}}
+		apiHandler.GetSeasonalClothing(w, r)
+
+		if w.Result().StatusCode != http.StatusUnauthorized {
+			t.Errorf("Expected %d got %d", http.StatusUnauthorized, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given a date, should list items for its season in the user's hemisphere", func(t *testing.T) {
+		for hemisphere, expected := range map[domain.Hemisphere]domain.Season{
+			domain.NorthernHemisphere: domain.Winter,
+			domain.SouthernHemisphere: domain.Summer,
+		} {
+			settings := repository.NewInMemorySettingsRepository()
+
+			if _, err := settings.Save(context.Background(), "test-user-id", domain.Settings{Hemisphere: hemisphere}); err != nil {
+				t.Fatalf("failed to save settings: %v", err)
+			}
+
+			w := httptest.NewRecorder()
+			ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+			r := httptest.NewRequestWithContext(ctx, http.MethodGet, "/clothes/seasonal?date=2024-12-25&occasion=work", nil)
+
+			repo := &DummyClothingRepo{}
+			apiHandler := &API{Repo: repo, Settings: settings}
+			apiHandler.GetSeasonalClothing(w, r)
+
+			if w.Result().StatusCode != http.StatusOK {
+				t.Fatalf("%s: Expected %d got %d", hemisphere, http.StatusOK, w.Result().StatusCode)
+			}
+
+			filter := repo.GetPageRequest.Filter
+
+			if !slices.Equal(filter.Seasons, []domain.Season{expected}) || !slices.Equal(filter.Occasions, []domain.Occasion{domain.Work}) || filter.Trashed {
+				t.Errorf("%s: Expected live %s items for work, got %+v", hemisphere, expected, filter)
+			}
+
+			var body struct {
+				Season     domain.Season     `json:"season"`
+				Hemisphere domain.Hemisphere `json:"hemisphere"`
+			}
+
+			if err := json.NewDecoder(w.Result().Body).Decode(&body); err != nil || body.Season != expected || body.Hemisphere != hemisphere {
+				t.Errorf("%s: Expected the response to say %s in the %s hemisphere, got %+v and %v", hemisphere, expected, hemisphere, body, err)
+			}
+		}
+	})
+
+	t.Run("Given no settings store, should use the default hemisphere", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+		r := httptest.NewRequestWithContext(ctx, http.MethodGet, "/clothes/seasonal?date=2024-07-01", nil)
+
+		repo := &DummyClothingRepo{}
+		apiHandler := &API{Repo: repo}
+		apiHandler.GetSeasonalClothing(w, r)
+
+		if w.Result().StatusCode != http.StatusOK {
+			t.Fatalf("Expected %d got %d", http.StatusOK, w.Result().StatusCode)
+		}
+
+		if seasons := repo.GetPageRequest.Filter.Seasons; !slices.Equal(seasons, []domain.Season{domain.Summer}) {
+			t.Errorf("Expected summer items, got %q", seasons)
+		}
+	})
+
+	t.Run("Given an invalid date or a season, should return 400", func(t *testing.T) {
+		for _, query := range []string{"date=25/12/2024", "date=2024-02-30", "date=2024-12-25&season=summer"} {
+			w := httptest.NewRecorder()
+			ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+			r := httptest.NewRequestWithContext(ctx, http.MethodGet, "/clothes/seasonal?"+query, nil)
+
+			repo := &DummyClothingRepo{}
+			apiHandler := &API{Repo: repo}
+			apiHandler.GetSeasonalClothing(w, r)
+
+			if w.Result().StatusCode != http.StatusBadRequest {
+				t.Errorf("%s: Expected %d got %d", query, http.StatusBadRequest, w.Result().StatusCode)
+			}
+
+			if repo.GetPageRequest != nil {
+				t.Errorf("%s: Expected no page to be read", query)
+			}
+		}
+	})
+}
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"bytes"
+	"clothes_management/internal/domain"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// GetSettings returns the user's settings, which are the defaults until they save any.
+func (a *API) GetSettings(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, fmt.Sprintf("Unauthorised method %s.", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	userId, ok := r.Context().Value(UserIDContextKey).(string)
+
+	if !ok {
+		http.Error(w, "UserID not provided", http.StatusUnauthorized)
+		return
+	}
+
+	if strings.TrimSpace(userId) == "" {
+		http.Error(w, "UserID not provided", http.StatusUnauthorized)
+		return
+	}
+
+	settings, err := a.userSettings(r.Context(), userId)
+
+	if err != nil {
+		writeRepositoryError(w, err, "", "Error getting settings")
+		return
+	}
+
+	resp := map[string]any{"success": true, "data": settings}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(resp)
+}
+
+// UpdateSettings replaces the user's settings with the body.
+func (a *API) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, fmt.Sprintf("Unauthorised method %s.", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	userId, ok := r.Context().Value(UserIDContextKey).(string)
+
+	if !ok {
+		http.Error(w, "UserID not provided", http.StatusUnauthorized)
+		return
+	}
+
+	if strings.TrimSpace(userId) == "" {
+		http.Error(w, "UserID not provided", http.StatusUnauthorized)
+		return
+	}
+
+	if r.Body == nil || r.Body == http.NoBody {
+		http.Error(w, "Request body must not be empty or missing", http.StatusBadRequest)
+		return
+	}
+
+	bodyBytes, err := io.ReadAll(r.Body)
+
+	if err != nil {
+		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	var settings domain.Settings
+
+	dec := json.NewDecoder(bytes.NewReader(bodyBytes))
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(&settings); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request body, superfluous fields %s", err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	if settings.UserId != "" && settings.UserId != userId {
+		http.Error(w, fmt.Sprintf("Body has UserId = %s, but UserId = %s, resulting is mismatch", settings.UserId, userId), http.StatusBadRequest)
+		return
+	}
+
+	saved, err := a.Settings.Save(r.Context(), userId, settings)
+
+	if err != nil {
+		writeRepositoryError(w, err, "", "Error saving settings")
+		return
+	}
+
+	resp := map[string]any{"success": true, "data": saved}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(resp)
+}
+
+// userSettings returns the user's settings, or the defaults when the API has no settings store.
+func (a *API) userSettings(ctx context.Context, userId string) (domain.Settings, error) {
+	if a.Settings == nil {
+		return domain.DefaultSettings(userId), nil
+	}
+
+	return a.Settings.Get(ctx, userId)
+}
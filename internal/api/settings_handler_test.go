@@ -0,0 +1,125 @@
+package api
+
+import (
+	"clothes_management/internal/domain"
+	"clothes_management/internal/repository"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newSettingsRequest(method, body string) *http.Request {
+	ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+	return httptest.NewRequestWithContext(ctx, method, "/settings", strings.NewReader(body))
+}
+
+func decodeSettingsResponse(t *testing.T, resp *http.Response) domain.Settings {
+	t.Helper()
+
+	var body struct {
+		Success bool            `json:"success"`
+		Data    domain.Settings `json:"data"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if !body.Success {
+		t.Fatalf("Expected success to be true")
+	}
+
+	return body.Data
+}
+
+func TestGetSettings(t *testing.T) {
+	t.Run("Given no UserID in context, should return 401", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/settings", nil)
+
+		apiHandler := &API{Settings: repository.NewInMemorySettingsRepository()}
+		apiHandler.GetSettings(w, r)
+
+		if w.Result().StatusCode != http.StatusUnauthorized {
+			t.Errorf("Expected %d got %d", http.StatusUnauthorized, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given a user with no saved settings, should return the defaults", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		apiHandler := &API{Settings: repository.NewInMemorySettingsRepository()}
+		apiHandler.GetSettings(w, newSettingsRequest(http.MethodGet, ""))
+
+		if w.Result().StatusCode != http.StatusOK {
+			t.Fatalf("Expected %d got %d", http.StatusOK, w.Result().StatusCode)
+		}
+
+		if settings := decodeSettingsResponse(t, w.Result()); settings != domain.DefaultSettings("test-user-id") {
+			t.Errorf("Expected the default settings, got %+v", settings)
+		}
+	})
+}
+
+func TestUpdateSettings(t *testing.T) {
+	t.Run("Given a GET request, should return 405", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		apiHandler := &API{Settings: repository.NewInMemorySettingsRepository()}
+		apiHandler.UpdateSettings(w, newSettingsRequest(http.MethodGet, `{"hemisphere": "southern"}`))
+
+		if w.Result().StatusCode != http.StatusMethodNotAllowed {
+			t.Errorf("Expected %d got %d", http.StatusMethodNotAllowed, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given a hemisphere, should save it for GetSettings", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		apiHandler := &API{Settings: repository.NewInMemorySettingsRepository()}
+		apiHandler.UpdateSettings(w, newSettingsRequest(http.MethodPut, `{"hemisphere": "Southern"}`))
+
+		if w.Result().StatusCode != http.StatusOK {
+			t.Fatalf("Expected %d got %d", http.StatusOK, w.Result().StatusCode)
+		}
+
+		expected := domain.Settings{UserId: "test-user-id", Hemisphere: domain.SouthernHemisphere}
+
+		if saved := decodeSettingsResponse(t, w.Result()); saved != expected {
+			t.Errorf("Expected %+v to be saved, got %+v", expected, saved)
+		}
+
+		w = httptest.NewRecorder()
+		apiHandler.GetSettings(w, newSettingsRequest(http.MethodGet, ""))
+
+		if settings := decodeSettingsResponse(t, w.Result()); settings != expected {
+			t.Errorf("Expected %+v, got %+v", expected, settings)
+		}
+	})
+
+	t.Run("Given an invalid body, should return 400 and keep the settings", func(t *testing.T) {
+		for _, body := range []string{
+			`{"hemisphere": "eastern"}`,
+			`{}`,
+			`{"hemisphere": "southern", "units": "metric"}`,
+			`{"userId": "another-user-id", "hemisphere": "southern"}`,
+		} {
+			w := httptest.NewRecorder()
+
+			settings := repository.NewInMemorySettingsRepository()
+			apiHandler := &API{Settings: settings}
+			apiHandler.UpdateSettings(w, newSettingsRequest(http.MethodPut, body))
+
+			if w.Result().StatusCode != http.StatusBadRequest {
+				t.Errorf("%s: Expected %d got %d", body, http.StatusBadRequest, w.Result().StatusCode)
+			}
+
+			if saved, _ := settings.Get(context.Background(), "test-user-id"); saved.Hemisphere != domain.DefaultHemisphere {
+				t.Errorf("%s: Expected the settings to be unchanged, got %+v", body, saved)
+			}
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"clothes_management/internal/domain"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// GetClothingStats returns aggregate statistics over all of the user's clothing, with every price
+// converted into the 'currency' parameter, GBP by default, through Rates. The cheapest and dearest items
+// are reported with their converted prices.
+func (a *API) GetClothingStats(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, fmt.Sprintf("Unauthorised method %s.", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	userId, ok := r.Context().Value(UserIDContextKey).(string)
+
+	if !ok {
+		http.Error(w, "UserID not provided", http.StatusUnauthorized)
+		return
+	}
+
+	if strings.TrimSpace(userId) == "" {
+		http.Error(w, "UserID not provided", http.StatusUnauthorized)
+		return
+	}
+
+	currency := domain.DefaultCurrency
+
+	if raw := r.URL.Query().Get("currency"); raw != "" {
+		if currency, ok = domain.ParseCurrency(raw); !ok {
+			http.Error(w, fmt.Sprintf("Invalid 'currency' %q, must be a supported ISO 4217 code", raw), http.StatusBadRequest)
+			return
+		}
+	}
+
+	items, err := a.Repo.GetAll(r.Context(), userId)
+
+	if err != nil {
+		writeRepositoryError(w, err, "", "Error getting clothing statistics")
+		return
+	}
+
+	for i := range items {
+		price, err := domain.Convert(r.Context(), a.Rates, items[i].PriceMoney(), currency)
+
+		if errors.Is(err, domain.ErrNoExchangeRate) {
+			http.Error(w, fmt.Sprintf("Unable to report in %s: %s", currency, err.Error()), http.StatusUnprocessableEntity)
+			return
+		}
+
+		if err != nil {
+			log.Print(err)
+			http.Error(w, "Error getting clothing statistics", http.StatusInternalServerError)
+			return
+		}
+
+		items[i].Price = domain.Pence(price.Amount)
+		items[i].Currency = currency
+	}
+
+	resp := map[string]any{"success": true, "data": domain.CalculateStats(items, currency)}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(resp)
+}
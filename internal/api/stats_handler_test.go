@@ -0,0 +1,163 @@
+package api
+
+import (
+	"clothes_management/internal/domain"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// eurRates only converts GBP into EUR, at 1.25.
+type eurRates struct{}
+
+func (eurRates) Rate(ctx context.Context, from, to domain.Currency) (float64, error) {
+	if from != "GBP" || to != "EUR" {
+		return 0, fmt.Errorf("%w from %s to %s", domain.ErrNoExchangeRate, from, to)
+	}
+
+	return 1.25, nil
+}
+
+func TestGetClothingStats(t *testing.T) {
+	t.Run("Given request method is not allowed, should return error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+		r := httptest.NewRequestWithContext(ctx, http.MethodPost, "/clothes/stats", nil)
+
+		apiHandler := &API{
+			Repo: &DummyClothingRepo{},
+		}
+		apiHandler.GetClothingStats(w, r)
+
+		if w.Result().StatusCode != http.StatusMethodNotAllowed {
+			t.Errorf("Expected %d got %d", http.StatusMethodNotAllowed, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given no UserID in context, should return 401", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/clothes/stats", nil)
+
+		apiHandler := &API{
+			Repo: &DummyClothingRepo{},
+		}
+		apiHandler.GetClothingStats(w, r)
+
+		if w.Result().StatusCode != http.StatusUnauthorized {
+			t.Errorf("Expected %d got %d", http.StatusUnauthorized, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given the repository fails, should return 500", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+		r := httptest.NewRequestWithContext(ctx, http.MethodGet, "/clothes/stats", nil)
+
+		apiHandler := &API{
+			Repo: &DummyClothingRepo{GetAllError: errors.New("boom")},
+		}
+		apiHandler.GetClothingStats(w, r)
+
+		if w.Result().StatusCode != http.StatusInternalServerError {
+			t.Errorf("Expected %d got %d", http.StatusInternalServerError, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given items, should return their statistics", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+		r := httptest.NewRequestWithContext(ctx, http.MethodGet, "/clothes/stats", nil)
+
+		apiHandler := &API{
+			Repo: &DummyClothingRepo{AllItems: []domain.Clothing{
+				{Id: "1", Price: 1000, ClothingType: "Jumper", Brand: "XYZ", Store: "This Store", Size: "M"},
+				{Id: "2", Price: 3000, ClothingType: "Coat", Brand: "XYZ", Store: "This Store", Size: "L"},
+			}},
+		}
+		apiHandler.GetClothingStats(w, r)
+
+		resp := w.Result()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Expected %d got %d", http.StatusOK, resp.StatusCode)
+		}
+
+		var body struct {
+			Success bool                 `json:"success"`
+			Data    domain.WardrobeStats `json:"data"`
+		}
+
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+
+		if !body.Success || body.Data.Count != 2 || body.Data.Average.Formatted != "£20.00" {
+			t.Errorf("Expected success with 2 items averaging £20.00, got %+v", body)
+		}
+
+		if body.Data.MaxPriceItem == nil || body.Data.MaxPriceItem.Id != "2" {
+			t.Errorf("Expected max price item 2, got %v", body.Data.MaxPriceItem)
+		}
+	})
+
+	t.Run("Given a reporting currency, should convert every price into it", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+		r := httptest.NewRequestWithContext(ctx, http.MethodGet, "/clothes/stats?currency=eur", nil)
+
+		apiHandler := &API{
+			Repo: &DummyClothingRepo{AllItems: []domain.Clothing{
+				{Id: "1", Price: 1000, ClothingType: "Jumper", Brand: "XYZ", Store: "This Store", Size: "M"},
+				{Id: "2", Price: 2000, Currency: "EUR", ClothingType: "Coat", Brand: "XYZ", Store: "This Store", Size: "L"},
+			}},
+			Rates: eurRates{},
+		}
+		apiHandler.GetClothingStats(w, r)
+
+		if w.Result().StatusCode != http.StatusOK {
+			t.Fatalf("Expected %d got %d", http.StatusOK, w.Result().StatusCode)
+		}
+
+		var body struct {
+			Data domain.WardrobeStats `json:"data"`
+		}
+
+		if err := json.NewDecoder(w.Result().Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+
+		if body.Data.Total.Pence != 3250 || body.Data.Total.Currency != "EUR" || body.Data.Total.Formatted != "€32.50" {
+			t.Errorf("Expected a total of €32.50, got %+v", body.Data.Total)
+		}
+	})
+
+	t.Run("Given an unsupported currency, should return 400", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+		r := httptest.NewRequestWithContext(ctx, http.MethodGet, "/clothes/stats?currency=XXX", nil)
+
+		apiHandler := &API{Repo: &DummyClothingRepo{}}
+		apiHandler.GetClothingStats(w, r)
+
+		if w.Result().StatusCode != http.StatusBadRequest {
+			t.Errorf("Expected %d got %d", http.StatusBadRequest, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given an item in a currency without an exchange rate, should return 422", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+		r := httptest.NewRequestWithContext(ctx, http.MethodGet, "/clothes/stats", nil)
+
+		apiHandler := &API{Repo: &DummyClothingRepo{AllItems: []domain.Clothing{{Id: "1", Price: 1000, Currency: "JPY"}}}}
+		apiHandler.GetClothingStats(w, r)
+
+		if w.Result().StatusCode != http.StatusUnprocessableEntity {
+			t.Errorf("Expected %d got %d", http.StatusUnprocessableEntity, w.Result().StatusCode)
+		}
+	})
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"clothes_management/internal/domain"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// GetTags lists every tag on the user's live items with how many items have it, most used first.
+func (a *API) GetTags(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, fmt.Sprintf("Unauthorised method %s.", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	userId, ok := r.Context().Value(UserIDContextKey).(string)
+
+	if !ok {
+		http.Error(w, "UserID not provided", http.StatusUnauthorized)
+		return
+	}
+
+	if strings.TrimSpace(userId) == "" {
+		http.Error(w, "UserID not provided", http.StatusUnauthorized)
+		return
+	}
+
+	items, err := a.Repo.GetAll(r.Context(), userId)
+
+	if err != nil {
+		writeRepositoryError(w, err, "", "Error getting tags")
+		return
+	}
+
+	resp := map[string]any{"success": true, "data": domain.CountTags(items)}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"clothes_management/internal/domain"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestGetTags(t *testing.T) {
+	t.Run("Given request method is not allowed, should return error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+		r := httptest.NewRequestWithContext(ctx, http.MethodPost, "/tags", nil)
+
+		apiHandler := &API{Repo: &DummyClothingRepo{}}
+		apiHandler.GetTags(w, r)
+
+		if w.Result().StatusCode != http.StatusMethodNotAllowed {
+			t.Errorf("Expected %d got %d", http.StatusMethodNotAllowed, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given no UserID in context, should return 401", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		apiHandler := &API{Repo: &DummyClothingRepo{}}
+		apiHandler.GetTags(w, httptest.NewRequest(http.MethodGet, "/tags", nil))
+
+		if w.Result().StatusCode != http.StatusUnauthorized {
+			t.Errorf("Expected %d got %d", http.StatusUnauthorized, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given tagged items, should return each tag with its count", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+		r := httptest.NewRequestWithContext(ctx, http.MethodGet, "/tags", nil)
+
+		repo := &DummyClothingRepo{AllItems: []domain.Clothing{
+			{Id: "1", Tags: []string{"summer", "work"}},
+			{Id: "2", Tags: []string{"work"}},
+			{Id: "3"},
+		}}
+		apiHandler := &API{Repo: repo}
+		apiHandler.GetTags(w, r)
+
+		if w.Result().StatusCode != http.StatusOK {
+			t.Fatalf("Expected %d got %d", http.StatusOK, w.Result().StatusCode)
+		}
+
+		var body struct {
+			Success bool              `json:"success"`
+			Data    []domain.TagCount `json:"data"`
+		}
+
+		if err := json.NewDecoder(w.Result().Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+
+		expected := []domain.TagCount{{Tag: "work", Count: 2}, {Tag: "summer", Count: 1}}
+
+		if !body.Success || !slices.Equal(body.Data, expected) {
+			t.Errorf("Expected %+v, got %+v", expected, body.Data)
+		}
+	})
+
+	t.Run("Given the repository fails, should return 500", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+		r := httptest.NewRequestWithContext(ctx, http.MethodGet, "/tags", nil)
+
+		apiHandler := &API{Repo: &DummyClothingRepo{GetAllError: errors.New("boom")}}
+		apiHandler.GetTags(w, r)
+
+		if w.Result().StatusCode != http.StatusInternalServerError {
+			t.Errorf("Expected %d got %d", http.StatusInternalServerError, w.Result().StatusCode)
+		}
+	})
+}
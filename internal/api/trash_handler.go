@@ -0,0 +1,142 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// GetTrash lists the user's trashed items a page at a time, taking the same parameters as GetClothing.
+func (a *API) GetTrash(w http.ResponseWriter, r *http.Request) {
+	a.listClothing(w, r, true, nil)
+}
+
+// RestoreClothing moves a trashed item back out of the trash, conditional on If-Match when given, and
+// returns it with its new ETag.
+func (a *API) RestoreClothing(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, fmt.Sprintf("Unauthorised method %s.", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	userId, ok := r.Context().Value(UserIDContextKey).(string)
+
+	if !ok {
+		http.Error(w, "UserID not provided", http.StatusUnauthorized)
+		return
+	}
+
+	if strings.TrimSpace(userId) == "" {
+		http.Error(w, "UserID not provided", http.StatusUnauthorized)
+		return
+	}
+
+	vars := mux.Vars(r)
+	id, exists := vars["id"]
+
+	if !exists {
+		http.Error(w, "Missing 'id' parameter", http.StatusBadRequest)
+		return
+	}
+
+	id = strings.TrimSpace(id)
+
+	if len(id) == 0 {
+		http.Error(w, "Missing 'id' parameter", http.StatusBadRequest)
+		return
+	}
+
+	expectedVersion, ok := ParseIfMatch(r.Header.Get("If-Match"))
+
+	if !ok {
+		http.Error(w, "If-Match header must be a single ETag previously returned for this item", http.StatusPreconditionFailed)
+		return
+	}
+
+	item, err := a.Repo.Restore(r.Context(), userId, id, expectedVersion)
+
+	if err != nil {
+		writeRepositoryError(w, err, id, fmt.Sprintf("Unable to restore clothing for ID %s", id))
+		return
+	}
+
+	resp := map[string]any{"success": true, "data": item}
+	w.Header().Set("ETag", VersionETag(item.Version))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(resp)
+}
+
+// EmptyTrash permanently deletes everything in the user's trash, with the items' images, and returns the
+// ids purged.
+func (a *API) EmptyTrash(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, fmt.Sprintf("Unauthorised method %s.", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	userId, ok := r.Context().Value(UserIDContextKey).(string)
+
+	if !ok {
+		http.Error(w, "UserID not provided", http.StatusUnauthorized)
+		return
+	}
+
+	if strings.TrimSpace(userId) == "" {
+		http.Error(w, "UserID not provided", http.StatusUnauthorized)
+		return
+	}
+
+	purged, err := a.Repo.PurgeTrash(r.Context(), userId, time.Now())
+
+	if err != nil {
+		writeRepositoryError(w, err, "", "Error emptying the trash")
+		return
+	}
+
+	for _, id := range purged {
+		a.deleteImage(r.Context(), userId, id)
+	}
+
+	resp := map[string]any{"success": true, "data": purged}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(resp)
+}
+
+// PurgeExpiredTrash permanently deletes every user's items that have been in the trash for longer than
+// retention, with their images, and returns how many were purged. It is run in the background rather
+// than by a request.
+func (a *API) PurgeExpiredTrash(ctx context.Context, retention time.Duration) (int, error) {
+	purged, err := a.Repo.PurgeExpiredTrash(ctx, time.Now().Add(-retention))
+
+	if err != nil {
+		return 0, err
+	}
+
+	for _, item := range purged {
+		a.deleteImage(ctx, item.UserId, item.Id)
+	}
+
+	return len(purged), nil
+}
+
+// deleteImage deletes a purged item's image. The item is already gone, so an orphaned image is logged
+// rather than reported as a failure.
+func (a *API) deleteImage(ctx context.Context, userId, id string) {
+	if a.Images == nil {
+		return
+	}
+
+	if err := a.Images.Delete(ctx, userId, id); err != nil {
+		log.Printf("WARN: Failed to delete image for ID %s: %v", id, err)
+	}
+}
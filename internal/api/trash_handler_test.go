@@ -0,0 +1,249 @@
+package api
+
+import (
+	"clothes_management/internal/domain"
+	"clothes_management/internal/repository"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+func newRestoreRequest(id string) *http.Request {
+	ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+	r := httptest.NewRequestWithContext(ctx, http.MethodPost, "/clothes/"+id+"/restore", nil)
+	return mux.SetURLVars(r, map[string]string{"id": id})
+}
+
+func TestGetTrash(t *testing.T) {
+	t.Run("Given no UserID in context, should return 401", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/clothes/trash", nil)
+
+		apiHandler := &API{Repo: &DummyClothingRepo{}}
+		apiHandler.GetTrash(w, r)
+
+		if w.Result().StatusCode != http.StatusUnauthorized {
+			t.Errorf("Expected %d got %d", http.StatusUnauthorized, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given a filter, should list a page of the trash with it", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+		r := httptest.NewRequestWithContext(ctx, http.MethodGet, "/clothes/trash?brand=XYZ&limit=10", nil)
+
+		deletedAt := time.Now()
+		repo := &DummyClothingRepo{AllItems: []domain.Clothing{{Id: "1", Brand: "XYZ", DeletedAt: &deletedAt}}}
+		apiHandler := &API{Repo: repo}
+		apiHandler.GetTrash(w, r)
+
+		if w.Result().StatusCode != http.StatusOK {
+			t.Fatalf("Expected %d got %d", http.StatusOK, w.Result().StatusCode)
+		}
+
+		request := repo.GetPageRequest
+
+		if request == nil || !request.Filter.Trashed || request.Filter.Brand != "XYZ" || request.Limit != 10 {
+			t.Errorf("Expected a trashed, XYZ page of 10, got %+v", request)
+		}
+
+		var body struct {
+			Data []domain.Clothing `json:"data"`
+		}
+
+		if err := json.NewDecoder(w.Result().Body).Decode(&body); err != nil || len(body.Data) != 1 || body.Data[0].DeletedAt == nil {
+			t.Errorf("Expected the trashed item with its deletedAt, got %+v and %v", body.Data, err)
+		}
+	})
+
+	t.Run("Given GetClothing, should not ask for the trash", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+
+		repo := &DummyClothingRepo{}
+		apiHandler := &API{Repo: repo}
+		apiHandler.GetClothing(w, httptest.NewRequestWithContext(ctx, http.MethodGet, "/clothes", nil))
+
+		if repo.GetPageRequest == nil || repo.GetPageRequest.Filter.Trashed {
+			t.Errorf("Expected a page of live items, got %+v", repo.GetPageRequest)
+		}
+	})
+}
+
+func TestRestoreClothing(t *testing.T) {
+	t.Run("Given request method is not allowed, should return error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := newRestoreRequest("legit-id")
+		r.Method = http.MethodGet
+
+		apiHandler := &API{Repo: &DummyClothingRepo{}}
+		apiHandler.RestoreClothing(w, r)
+
+		if w.Result().StatusCode != http.StatusMethodNotAllowed {
+			t.Errorf("Expected %d got %d", http.StatusMethodNotAllowed, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given no UserID in context, should return 401", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := mux.SetURLVars(httptest.NewRequest(http.MethodPost, "/clothes/legit-id/restore", nil), map[string]string{"id": "legit-id"})
+
+		apiHandler := &API{Repo: &DummyClothingRepo{}}
+		apiHandler.RestoreClothing(w, r)
+
+		if w.Result().StatusCode != http.StatusUnauthorized {
+			t.Errorf("Expected %d got %d", http.StatusUnauthorized, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given If-Match, should restore at that version and return the item with its ETag", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := newRestoreRequest("legit-id")
+		r.Header.Set("If-Match", `"2"`)
+
+		repo := &DummyClothingRepo{ShouldExist: true}
+		apiHandler := &API{Repo: repo}
+		apiHandler.RestoreClothing(w, r)
+
+		resp := w.Result()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Expected %d got %d", http.StatusOK, resp.StatusCode)
+		}
+
+		if repo.RestoredID != "legit-id" || repo.RestoredVersion != 2 {
+			t.Errorf("Expected Restore of legit-id at version 2, got %s at %d", repo.RestoredID, repo.RestoredVersion)
+		}
+
+		if resp.Header.Get("ETag") != VersionETag(3) {
+			t.Errorf("Expected ETag %s, got %s", VersionETag(3), resp.Header.Get("ETag"))
+		}
+	})
+
+	t.Run("Given an item that is not in the trash, should return 404", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		apiHandler := &API{Repo: &DummyClothingRepo{}}
+		apiHandler.RestoreClothing(w, newRestoreRequest("legit-id"))
+
+		if w.Result().StatusCode != http.StatusNotFound {
+			t.Errorf("Expected %d got %d", http.StatusNotFound, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given a stale or invalid If-Match, should return 412", func(t *testing.T) {
+		stale := newRestoreRequest("legit-id")
+		stale.Header.Set("If-Match", `"1"`)
+
+		invalid := newRestoreRequest("legit-id")
+		invalid.Header.Set("If-Match", "1")
+
+		for _, r := range []*http.Request{stale, invalid} {
+			w := httptest.NewRecorder()
+
+			apiHandler := &API{Repo: &DummyClothingRepo{RestoreError: fmt.Errorf("stale: %w", repository.ErrPreconditionFailed)}}
+			apiHandler.RestoreClothing(w, r)
+
+			if w.Result().StatusCode != http.StatusPreconditionFailed {
+				t.Errorf("If-Match %s: Expected %d got %d", r.Header.Get("If-Match"), http.StatusPreconditionFailed, w.Result().StatusCode)
+			}
+		}
+	})
+}
+
+func TestEmptyTrash(t *testing.T) {
+	t.Run("Given request method is not allowed, should return error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+
+		apiHandler := &API{Repo: &DummyClothingRepo{}}
+		apiHandler.EmptyTrash(w, httptest.NewRequestWithContext(ctx, http.MethodPost, "/clothes/trash", nil))
+
+		if w.Result().StatusCode != http.StatusMethodNotAllowed {
+			t.Errorf("Expected %d got %d", http.StatusMethodNotAllowed, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given trashed items, should purge everything trashed so far and delete their images", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+
+		repo := &DummyClothingRepo{PurgeResult: []domain.Clothing{{Id: "1"}, {Id: "2"}}}
+		images := &DummyImageStore{Images: map[string][]byte{"test-user-id/1": pngBytes, "test-user-id/2": pngBytes, "test-user-id/3": pngBytes}}
+		apiHandler := &API{Repo: repo, Images: images}
+
+		before := time.Now()
+		apiHandler.EmptyTrash(w, httptest.NewRequestWithContext(ctx, http.MethodDelete, "/clothes/trash", nil))
+
+		if w.Result().StatusCode != http.StatusOK {
+			t.Fatalf("Expected %d got %d", http.StatusOK, w.Result().StatusCode)
+		}
+
+		if repo.PurgedBefore.Before(before) {
+			t.Errorf("Expected items trashed up to now to be purged, got %v", repo.PurgedBefore)
+		}
+
+		var body struct {
+			Success bool     `json:"success"`
+			Data    []string `json:"data"`
+		}
+
+		if err := json.NewDecoder(w.Result().Body).Decode(&body); err != nil || !body.Success || !slices.Equal(body.Data, []string{"1", "2"}) {
+			t.Errorf("Expected the purged ids 1 and 2, got %+v and %v", body, err)
+		}
+
+		if len(images.Images) != 1 || images.Images["test-user-id/3"] == nil {
+			t.Errorf("Expected only the image of item 3 to be kept, got %v", images.Images)
+		}
+	})
+
+	t.Run("Given the repository fails, should return 500", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+
+		apiHandler := &API{Repo: &DummyClothingRepo{PurgeError: errors.New("boom")}}
+		apiHandler.EmptyTrash(w, httptest.NewRequestWithContext(ctx, http.MethodDelete, "/clothes/trash", nil))
+
+		if w.Result().StatusCode != http.StatusInternalServerError {
+			t.Errorf("Expected %d got %d", http.StatusInternalServerError, w.Result().StatusCode)
+		}
+	})
+}
+
+func TestPurgeExpiredTrash(t *testing.T) {
+	t.Run("Given a retention period, should purge items trashed before it and delete each owner's image", func(t *testing.T) {
+		repo := &DummyClothingRepo{PurgeResult: []domain.Clothing{{Id: "1", UserId: "user-a"}, {Id: "2", UserId: "user-b"}}}
+		images := &DummyImageStore{Images: map[string][]byte{"user-a/1": pngBytes, "user-b/2": pngBytes}}
+		apiHandler := &API{Repo: repo, Images: images}
+
+		purged, err := apiHandler.PurgeExpiredTrash(context.Background(), 24*time.Hour)
+
+		if err != nil || purged != 2 {
+			t.Errorf("Expected 2 purged, got %d and %v", purged, err)
+		}
+
+		if cutoff := time.Since(repo.PurgedBefore); cutoff < 24*time.Hour || cutoff > 25*time.Hour {
+			t.Errorf("Expected a cutoff a day ago, got %v", repo.PurgedBefore)
+		}
+
+		if len(images.Images) != 0 {
+			t.Errorf("Expected both images to be deleted, got %v", images.Images)
+		}
+	})
+
+	t.Run("Given the repository fails, should return the error", func(t *testing.T) {
+		apiHandler := &API{Repo: &DummyClothingRepo{PurgeError: errors.New("boom")}}
+
+		if _, err := apiHandler.PurgeExpiredTrash(context.Background(), time.Hour); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+}
@@ -0,0 +1,193 @@
+package api
+
+import (
+	"bytes"
+	"clothes_management/internal/domain"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// RecordWearRequest is the optional body of RecordClothingWear. WornAt is a date, as in "2026-03-14",
+// or an RFC 3339 time, and defaults to now.
+type RecordWearRequest struct {
+	WornAt string `json:"wornAt"`
+}
+
+// WearLog is an item's wear tracking: how often and when it was worn, and what each wear has cost.
+// CostPerWear is null until the item is first worn.
+type WearLog struct {
+	WearCount   int64          `json:"wearCount"`
+	LastWornAt  *time.Time     `json:"lastWornAt"`
+	CostPerWear *domain.Amount `json:"costPerWear"`
+	Wears       []domain.Wear  `json:"wears"`
+}
+
+// parseWornAt reads a wear time given as a date, which is taken as midnight UTC, or an RFC 3339 time.
+func parseWornAt(value string) (time.Time, error) {
+	if wornAt, err := time.Parse(time.DateOnly, value); err == nil {
+		return wornAt, nil
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
+
+// RecordClothingWear logs that an item was worn, now or at the time in the body, and returns the item
+// with its new wear count and ETag. Logging a wear never conflicts with other changes, so it takes no
+// If-Match.
+func (a *API) RecordClothingWear(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, fmt.Sprintf("Unauthorised method %s.", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	userId, ok := r.Context().Value(UserIDContextKey).(string)
+
+	if !ok {
+		http.Error(w, "UserID not provided", http.StatusUnauthorized)
+		return
+	}
+
+	if strings.TrimSpace(userId) == "" {
+		http.Error(w, "UserID not provided", http.StatusUnauthorized)
+		return
+	}
+
+	vars := mux.Vars(r)
+	id, exists := vars["id"]
+
+	if !exists {
+		http.Error(w, "Missing 'id' parameter", http.StatusBadRequest)
+		return
+	}
+
+	id = strings.TrimSpace(id)
+
+	if len(id) == 0 {
+		http.Error(w, "Missing 'id' parameter", http.StatusBadRequest)
+		return
+	}
+
+	var req RecordWearRequest
+
+	if r.Body != nil && r.Body != http.NoBody {
+		bodyBytes, err := io.ReadAll(r.Body)
+
+		if err != nil {
+			http.Error(w, "Failed to read request body", http.StatusBadRequest)
+			return
+		}
+
+		if len(bytes.TrimSpace(bodyBytes)) > 0 {
+			dec := json.NewDecoder(bytes.NewReader(bodyBytes))
+			dec.DisallowUnknownFields()
+
+			if err := dec.Decode(&req); err != nil {
+				http.Error(w, "Request body must be JSON with an optional 'wornAt'", http.StatusBadRequest)
+				return
+			}
+		}
+	}
+
+	wornAt := time.Now()
+
+	if strings.TrimSpace(req.WornAt) != "" {
+		parsed, err := parseWornAt(strings.TrimSpace(req.WornAt))
+
+		if err != nil {
+			http.Error(w, "'wornAt' must be a date such as 2026-03-14 or an RFC 3339 time", http.StatusBadRequest)
+			return
+		}
+
+		if parsed.After(wornAt) {
+			http.Error(w, "'wornAt' must not be in the future", http.StatusBadRequest)
+			return
+		}
+
+		wornAt = parsed
+	}
+
+	item, err := a.Repo.RecordWear(r.Context(), userId, id, wornAt)
+
+	if err != nil {
+		writeRepositoryError(w, err, id, fmt.Sprintf("Unable to record a wear for ID %s", id))
+		return
+	}
+
+	resp := map[string]any{"success": true, "data": item}
+	w.Header().Set("ETag", VersionETag(item.Version))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(resp)
+}
+
+// GetClothingWears returns an item's wear log, oldest wear first, with its cost per wear.
+func (a *API) GetClothingWears(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, fmt.Sprintf("Unauthorised method %s.", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	userId, ok := r.Context().Value(UserIDContextKey).(string)
+
+	if !ok {
+		http.Error(w, "UserID not provided", http.StatusUnauthorized)
+		return
+	}
+
+	if strings.TrimSpace(userId) == "" {
+		http.Error(w, "UserID not provided", http.StatusUnauthorized)
+		return
+	}
+
+	vars := mux.Vars(r)
+	id, exists := vars["id"]
+
+	if !exists {
+		http.Error(w, "Missing 'id' parameter", http.StatusBadRequest)
+		return
+	}
+
+	id = strings.TrimSpace(id)
+
+	if len(id) == 0 {
+		http.Error(w, "Missing 'id' parameter", http.StatusBadRequest)
+		return
+	}
+
+	item, err := a.Repo.GetById(r.Context(), userId, id)
+
+	if err != nil {
+		writeRepositoryError(w, err, id, fmt.Sprintf("Unable to get wears for ID %s", id))
+		return
+	}
+
+	wears, err := a.Repo.ListWears(r.Context(), userId, id)
+
+	if err != nil {
+		writeRepositoryError(w, err, id, fmt.Sprintf("Unable to get wears for ID %s", id))
+		return
+	}
+
+	wearLog := WearLog{
+		WearCount:  item.WearCount,
+		LastWornAt: item.LastWornAt,
+		Wears:      wears,
+	}
+
+	if costPerWear, ok := item.CostPerWear(); ok {
+		wearLog.CostPerWear = &costPerWear
+	}
+
+	resp := map[string]any{"success": true, "data": wearLog}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(resp)
+}
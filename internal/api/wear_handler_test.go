@@ -0,0 +1,221 @@
+package api
+
+import (
+	"clothes_management/internal/domain"
+	"clothes_management/internal/repository"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+func newWearRequest(method, id, body string) *http.Request {
+	ctx := context.WithValue(context.TODO(), UserIDContextKey, "test-user-id")
+	r := httptest.NewRequestWithContext(ctx, method, "/clothes/"+id+"/wear", strings.NewReader(body))
+	return mux.SetURLVars(r, map[string]string{"id": id})
+}
+
+func saveWearTestItem(t *testing.T, repo repository.ClothingRepository) domain.Clothing {
+	t.Helper()
+
+	saved, err := repo.Save(context.Background(), "test-user-id", domain.Clothing{
+		ClothingType: "Jumper", Description: "Blue", Brand: "XYZ", Store: "This Store", Size: "L", Price: 5000,
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error on Save, got %v", err)
+	}
+
+	return saved
+}
+
+func TestRecordClothingWear(t *testing.T) {
+	t.Run("Given request method is not allowed, should return error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		apiHandler := &API{Repo: &DummyClothingRepo{}}
+		apiHandler.RecordClothingWear(w, newWearRequest(http.MethodGet, "1", ""))
+
+		if w.Result().StatusCode != http.StatusMethodNotAllowed {
+			t.Errorf("Expected %d got %d", http.StatusMethodNotAllowed, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given no UserID in context, should return 401", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := mux.SetURLVars(httptest.NewRequest(http.MethodPost, "/clothes/1/wear", nil), map[string]string{"id": "1"})
+
+		apiHandler := &API{Repo: &DummyClothingRepo{}}
+		apiHandler.RecordClothingWear(w, r)
+
+		if w.Result().StatusCode != http.StatusUnauthorized {
+			t.Errorf("Expected %d got %d", http.StatusUnauthorized, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given no body, should log a wear now and return the item with its ETag", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		repo := &DummyClothingRepo{ShouldExist: true}
+		before := time.Now()
+
+		apiHandler := &API{Repo: repo}
+		apiHandler.RecordClothingWear(w, newWearRequest(http.MethodPost, "1", ""))
+
+		if w.Result().StatusCode != http.StatusOK {
+			t.Fatalf("Expected %d got %d: %s", http.StatusOK, w.Result().StatusCode, w.Body.String())
+		}
+
+		if repo.WornAt.Before(before) || repo.WornAt.After(time.Now()) {
+			t.Errorf("Expected a wear logged now, got %v", repo.WornAt)
+		}
+
+		if etag := w.Result().Header.Get("ETag"); etag != VersionETag(2) {
+			t.Errorf("Expected ETag %s, got %s", VersionETag(2), etag)
+		}
+	})
+
+	t.Run("Given a date or time, should log the wear then", func(t *testing.T) {
+		for body, expected := range map[string]time.Time{
+			`{"wornAt":"2026-03-14"}`:                time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC),
+			`{"wornAt":"2026-03-14T18:30:00+01:00"}`: time.Date(2026, 3, 14, 17, 30, 0, 0, time.UTC),
+		} {
+			w := httptest.NewRecorder()
+			repo := &DummyClothingRepo{ShouldExist: true}
+
+			apiHandler := &API{Repo: repo}
+			apiHandler.RecordClothingWear(w, newWearRequest(http.MethodPost, "1", body))
+
+			if w.Result().StatusCode != http.StatusOK || !repo.WornAt.Equal(expected) {
+				t.Errorf("%s: Expected a wear at %v, got %d and %v", body, expected, w.Result().StatusCode, repo.WornAt)
+			}
+		}
+	})
+
+	t.Run("Given an invalid or future wornAt, or an unknown field, should return 400", func(t *testing.T) {
+		future := time.Now().Add(48 * time.Hour).Format(time.DateOnly)
+
+		for _, body := range []string{`{"wornAt":"14/03/2026"}`, `{"wornAt":"` + future + `"}`, `{"when":"2026-03-14"}`, `not json`} {
+			w := httptest.NewRecorder()
+
+			apiHandler := &API{Repo: &DummyClothingRepo{ShouldExist: true}}
+			apiHandler.RecordClothingWear(w, newWearRequest(http.MethodPost, "1", body))
+
+			if w.Result().StatusCode != http.StatusBadRequest {
+				t.Errorf("%s: Expected %d got %d", body, http.StatusBadRequest, w.Result().StatusCode)
+			}
+		}
+	})
+
+	t.Run("Given a missing item, should return 404", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		apiHandler := &API{Repo: &DummyClothingRepo{}}
+		apiHandler.RecordClothingWear(w, newWearRequest(http.MethodPost, "1", ""))
+
+		if w.Result().StatusCode != http.StatusNotFound {
+			t.Errorf("Expected %d got %d", http.StatusNotFound, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given the repository fails, should return 500", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		apiHandler := &API{Repo: &DummyClothingRepo{ShouldExist: true, WearError: errors.New("boom")}}
+		apiHandler.RecordClothingWear(w, newWearRequest(http.MethodPost, "1", ""))
+
+		if w.Result().StatusCode != http.StatusInternalServerError {
+			t.Errorf("Expected %d got %d", http.StatusInternalServerError, w.Result().StatusCode)
+		}
+	})
+}
+
+func TestGetClothingWears(t *testing.T) {
+	t.Run("Given request method is not allowed, should return error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		apiHandler := &API{Repo: &DummyClothingRepo{}}
+		apiHandler.GetClothingWears(w, newWearRequest(http.MethodPost, "1", ""))
+
+		if w.Result().StatusCode != http.StatusMethodNotAllowed {
+			t.Errorf("Expected %d got %d", http.StatusMethodNotAllowed, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Given a worn item, should return its wears oldest first with the cost per wear", func(t *testing.T) {
+		repo := repository.NewInMemoryClothingRepository()
+		saved := saveWearTestItem(t, repo)
+		apiHandler := &API{Repo: repo}
+
+		for _, body := range []string{`{"wornAt":"2026-03-14"}`, `{"wornAt":"2026-03-01"}`, `{"wornAt":"2026-03-07"}`} {
+			w := httptest.NewRecorder()
+			apiHandler.RecordClothingWear(w, newWearRequest(http.MethodPost, saved.Id, body))
+
+			if w.Result().StatusCode != http.StatusOK {
+				t.Fatalf("Expected %d got %d: %s", http.StatusOK, w.Result().StatusCode, w.Body.String())
+			}
+		}
+
+		w := httptest.NewRecorder()
+		apiHandler.GetClothingWears(w, newWearRequest(http.MethodGet, saved.Id, ""))
+
+		if w.Result().StatusCode != http.StatusOK {
+			t.Fatalf("Expected %d got %d", http.StatusOK, w.Result().StatusCode)
+		}
+
+		var body struct {
+			Data WearLog `json:"data"`
+		}
+
+		if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+
+		lastWornAt := time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC)
+
+		if body.Data.WearCount != 3 || body.Data.LastWornAt == nil || !body.Data.LastWornAt.Equal(lastWornAt) {
+			t.Errorf("Expected 3 wears, last on %v, got %+v", lastWornAt, body.Data)
+		}
+
+		if body.Data.CostPerWear == nil || body.Data.CostPerWear.Pence != 1667 || body.Data.CostPerWear.Formatted != domain.Pence(1667).AsPounds() {
+			t.Errorf("Expected a cost per wear of £16.67, got %+v", body.Data.CostPerWear)
+		}
+
+		if len(body.Data.Wears) != 3 || body.Data.Wears[0].WornAt.Day() != 1 || body.Data.Wears[2].WornAt.Day() != 14 {
+			t.Errorf("Expected the wears oldest first, got %+v", body.Data.Wears)
+		}
+	})
+
+	t.Run("Given an item never worn, should return no wears and a null cost per wear", func(t *testing.T) {
+		repo := repository.NewInMemoryClothingRepository()
+		saved := saveWearTestItem(t, repo)
+
+		w := httptest.NewRecorder()
+		apiHandler := &API{Repo: repo}
+		apiHandler.GetClothingWears(w, newWearRequest(http.MethodGet, saved.Id, ""))
+
+		if w.Result().StatusCode != http.StatusOK {
+			t.Fatalf("Expected %d got %d", http.StatusOK, w.Result().StatusCode)
+		}
+
+		if body := w.Body.String(); !strings.Contains(body, `"costPerWear":null`) || !strings.Contains(body, `"wears":[]`) {
+			t.Errorf("Expected no wears and a null cost per wear, got %s", body)
+		}
+	})
+
+	t.Run("Given a missing item, should return 404", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		apiHandler := &API{Repo: repository.NewInMemoryClothingRepository()}
+		apiHandler.GetClothingWears(w, newWearRequest(http.MethodGet, "missing", ""))
+
+		if w.Result().StatusCode != http.StatusNotFound {
+			t.Errorf("Expected %d got %d", http.StatusNotFound, w.Result().StatusCode)
+		}
+	})
+}
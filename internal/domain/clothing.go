@@ -3,6 +3,7 @@ package domain
 import (
 	"errors"
 	"strings"
+	"time"
 )
 
 type Clothing struct {
@@ -15,6 +16,29 @@ type Clothing struct {
 	ImageUrl     string `json:"imageUrl" dynamodbav:"ImageUrl"`
 	Price        Pence  `json:"pricePence" dynamodbav:"PricePence"`
 	Size         string `json:"size" dynamodbav:"Size"`
+	Version      int64  `json:"version" dynamodbav:"Version"`
+	// Currency is the currency of Price. It is empty on items stored before currencies were recorded,
+	// which are in DefaultCurrency.
+	Currency Currency `json:"currency" dynamodbav:"Currency"`
+	// DeletedAt is set, to the second, while the item is in the trash.
+	DeletedAt *time.Time `json:"deletedAt,omitempty" dynamodbav:"DeletedAt,omitempty,unixtime"`
+	// PurchasedAt is when the item was bought, to the second, if known.
+	PurchasedAt *time.Time `json:"purchasedAt,omitempty" dynamodbav:"PurchasedAt,omitempty,unixtime"`
+	// WearCount and LastWornAt are only changed by logging a wear, never by updating the item. LastWornAt
+	// is the latest time the item was worn, to the second, and nil until it is first worn.
+	WearCount  int64      `json:"wearCount" dynamodbav:"WearCount"`
+	LastWornAt *time.Time `json:"lastWornAt,omitempty" dynamodbav:"LastWornAt,omitempty,unixtime"`
+	// Tags are free-form labels such as "work" or "winter", kept as NormaliseTags returns them. An item
+	// without tags has none at all, rather than an empty list.
+	Tags []string `json:"tags,omitempty" dynamodbav:"Tags,omitempty"`
+	// Colour is the item's main colour and SecondaryColours any others it has, in order of prominence.
+	// Both are optional, but an item with secondary colours must have a main one.
+	Colour           Colour   `json:"colour,omitempty" dynamodbav:"Colour,omitempty"`
+	SecondaryColours []Colour `json:"secondaryColours,omitempty" dynamodbav:"SecondaryColours,omitempty"`
+	// Seasons and Occasions are what the item is worn for, each listed once. An item without any has
+	// not been classified, rather than suiting none or all of them.
+	Seasons   []Season   `json:"seasons,omitempty" dynamodbav:"Seasons,omitempty"`
+	Occasions []Occasion `json:"occasions,omitempty" dynamodbav:"Occasions,omitempty"`
 }
 
 func (c Clothing) Validate() error {
@@ -22,6 +46,34 @@ func (c Clothing) Validate() error {
 		return errors.New("Clothing Price must be greater than or equal to 0")
 	}
 
+	if _, ok := currencies[c.Currency]; c.Currency != "" && !ok {
+		return errors.New("Clothing Currency must be a supported ISO 4217 code")
+	}
+
+	if c.PurchasedAt != nil && c.PurchasedAt.After(time.Now()) {
+		return errors.New("Clothing PurchasedAt must not be in the future")
+	}
+
+	if c.WearCount < 0 {
+		return errors.New("Clothing WearCount must be greater than or equal to 0")
+	}
+
+	if err := validateTags(c.Tags); err != nil {
+		return err
+	}
+
+	if err := validateColours(c.Colour, c.SecondaryColours); err != nil {
+		return err
+	}
+
+	if err := validateChoices("Season", c.Seasons, seasons); err != nil {
+		return err
+	}
+
+	if err := validateChoices("Occasion", c.Occasions, occasions); err != nil {
+		return err
+	}
+
 	if strings.TrimSpace(c.ClothingType) == "" {
 		return errors.New("Clothing Type must not be empty")
 	}
@@ -44,3 +96,14 @@ func (c Clothing) Validate() error {
 
 	return nil
 }
+
+// PriceMoney is Price in the item's currency.
+func (c Clothing) PriceMoney() Money {
+	currency := c.Currency
+
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+
+	return Money{Amount: int64(c.Price), Currency: currency}
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestIsValid(t *testing.T) {
 	// Happy Path
@@ -295,4 +298,82 @@ func TestIsValid(t *testing.T) {
 			t.Errorf("Expected '%s' but got '%s'", expectedText, text)
 		}
 	})
+
+	// Currency
+
+	t.Run("Given Clothing has an unsupported Currency, should return an appropriate error", func(t *testing.T) {
+		var item Clothing = Clothing{
+			Price:        Pence(2000),
+			Currency:     "XXX",
+			ClothingType: "Jumper",
+			Description:  "Red Loosefit Jumper",
+			Brand:        "A&B",
+			Store:        "Totally Real Store",
+			Size:         "Medium",
+		}
+
+		got := item.Validate()
+
+		if got == nil || got.Error() != "Clothing Currency must be a supported ISO 4217 code" {
+			t.Errorf("Expected a Currency error, but got %v", got)
+		}
+	})
+
+	t.Run("Given Clothing has no Currency, should return nil and price it in GBP", func(t *testing.T) {
+		var item Clothing = Clothing{
+			Price:        Pence(2000),
+			ClothingType: "Jumper",
+			Description:  "Red Loosefit Jumper",
+			Brand:        "A&B",
+			Store:        "Totally Real Store",
+			Size:         "Medium",
+		}
+
+		if got := item.Validate(); got != nil {
+			t.Errorf("Expected no error, but got %v", got)
+		}
+
+		if money := item.PriceMoney(); money.Currency != DefaultCurrency || money.Amount != 2000 {
+			t.Errorf("Expected 2000 GBP, got %+v", money)
+		}
+	})
+
+	// Wear tracking
+
+	t.Run("Given Clothing was purchased in the future, should return an appropriate error", func(t *testing.T) {
+		purchasedAt := time.Now().Add(time.Hour)
+		var item Clothing = Clothing{
+			Price:        Pence(2000),
+			ClothingType: "Jumper",
+			Description:  "Red Loosefit Jumper",
+			Brand:        "A&B",
+			Store:        "Totally Real Store",
+			Size:         "Medium",
+			PurchasedAt:  &purchasedAt,
+		}
+
+		got := item.Validate()
+
+		if got == nil || got.Error() != "Clothing PurchasedAt must not be in the future" {
+			t.Errorf("Expected a PurchasedAt error, but got %v", got)
+		}
+	})
+
+	t.Run("Given Clothing has a negative WearCount, should return an appropriate error", func(t *testing.T) {
+		var item Clothing = Clothing{
+			Price:        Pence(2000),
+			ClothingType: "Jumper",
+			Description:  "Red Loosefit Jumper",
+			Brand:        "A&B",
+			Store:        "Totally Real Store",
+			Size:         "Medium",
+			WearCount:    -1,
+		}
+
+		got := item.Validate()
+
+		if got == nil || got.Error() != "Clothing WearCount must be greater than or equal to 0" {
+			t.Errorf("Expected a WearCount error, but got %v", got)
+		}
+	})
 }
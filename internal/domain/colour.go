@@ -0,0 +1,212 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// MaxSecondaryColours bounds how many colours an item can have besides its primary colour.
+const MaxSecondaryColours = 3
+
+// Colour is the name of a colour in the palette, such as "navy" or "light blue".
+type Colour string
+
+type paletteEntry struct {
+	name Colour
+	hex  string
+}
+
+// palette is every Colour, with a hex value typical of clothing in that colour. Hex values given as
+// colours are matched to the nearest entry, so the values decide where one colour ends and the next
+// begins.
+var palette = []paletteEntry{
+	{"black", "#000000"},
+	{"white", "#ffffff"},
+	{"cream", "#f3ead3"},
+	{"beige", "#d8c3a5"},
+	{"khaki", "#c3b091"},
+	{"tan", "#c19a6b"},
+	{"brown", "#6f4e37"},
+	{"grey", "#8c8c8c"},
+	{"silver", "#c0c0c0"},
+	{"charcoal", "#36454f"},
+	{"navy", "#1f2a44"},
+	{"blue", "#2f5fb3"},
+	{"light blue", "#a7c7e7"},
+	{"teal", "#008080"},
+	{"green", "#2e7d32"},
+	{"olive", "#708238"},
+	{"yellow", "#f4d03f"},
+	{"mustard", "#d4a017"},
+	{"gold", "#d4af37"},
+	{"orange", "#e67e22"},
+	{"red", "#c0392b"},
+	{"burgundy", "#800020"},
+	{"pink", "#f4a6c1"},
+	{"purple", "#6a3d9a"},
+	{"lilac", "#c8a2c8"},
+}
+
+// colourAliases are other names for palette colours that people commonly use.
+var colourAliases = map[string]Colour{
+	"gray":        "grey",
+	"off white":   "cream",
+	"ivory":       "cream",
+	"camel":       "tan",
+	"stone":       "beige",
+	"maroon":      "burgundy",
+	"wine":        "burgundy",
+	"denim":       "blue",
+	"royal blue":  "blue",
+	"sky blue":    "light blue",
+	"turquoise":   "teal",
+	"mint":        "green",
+	"khaki green": "olive",
+	"rose":        "pink",
+	"lavender":    "lilac",
+	"violet":      "purple",
+}
+
+// PaletteColours lists every Colour in the palette.
+func PaletteColours() []Colour {
+	colours := make([]Colour, len(palette))
+
+	for i, entry := range palette {
+		colours[i] = entry.name
+	}
+
+	return colours
+}
+
+// Hex is the colour's hex value, as in "#1f2a44", or empty if it is not in the palette.
+func (c Colour) Hex() string {
+	for _, entry := range palette {
+		if entry.name == c {
+			return entry.hex
+		}
+	}
+
+	return ""
+}
+
+// ParseColour maps free text to a palette Colour: a palette name or a common alias for one, in any case
+// and spacing, or a hex value such as "#1f2a44" or "#fff", which is matched to the nearest palette
+// colour. It returns false for anything else.
+func ParseColour(s string) (Colour, bool) {
+	name := strings.ToLower(strings.Join(strings.Fields(s), " "))
+
+	if Colour(name).Hex() != "" {
+		return Colour(name), true
+	}
+
+	if colour, ok := colourAliases[name]; ok {
+		return colour, true
+	}
+
+	r, g, b, ok := parseHex(name)
+
+	if !ok {
+		return "", false
+	}
+
+	nearest := palette[0]
+
+	for _, entry := range palette[1:] {
+		if colourDistance(r, g, b, entry.hex) < colourDistance(r, g, b, nearest.hex) {
+			nearest = entry
+		}
+	}
+
+	return nearest.name, true
+}
+
+// UnmarshalJSON maps free text as ParseColour does, keeping text it cannot map so Validate rejects it.
+func (c *Colour) UnmarshalJSON(data []byte) error {
+	var text string
+
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+
+	if colour, ok := ParseColour(text); ok {
+		*c = colour
+		return nil
+	}
+
+	*c = Colour(strings.TrimSpace(text))
+
+	return nil
+}
+
+// parseHex reads "#rrggbb" or the shorthand "#rgb".
+func parseHex(s string) (r, g, b int64, ok bool) {
+	digits, found := strings.CutPrefix(s, "#")
+
+	if !found {
+		return 0, 0, 0, false
+	}
+
+	if len(digits) == 3 {
+		digits = string([]byte{digits[0], digits[0], digits[1], digits[1], digits[2], digits[2]})
+	}
+
+	if len(digits) != 6 {
+		return 0, 0, 0, false
+	}
+
+	value, err := strconv.ParseUint(digits, 16, 32)
+
+	if err != nil {
+		return 0, 0, 0, false
+	}
+
+	return int64(value >> 16), int64(value >> 8 & 0xff), int64(value & 0xff), true
+}
+
+// colourDistance is the "redmean" approximation of how different r, g, b looks from the palette hex,
+// which weights the channels by how sensitive the eye is to each, unlike plain RGB distance.
+func colourDistance(r, g, b int64, hex string) int64 {
+	pr, pg, pb, _ := parseHex(hex)
+	redMean := (r + pr) / 2
+	dr, dg, db := r-pr, g-pg, b-pb
+
+	return ((512+redMean)*dr*dr)>>8 + 4*dg*dg + ((767-redMean)*db*db)>>8
+}
+
+func validateColours(primary Colour, secondary []Colour) error {
+	if primary != "" && primary.Hex() == "" {
+		return fmt.Errorf("Clothing Colour %q must be a palette colour", primary)
+	}
+
+	if len(secondary) == 0 {
+		return nil
+	}
+
+	if primary == "" {
+		return fmt.Errorf("Clothing must have a Colour to have SecondaryColours")
+	}
+
+	if len(secondary) > MaxSecondaryColours {
+		return fmt.Errorf("Clothing must not have more than %d SecondaryColours", MaxSecondaryColours)
+	}
+
+	for i, colour := range secondary {
+		if colour.Hex() == "" {
+			return fmt.Errorf("Clothing SecondaryColour %q must be a palette colour", colour)
+		}
+
+		if colour == primary || slices.Contains(secondary[:i], colour) {
+			return fmt.Errorf("Clothing must not have colour %s more than once", colour)
+		}
+	}
+
+	return nil
+}
+
+// HasColour reports whether colour is the item's primary colour or one of its secondary colours.
+func (c Clothing) HasColour(colour Colour) bool {
+	return c.Colour == colour || slices.Contains(c.SecondaryColours, colour)
+}
@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseColour(t *testing.T) {
+	t.Run("Given palette names and aliases in any case and spacing, should return the palette colour", func(t *testing.T) {
+		cases := map[string]Colour{
+			"navy":           "navy",
+			" Light   BLUE ": "light blue",
+			"Gray":           "grey",
+			"maroon":         "burgundy",
+		}
+
+		for input, expected := range cases {
+			if got, ok := ParseColour(input); !ok || got != expected {
+				t.Errorf("%q: Expected %s, got %q (%v)", input, expected, got, ok)
+			}
+		}
+	})
+
+	t.Run("Given hex values, should return the nearest palette colour", func(t *testing.T) {
+		cases := map[string]Colour{
+			"#1f2a44": "navy",
+			"#000080": "navy",
+			"#FFF":    "white",
+			"#010101": "black",
+			"#b22222": "red",
+			"#808000": "olive",
+		}
+
+		for input, expected := range cases {
+			if got, ok := ParseColour(input); !ok || got != expected {
+				t.Errorf("%q: Expected %s, got %q (%v)", input, expected, got, ok)
+			}
+		}
+	})
+
+	t.Run("Given text that is neither a colour nor a hex value, should return false", func(t *testing.T) {
+		for _, input := range []string{"", "sparkly", "1f2a44", "#12345", "#ggg"} {
+			if got, ok := ParseColour(input); ok {
+				t.Errorf("%q: Expected false, got %q", input, got)
+			}
+		}
+	})
+
+	t.Run("Given every palette colour, should parse its name and its own hex value to itself", func(t *testing.T) {
+		for _, colour := range PaletteColours() {
+			if got, ok := ParseColour(string(colour)); !ok || got != colour {
+				t.Errorf("%s: Expected itself from its name, got %q", colour, got)
+			}
+
+			if got, ok := ParseColour(colour.Hex()); !ok || got != colour {
+				t.Errorf("%s: Expected itself from %s, got %q", colour, colour.Hex(), got)
+			}
+		}
+	})
+}
+
+func TestColourUnmarshalJSON(t *testing.T) {
+	t.Run("Given free text, should map it to the palette colour", func(t *testing.T) {
+		var colour Colour
+
+		if err := json.Unmarshal([]byte(`"#1F2A44"`), &colour); err != nil || colour != "navy" {
+			t.Errorf("Expected navy, got %q (%v)", colour, err)
+		}
+	})
+
+	t.Run("Given text that is not a colour, should keep it so that Validate rejects it", func(t *testing.T) {
+		var colour Colour
+
+		if err := json.Unmarshal([]byte(`" sparkly "`), &colour); err != nil || colour != "sparkly" {
+			t.Errorf("Expected sparkly, got %q (%v)", colour, err)
+		}
+	})
+}
+
+func TestValidateColours(t *testing.T) {
+	item := func(colour Colour, secondary ...Colour) Clothing {
+		return Clothing{ClothingType: "Jumper", Description: "Striped", Brand: "XYZ", Store: "This Store", Size: "L", Colour: colour, SecondaryColours: secondary}
+	}
+
+	t.Run("Given palette colours, should be valid", func(t *testing.T) {
+		for _, clothing := range []Clothing{item(""), item("navy"), item("navy", "white", "red")} {
+			if err := clothing.Validate(); err != nil {
+				t.Errorf("%q %q: Expected no error, got %v", clothing.Colour, clothing.SecondaryColours, err)
+			}
+		}
+	})
+
+	t.Run("Given invalid colours, should return an error", func(t *testing.T) {
+		cases := map[string]Clothing{
+			"unknown primary":      item("sparkly"),
+			"unknown secondary":    item("navy", "sparkly"),
+			"secondary only":       item("", "white"),
+			"repeated primary":     item("navy", "navy"),
+			"repeated secondary":   item("navy", "white", "white"),
+			"too many secondaries": item("navy", "white", "red", "grey", "black"),
+		}
+
+		for name, clothing := range cases {
+			if err := clothing.Validate(); err == nil {
+				t.Errorf("%s: Expected an error", name)
+			}
+		}
+	})
+
+	t.Run("Given a secondary colour, HasColour should match it as well as the primary", func(t *testing.T) {
+		clothing := item("navy", "white")
+
+		if !clothing.HasColour("navy") || !clothing.HasColour("white") || clothing.HasColour("red") {
+			t.Errorf("Expected navy and white only")
+		}
+	})
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+)
+
+var ErrNoExchangeRate = errors.New("no exchange rate")
+
+// ExchangeRateProvider gives how many units of to one unit of from is worth. Rate fails with an error
+// wrapping ErrNoExchangeRate for a pair it has no rate for.
+type ExchangeRateProvider interface {
+	Rate(ctx context.Context, from, to Currency) (float64, error)
+}
+
+// Convert converts m into to at the provider's rate, rounded to the nearest minor unit of to with halves
+// away from zero. Money already in to is returned as it is, without asking rates, which may be nil when
+// no conversion is possible.
+func Convert(ctx context.Context, rates ExchangeRateProvider, m Money, to Currency) (Money, error) {
+	if m.Currency == to {
+		return m, nil
+	}
+
+	if rates == nil {
+		return Money{}, fmt.Errorf("%w from %s to %s", ErrNoExchangeRate, m.Currency, to)
+	}
+
+	rate, err := rates.Rate(ctx, m.Currency, to)
+
+	if err != nil {
+		return Money{}, err
+	}
+
+	// Rates are between major units, so shift by the difference in minor unit digits as well.
+	amount := float64(m.Amount) * rate * math.Pow10(to.Exponent()-m.Currency.Exponent())
+
+	return Money{Amount: int64(math.Round(amount)), Currency: to}, nil
+}
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+type HistoryAction string
+
+const (
+	HistoryCreated  HistoryAction = "create"
+	HistoryUpdated  HistoryAction = "update"
+	HistoryDeleted  HistoryAction = "delete"
+	HistoryRestored HistoryAction = "restore"
+	// HistoryPurged records an item being deleted permanently from the trash.
+	HistoryPurged HistoryAction = "purge"
+	// HistoryWorn records a wear being logged, which changes the item's wear count.
+	HistoryWorn HistoryAction = "wear"
+)
+
+// SystemActor is the ActedBy of changes made by the service itself rather than by a user, such as
+// purging expired trash.
+const SystemActor = "system"
+
+// FieldChange is one field's value before and after a change, as JSON. A field the item did not have,
+// on either side, is null.
+type FieldChange struct {
+	Field string          `json:"field" dynamodbav:"Field"`
+	Old   json.RawMessage `json:"old" dynamodbav:"Old"`
+	New   json.RawMessage `json:"new" dynamodbav:"New"`
+}
+
+// HistoryEntry records one change to an item: what was done, by whom and when, and the fields it changed.
+type HistoryEntry struct {
+	UserId    string        `json:"userId" dynamodbav:"UserId"`
+	ItemId    string        `json:"itemId" dynamodbav:"ItemId"`
+	Action    HistoryAction `json:"action" dynamodbav:"Action"`
+	ActedBy   string        `json:"actedBy" dynamodbav:"ActedBy"`
+	Timestamp time.Time     `json:"timestamp" dynamodbav:"Timestamp"`
+	Changes   []FieldChange `json:"changes" dynamodbav:"Changes"`
+}
+
+// clothingDiffFields are the JSON names of the Clothing fields DiffClothing compares, in declaration
+// order. The identity and version fields are left out, as every change would list them.
+var clothingDiffFields = func() []string {
+	var names []string
+	clothingType := reflect.TypeFor[Clothing]()
+
+	for i := range clothingType.NumField() {
+		name, _, _ := strings.Cut(clothingType.Field(i).Tag.Get("json"), ",")
+
+		if name != "id" && name != "userId" && name != "version" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}()
+
+// DiffClothing lists the fields whose JSON values differ between old and new. A nil item has no fields,
+// so diffing against nil lists every field the other item has, as when it is created or purged.
+func DiffClothing(old, new *Clothing) []FieldChange {
+	oldFields, newFields := clothingJSONFields(old), clothingJSONFields(new)
+	changes := []FieldChange{}
+
+	for _, name := range clothingDiffFields {
+		if !bytes.Equal(oldFields[name], newFields[name]) {
+			changes = append(changes, FieldChange{Field: name, Old: oldFields[name], New: newFields[name]})
+		}
+	}
+
+	return changes
+}
+
+func clothingJSONFields(clothing *Clothing) map[string]json.RawMessage {
+	fields := map[string]json.RawMessage{}
+
+	if clothing == nil {
+		return fields
+	}
+
+	// Clothing holds nothing json cannot encode, so neither call can fail.
+	raw, _ := json.Marshal(clothing)
+	json.Unmarshal(raw, &fields)
+
+	return fields
+}
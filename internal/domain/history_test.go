@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func changedFields(changes []FieldChange) []string {
+	fields := make([]string, 0, len(changes))
+
+	for _, change := range changes {
+		fields = append(fields, change.Field)
+	}
+
+	return fields
+}
+
+func TestDiffClothing(t *testing.T) {
+	jumper := Clothing{Id: "1", UserId: "user", ClothingType: "Jumper", Description: "Blue", Brand: "XYZ", Price: 2000, Size: "L", Version: 1}
+
+	t.Run("Given changed fields and a new version, should list only the changed fields with old and new values", func(t *testing.T) {
+		updated := jumper
+		updated.Description = "Red"
+		updated.Price = 2500
+		updated.Version = 2
+
+		changes := DiffClothing(&jumper, &updated)
+
+		if !slices.Equal(changedFields(changes), []string{"description", "pricePence"}) {
+			t.Fatalf("Expected description and pricePence, got %+v", changes)
+		}
+
+		if string(changes[0].Old) != `"Blue"` || string(changes[0].New) != `"Red"` || string(changes[1].Old) != "2000" || string(changes[1].New) != "2500" {
+			t.Errorf("Expected the old and new values as JSON, got %+v", changes)
+		}
+	})
+
+	t.Run("Given no old item, should list every field with a null old value", func(t *testing.T) {
+		changes := DiffClothing(nil, &jumper)
+
+		expected := []string{"clothingType", "description", "brand", "store", "imageUrl", "pricePence", "size", "currency", "wearCount"}
+
+		if !slices.Equal(changedFields(changes), expected) {
+			t.Fatalf("Expected %v, got %v", expected, changedFields(changes))
+		}
+
+		for _, change := range changes {
+			if change.Old != nil {
+				t.Errorf("Expected a null old %s, got %s", change.Field, change.Old)
+			}
+		}
+	})
+
+	t.Run("Given an item moved to the trash, should list only deletedAt", func(t *testing.T) {
+		deletedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+		trashed := jumper
+		trashed.DeletedAt = &deletedAt
+
+		changes := DiffClothing(&jumper, &trashed)
+
+		if len(changes) != 1 || changes[0].Field != "deletedAt" || changes[0].Old != nil || string(changes[0].New) != `"2026-01-02T03:04:05Z"` {
+			t.Errorf("Expected deletedAt to be set, got %+v", changes)
+		}
+	})
+
+	t.Run("Given identical items, should return an empty, non-nil list", func(t *testing.T) {
+		if changes := DiffClothing(&jumper, &jumper); changes == nil || len(changes) != 0 {
+			t.Errorf("Expected no changes, got %#v", changes)
+		}
+	})
+}
@@ -1,32 +1,130 @@
 package domain
 
 import (
+	"encoding/json"
+	"fmt"
 	"strings"
 
 	"github.com/dustin/go-humanize"
 )
 
+// Pence is an amount in the minor unit of a currency. It predates Currency, so it is named for GBP, the
+// currency of items that have none.
 type Pence int64
 
+// AsPounds formats p as GBP.
 func (p Pence) AsPounds() string {
+	return Money{Amount: int64(p), Currency: DefaultCurrency}.Format()
+}
+
+// Currency is an ISO 4217 currency code.
+type Currency string
+
+// DefaultCurrency is the currency of items stored before currencies were recorded, and of new items that
+// do not give one.
+const DefaultCurrency Currency = "GBP"
+
+type currencyInfo struct {
+	// exponent is the number of digits after the decimal point, so an amount of 1 minor unit is 10^-exponent.
+	exponent int
+	symbol   string
+}
+
+// currencies are the supported currencies. A currency without a symbol of its own is written with its
+// code and a space, as in "CHF 12.50".
+var currencies = map[Currency]currencyInfo{
+	"AED": {2, "AED "},
+	"AUD": {2, "A$"},
+	"BHD": {3, "BHD "},
+	"BRL": {2, "R$"},
+	"CAD": {2, "CA$"},
+	"CHF": {2, "CHF "},
+	"CNY": {2, "CN¥"},
+	"CZK": {2, "CZK "},
+	"DKK": {2, "DKK "},
+	"EUR": {2, "€"},
+	"GBP": {2, "£"},
+	"HKD": {2, "HK$"},
+	"INR": {2, "₹"},
+	"ISK": {0, "ISK "},
+	"JOD": {3, "JOD "},
+	"JPY": {0, "¥"},
+	"KRW": {0, "₩"},
+	"KWD": {3, "KWD "},
+	"MXN": {2, "MX$"},
+	"NOK": {2, "NOK "},
+	"NZD": {2, "NZ$"},
+	"OMR": {3, "OMR "},
+	"PLN": {2, "PLN "},
+	"SEK": {2, "SEK "},
+	"SGD": {2, "SGD "},
+	"THB": {2, "฿"},
+	"TRY": {2, "₺"},
+	"USD": {2, "$"},
+	"ZAR": {2, "ZAR "},
+}
+
+// ParseCurrency returns the supported Currency with code s, ignoring case and surrounding whitespace,
+// or false if there is none.
+func ParseCurrency(s string) (Currency, bool) {
+	currency := Currency(strings.ToUpper(strings.TrimSpace(s)))
+	_, ok := currencies[currency]
+
+	return currency, ok
+}
+
+// Exponent is the number of minor unit digits of c: 2 for GBP, 0 for JPY and 3 for BHD.
+func (c Currency) Exponent() int {
+	return currencies[c].exponent
+}
+
+// Symbol is written before amounts in c.
+func (c Currency) Symbol() string {
+	return currencies[c].symbol
+}
 
-	isNegative := p < 0
+// UnmarshalJSON accepts a code in any case, so that Validate only has to reject unknown codes.
+func (c *Currency) UnmarshalJSON(data []byte) error {
+	var code string
 
-	if isNegative {
-		p = -p
+	if err := json.Unmarshal(data, &code); err != nil {
+		return err
 	}
 
-	poundsValue := float64(p) / 100.0
+	*c = Currency(strings.ToUpper(strings.TrimSpace(code)))
 
-	poundString := "£" + humanize.CommafWithDigits(poundsValue, 2)
+	return nil
+}
+
+// Money is an amount in the minor unit of its currency, such as cents for USD.
+type Money struct {
+	Amount   int64    `json:"amount"`
+	Currency Currency `json:"currency"`
+}
 
-	if !strings.Contains(poundString, ".") {
-		poundString = poundString + ".00"
+// Format writes m with its currency's symbol, thousands separators and minor unit digits, as in
+// "£1,200.55", "¥1,200" or "-BHD 1.250".
+func (m Money) Format() string {
+	amount := m.Amount
+	sign := ""
+
+	if amount < 0 {
+		amount = -amount
+		sign = "-"
 	}
 
-	if isNegative {
-		poundString = "-" + poundString
+	exponent := m.Currency.Exponent()
+	scale := int64(1)
+
+	for range exponent {
+		scale *= 10
+	}
+
+	formatted := sign + m.Currency.Symbol() + humanize.Comma(amount/scale)
+
+	if exponent > 0 {
+		formatted += fmt.Sprintf(".%0*d", exponent, amount%scale)
 	}
 
-	return poundString
+	return formatted
 }
@@ -1,6 +1,11 @@
 package domain
 
-import "testing"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
 
 func TestAsPounds(t *testing.T) {
 	t.Run("Given 0 pence, then should return £0.00", func(t *testing.T) {
@@ -56,4 +61,102 @@ func TestAsPounds(t *testing.T) {
 		}
 
 	})
+
+	t.Run("Given a whole number of pounds over 1,000 (100110), should keep the trailing zero (£1,001.10)", func(t *testing.T) {
+		pence := Pence(100110)
+		expected := "£1,001.10"
+		got := pence.AsPounds()
+
+		if got != expected {
+			t.Errorf("Expected %s got %s\n", expected, got)
+		}
+	})
+}
+
+func TestMoneyFormat(t *testing.T) {
+	t.Run("Given currencies with 0, 2 and 3 minor unit digits, should format each with its own symbol and digits", func(t *testing.T) {
+		for _, tc := range []struct {
+			money    Money
+			expected string
+		}{
+			{Money{Amount: 120055, Currency: "USD"}, "$1,200.55"},
+			{Money{Amount: 1250, Currency: "EUR"}, "€12.50"},
+			{Money{Amount: 1200, Currency: "JPY"}, "¥1,200"},
+			{Money{Amount: 1250, Currency: "BHD"}, "BHD 1.250"},
+			{Money{Amount: -5, Currency: "BHD"}, "-BHD 0.005"},
+			{Money{Amount: 1250, Currency: "CHF"}, "CHF 12.50"},
+		} {
+			if got := tc.money.Format(); got != tc.expected {
+				t.Errorf("%+v: Expected %s got %s", tc.money, tc.expected, got)
+			}
+		}
+	})
+}
+
+func TestParseCurrency(t *testing.T) {
+	t.Run("Given a known code in any case, should return it upper case", func(t *testing.T) {
+		if currency, ok := ParseCurrency(" jpy "); !ok || currency != "JPY" || currency.Exponent() != 0 {
+			t.Errorf("Expected JPY with exponent 0, got %s and %v", currency, ok)
+		}
+	})
+
+	t.Run("Given an unknown code, should return false", func(t *testing.T) {
+		for _, code := range []string{"", "XXX", "pounds"} {
+			if _, ok := ParseCurrency(code); ok {
+				t.Errorf("Expected %q to be unknown", code)
+			}
+		}
+	})
+}
+
+// fixedRates has a single rate from GBP into each currency.
+type fixedRates map[Currency]float64
+
+func (f fixedRates) Rate(ctx context.Context, from, to Currency) (float64, error) {
+	rate, ok := f[to]
+
+	if from != DefaultCurrency || !ok {
+		return 0, fmt.Errorf("%w from %s to %s", ErrNoExchangeRate, from, to)
+	}
+
+	return rate, nil
+}
+
+func TestConvert(t *testing.T) {
+	rates := fixedRates{"EUR": 1.17, "JPY": 190.5, "BHD": 0.4765}
+
+	t.Run("Given a rate, should convert between minor units and round to the nearest", func(t *testing.T) {
+		for _, tc := range []struct {
+			to       Currency
+			expected int64
+		}{
+			{"EUR", 1463},
+			{"JPY", 2381},
+			{"BHD", 5956},
+		} {
+			got, err := Convert(context.Background(), rates, Money{Amount: 1250, Currency: DefaultCurrency}, tc.to)
+
+			if err != nil || got.Amount != tc.expected || got.Currency != tc.to {
+				t.Errorf("%s: Expected %d, got %+v and %v", tc.to, tc.expected, got, err)
+			}
+		}
+	})
+
+	t.Run("Given money already in the currency, should return it without a provider", func(t *testing.T) {
+		money := Money{Amount: 1250, Currency: "JPY"}
+
+		if got, err := Convert(context.Background(), nil, money, "JPY"); err != nil || got != money {
+			t.Errorf("Expected %+v, got %+v and %v", money, got, err)
+		}
+	})
+
+	t.Run("Given no rate or no provider, should return ErrNoExchangeRate", func(t *testing.T) {
+		if _, err := Convert(context.Background(), rates, Money{Amount: 1250, Currency: "USD"}, "EUR"); !errors.Is(err, ErrNoExchangeRate) {
+			t.Errorf("Expected ErrNoExchangeRate, got %v", err)
+		}
+
+		if _, err := Convert(context.Background(), nil, Money{Amount: 1250, Currency: "USD"}, "EUR"); !errors.Is(err, ErrNoExchangeRate) {
+			t.Errorf("Expected ErrNoExchangeRate, got %v", err)
+		}
+	})
 }
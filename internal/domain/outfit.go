@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// MaxOutfitItems bounds how many items a single outfit can hold.
+const MaxOutfitItems = 50
+
+// Outfit is a named selection of a user's clothing to be worn together.
+type Outfit struct {
+	Id     string `json:"id" dynamodbav:"Id"`
+	UserId string `json:"userId" dynamodbav:"UserId"`
+	Name   string `json:"name" dynamodbav:"Name"`
+	// Occasion is what the outfit is for, from the same choices as clothing, and may be empty.
+	Occasion Occasion `json:"occasion" dynamodbav:"Occasion"`
+	// ItemIds are the ids of the outfit's clothing, in the order the outfit lists them.
+	ItemIds []string `json:"itemIds" dynamodbav:"ItemIds"`
+}
+
+func (o Outfit) Validate() error {
+	if strings.TrimSpace(o.Name) == "" {
+		return errors.New("Outfit Name must not be empty")
+	}
+
+	if o.Occasion != "" && !slices.Contains(occasions, o.Occasion) {
+		return fmt.Errorf("Outfit Occasion %q must be one of %v", o.Occasion, occasions)
+	}
+
+	if len(o.ItemIds) == 0 {
+		return errors.New("Outfit must contain at least one item")
+	}
+
+	if len(o.ItemIds) > MaxOutfitItems {
+		return fmt.Errorf("Outfit must not contain more than %d items", MaxOutfitItems)
+	}
+
+	for i, id := range o.ItemIds {
+		if strings.TrimSpace(id) == "" {
+			return errors.New("Outfit item ids must not be empty")
+		}
+
+		if slices.Contains(o.ItemIds[:i], id) {
+			return fmt.Errorf("Outfit must not contain item %s more than once", id)
+		}
+	}
+
+	return nil
+}
+
+// Uses reports whether the item with id itemId is part of the outfit.
+func (o Outfit) Uses(itemId string) bool {
+	return slices.Contains(o.ItemIds, itemId)
+}
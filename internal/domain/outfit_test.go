@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestOutfitValidate(t *testing.T) {
+	t.Run("Given a name and distinct item ids, should return nil", func(t *testing.T) {
+		outfit := Outfit{Name: "Office", ItemIds: []string{"a", "b"}}
+
+		if got := outfit.Validate(); got != nil {
+			t.Errorf("Expected no error, but got %v", got)
+		}
+	})
+
+	t.Run("Given a known occasion, should return nil", func(t *testing.T) {
+		outfit := Outfit{Name: "Office", Occasion: Work, ItemIds: []string{"a"}}
+
+		if got := outfit.Validate(); got != nil {
+			t.Errorf("Expected no error, but got %v", got)
+		}
+	})
+
+	for _, tc := range []struct {
+		name     string
+		outfit   Outfit
+		expected string
+	}{
+		{"a blank name", Outfit{Name: "  ", ItemIds: []string{"a"}}, "Outfit Name must not be empty"},
+		{"an unknown occasion", Outfit{Name: "Office", Occasion: "wedding", ItemIds: []string{"a"}}, fmt.Sprintf("Outfit Occasion \"wedding\" must be one of %v", occasions)},
+		{"no items", Outfit{Name: "Office"}, "Outfit must contain at least one item"},
+		{"a blank item id", Outfit{Name: "Office", ItemIds: []string{"a", " "}}, "Outfit item ids must not be empty"},
+		{"a repeated item", Outfit{Name: "Office", ItemIds: []string{"a", "b", "a"}}, "Outfit must not contain item a more than once"},
+		{"too many items", Outfit{Name: "Office", ItemIds: make([]string, MaxOutfitItems+1)}, fmt.Sprintf("Outfit must not contain more than %d items", MaxOutfitItems)},
+	} {
+		t.Run(fmt.Sprintf("Given %s, should return an appropriate error", tc.name), func(t *testing.T) {
+			got := tc.outfit.Validate()
+
+			if got == nil || got.Error() != tc.expected {
+				t.Errorf("Expected '%s' but got '%v'", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestOutfitUses(t *testing.T) {
+	t.Run("Given an outfit, should report only its own items as used", func(t *testing.T) {
+		outfit := Outfit{Name: "Office", ItemIds: []string{"a", "b"}}
+
+		if !outfit.Uses("b") || outfit.Uses("c") {
+			t.Errorf("Expected b to be used and c not, got %v and %v", outfit.Uses("b"), outfit.Uses("c"))
+		}
+	})
+}
@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Season is a season an item suits.
+type Season string
+
+const (
+	Spring Season = "spring"
+	Summer Season = "summer"
+	Autumn Season = "autumn"
+	Winter Season = "winter"
+)
+
+// seasons are every Season, in the order of the year.
+var seasons = []Season{Spring, Summer, Autumn, Winter}
+
+// ParseSeason returns the Season named by s, ignoring case and surrounding whitespace and accepting
+// "fall" for autumn, or false if there is none.
+func ParseSeason(s string) (Season, bool) {
+	season := Season(strings.ToLower(strings.TrimSpace(s)))
+
+	if season == "fall" {
+		season = Autumn
+	}
+
+	return season, slices.Contains(seasons, season)
+}
+
+// UnmarshalJSON accepts what ParseSeason does, keeping other text so that Validate rejects it.
+func (s *Season) UnmarshalJSON(data []byte) error {
+	return unmarshalChoice(data, s, ParseSeason)
+}
+
+// Occasion is a kind of occasion an item suits.
+type Occasion string
+
+const (
+	Casual  Occasion = "casual"
+	Work    Occasion = "work"
+	Formal  Occasion = "formal"
+	Party   Occasion = "party"
+	Sport   Occasion = "sport"
+	Lounge  Occasion = "lounge"
+	Outdoor Occasion = "outdoor"
+)
+
+// occasions are every Occasion.
+var occasions = []Occasion{Casual, Work, Formal, Party, Sport, Lounge, Outdoor}
+
+// ParseOccasion returns the Occasion named by s, ignoring case and surrounding whitespace, or false if
+// there is none.
+func ParseOccasion(s string) (Occasion, bool) {
+	occasion := Occasion(strings.ToLower(strings.TrimSpace(s)))
+
+	return occasion, slices.Contains(occasions, occasion)
+}
+
+// UnmarshalJSON accepts what ParseOccasion does, keeping other text so that Validate rejects it.
+func (o *Occasion) UnmarshalJSON(data []byte) error {
+	return unmarshalChoice(data, o, ParseOccasion)
+}
+
+func unmarshalChoice[T ~string](data []byte, choice *T, parse func(string) (T, bool)) error {
+	var text string
+
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+
+	if parsed, ok := parse(text); ok {
+		*choice = parsed
+		return nil
+	}
+
+	*choice = T(strings.TrimSpace(text))
+
+	return nil
+}
+
+// validateChoices checks that values, the item's field, are each one of choices and listed only once.
+func validateChoices[T ~string](field string, values []T, choices []T) error {
+	for i, value := range values {
+		if !slices.Contains(choices, value) {
+			return fmt.Errorf("Clothing %s %q must be one of %v", field, value, choices)
+		}
+
+		if slices.Contains(values[:i], value) {
+			return fmt.Errorf("Clothing must not have %s %s more than once", field, value)
+		}
+	}
+
+	return nil
+}
+
+// Hemisphere decides which months are in which season.
+type Hemisphere string
+
+const (
+	NorthernHemisphere Hemisphere = "northern"
+	SouthernHemisphere Hemisphere = "southern"
+)
+
+// DefaultHemisphere is the hemisphere of users who have not chosen one.
+const DefaultHemisphere = NorthernHemisphere
+
+// ParseHemisphere returns the Hemisphere named by s, ignoring case and surrounding whitespace, or false
+// if there is none.
+func ParseHemisphere(s string) (Hemisphere, bool) {
+	hemisphere := Hemisphere(strings.ToLower(strings.TrimSpace(s)))
+
+	return hemisphere, hemisphere == NorthernHemisphere || hemisphere == SouthernHemisphere
+}
+
+// UnmarshalJSON accepts what ParseHemisphere does, keeping other text so that Validate rejects it.
+func (h *Hemisphere) UnmarshalJSON(data []byte) error {
+	return unmarshalChoice(data, h, ParseHemisphere)
+}
+
+// SeasonOf is the meteorological season of date in hemisphere, in which each season is three whole
+// months: spring is March to May in the northern hemisphere and September to November in the southern.
+func SeasonOf(date time.Time, hemisphere Hemisphere) Season {
+	month := int(date.Month())
+
+	if hemisphere == SouthernHemisphere {
+		month = (month+5)%12 + 1
+	}
+
+	// December is counted with the January and February after it.
+	return seasons[(month/3+3)%4]
+}
+
+// Suits reports whether the item is for season.
+func (c Clothing) Suits(season Season) bool {
+	return slices.Contains(c.Seasons, season)
+}
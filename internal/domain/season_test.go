@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestSeasonOf(t *testing.T) {
+	t.Run("Given dates through the year, should return the meteorological season in each hemisphere", func(t *testing.T) {
+		cases := []struct {
+			month              time.Month
+			northern, southern Season
+		}{
+			{time.January, Winter, Summer},
+			{time.February, Winter, Summer},
+			{time.March, Spring, Autumn},
+			{time.May, Spring, Autumn},
+			{time.June, Summer, Winter},
+			{time.August, Summer, Winter},
+			{time.September, Autumn, Spring},
+			{time.November, Autumn, Spring},
+			{time.December, Winter, Summer},
+		}
+
+		for _, tc := range cases {
+			date := time.Date(2026, tc.month, 15, 0, 0, 0, 0, time.UTC)
+
+			if got := SeasonOf(date, NorthernHemisphere); got != tc.northern {
+				t.Errorf("%s in the northern hemisphere: Expected %s, got %s", tc.month, tc.northern, got)
+			}
+
+			if got := SeasonOf(date, SouthernHemisphere); got != tc.southern {
+				t.Errorf("%s in the southern hemisphere: Expected %s, got %s", tc.month, tc.southern, got)
+			}
+		}
+	})
+}
+
+func TestSeasonsAndOccasions(t *testing.T) {
+	item := func(body string) (Clothing, error) {
+		clothing := Clothing{ClothingType: "Coat", Description: "Wool", Brand: "XYZ", Store: "This Store", Size: "L"}
+		err := json.Unmarshal([]byte(body), &clothing)
+
+		return clothing, err
+	}
+
+	t.Run("Given seasons and occasions in any case, should read and validate them", func(t *testing.T) {
+		clothing, err := item(`{"seasons": [" Winter", "FALL"], "occasions": ["Work", "formal"]}`)
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if err := clothing.Validate(); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+
+		if !clothing.Suits(Winter) || !clothing.Suits(Autumn) || clothing.Suits(Summer) {
+			t.Errorf("Expected winter and autumn, got %q", clothing.Seasons)
+		}
+
+		if len(clothing.Occasions) != 2 || clothing.Occasions[0] != Work || clothing.Occasions[1] != Formal {
+			t.Errorf("Expected work and formal, got %q", clothing.Occasions)
+		}
+	})
+
+	t.Run("Given unknown or repeated seasons or occasions, should return an error", func(t *testing.T) {
+		for _, body := range []string{`{"seasons": ["monsoon"]}`, `{"seasons": ["winter", "Winter"]}`, `{"occasions": ["wedding"]}`, `{"occasions": ["work", "work"]}`} {
+			clothing, err := item(body)
+
+			if err != nil {
+				t.Fatalf("%s: Expected no error on Unmarshal, got %v", body, err)
+			}
+
+			if err := clothing.Validate(); err == nil {
+				t.Errorf("%s: Expected an error", body)
+			}
+		}
+	})
+}
+
+func TestSettingsValidate(t *testing.T) {
+	t.Run("Given the default or a chosen hemisphere, should be valid, and otherwise return an error", func(t *testing.T) {
+		if err := DefaultSettings("user").Validate(); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+
+		var settings Settings
+
+		if err := json.Unmarshal([]byte(`{"hemisphere": " Southern"}`), &settings); err != nil || settings.Validate() != nil {
+			t.Errorf("Expected southern to be valid, got %+v and %v", settings, err)
+		}
+
+		if err := (Settings{Hemisphere: "eastern"}).Validate(); err == nil {
+			t.Error("Expected an error")
+		}
+	})
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "errors"
+
+// Settings are a user's preferences for how the service treats their wardrobe.
+type Settings struct {
+	UserId string `json:"userId" dynamodbav:"UserId"`
+	// Hemisphere decides the season of a date for the user's seasonal listing.
+	Hemisphere Hemisphere `json:"hemisphere" dynamodbav:"Hemisphere"`
+}
+
+// DefaultSettings are the settings of a user who has not saved any.
+func DefaultSettings(userId string) Settings {
+	return Settings{UserId: userId, Hemisphere: DefaultHemisphere}
+}
+
+func (s Settings) Validate() error {
+	if s.Hemisphere != NorthernHemisphere && s.Hemisphere != SouthernHemisphere {
+		return errors.New("Settings Hemisphere must be northern or southern")
+	}
+
+	return nil
+}
@@ -0,0 +1,275 @@
+package domain
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SizeSystem is the kind of size a Size is.
+type SizeSystem string
+
+const (
+	// LetterSize runs from XXS to XXXL and is the same in every region.
+	LetterSize SizeSystem = "letter"
+	// DressSize is a women's dress size, such as UK 10, EU 38 or US 6.
+	DressSize SizeSystem = "dress"
+	// ShoeSize is an adult shoe size, such as UK 8, EU 42 or US 9. US sizes are men's sizes.
+	ShoeSize SizeSystem = "shoe"
+	// WaistLegSize is a waist and, optionally, leg measurement in inches, such as 32/34.
+	WaistLegSize SizeSystem = "waistLeg"
+)
+
+// SizeRegion is the region a dress or shoe size is given in.
+type SizeRegion string
+
+const (
+	UKSize SizeRegion = "UK"
+	EUSize SizeRegion = "EU"
+	USSize SizeRegion = "US"
+)
+
+// Size is what ParseSize understood of the free text an item's size is stored as. Raw is always the
+// text as given; a Size with no System was not understood and is only comparable by its text.
+type Size struct {
+	Raw    string
+	System SizeSystem
+	// Region and Number are set for dress and shoe sizes. Shoe sizes can be half sizes.
+	Region SizeRegion
+	Number float64
+	// Letter is set for letter sizes, as one of XXS, XS, S, M, L, XL, XXL and XXXL.
+	Letter string
+	// Waist and Leg are set for waist and leg sizes, in inches. Leg is 0 when only a waist was given.
+	Waist int
+	Leg   int
+}
+
+var letterSizes = map[string]string{
+	"XXS": "XXS", "2XS": "XXS", "XXSMALL": "XXS",
+	"XS": "XS", "XSMALL": "XS", "EXTRASMALL": "XS",
+	"S": "S", "SMALL": "S",
+	"M": "M", "MED": "M", "MEDIUM": "M",
+	"L": "L", "LARGE": "L",
+	"XL": "XL", "XLARGE": "XL", "EXTRALARGE": "XL",
+	"XXL": "XXL", "2XL": "XXL", "XXLARGE": "XXL",
+	"XXXL": "XXXL", "3XL": "XXXL", "XXXLARGE": "XXXL",
+}
+
+var (
+	waistLegPattern = regexp.MustCompile(`^W?(\d{2})W?(?:/|X)?L?(\d{2})L?$`)
+	waistPattern    = regexp.MustCompile(`^(?:W(\d{2})|(\d{2})W)$`)
+	regionPattern   = regexp.MustCompile(`^(UK|EUR?|USA?)?(\d{1,2}(?:\.5)?)(UK|EUR?|USA?)?$`)
+)
+
+// legwearWords and footwearWords in an item's ClothingType decide what a number without a region means:
+// a waist, a shoe size, or otherwise a dress size. Legwear is checked first, so bootcut jeans are jeans,
+// and a number under 20 on legwear is a dress size, as women's trousers are often sized.
+var (
+	footwearWords = []string{"shoe", "boot", "trainer", "sneaker", "sandal", "heel", "loafer", "slipper", "footwear"}
+	legwearWords  = []string{"jean", "trouser", "chino", "short", "pant", "jogger", "legging"}
+)
+
+// ParseSize reads the sizes found in stored items: letter sizes and their spelt out forms such as
+// "Medium", dress and shoe sizes with or without a region such as "UK 10", "EU38" or "8", and waist and
+// leg sizes such as "32/34", "W32 L34" or "W32". What a plain number means depends on clothingType, as
+// legwearWords and footwearWords describe. A plain number is a UK size unless it is only plausible as an
+// EU size, as 38 is for a dress.
+func ParseSize(raw, clothingType string) Size {
+	size := Size{Raw: raw}
+	compact := strings.NewReplacer(" ", "", "\t", "", "-", "").Replace(strings.ToUpper(strings.TrimSpace(raw)))
+	kind := strings.ToLower(clothingType)
+
+	if letter, ok := letterSizes[compact]; ok {
+		size.System, size.Letter = LetterSize, letter
+		return size
+	}
+
+	if match := waistLegPattern.FindStringSubmatch(compact); match != nil {
+		return waistLeg(size, match[1], match[2])
+	}
+
+	if match := waistPattern.FindStringSubmatch(compact); match != nil {
+		return waistLeg(size, match[1]+match[2], "")
+	}
+
+	match := regionPattern.FindStringSubmatch(compact)
+
+	if match == nil || (match[1] != "" && match[3] != "") {
+		return size
+	}
+
+	number, _ := strconv.ParseFloat(match[2], 64)
+	region := SizeRegion(match[1] + match[3])
+
+	// EUR and USA are shortened to EU and US.
+	if len(region) > 2 {
+		region = region[:2]
+	}
+
+	switch {
+	case containsAny(kind, legwearWords) && region == "" && number >= 20:
+		return waistLeg(size, match[2], "")
+	case containsAny(kind, footwearWords):
+		size.System = ShoeSize
+
+		if region == "" && number >= 30 {
+			region = EUSize
+		}
+	default:
+		// Legwear with a region, or a number too small to be a waist, is sized as dresses are.
+		size.System = DressSize
+
+		if region == "" && number >= 32 {
+			region = EUSize
+		}
+	}
+
+	if region == "" {
+		region = UKSize
+	}
+
+	size.Region, size.Number = region, number
+
+	if !size.plausible() {
+		return Size{Raw: raw}
+	}
+
+	return size
+}
+
+func waistLeg(size Size, waist, leg string) Size {
+	size.System = WaistLegSize
+	size.Waist, _ = strconv.Atoi(waist)
+
+	if leg != "" {
+		size.Leg, _ = strconv.Atoi(leg)
+	}
+
+	if size.Waist < 20 || size.Waist > 60 || (leg != "" && (size.Leg < 20 || size.Leg > 40)) {
+		return Size{Raw: size.Raw}
+	}
+
+	return size
+}
+
+func containsAny(s string, words []string) bool {
+	for _, word := range words {
+		if strings.Contains(s, word) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sizeLimits are the smallest and largest dress and shoe sizes made in each region.
+var sizeLimits = map[SizeSystem]map[SizeRegion][2]float64{
+	DressSize: {UKSize: {2, 34}, EUSize: {30, 62}, USSize: {0, 30}},
+	ShoeSize:  {UKSize: {1, 16}, EUSize: {32, 52}, USSize: {2, 17}},
+}
+
+// plausible reports whether a dress or shoe size is one that is made in its region. Dress sizes are
+// even, and only shoe sizes come in halves.
+func (s Size) plausible() bool {
+	limit := sizeLimits[s.System][s.Region]
+
+	if s.Number < limit[0] || s.Number > limit[1] {
+		return false
+	}
+
+	return s.System != DressSize || math.Mod(s.Number, 2) == 0
+}
+
+// In converts a dress or shoe size to region, as the nearest size made there. Letter and waist sizes
+// are the same in every region, so are returned as they are. It returns false for a size that was not
+// understood.
+func (s Size) In(region SizeRegion) (Size, bool) {
+	if s.System == "" {
+		return s, false
+	}
+
+	if (s.System != DressSize && s.System != ShoeSize) || s.Region == region {
+		return s, true
+	}
+
+	uk := s.Number
+
+	// Dress sizes are a fixed offset apart. UK and EU shoe sizes are a third of an inch and two thirds
+	// of a centimetre per size, measured from different starting lengths, so do not line up exactly.
+	switch {
+	case s.System == DressSize && s.Region == EUSize:
+		uk = s.Number - 28
+	case s.System == DressSize && s.Region == USSize:
+		uk = s.Number + 4
+	case s.System == ShoeSize && s.Region == EUSize:
+		uk = math.Round((s.Number/1.27-25)*2) / 2
+	case s.System == ShoeSize && s.Region == USSize:
+		uk = s.Number - 1
+	}
+
+	converted := Size{System: s.System, Region: region, Number: uk}
+
+	switch {
+	case s.System == DressSize && region == EUSize:
+		converted.Number = uk + 28
+	case s.System == DressSize && region == USSize:
+		converted.Number = uk - 4
+	case s.System == ShoeSize && region == EUSize:
+		converted.Number = math.Round((uk + 25) * 1.27)
+	case s.System == ShoeSize && region == USSize:
+		converted.Number = uk + 1
+	}
+
+	converted.Raw = converted.String()
+
+	return converted, true
+}
+
+// Matches reports whether s and other are the same size, in any region. A shoe size matches the sizes it
+// converts to and from, so UK 7 and UK 7.5 both match EU 41 but not each other. Sizes that were not
+// understood match text that is the same apart from case and spacing.
+func (s Size) Matches(other Size) bool {
+	if s.System == "" || other.System == "" {
+		return s.System == other.System && strings.EqualFold(strings.Join(strings.Fields(s.Raw), " "), strings.Join(strings.Fields(other.Raw), " "))
+	}
+
+	if s.System != other.System {
+		return false
+	}
+
+	switch s.System {
+	case LetterSize:
+		return s.Letter == other.Letter
+	case WaistLegSize:
+		return s.Waist == other.Waist && (s.Leg == other.Leg || s.Leg == 0 || other.Leg == 0)
+	}
+
+	there, _ := s.In(other.Region)
+	here, _ := other.In(s.Region)
+
+	return there.Number == other.Number || here.Number == s.Number
+}
+
+// String formats s as in "M", "UK 10", "EU 42.5" or "W32 L34", or is Raw if s was not understood.
+func (s Size) String() string {
+	switch s.System {
+	case LetterSize:
+		return s.Letter
+	case DressSize, ShoeSize:
+		return string(s.Region) + " " + strconv.FormatFloat(s.Number, 'f', -1, 64)
+	case WaistLegSize:
+		if s.Leg == 0 {
+			return "W" + strconv.Itoa(s.Waist)
+		}
+
+		return "W" + strconv.Itoa(s.Waist) + " L" + strconv.Itoa(s.Leg)
+	}
+
+	return s.Raw
+}
+
+// ParsedSize is the item's Size as ParseSize understands it for the item's type.
+func (c Clothing) ParsedSize() Size {
+	return ParseSize(c.Size, c.ClothingType)
+}
@@ -0,0 +1,123 @@
+package domain
+
+import "testing"
+
+func TestParseSize(t *testing.T) {
+	t.Run("Given the sizes found in stored items, should understand them for the item's type", func(t *testing.T) {
+		cases := []struct {
+			raw, clothingType, expected string
+			system                      SizeSystem
+		}{
+			{"Medium", "Jumper", "M", LetterSize},
+			{" x-large ", "Coat", "XL", LetterSize},
+			{"2XL", "T-Shirt", "XXL", LetterSize},
+			{"10", "Dress", "UK 10", DressSize},
+			{"38", "Dress", "EU 38", DressSize},
+			{"US 6", "Skirt", "US 6", DressSize},
+			{"eu38", "Dress", "EU 38", DressSize},
+			{"8", "Trainers", "UK 8", ShoeSize},
+			{"42", "Ankle Boots", "EU 42", ShoeSize},
+			{"7.5 UK", "Shoes", "UK 7.5", ShoeSize},
+			{"32/34", "Jeans", "W32 L34", WaistLegSize},
+			{"W32 L30", "Bootcut Jeans", "W32 L30", WaistLegSize},
+			{"32", "Chinos", "W32", WaistLegSize},
+			{"12", "Trousers", "UK 12", DressSize},
+		}
+
+		for _, tc := range cases {
+			size := ParseSize(tc.raw, tc.clothingType)
+
+			if size.System != tc.system || size.String() != tc.expected || size.Raw != tc.raw {
+				t.Errorf("%q for %s: Expected %s %s, got %+v", tc.raw, tc.clothingType, tc.system, tc.expected, size)
+			}
+		}
+	})
+
+	t.Run("Given sizes that are not made or not sizes at all, should keep only the raw text", func(t *testing.T) {
+		for _, raw := range []string{"One Size", "11", "UK 10 EU 38", "EU 99", "10/12", "W12"} {
+			if size := ParseSize(raw, "Dress"); size.System != "" || size.Raw != raw {
+				t.Errorf("%q: Expected it not to be understood, got %+v", raw, size)
+			}
+		}
+	})
+}
+
+func TestSizeIn(t *testing.T) {
+	t.Run("Given dress and shoe sizes, should convert them between regions", func(t *testing.T) {
+		cases := []struct {
+			raw, clothingType string
+			region            SizeRegion
+			expected          string
+		}{
+			{"UK 10", "Dress", EUSize, "EU 38"},
+			{"UK 10", "Dress", USSize, "US 6"},
+			{"EU 38", "Dress", USSize, "US 6"},
+			{"UK 8", "Shoes", EUSize, "EU 42"},
+			{"EU 42", "Shoes", UKSize, "UK 8"},
+			{"UK 8", "Shoes", USSize, "US 9"},
+			{"EU 40", "Shoes", UKSize, "UK 6.5"},
+		}
+
+		for _, tc := range cases {
+			converted, ok := ParseSize(tc.raw, tc.clothingType).In(tc.region)
+
+			if !ok || converted.String() != tc.expected || converted.Raw != tc.expected {
+				t.Errorf("%s %s in %s: Expected %s, got %+v", tc.clothingType, tc.raw, tc.region, tc.expected, converted)
+			}
+		}
+	})
+
+	t.Run("Given letter and waist sizes, should return them unchanged, and false for sizes not understood", func(t *testing.T) {
+		if size, ok := ParseSize("M", "Jumper").In(EUSize); !ok || size.String() != "M" {
+			t.Errorf("Expected M, got %+v", size)
+		}
+
+		if size, ok := ParseSize("32/34", "Jeans").In(USSize); !ok || size.String() != "W32 L34" {
+			t.Errorf("Expected W32 L34, got %+v", size)
+		}
+
+		if _, ok := ParseSize("One Size", "Hat").In(EUSize); ok {
+			t.Error("Expected false")
+		}
+	})
+}
+
+func TestSizeMatches(t *testing.T) {
+	matches := func(a, b, clothingType string) bool {
+		return ParseSize(a, clothingType).Matches(ParseSize(b, clothingType))
+	}
+
+	t.Run("Given the same size in different regions or spellings, should match", func(t *testing.T) {
+		cases := [][3]string{
+			{"UK 10", "EU 38", "Dress"},
+			{"10", "US 6", "Dress"},
+			{"Medium", "m", "Jumper"},
+			{"UK 7", "EU 41", "Shoes"},
+			{"UK 7.5", "EU 41", "Shoes"},
+			{"W32", "32/34", "Jeans"},
+			{"one  size", "One Size", "Hat"},
+		}
+
+		for _, tc := range cases {
+			if !matches(tc[0], tc[1], tc[2]) {
+				t.Errorf("%s and %s for %s: Expected a match", tc[0], tc[1], tc[2])
+			}
+		}
+	})
+
+	t.Run("Given different sizes, should not match", func(t *testing.T) {
+		cases := [][3]string{
+			{"UK 10", "EU 40", "Dress"},
+			{"UK 7", "UK 7.5", "Shoes"},
+			{"M", "L", "Jumper"},
+			{"32/34", "32/32", "Jeans"},
+			{"10", "One Size", "Dress"},
+		}
+
+		for _, tc := range cases {
+			if matches(tc[0], tc[1], tc[2]) {
+				t.Errorf("%s and %s for %s: Expected no match", tc[0], tc[1], tc[2])
+			}
+		}
+	})
+}
@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+)
+
+// Amount is a price in the minor unit of Currency alongside its display form.
+type Amount struct {
+	Pence     Pence    `json:"pence"`
+	Currency  Currency `json:"currency"`
+	Formatted string   `json:"formatted"`
+}
+
+func NewAmount(p Pence, currency Currency) Amount {
+	return Amount{Pence: p, Currency: currency, Formatted: Money{Amount: int64(p), Currency: currency}.Format()}
+}
+
+// GroupStats summarises the items sharing one value of a field, such as every item from one brand.
+type GroupStats struct {
+	Value   string `json:"value"`
+	Count   int    `json:"count"`
+	Total   Amount `json:"total"`
+	Average Amount `json:"average"`
+}
+
+type WardrobeStats struct {
+	Count          int          `json:"count"`
+	Total          Amount       `json:"total"`
+	Average        Amount       `json:"average"`
+	Median         Amount       `json:"median"`
+	MinPriceItem   *Clothing    `json:"minPriceItem"`
+	MaxPriceItem   *Clothing    `json:"maxPriceItem"`
+	ByClothingType []GroupStats `json:"byClothingType"`
+	ByBrand        []GroupStats `json:"byBrand"`
+	ByStore        []GroupStats `json:"byStore"`
+	BySize         []GroupStats `json:"bySize"`
+}
+
+// CalculateStats aggregates a wardrobe whose prices are all in currency, so callers convert items in
+// other currencies first. Averages and medians are rounded to the nearest minor unit, and when several
+// items share the lowest or highest price the first of them in items is reported.
+// Breakdowns group values case-insensitively, largest group first.
+func CalculateStats(items []Clothing, currency Currency) WardrobeStats {
+	stats := WardrobeStats{
+		Count:          len(items),
+		ByClothingType: groupStats(items, currency, func(c Clothing) string { return c.ClothingType }),
+		ByBrand:        groupStats(items, currency, func(c Clothing) string { return c.Brand }),
+		ByStore:        groupStats(items, currency, func(c Clothing) string { return c.Store }),
+		BySize:         groupStats(items, currency, func(c Clothing) string { return c.Size }),
+	}
+
+	if len(items) == 0 {
+		stats.Total = NewAmount(0, currency)
+		stats.Average = NewAmount(0, currency)
+		stats.Median = NewAmount(0, currency)
+		return stats
+	}
+
+	var total Pence
+	prices := make([]Pence, 0, len(items))
+
+	for i := range items {
+		total += items[i].Price
+		prices = append(prices, items[i].Price)
+
+		if stats.MinPriceItem == nil || items[i].Price < stats.MinPriceItem.Price {
+			stats.MinPriceItem = &items[i]
+		}
+
+		if stats.MaxPriceItem == nil || items[i].Price > stats.MaxPriceItem.Price {
+			stats.MaxPriceItem = &items[i]
+		}
+	}
+
+	slices.Sort(prices)
+
+	middle := len(prices) / 2
+	median := prices[middle]
+
+	if len(prices)%2 == 0 {
+		median = divideRounded(prices[middle-1]+prices[middle], 2)
+	}
+
+	stats.Total = NewAmount(total, currency)
+	stats.Average = NewAmount(divideRounded(total, len(items)), currency)
+	stats.Median = NewAmount(median, currency)
+
+	return stats
+}
+
+func groupStats(items []Clothing, currency Currency, field func(Clothing) string) []GroupStats {
+	groups := []GroupStats{}
+	indexes := map[string]int{}
+
+	for _, item := range items {
+		key := strings.ToLower(strings.TrimSpace(field(item)))
+		index, exists := indexes[key]
+
+		if !exists {
+			index = len(groups)
+			indexes[key] = index
+			groups = append(groups, GroupStats{Value: strings.TrimSpace(field(item))})
+		}
+
+		groups[index].Count++
+		groups[index].Total.Pence += item.Price
+	}
+
+	for i := range groups {
+		groups[i].Total = NewAmount(groups[i].Total.Pence, currency)
+		groups[i].Average = NewAmount(divideRounded(groups[i].Total.Pence, groups[i].Count), currency)
+	}
+
+	slices.SortStableFunc(groups, func(a, b GroupStats) int {
+		if a.Count != b.Count {
+			return b.Count - a.Count
+		}
+
+		return strings.Compare(strings.ToLower(a.Value), strings.ToLower(b.Value))
+	})
+
+	return groups
+}
+
+// divideRounded divides to the nearest minor unit, rounding halves away from zero.
+func divideRounded(total Pence, count int) Pence {
+	n := Pence(count)
+
+	if total < 0 {
+		return -((-total*2 + n) / (2 * n))
+	}
+
+	return (total*2 + n) / (2 * n)
+}
@@ -0,0 +1,118 @@
+package domain
+
+import "testing"
+
+func TestCalculateStats(t *testing.T) {
+	t.Run("Given no items, should return zero amounts, no min or max item and empty breakdowns", func(t *testing.T) {
+		stats := CalculateStats([]Clothing{}, DefaultCurrency)
+
+		if stats.Count != 0 || stats.Total.Pence != 0 || stats.Average.Pence != 0 || stats.Median.Pence != 0 {
+			t.Errorf("Expected zero stats, got %+v", stats)
+		}
+
+		if stats.Average.Formatted != "£0.00" {
+			t.Errorf("Expected average £0.00, got %s", stats.Average.Formatted)
+		}
+
+		if stats.MinPriceItem != nil || stats.MaxPriceItem != nil {
+			t.Errorf("Expected no min or max item, got %v and %v", stats.MinPriceItem, stats.MaxPriceItem)
+		}
+
+		if stats.ByBrand == nil || len(stats.ByBrand) != 0 {
+			t.Errorf("Expected an empty, non-nil brand breakdown, got %v", stats.ByBrand)
+		}
+	})
+
+	t.Run("Given several items, should calculate the totals, average, median and extremes", func(t *testing.T) {
+		items := []Clothing{
+			{Id: "1", Price: 1000, ClothingType: "Jumper", Brand: "XYZ", Store: "This Store", Size: "M"},
+			{Id: "2", Price: 2501, ClothingType: "Coat", Brand: "xyz", Store: "That Store", Size: "L"},
+			{Id: "3", Price: 500, ClothingType: "jumper", Brand: "Abc", Store: "This Store", Size: "M"},
+			{Id: "4", Price: 2501, ClothingType: "Hat", Brand: "Abc", Store: "This Store", Size: "S"},
+		}
+
+		stats := CalculateStats(items, DefaultCurrency)
+
+		if stats.Count != 4 {
+			t.Errorf("Expected count 4, got %d", stats.Count)
+		}
+
+		if stats.Total.Pence != 6502 || stats.Total.Formatted != "£65.02" {
+			t.Errorf("Expected total 6502 (£65.02), got %+v", stats.Total)
+		}
+
+		// 6502 / 4 = 1625.5, rounded half away from zero.
+		if stats.Average.Pence != 1626 {
+			t.Errorf("Expected average 1626, got %d", stats.Average.Pence)
+		}
+
+		// (1000 + 2501) / 2 = 1750.5
+		if stats.Median.Pence != 1751 {
+			t.Errorf("Expected median 1751, got %d", stats.Median.Pence)
+		}
+
+		if stats.MinPriceItem == nil || stats.MinPriceItem.Id != "3" {
+			t.Errorf("Expected min price item 3, got %v", stats.MinPriceItem)
+		}
+
+		if stats.MaxPriceItem == nil || stats.MaxPriceItem.Id != "2" {
+			t.Errorf("Expected the first of the tied max price items (2), got %v", stats.MaxPriceItem)
+		}
+	})
+
+	t.Run("Given an odd number of items, median should be the middle price", func(t *testing.T) {
+		stats := CalculateStats([]Clothing{{Price: 900}, {Price: 100}, {Price: 300}}, DefaultCurrency)
+
+		if stats.Median.Pence != 300 {
+			t.Errorf("Expected median 300, got %d", stats.Median.Pence)
+		}
+	})
+
+	t.Run("Given values differing only in case, should group them under the first spelling, largest group first", func(t *testing.T) {
+		items := []Clothing{
+			{Price: 1000, ClothingType: "Jumper", Brand: "XYZ", Store: "This Store", Size: "M"},
+			{Price: 2000, ClothingType: "Coat", Brand: "xyz", Store: "That Store", Size: "L"},
+			{Price: 500, ClothingType: "jumper", Brand: "Abc", Store: "This Store", Size: "M"},
+		}
+
+		stats := CalculateStats(items, DefaultCurrency)
+
+		if len(stats.ByClothingType) != 2 {
+			t.Fatalf("Expected 2 clothing type groups, got %v", stats.ByClothingType)
+		}
+
+		jumpers := stats.ByClothingType[0]
+
+		if jumpers.Value != "Jumper" || jumpers.Count != 2 || jumpers.Total.Pence != 1500 || jumpers.Average.Pence != 750 {
+			t.Errorf("Expected Jumper with 2 items totalling 1500 averaging 750, got %+v", jumpers)
+		}
+
+		if stats.ByBrand[0].Value != "XYZ" || stats.ByBrand[0].Count != 2 {
+			t.Errorf("Expected XYZ with 2 items first, got %+v", stats.ByBrand[0])
+		}
+
+		if stats.ByStore[1].Value != "That Store" || stats.BySize[1].Value != "L" {
+			t.Errorf("Expected the single item groups second, got %+v and %+v", stats.ByStore[1], stats.BySize[1])
+		}
+	})
+
+	t.Run("Given groups with equal counts, should order them by value", func(t *testing.T) {
+		stats := CalculateStats([]Clothing{{Brand: "zed"}, {Brand: "Abc"}, {Brand: "mid"}}, DefaultCurrency)
+
+		if stats.ByBrand[0].Value != "Abc" || stats.ByBrand[1].Value != "mid" || stats.ByBrand[2].Value != "zed" {
+			t.Errorf("Expected Abc, mid, zed, got %+v", stats.ByBrand)
+		}
+	})
+
+	t.Run("Given a currency, should report and format every amount in it", func(t *testing.T) {
+		stats := CalculateStats([]Clothing{{Price: 1500, Brand: "XYZ"}, {Price: 2501, Brand: "XYZ"}}, "JPY")
+
+		if stats.Total.Currency != "JPY" || stats.Total.Formatted != "¥4,001" || stats.Average.Formatted != "¥2,001" {
+			t.Errorf("Expected a total of ¥4,001 and average of ¥2,001, got %+v and %+v", stats.Total, stats.Average)
+		}
+
+		if stats.ByBrand[0].Total.Currency != "JPY" || stats.ByBrand[0].Total.Formatted != "¥4,001" {
+			t.Errorf("Expected the brand total in JPY, got %+v", stats.ByBrand[0].Total)
+		}
+	})
+}
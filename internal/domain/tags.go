@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// MaxTags bounds how many tags a single item can have.
+	MaxTags = 20
+	// MaxTagLength bounds the characters in a tag, after normalising it.
+	MaxTagLength = 32
+)
+
+// TagCount is how many of a user's items have a tag.
+type TagCount struct {
+	Tag   string `json:"tag"`
+	Count int    `json:"count"`
+}
+
+// NormaliseTag lowercases tag, trims it and collapses the whitespace inside it to single spaces, so
+// "Work", " work " and "WORK" are the same tag.
+func NormaliseTag(tag string) string {
+	return strings.ToLower(strings.Join(strings.Fields(tag), " "))
+}
+
+// NormaliseTags normalises each tag, dropping blank ones, and returns them sorted without duplicates, or
+// nil when none are left. Tags are kept in this form, so every backend returns them in the same order.
+func NormaliseTags(tags []string) []string {
+	var normalised []string
+
+	for _, tag := range tags {
+		if tag = NormaliseTag(tag); tag != "" {
+			normalised = append(normalised, tag)
+		}
+	}
+
+	slices.Sort(normalised)
+
+	return slices.Compact(normalised)
+}
+
+func validateTags(tags []string) error {
+	if len(tags) > MaxTags {
+		return fmt.Errorf("Clothing must not have more than %d tags", MaxTags)
+	}
+
+	for _, tag := range tags {
+		if utf8.RuneCountInString(NormaliseTag(tag)) > MaxTagLength {
+			return fmt.Errorf("Clothing Tag %q must not be longer than %d characters", tag, MaxTagLength)
+		}
+	}
+
+	if !slices.Equal(tags, NormaliseTags(tags)) {
+		return errors.New("Clothing Tags must be lowercase, trimmed, sorted and unique")
+	}
+
+	return nil
+}
+
+// CountTags counts the items with each tag, most used first and then by tag.
+func CountTags(items []Clothing) []TagCount {
+	counts := map[string]int{}
+
+	for _, item := range items {
+		for _, tag := range item.Tags {
+			counts[tag]++
+		}
+	}
+
+	tagCounts := make([]TagCount, 0, len(counts))
+
+	for tag, count := range counts {
+		tagCounts = append(tagCounts, TagCount{Tag: tag, Count: count})
+	}
+
+	slices.SortFunc(tagCounts, func(a, b TagCount) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.Tag, b.Tag)
+	})
+
+	return tagCounts
+}
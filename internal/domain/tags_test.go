@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNormaliseTags(t *testing.T) {
+	t.Run("Given tags in mixed case with extra whitespace, should lowercase, trim and collapse them", func(t *testing.T) {
+		got := NormaliseTags([]string{"  Winter ", "WORK  wear", "\tsummer"})
+
+		if !slices.Equal(got, []string{"summer", "winter", "work wear"}) {
+			t.Errorf("Expected summer, winter and work wear, got %q", got)
+		}
+	})
+
+	t.Run("Given duplicate and blank tags, should keep each tag once and drop the blanks", func(t *testing.T) {
+		got := NormaliseTags([]string{"work", " ", "Work", "", "WORK "})
+
+		if !slices.Equal(got, []string{"work"}) {
+			t.Errorf("Expected only work, got %q", got)
+		}
+	})
+
+	t.Run("Given no tags, should return nil", func(t *testing.T) {
+		if got := NormaliseTags([]string{" "}); got != nil {
+			t.Errorf("Expected nil, got %q", got)
+		}
+	})
+}
+
+func TestValidateTags(t *testing.T) {
+	item := func(tags ...string) Clothing {
+		return Clothing{ClothingType: "Jumper", Description: "Blue", Brand: "XYZ", Store: "This Store", Size: "L", Tags: tags}
+	}
+
+	t.Run("Given normalised tags, should be valid", func(t *testing.T) {
+		if err := item("summer", "work wear").Validate(); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+
+	t.Run("Given tags that are not normalised, should return an error", func(t *testing.T) {
+		for _, tags := range [][]string{{"Work"}, {" work"}, {"work", "summer"}, {"work", "work"}, {""}} {
+			if err := item(tags...).Validate(); err == nil {
+				t.Errorf("Tags %q: Expected an error", tags)
+			}
+		}
+	})
+
+	t.Run("Given more than MaxTags tags, should return an error", func(t *testing.T) {
+		var tags []string
+
+		for i := range MaxTags + 1 {
+			tags = append(tags, string(rune('a'+i)))
+		}
+
+		if err := item(tags...).Validate(); err == nil {
+			t.Error("Expected an error")
+		}
+	})
+
+	t.Run("Given a tag longer than MaxTagLength, should return an error", func(t *testing.T) {
+		if err := item(strings.Repeat("é", MaxTagLength)).Validate(); err != nil {
+			t.Errorf("Expected %d characters to be valid, got %v", MaxTagLength, err)
+		}
+
+		if err := item(strings.Repeat("é", MaxTagLength+1)).Validate(); err == nil {
+			t.Error("Expected an error")
+		}
+	})
+}
+
+func TestCountTags(t *testing.T) {
+	t.Run("Given tagged items, should count each tag, most used first", func(t *testing.T) {
+		got := CountTags([]Clothing{
+			{Tags: []string{"summer", "work"}},
+			{Tags: []string{"work"}},
+			{Tags: []string{"casual", "winter"}},
+			{},
+		})
+
+		expected := []TagCount{{"work", 2}, {"casual", 1}, {"summer", 1}, {"winter", 1}}
+
+		if !slices.Equal(got, expected) {
+			t.Errorf("Expected %+v, got %+v", expected, got)
+		}
+	})
+
+	t.Run("Given no tagged items, should return an empty list", func(t *testing.T) {
+		if got := CountTags([]Clothing{{}}); got == nil || len(got) != 0 {
+			t.Errorf("Expected an empty list, got %#v", got)
+		}
+	})
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// Wear is one time an item was worn. WornAt is when, to the second, and RecordedAt when the wear was
+// logged, which is later for a wear logged after the fact.
+type Wear struct {
+	WornAt     time.Time `json:"wornAt" dynamodbav:"WornAt,unixtime"`
+	RecordedAt time.Time `json:"recordedAt" dynamodbav:"RecordedAt,unixtime"`
+}
+
+// CostPerWear is the item's price divided by the times it has been worn, rounded to the nearest minor
+// unit, or false when it has not been worn yet.
+func (c Clothing) CostPerWear() (Amount, bool) {
+	if c.WearCount <= 0 {
+		return Amount{}, false
+	}
+
+	price := c.PriceMoney()
+
+	return NewAmount(divideRounded(c.Price, int(c.WearCount)), price.Currency), true
+}
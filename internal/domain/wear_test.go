@@ -0,0 +1,31 @@
+package domain
+
+import "testing"
+
+func TestCostPerWear(t *testing.T) {
+	t.Run("Given an item that has not been worn, should return false", func(t *testing.T) {
+		if _, ok := (Clothing{Price: 5000}).CostPerWear(); ok {
+			t.Error("Expected no cost per wear")
+		}
+	})
+
+	t.Run("Given an item worn three times, should divide the price rounding to the nearest penny", func(t *testing.T) {
+		got, ok := (Clothing{Price: 5000, WearCount: 3}).CostPerWear()
+
+		if !ok {
+			t.Fatal("Expected a cost per wear")
+		}
+
+		if got.Pence != 1667 || got.Currency != DefaultCurrency || got.Formatted != Pence(1667).AsPounds() {
+			t.Errorf("Expected £16.67, got %+v", got)
+		}
+	})
+
+	t.Run("Given an item priced in another currency, should give the cost per wear in that currency", func(t *testing.T) {
+		got, ok := (Clothing{Price: 12000, Currency: "JPY", WearCount: 4}).CostPerWear()
+
+		if !ok || got.Pence != 3000 || got.Currency != "JPY" || got.Formatted != "¥3,000" {
+			t.Errorf("Expected ¥3,000, got %+v", got)
+		}
+	})
+}
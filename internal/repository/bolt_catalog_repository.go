@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.etcd.io/bbolt"
+)
+
+// catalogBucket holds a nested bucket per CatalogKind mapping the de-duplication key to the spelling.
+var catalogBucket = []byte("catalog")
+
+type BoltCatalogRepository struct {
+	db *bbolt.DB
+}
+
+func NewBoltCatalogRepository(db *bbolt.DB) (*BoltCatalogRepository, error) {
+	if db == nil {
+		return nil, fmt.Errorf("db should not be nil")
+	}
+
+	err := db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(catalogBucket)
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create catalog bucket: %w", err)
+	}
+
+	return &BoltCatalogRepository{db: db}, nil
+}
+
+func (b *BoltCatalogRepository) Register(ctx context.Context, kind CatalogKind, value string) error {
+	if err := validateCatalogEntry(kind, value); err != nil {
+		return err
+	}
+
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		bucket, err := tx.Bucket(catalogBucket).CreateBucketIfNotExists([]byte(kind))
+
+		if err != nil {
+			return fmt.Errorf("failed to create bucket for catalog %s: %w", kind, err)
+		}
+
+		key := []byte(catalogKey(value))
+
+		if bucket.Get(key) != nil {
+			return nil
+		}
+
+		return bucket.Put(key, []byte(strings.TrimSpace(value)))
+	})
+}
+
+func (b *BoltCatalogRepository) List(ctx context.Context, kind CatalogKind) ([]string, error) {
+	if _, ok := ParseCatalogKind(string(kind)); !ok {
+		return []string{}, newValidationError("Unknown catalog " + string(kind))
+	}
+
+	values := []string{}
+
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(catalogBucket).Bucket([]byte(kind))
+
+		if bucket == nil {
+			return nil
+		}
+
+		return bucket.ForEach(func(_, value []byte) error {
+			values = append(values, string(value))
+			return nil
+		})
+	})
+
+	if err != nil {
+		return []string{}, err
+	}
+
+	sortCatalogValues(values)
+
+	return values, nil
+}
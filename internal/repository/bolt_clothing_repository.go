@@ -0,0 +1,587 @@
+package repository
+
+import (
+	"bytes"
+	"clothes_management/internal/domain"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.etcd.io/bbolt"
+)
+
+// clothingBucket holds a nested bucket per user, keyed by user id, whose keys are item ids and whose
+// values are the JSON encoded items. bbolt keeps keys in byte order, which gives the same Id ordering
+// as the other backends.
+var clothingBucket = []byte("clothing")
+
+// wearBucket holds a nested bucket per user mapping wear keys to JSON wears, so an item's wears are
+// adjacent and in the order they were worn.
+var wearBucket = []byte("wears")
+
+// BoltClothingRepository stores clothing in a single bbolt file. Every write is a transaction that is
+// fsynced before it returns, and bbolt's copy-on-write pages mean a crash leaves the last committed
+// state intact, so no separate recovery step is needed on open.
+type BoltClothingRepository struct {
+	db *bbolt.DB
+}
+
+func NewBoltClothingRepository(db *bbolt.DB) (*BoltClothingRepository, error) {
+	if db == nil {
+		return nil, fmt.Errorf("db should not be nil")
+	}
+
+	err := db.Update(func(tx *bbolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(clothingBucket); err != nil {
+			return err
+		}
+
+		_, err := tx.CreateBucketIfNotExists(wearBucket)
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create clothing buckets: %w", err)
+	}
+
+	return &BoltClothingRepository{db: db}, nil
+}
+
+func userBucket(tx *bbolt.Tx, userId string) *bbolt.Bucket {
+	return tx.Bucket(clothingBucket).Bucket([]byte(userId))
+}
+
+func getBoltItem(bucket *bbolt.Bucket, id string) (domain.Clothing, bool, error) {
+	if bucket == nil {
+		return domain.Clothing{}, false, nil
+	}
+
+	raw := bucket.Get([]byte(id))
+
+	if raw == nil {
+		return domain.Clothing{}, false, nil
+	}
+
+	var item domain.Clothing
+
+	if err := json.Unmarshal(raw, &item); err != nil {
+		return domain.Clothing{}, false, fmt.Errorf("failed to decode item %s: %w", id, err)
+	}
+
+	return item, true, nil
+}
+
+func putBoltItem(bucket *bbolt.Bucket, item domain.Clothing) error {
+	raw, err := json.Marshal(item)
+
+	if err != nil {
+		return fmt.Errorf("failed to encode item %s: %w", item.Id, err)
+	}
+
+	return bucket.Put([]byte(item.Id), raw)
+}
+
+func (b *BoltClothingRepository) Save(ctx context.Context, userId string, clothing domain.Clothing) (domain.Clothing, error) {
+	if strings.TrimSpace(userId) == "" {
+		return domain.Clothing{}, newValidationError("User ID must not be empty or whitespace")
+	}
+
+	if err := clothing.Validate(); err != nil {
+		return domain.Clothing{}, &ValidationError{Err: err}
+	}
+
+	// Ids are always generated here so callers can never choose, or collide with, another item's id.
+	clothing.Id = uuid.New().String()
+
+	clothing.UserId = userId
+	clothing.Version = 1
+	clothing.DeletedAt = nil
+	clothing.WearCount = 0
+	clothing.LastWornAt = nil
+
+	err := b.db.Update(func(tx *bbolt.Tx) error {
+		bucket, err := tx.Bucket(clothingBucket).CreateBucketIfNotExists([]byte(userId))
+
+		if err != nil {
+			return fmt.Errorf("failed to create bucket for user: %w", err)
+		}
+
+		if bucket.Get([]byte(clothing.Id)) != nil {
+			return newConflictError("Item with id %s already exists", clothing.Id)
+		}
+
+		return putBoltItem(bucket, clothing)
+	})
+
+	if err != nil {
+		return domain.Clothing{}, err
+	}
+
+	return clothing, nil
+}
+
+func (b *BoltClothingRepository) SaveMany(ctx context.Context, userId string, clothing []domain.Clothing) ([]domain.Clothing, error) {
+	prepared, err := prepareNewItems(userId, clothing)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// A single transaction, so either every item is written or none are.
+	err = b.db.Update(func(tx *bbolt.Tx) error {
+		bucket, err := tx.Bucket(clothingBucket).CreateBucketIfNotExists([]byte(userId))
+
+		if err != nil {
+			return fmt.Errorf("failed to create bucket for user: %w", err)
+		}
+
+		for _, item := range prepared {
+			if bucket.Get([]byte(item.Id)) != nil {
+				return newConflictError("Item with id %s already exists", item.Id)
+			}
+
+			if err := putBoltItem(bucket, item); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return prepared, nil
+}
+
+// readAll returns the user's items that match filter, in Id order.
+func (b *BoltClothingRepository) readAll(userId string, filter ClothingFilter) ([]domain.Clothing, error) {
+	items := []domain.Clothing{}
+
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		bucket := userBucket(tx, userId)
+
+		if bucket == nil {
+			return nil
+		}
+
+		return bucket.ForEach(func(key, raw []byte) error {
+			var item domain.Clothing
+
+			if err := json.Unmarshal(raw, &item); err != nil {
+				return fmt.Errorf("failed to decode item %s: %w", key, err)
+			}
+
+			if filter.Matches(item) {
+				items = append(items, item)
+			}
+
+			return nil
+		})
+	})
+
+	if err != nil {
+		return []domain.Clothing{}, err
+	}
+
+	return items, nil
+}
+
+func (b *BoltClothingRepository) GetAll(ctx context.Context, userId string) ([]domain.Clothing, error) {
+	if strings.TrimSpace(userId) == "" {
+		return []domain.Clothing{}, newValidationError("User ID must not be empty or whitespace")
+	}
+
+	return b.readAll(userId, ClothingFilter{})
+}
+
+func (b *BoltClothingRepository) GetPage(ctx context.Context, userId string, page PageRequest) (Page, error) {
+	if strings.TrimSpace(userId) == "" {
+		return Page{Items: []domain.Clothing{}}, newValidationError("User ID must not be empty or whitespace")
+	}
+
+	if err := page.Filter.Validate(); err != nil {
+		return Page{Items: []domain.Clothing{}}, &ValidationError{Err: err}
+	}
+
+	items, err := b.readAll(userId, page.Filter)
+
+	if err != nil {
+		return Page{Items: []domain.Clothing{}}, err
+	}
+
+	return sortedPage(items, page)
+}
+
+func (b *BoltClothingRepository) GetById(ctx context.Context, userId, id string) (domain.Clothing, error) {
+	if strings.TrimSpace(userId) == "" {
+		return domain.Clothing{}, newValidationError("User ID must not be empty or whitespace")
+	}
+
+	if strings.TrimSpace(id) == "" {
+		return domain.Clothing{}, newValidationError("ID must not be empty or whitespace")
+	}
+
+	var item domain.Clothing
+
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		stored, exists, err := getBoltItem(userBucket(tx, userId), id)
+
+		if err != nil {
+			return err
+		}
+
+		if !exists || stored.DeletedAt != nil {
+			return newNotFoundError("No item exists for id %s", id)
+		}
+
+		item = stored
+		return nil
+	})
+
+	if err != nil {
+		return domain.Clothing{}, err
+	}
+
+	return item, nil
+}
+
+func (b *BoltClothingRepository) Update(ctx context.Context, userId string, clothing domain.Clothing) (domain.Clothing, error) {
+	if strings.TrimSpace(userId) == "" {
+		return domain.Clothing{}, newValidationError("User ID must not be empty or whitespace")
+	}
+
+	if err := clothing.Validate(); err != nil {
+		return domain.Clothing{}, &ValidationError{Err: err}
+	}
+
+	if strings.TrimSpace(clothing.Id) == "" {
+		return domain.Clothing{}, newValidationError("cannot update clothing without ID")
+	}
+
+	if clothing.UserId != "" && clothing.UserId != userId {
+		return domain.Clothing{}, newValidationError("Mismatch of user ID")
+	}
+
+	err := b.db.Update(func(tx *bbolt.Tx) error {
+		bucket := userBucket(tx, userId)
+		stored, exists, err := getBoltItem(bucket, clothing.Id)
+
+		if err != nil {
+			return err
+		}
+
+		if !exists || stored.DeletedAt != nil {
+			return newNotFoundError("No item exists for id %s", clothing.Id)
+		}
+
+		if clothing.Version != 0 && clothing.Version != stored.Version {
+			return newPreconditionFailedError("Item with id %s is at version %d, not %d", clothing.Id, stored.Version, clothing.Version)
+		}
+
+		clothing.UserId = userId
+		clothing.Version = stored.Version + 1
+		clothing.DeletedAt = nil
+		clothing.WearCount = stored.WearCount
+		clothing.LastWornAt = stored.LastWornAt
+
+		return putBoltItem(bucket, clothing)
+	})
+
+	if err != nil {
+		return domain.Clothing{}, err
+	}
+
+	return clothing, nil
+}
+
+func (b *BoltClothingRepository) Exists(ctx context.Context, userId, id string) (bool, error) {
+	if strings.TrimSpace(userId) == "" {
+		return false, newValidationError("User ID must not be empty or whitespace")
+	}
+
+	if strings.TrimSpace(id) == "" {
+		return false, newValidationError("ID cannot be empty or whitespace")
+	}
+
+	exists := false
+
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		stored, found, err := getBoltItem(userBucket(tx, userId), id)
+		exists = found && stored.DeletedAt == nil
+		return err
+	})
+
+	return exists, err
+}
+
+func (b *BoltClothingRepository) Trash(ctx context.Context, userId, id string, expectedVersion int64) (domain.Clothing, error) {
+	return b.moveTrash(userId, id, expectedVersion, true)
+}
+
+func (b *BoltClothingRepository) TrashMany(ctx context.Context, userId string, ids []string) ([]domain.Clothing, error) {
+	unique, err := uniqueIds(userId, ids)
+
+	if err != nil {
+		return nil, err
+	}
+
+	trashed := []domain.Clothing{}
+
+	err = b.db.Update(func(tx *bbolt.Tx) error {
+		bucket := userBucket(tx, userId)
+
+		if bucket == nil {
+			return nil
+		}
+
+		deletedAt := trashedNow()
+
+		for _, id := range unique {
+			stored, exists, err := getBoltItem(bucket, id)
+
+			if err != nil {
+				return err
+			}
+
+			if !exists || stored.DeletedAt != nil {
+				continue
+			}
+
+			stored.DeletedAt = deletedAt
+			stored.Version++
+
+			if err := putBoltItem(bucket, stored); err != nil {
+				return err
+			}
+
+			trashed = append(trashed, stored)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return trashed, nil
+}
+
+func (b *BoltClothingRepository) Restore(ctx context.Context, userId, id string, expectedVersion int64) (domain.Clothing, error) {
+	return b.moveTrash(userId, id, expectedVersion, false)
+}
+
+// moveTrash moves a live item into the trash when trash is true, or a trashed item out of it when false.
+func (b *BoltClothingRepository) moveTrash(userId, id string, expectedVersion int64, trash bool) (domain.Clothing, error) {
+	if err := validateTrashArgs(userId, id); err != nil {
+		return domain.Clothing{}, err
+	}
+
+	var item domain.Clothing
+
+	err := b.db.Update(func(tx *bbolt.Tx) error {
+		bucket := userBucket(tx, userId)
+		stored, exists, err := getBoltItem(bucket, id)
+
+		if err != nil {
+			return err
+		}
+
+		if !exists || (stored.DeletedAt == nil) != trash {
+			return newNotFoundError("No item exists for id %s", id)
+		}
+
+		if expectedVersion != 0 && expectedVersion != stored.Version {
+			return newPreconditionFailedError("Item with id %s is at version %d, not %d", id, stored.Version, expectedVersion)
+		}
+
+		stored.DeletedAt = nil
+
+		if trash {
+			stored.DeletedAt = trashedNow()
+		}
+
+		stored.Version++
+		item = stored
+
+		return putBoltItem(bucket, stored)
+	})
+
+	if err != nil {
+		return domain.Clothing{}, err
+	}
+
+	return item, nil
+}
+
+func (b *BoltClothingRepository) PurgeTrash(ctx context.Context, userId string, trashedBefore time.Time) ([]string, error) {
+	if strings.TrimSpace(userId) == "" {
+		return nil, newValidationError("User ID must not be empty or whitespace")
+	}
+
+	purged := []string{}
+
+	err := b.db.Update(func(tx *bbolt.Tx) error {
+		items, err := purgeBoltBucket(userBucket(tx, userId), trashedBefore)
+
+		for _, item := range items {
+			purged = append(purged, item.Id)
+		}
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return purged, nil
+}
+
+func (b *BoltClothingRepository) PurgeExpiredTrash(ctx context.Context, trashedBefore time.Time) ([]domain.Clothing, error) {
+	purged := []domain.Clothing{}
+
+	err := b.db.Update(func(tx *bbolt.Tx) error {
+		root := tx.Bucket(clothingBucket)
+
+		return root.ForEachBucket(func(userId []byte) error {
+			items, err := purgeBoltBucket(root.Bucket(userId), trashedBefore)
+			purged = append(purged, items...)
+			return err
+		})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return purged, nil
+}
+
+func (b *BoltClothingRepository) RecordWear(ctx context.Context, userId, id string, wornAt time.Time) (domain.Clothing, error) {
+	wear, err := validateWear(userId, id, wornAt)
+
+	if err != nil {
+		return domain.Clothing{}, err
+	}
+
+	var item domain.Clothing
+
+	// One transaction, so the count and the log cannot disagree.
+	err = b.db.Update(func(tx *bbolt.Tx) error {
+		bucket := userBucket(tx, userId)
+		stored, exists, err := getBoltItem(bucket, id)
+
+		if err != nil {
+			return err
+		}
+
+		if !exists || stored.DeletedAt != nil {
+			return newNotFoundError("No item exists for id %s", id)
+		}
+
+		item = applyWear(stored, wear)
+
+		if err := putBoltItem(bucket, item); err != nil {
+			return err
+		}
+
+		wears, err := tx.Bucket(wearBucket).CreateBucketIfNotExists([]byte(userId))
+
+		if err != nil {
+			return fmt.Errorf("failed to create wear bucket for user %s: %w", userId, err)
+		}
+
+		raw, err := json.Marshal(wear)
+
+		if err != nil {
+			return fmt.Errorf("failed to encode wear of item %s: %w", id, err)
+		}
+
+		return wears.Put([]byte(wearKey(id, wear)), raw)
+	})
+
+	if err != nil {
+		return domain.Clothing{}, err
+	}
+
+	return item, nil
+}
+
+func (b *BoltClothingRepository) ListWears(ctx context.Context, userId, id string) ([]domain.Wear, error) {
+	if err := validateTrashArgs(userId, id); err != nil {
+		return []domain.Wear{}, err
+	}
+
+	wears := []domain.Wear{}
+
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(wearBucket).Bucket([]byte(userId))
+
+		if bucket == nil {
+			return nil
+		}
+
+		prefix := []byte(id + "#")
+		cursor := bucket.Cursor()
+
+		for key, raw := cursor.Seek(prefix); key != nil && bytes.HasPrefix(key, prefix); key, raw = cursor.Next() {
+			var wear domain.Wear
+
+			if err := json.Unmarshal(raw, &wear); err != nil {
+				return fmt.Errorf("failed to decode wear %s: %w", key, err)
+			}
+
+			wears = append(wears, wear)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return []domain.Wear{}, err
+	}
+
+	return wears, nil
+}
+
+// purgeBoltBucket deletes the items in a user's bucket that were trashed at or before trashedBefore.
+func purgeBoltBucket(bucket *bbolt.Bucket, trashedBefore time.Time) ([]domain.Clothing, error) {
+	if bucket == nil {
+		return nil, nil
+	}
+
+	var purged []domain.Clothing
+
+	// bbolt does not allow deleting while iterating with ForEach, so the keys are deleted afterwards.
+	err := bucket.ForEach(func(key, raw []byte) error {
+		var item domain.Clothing
+
+		if err := json.Unmarshal(raw, &item); err != nil {
+			return fmt.Errorf("failed to decode item %s: %w", key, err)
+		}
+
+		if item.DeletedAt != nil && !item.DeletedAt.After(trashedBefore) {
+			purged = append(purged, item)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range purged {
+		if err := bucket.Delete([]byte(item.Id)); err != nil {
+			return nil, fmt.Errorf("failed to delete item %s: %w", item.Id, err)
+		}
+	}
+
+	return purged, nil
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"clothes_management/internal/domain"
+	"context"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+func openBoltDatabase(t *testing.T, path string) *bbolt.DB {
+	t.Helper()
+
+	db, err := bbolt.Open(path, 0o600, &bbolt.Options{Timeout: time.Second})
+	if err != nil {
+		t.Fatalf("Failed to open bbolt database: %v", err)
+	}
+
+	return db
+}
+
+func newBoltTestRepository(t *testing.T) *BoltClothingRepository {
+	t.Helper()
+
+	db := openBoltDatabase(t, filepath.Join(t.TempDir(), "clothes.db"))
+	t.Cleanup(func() { db.Close() })
+
+	repo, err := NewBoltClothingRepository(db)
+	if err != nil {
+		t.Fatalf("Expected no err on NewBoltClothingRepository, got %v", err)
+	}
+
+	return repo
+}
+
+func TestBoltClothingRepository(t *testing.T) {
+	jumper := domain.Clothing{
+		ClothingType: "Jumper",
+		Description:  "This Jumper",
+		Store:        "This Store",
+		Size:         "L",
+		Brand:        "XYZ",
+		Price:        2000,
+	}
+
+	t.Run("Given a nil db, constructor should return an error", func(t *testing.T) {
+		if _, err := NewBoltClothingRepository(nil); err == nil {
+			t.Errorf("Expected an error for a nil db")
+		}
+	})
+
+	t.Run("Given saved, updated and deleted items, should keep the latest state after the file is reopened", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "clothes.db")
+		db := openBoltDatabase(t, path)
+
+		repo, err := NewBoltClothingRepository(db)
+		if err != nil {
+			t.Fatalf("Expected no err on NewBoltClothingRepository, got %v", err)
+		}
+
+		kept, _ := repo.Save(context.Background(), "test-user-id", jumper)
+		deleted, _ := repo.Save(context.Background(), "test-user-id", jumper)
+
+		kept.Price = 1500
+		kept, err = repo.Update(context.Background(), "test-user-id", kept)
+		if err != nil {
+			t.Fatalf("Expected no error on Update, got %v", err)
+		}
+
+		if _, err := repo.Trash(context.Background(), "test-user-id", deleted.Id, 0); err != nil {
+			t.Fatalf("Expected no error on Trash, got %v", err)
+		}
+
+		db.Close()
+
+		db = openBoltDatabase(t, path)
+		defer db.Close()
+
+		reopened, err := NewBoltClothingRepository(db)
+		if err != nil {
+			t.Fatalf("Expected no err on NewBoltClothingRepository, got %v", err)
+		}
+
+		items, err := reopened.GetAll(context.Background(), "test-user-id")
+
+		if err != nil {
+			t.Fatalf("Expected no error on GetAll, got %v", err)
+		}
+
+		if len(items) != 1 || !reflect.DeepEqual(items[0], kept) {
+			t.Errorf("Expected only %+v, got %+v", kept, items)
+		}
+	})
+}
+
+func TestBoltCatalogRepository(t *testing.T) {
+	t.Run("Given registered values, should de-duplicate them and keep them after the file is reopened", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "clothes.db")
+		db := openBoltDatabase(t, path)
+
+		catalog, err := NewBoltCatalogRepository(db)
+		if err != nil {
+			t.Fatalf("Expected no err on NewBoltCatalogRepository, got %v", err)
+		}
+
+		for _, value := range []string{"XYZ", "xyz", "Abc"} {
+			if err := catalog.Register(context.Background(), CatalogBrands, value); err != nil {
+				t.Fatalf("Expected no error registering %q, got %v", value, err)
+			}
+		}
+
+		db.Close()
+
+		db = openBoltDatabase(t, path)
+		defer db.Close()
+
+		catalog, _ = NewBoltCatalogRepository(db)
+		values, err := catalog.List(context.Background(), CatalogBrands)
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if !slices.Equal(values, []string{"Abc", "XYZ"}) {
+			t.Errorf("Expected [Abc XYZ], got %v", values)
+		}
+	})
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"bytes"
+	"clothes_management/internal/domain"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go.etcd.io/bbolt"
+)
+
+// historyBucket holds a nested bucket per user mapping history keys to JSON entries, so an item's
+// entries are adjacent and in time order.
+var historyBucket = []byte("history")
+
+type BoltHistoryRepository struct {
+	db *bbolt.DB
+}
+
+func NewBoltHistoryRepository(db *bbolt.DB) (*BoltHistoryRepository, error) {
+	if db == nil {
+		return nil, fmt.Errorf("db should not be nil")
+	}
+
+	err := db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(historyBucket)
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create history bucket: %w", err)
+	}
+
+	return &BoltHistoryRepository{db: db}, nil
+}
+
+func (b *BoltHistoryRepository) Append(ctx context.Context, entries ...domain.HistoryEntry) error {
+	if err := validateHistoryEntries(entries); err != nil {
+		return err
+	}
+
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		for _, entry := range entries {
+			bucket, err := tx.Bucket(historyBucket).CreateBucketIfNotExists([]byte(entry.UserId))
+
+			if err != nil {
+				return fmt.Errorf("failed to create history bucket for user %s: %w", entry.UserId, err)
+			}
+
+			value, err := json.Marshal(entry)
+
+			if err != nil {
+				return fmt.Errorf("failed to marshal history entry: %w", err)
+			}
+
+			if err := bucket.Put([]byte(historyKey(entry)), value); err != nil {
+				return fmt.Errorf("failed to put history entry: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (b *BoltHistoryRepository) List(ctx context.Context, userId, itemId string) ([]domain.HistoryEntry, error) {
+	if err := validateHistoryQuery(userId, itemId); err != nil {
+		return []domain.HistoryEntry{}, err
+	}
+
+	entries := []domain.HistoryEntry{}
+
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(historyBucket).Bucket([]byte(userId))
+
+		if bucket == nil {
+			return nil
+		}
+
+		prefix := []byte(itemId + "#")
+		cursor := bucket.Cursor()
+
+		for key, value := cursor.Seek(prefix); key != nil && bytes.HasPrefix(key, prefix); key, value = cursor.Next() {
+			var entry domain.HistoryEntry
+
+			if err := json.Unmarshal(value, &entry); err != nil {
+				return fmt.Errorf("failed to unmarshal history entry %s: %w", key, err)
+			}
+
+			entries = append(entries, entry)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return []domain.HistoryEntry{}, err
+	}
+
+	return normaliseHistory(entries), nil
+}
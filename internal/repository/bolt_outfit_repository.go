@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"clothes_management/internal/domain"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.etcd.io/bbolt"
+)
+
+// outfitBucket holds a nested bucket per user mapping outfit ids to JSON outfits.
+var outfitBucket = []byte("outfits")
+
+type BoltOutfitRepository struct {
+	db *bbolt.DB
+}
+
+func NewBoltOutfitRepository(db *bbolt.DB) (*BoltOutfitRepository, error) {
+	if db == nil {
+		return nil, fmt.Errorf("db should not be nil")
+	}
+
+	err := db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(outfitBucket)
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create outfit bucket: %w", err)
+	}
+
+	return &BoltOutfitRepository{db: db}, nil
+}
+
+func putBoltOutfit(bucket *bbolt.Bucket, outfit domain.Outfit) error {
+	raw, err := json.Marshal(outfit)
+
+	if err != nil {
+		return fmt.Errorf("failed to encode outfit %s: %w", outfit.Id, err)
+	}
+
+	return bucket.Put([]byte(outfit.Id), raw)
+}
+
+func (b *BoltOutfitRepository) Save(ctx context.Context, userId string, outfit domain.Outfit) (domain.Outfit, error) {
+	if err := validateOutfitWrite(userId, outfit, false); err != nil {
+		return domain.Outfit{}, err
+	}
+
+	outfit.Id = uuid.New().String()
+	outfit.UserId = userId
+
+	err := b.db.Update(func(tx *bbolt.Tx) error {
+		bucket, err := tx.Bucket(outfitBucket).CreateBucketIfNotExists([]byte(userId))
+
+		if err != nil {
+			return fmt.Errorf("failed to create outfit bucket for user: %w", err)
+		}
+
+		return putBoltOutfit(bucket, outfit)
+	})
+
+	if err != nil {
+		return domain.Outfit{}, err
+	}
+
+	return outfit, nil
+}
+
+func (b *BoltOutfitRepository) GetAll(ctx context.Context, userId string) ([]domain.Outfit, error) {
+	if strings.TrimSpace(userId) == "" {
+		return []domain.Outfit{}, newValidationError("User ID must not be empty or whitespace")
+	}
+
+	outfits := []domain.Outfit{}
+
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(outfitBucket).Bucket([]byte(userId))
+
+		if bucket == nil {
+			return nil
+		}
+
+		return bucket.ForEach(func(key, raw []byte) error {
+			var outfit domain.Outfit
+
+			if err := json.Unmarshal(raw, &outfit); err != nil {
+				return fmt.Errorf("failed to decode outfit %s: %w", key, err)
+			}
+
+			outfits = append(outfits, outfit)
+			return nil
+		})
+	})
+
+	if err != nil {
+		return []domain.Outfit{}, err
+	}
+
+	sortOutfits(outfits)
+
+	return outfits, nil
+}
+
+func (b *BoltOutfitRepository) GetById(ctx context.Context, userId, id string) (domain.Outfit, error) {
+	if strings.TrimSpace(userId) == "" {
+		return domain.Outfit{}, newValidationError("User ID must not be empty or whitespace")
+	}
+
+	if strings.TrimSpace(id) == "" {
+		return domain.Outfit{}, newValidationError("ID must not be empty or whitespace")
+	}
+
+	var outfit domain.Outfit
+
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(outfitBucket).Bucket([]byte(userId))
+
+		if bucket == nil || bucket.Get([]byte(id)) == nil {
+			return newNotFoundError("No outfit exists for id %s", id)
+		}
+
+		if err := json.Unmarshal(bucket.Get([]byte(id)), &outfit); err != nil {
+			return fmt.Errorf("failed to decode outfit %s: %w", id, err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return domain.Outfit{}, err
+	}
+
+	return outfit, nil
+}
+
+func (b *BoltOutfitRepository) Update(ctx context.Context, userId string, outfit domain.Outfit) (domain.Outfit, error) {
+	if err := validateOutfitWrite(userId, outfit, true); err != nil {
+		return domain.Outfit{}, err
+	}
+
+	outfit.UserId = userId
+
+	err := b.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(outfitBucket).Bucket([]byte(userId))
+
+		if bucket == nil || bucket.Get([]byte(outfit.Id)) == nil {
+			return newNotFoundError("No outfit exists for id %s", outfit.Id)
+		}
+
+		return putBoltOutfit(bucket, outfit)
+	})
+
+	if err != nil {
+		return domain.Outfit{}, err
+	}
+
+	return outfit, nil
+}
+
+func (b *BoltOutfitRepository) Delete(ctx context.Context, userId, id string) error {
+	if strings.TrimSpace(userId) == "" {
+		return newValidationError("User ID must not be empty or whitespace")
+	}
+
+	if strings.TrimSpace(id) == "" {
+		return newValidationError("ID cannot be empty or whitespace")
+	}
+
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(outfitBucket).Bucket([]byte(userId))
+
+		if bucket == nil || bucket.Get([]byte(id)) == nil {
+			return newNotFoundError("Outfit with id %s does not exist", id)
+		}
+
+		return bucket.Delete([]byte(id))
+	})
+}
+
+func (b *BoltOutfitRepository) ListUsing(ctx context.Context, userId, itemId string) ([]domain.Outfit, error) {
+	if strings.TrimSpace(itemId) == "" {
+		return []domain.Outfit{}, newValidationError("Item ID must not be empty or whitespace")
+	}
+
+	outfits, err := b.GetAll(ctx, userId)
+
+	if err != nil {
+		return []domain.Outfit{}, err
+	}
+
+	return outfitsUsing(outfits, itemId), nil
+}
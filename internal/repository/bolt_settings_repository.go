@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"clothes_management/internal/domain"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"go.etcd.io/bbolt"
+)
+
+// settingsBucket maps user ids to JSON settings.
+var settingsBucket = []byte("settings")
+
+type BoltSettingsRepository struct {
+	db *bbolt.DB
+}
+
+func NewBoltSettingsRepository(db *bbolt.DB) (*BoltSettingsRepository, error) {
+	if db == nil {
+		return nil, fmt.Errorf("db should not be nil")
+	}
+
+	err := db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(settingsBucket)
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create settings bucket: %w", err)
+	}
+
+	return &BoltSettingsRepository{db: db}, nil
+}
+
+func (b *BoltSettingsRepository) Get(ctx context.Context, userId string) (domain.Settings, error) {
+	if strings.TrimSpace(userId) == "" {
+		return domain.Settings{}, newValidationError("User ID must not be empty or whitespace")
+	}
+
+	settings := domain.DefaultSettings(userId)
+
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		raw := tx.Bucket(settingsBucket).Get([]byte(userId))
+
+		if raw == nil {
+			return nil
+		}
+
+		if err := json.Unmarshal(raw, &settings); err != nil {
+			return fmt.Errorf("failed to decode settings for user %s: %w", userId, err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return domain.Settings{}, err
+	}
+
+	return settings, nil
+}
+
+func (b *BoltSettingsRepository) Save(ctx context.Context, userId string, settings domain.Settings) (domain.Settings, error) {
+	if err := validateSettingsWrite(userId, settings); err != nil {
+		return domain.Settings{}, err
+	}
+
+	settings.UserId = userId
+
+	raw, err := json.Marshal(settings)
+
+	if err != nil {
+		return domain.Settings{}, fmt.Errorf("failed to encode settings for user %s: %w", userId, err)
+	}
+
+	err = b.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(settingsBucket).Put([]byte(userId), raw)
+	})
+
+	if err != nil {
+		return domain.Settings{}, err
+	}
+
+	return settings, nil
+}
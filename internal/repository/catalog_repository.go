@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"strings"
+)
+
+// CatalogKind names one of the global lists shared by every user.
+type CatalogKind string
+
+const (
+	CatalogTypes  CatalogKind = "types"
+	CatalogBrands CatalogKind = "brands"
+	CatalogStores CatalogKind = "stores"
+)
+
+var catalogKinds = []CatalogKind{CatalogTypes, CatalogBrands, CatalogStores}
+
+// ParseCatalogKind returns the CatalogKind named by s, or false if there is no such catalog.
+func ParseCatalogKind(s string) (CatalogKind, bool) {
+	kind := CatalogKind(s)
+	return kind, slices.Contains(catalogKinds, kind)
+}
+
+// CatalogRepository stores the global clothing types, brands and stores. Values are de-duplicated
+// case-insensitively and the first spelling registered is the one listed.
+type CatalogRepository interface {
+	Register(ctx context.Context, kind CatalogKind, value string) error
+	List(ctx context.Context, kind CatalogKind) ([]string, error)
+}
+
+// catalogKey is the value entries are de-duplicated on.
+func catalogKey(value string) string {
+	return strings.ToLower(strings.TrimSpace(value))
+}
+
+func validateCatalogEntry(kind CatalogKind, value string) error {
+	if _, ok := ParseCatalogKind(string(kind)); !ok {
+		return newValidationError("Unknown catalog " + string(kind))
+	}
+
+	if strings.TrimSpace(value) == "" {
+		return newValidationError("Catalog value must not be empty or whitespace")
+	}
+
+	return nil
+}
+
+// sortCatalogValues orders values for display, case-insensitively with a byte-wise tie-break.
+func sortCatalogValues(values []string) {
+	slices.SortFunc(values, func(a, b string) int {
+		if c := strings.Compare(strings.ToLower(a), strings.ToLower(b)); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a, b)
+	})
+}
@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"clothes_management/internal/domain"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ClothingFilter narrows a listing down to matching items. Empty string fields and nil prices are
+// ignored. String fields must match exactly and price bounds are inclusive, so every backend can
+// apply the same semantics natively. Trashed lists the items in the trash instead of the live ones.
+//
+// Size is the exception: it matches items of the same size in any region or spelling, as
+// domain.Size.Matches decides for each item's type, so backends apply it in process.
+//
+// Currency matches items priced in it, counting items stored without a currency as priced in
+// domain.DefaultCurrency. Prices are minor units of each item's own currency, so price bounds are only
+// comparable within one currency and must be given with it.
+//
+// Tags, which must be normalised, match items with every one of them, or with any of them when AnyTag
+// is set. Colours match items with any of them as their primary or a secondary colour, or only as their
+// primary colour when PrimaryColour is set. Seasons and Occasions match items for any of them.
+type ClothingFilter struct {
+	ClothingType  string
+	Brand         string
+	Store         string
+	Size          string
+	MinPricePence *domain.Pence
+	MaxPricePence *domain.Pence
+	Currency      domain.Currency
+	Tags          []string
+	AnyTag        bool
+	Colours       []domain.Colour
+	PrimaryColour bool
+	Seasons       []domain.Season
+	Occasions     []domain.Occasion
+	Trashed       bool
+}
+
+func (f ClothingFilter) IsEmpty() bool {
+	return f.ClothingType == "" && f.Brand == "" && f.Store == "" && f.Size == "" &&
+		f.MinPricePence == nil && f.MaxPricePence == nil && f.Currency == "" && len(f.Tags) == 0 && !f.AnyTag &&
+		len(f.Colours) == 0 && !f.PrimaryColour && len(f.Seasons) == 0 && len(f.Occasions) == 0 && !f.Trashed
+}
+
+func (f ClothingFilter) Validate() error {
+	if f.MinPricePence != nil && *f.MinPricePence < 0 {
+		return errors.New("Minimum price must be greater than or equal to 0")
+	}
+
+	if f.MaxPricePence != nil && *f.MaxPricePence < 0 {
+		return errors.New("Maximum price must be greater than or equal to 0")
+	}
+
+	if f.MinPricePence != nil && f.MaxPricePence != nil && *f.MinPricePence > *f.MaxPricePence {
+		return errors.New("Minimum price must not be greater than maximum price")
+	}
+
+	if f.Currency != "" {
+		if parsed, ok := domain.ParseCurrency(string(f.Currency)); !ok || parsed != f.Currency {
+			return fmt.Errorf("Unknown currency %q", f.Currency)
+		}
+	}
+
+	if (f.MinPricePence != nil || f.MaxPricePence != nil) && f.Currency == "" {
+		return errors.New("Price bounds must be given with a currency, as prices in different currencies cannot be compared")
+	}
+
+	if len(f.Tags) > domain.MaxTags {
+		return fmt.Errorf("Must not filter by more than %d tags", domain.MaxTags)
+	}
+
+	if !slices.Equal(f.Tags, domain.NormaliseTags(f.Tags)) {
+		return errors.New("Tags must be lowercase, trimmed, sorted and unique")
+	}
+
+	for i, colour := range f.Colours {
+		if colour.Hex() == "" {
+			return fmt.Errorf("Colour %q must be a palette colour", colour)
+		}
+
+		if slices.Contains(f.Colours[:i], colour) {
+			return fmt.Errorf("Must not filter by colour %s more than once", colour)
+		}
+	}
+
+	for _, season := range f.Seasons {
+		if parsed, ok := domain.ParseSeason(string(season)); !ok || parsed != season {
+			return fmt.Errorf("Unknown season %q", season)
+		}
+	}
+
+	for _, occasion := range f.Occasions {
+		if parsed, ok := domain.ParseOccasion(string(occasion)); !ok || parsed != occasion {
+			return fmt.Errorf("Unknown occasion %q", occasion)
+		}
+	}
+
+	return nil
+}
+
+// Matches is the predicate used by backends that filter in process.
+func (f ClothingFilter) Matches(clothing domain.Clothing) bool {
+	if (clothing.DeletedAt != nil) != f.Trashed {
+		return false
+	}
+
+	if f.ClothingType != "" && clothing.ClothingType != f.ClothingType {
+		return false
+	}
+
+	if f.Brand != "" && clothing.Brand != f.Brand {
+		return false
+	}
+
+	if f.Store != "" && clothing.Store != f.Store {
+		return false
+	}
+
+	if f.Size != "" && !clothing.ParsedSize().Matches(domain.ParseSize(f.Size, clothing.ClothingType)) {
+		return false
+	}
+
+	if f.Currency != "" && clothing.PriceMoney().Currency != f.Currency {
+		return false
+	}
+
+	if f.MinPricePence != nil && clothing.Price < *f.MinPricePence {
+		return false
+	}
+
+	if f.MaxPricePence != nil && clothing.Price > *f.MaxPricePence {
+		return false
+	}
+
+	if len(f.Tags) > 0 {
+		has := func(tag string) bool { return slices.Contains(clothing.Tags, tag) }
+
+		if f.AnyTag && !slices.ContainsFunc(f.Tags, has) {
+			return false
+		}
+
+		if !f.AnyTag && slices.ContainsFunc(f.Tags, func(tag string) bool { return !has(tag) }) {
+			return false
+		}
+	}
+
+	if len(f.Seasons) > 0 && !slices.ContainsFunc(f.Seasons, clothing.Suits) {
+		return false
+	}
+
+	if len(f.Occasions) > 0 && !slices.ContainsFunc(f.Occasions, func(occasion domain.Occasion) bool { return slices.Contains(clothing.Occasions, occasion) }) {
+		return false
+	}
+
+	if len(f.Colours) > 0 {
+		has := clothing.HasColour
+
+		if f.PrimaryColour {
+			has = func(colour domain.Colour) bool { return clothing.Colour == colour }
+		}
+
+		if !slices.ContainsFunc(f.Colours, has) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"clothes_management/internal/domain"
+	"testing"
+)
+
+func pencePointer(p domain.Pence) *domain.Pence {
+	return &p
+}
+
+func TestClothingFilterMatches(t *testing.T) {
+	item := domain.Clothing{
+		ClothingType: "Jumper",
+		Description:  "Red Loosefit Jumper",
+		Brand:        "A&B",
+		Store:        "Totally Real Store",
+		Size:         "Medium",
+		Price:        2000,
+	}
+
+	t.Run("Given an empty filter, should match", func(t *testing.T) {
+		if !(ClothingFilter{}).Matches(item) {
+			t.Error("Expected empty filter to match")
+		}
+	})
+
+	t.Run("Given matching attributes and an inclusive price range, should match", func(t *testing.T) {
+		filter := ClothingFilter{
+			ClothingType:  "Jumper",
+			Brand:         "A&B",
+			Store:         "Totally Real Store",
+			Size:          "Medium",
+			MinPricePence: pencePointer(2000),
+			MaxPricePence: pencePointer(2000),
+			Currency:      domain.DefaultCurrency,
+		}
+
+		if !filter.Matches(item) {
+			t.Error("Expected filter to match")
+		}
+	})
+
+	t.Run("Given any attribute differs, should not match", func(t *testing.T) {
+		filters := []ClothingFilter{
+			{ClothingType: "Shirt"},
+			{Brand: "a&b"},
+			{Store: "Other Store"},
+			{Size: "Small"},
+		}
+
+		for _, filter := range filters {
+			if filter.Matches(item) {
+				t.Errorf("Expected %+v not to match", filter)
+			}
+		}
+	})
+
+	t.Run("Given price outside the range, should not match", func(t *testing.T) {
+		if (ClothingFilter{MinPricePence: pencePointer(2001), Currency: domain.DefaultCurrency}).Matches(item) {
+			t.Error("Expected minimum price to exclude item")
+		}
+
+		if (ClothingFilter{MaxPricePence: pencePointer(1999), Currency: domain.DefaultCurrency}).Matches(item) {
+			t.Error("Expected maximum price to exclude item")
+		}
+	})
+
+	t.Run("Given a currency, should match only items priced in it", func(t *testing.T) {
+		yen := item
+		yen.Currency = "JPY"
+
+		if !(ClothingFilter{Currency: "JPY", MaxPricePence: pencePointer(2000)}).Matches(yen) {
+			t.Error("Expected the yen item to match in JPY")
+		}
+
+		if (ClothingFilter{Currency: domain.DefaultCurrency, MaxPricePence: pencePointer(2000)}).Matches(yen) {
+			t.Error("Expected the yen item not to match in the default currency")
+		}
+
+		if !(ClothingFilter{Currency: domain.DefaultCurrency}).Matches(item) {
+			t.Error("Expected an item without a currency to match the default currency")
+		}
+	})
+
+	t.Run("Given tags, should match items with all of them, or any of them with AnyTag", func(t *testing.T) {
+		tagged := item
+		tagged.Tags = []string{"summer", "work"}
+
+		if !(ClothingFilter{Tags: []string{"summer", "work"}}).Matches(tagged) {
+			t.Error("Expected all of the item's tags to match")
+		}
+
+		if (ClothingFilter{Tags: []string{"winter", "work"}}).Matches(tagged) {
+			t.Error("Expected a tag the item lacks to exclude it")
+		}
+
+		if !(ClothingFilter{Tags: []string{"winter", "work"}, AnyTag: true}).Matches(tagged) {
+			t.Error("Expected any of the tags to match with AnyTag")
+		}
+
+		if (ClothingFilter{Tags: []string{"winter"}, AnyTag: true}).Matches(tagged) {
+			t.Error("Expected no shared tag to exclude the item with AnyTag")
+		}
+	})
+}
+
+func TestClothingFilterValidate(t *testing.T) {
+	t.Run("Given a valid range, should return nil", func(t *testing.T) {
+		filter := ClothingFilter{MinPricePence: pencePointer(0), MaxPricePence: pencePointer(100), Currency: "JPY"}
+
+		if err := filter.Validate(); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+
+	t.Run("Given an invalid range, should return an error", func(t *testing.T) {
+		filters := []ClothingFilter{
+			{MinPricePence: pencePointer(-1)},
+			{MaxPricePence: pencePointer(-1)},
+			{MinPricePence: pencePointer(200), MaxPricePence: pencePointer(100)},
+			{MinPricePence: pencePointer(100)},
+			{Currency: "XXX"},
+			{Tags: []string{"Work"}},
+			{Tags: []string{"work", "summer"}},
+		}
+
+		for _, filter := range filters {
+			if err := filter.Validate(); err == nil {
+				t.Errorf("Expected error for %+v", filter)
+			}
+		}
+	})
+}
@@ -2,13 +2,188 @@ package repository
 
 import (
 	"clothes_management/internal/domain"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultPageLimit = 25
+	MaxPageLimit     = 100
 )
 
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// PageRequest asks for at most Limit items matching Filter, ordered by Sort, starting after the position
+// encoded in Cursor. An empty Cursor starts from the beginning, a Limit <= 0 uses DefaultPageLimit, and an
+// empty Sort orders by Id. A cursor is only valid with the Sort it was issued for.
+type PageRequest struct {
+	Limit  int
+	Cursor string
+	Filter ClothingFilter
+	Sort   []SortKey
+}
+
+// Page is a single page of results. NextCursor is empty when there are no further pages.
+type Page struct {
+	Items      []domain.Clothing
+	NextCursor string
+}
+
+// ClothingRepository stores each user's clothing. Every write sets Version: Save stores version 1 and
+// Update increments it. Update takes its expected version from the item and fails with
+// ErrPreconditionFailed when the stored item has moved on; an expected version of 0 skips the check.
+// SaveMany stores every item as Save would, or none of them when any item fails validation.
+//
+// Trash moves an item to the trash by setting DeletedAt, and Restore clears it again; both bump the
+// version and check it as Update does. TrashMany trashes whichever of the ids are live items, without a
+// version check, and returns those it trashed in the order given. Trashed items are only seen by GetPage
+// with Filter.Trashed, Restore and the purges, which delete trashed items permanently once they were
+// trashed at or before the given time. Every other method treats them as missing. Items are only ever
+// deleted from the trash.
+//
+// RecordWear logs that a live item was worn at wornAt, adding it to WearCount and moving LastWornAt on
+// when it is later. It bumps the version without checking it, so wears logged from several devices
+// never conflict, while Update keeps the stored WearCount and LastWornAt whatever it is given. ListWears
+// returns an item's logged wears ordered by WornAt, then in the order they were logged; the log is left
+// behind when the item is deleted.
 type ClothingRepository interface {
-	Save(userId string, clothing domain.Clothing) (domain.Clothing, error)
-	GetAll(userId string) ([]domain.Clothing, error)
-	GetById(userId, id string) (domain.Clothing, error)
-	Update(userId string, clothing domain.Clothing) (domain.Clothing, error)
-	Delete(userId, id string) error
-	Exists(userId, id string) (bool, error)
+	Save(ctx context.Context, userId string, clothing domain.Clothing) (domain.Clothing, error)
+	SaveMany(ctx context.Context, userId string, clothing []domain.Clothing) ([]domain.Clothing, error)
+	GetAll(ctx context.Context, userId string) ([]domain.Clothing, error)
+	GetPage(ctx context.Context, userId string, page PageRequest) (Page, error)
+	GetById(ctx context.Context, userId, id string) (domain.Clothing, error)
+	Update(ctx context.Context, userId string, clothing domain.Clothing) (domain.Clothing, error)
+	Exists(ctx context.Context, userId, id string) (bool, error)
+	Trash(ctx context.Context, userId, id string, expectedVersion int64) (domain.Clothing, error)
+	TrashMany(ctx context.Context, userId string, ids []string) ([]domain.Clothing, error)
+	Restore(ctx context.Context, userId, id string, expectedVersion int64) (domain.Clothing, error)
+	PurgeTrash(ctx context.Context, userId string, trashedBefore time.Time) ([]string, error)
+	PurgeExpiredTrash(ctx context.Context, trashedBefore time.Time) ([]domain.Clothing, error)
+	RecordWear(ctx context.Context, userId, id string, wornAt time.Time) (domain.Clothing, error)
+	ListWears(ctx context.Context, userId, id string) ([]domain.Wear, error)
+}
+
+func (p PageRequest) limit() int {
+	if p.Limit <= 0 {
+		return DefaultPageLimit
+	}
+
+	if p.Limit > MaxPageLimit {
+		return MaxPageLimit
+	}
+
+	return p.Limit
+}
+
+// prepareNewItems validates items for SaveMany and returns copies carrying a generated id, the user id,
+// version 1 and no wears, in the same order. Callers must not write anything when it returns an error.
+func prepareNewItems(userId string, items []domain.Clothing) ([]domain.Clothing, error) {
+	if strings.TrimSpace(userId) == "" {
+		return nil, newValidationError("User ID must not be empty or whitespace")
+	}
+
+	prepared := make([]domain.Clothing, len(items))
+
+	for i, item := range items {
+		if err := item.Validate(); err != nil {
+			return nil, &ValidationError{Err: fmt.Errorf("item %d: %w", i, err)}
+		}
+
+		item.Id = uuid.New().String()
+		item.UserId = userId
+		item.Version = 1
+		item.DeletedAt = nil
+		item.WearCount = 0
+		item.LastWornAt = nil
+		prepared[i] = item
+	}
+
+	return prepared, nil
+}
+
+// trashedNow returns the time an item trashed now is stamped with. It is truncated to the second, as
+// that is all DynamoDB and SQL store.
+func trashedNow() *time.Time {
+	now := time.Now().UTC().Truncate(time.Second)
+	return &now
+}
+
+// validateTrashArgs validates the user and item ids taken by Trash, Restore and the wear methods.
+func validateTrashArgs(userId, id string) error {
+	if strings.TrimSpace(userId) == "" {
+		return newValidationError("User ID must not be empty or whitespace")
+	}
+
+	if strings.TrimSpace(id) == "" {
+		return newValidationError("ID cannot be empty or whitespace")
+	}
+
+	return nil
+}
+
+// uniqueIds validates ids for TrashMany and returns them trimmed and without duplicates, in the order
+// they were first given.
+func uniqueIds(userId string, ids []string) ([]string, error) {
+	if strings.TrimSpace(userId) == "" {
+		return nil, newValidationError("User ID must not be empty or whitespace")
+	}
+
+	unique := make([]string, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+
+	for i, id := range ids {
+		id = strings.TrimSpace(id)
+
+		if id == "" {
+			return nil, newValidationError(fmt.Sprintf("ID %d cannot be empty or whitespace", i))
+		}
+
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	return unique, nil
+}
+
+// validateWear validates the arguments of RecordWear and returns the wear it logs: worn at wornAt in UTC,
+// to the second, and recorded now.
+func validateWear(userId, id string, wornAt time.Time) (domain.Wear, error) {
+	if err := validateTrashArgs(userId, id); err != nil {
+		return domain.Wear{}, err
+	}
+
+	if wornAt.IsZero() {
+		return domain.Wear{}, newValidationError("Worn at time must be given")
+	}
+
+	return domain.Wear{
+		WornAt:     wornAt.UTC().Truncate(time.Second),
+		RecordedAt: time.Now().UTC().Truncate(time.Second),
+	}, nil
+}
+
+// applyWear counts wear against item as RecordWear stores it, bumping the version.
+func applyWear(item domain.Clothing, wear domain.Wear) domain.Clothing {
+	item.WearCount++
+	item.Version++
+
+	if item.LastWornAt == nil || wear.WornAt.After(*item.LastWornAt) {
+		wornAt := wear.WornAt
+		item.LastWornAt = &wornAt
+	}
+
+	return item
+}
+
+// wearKey builds a new, unique key ordering an item's wears by WornAt. Like historyKey it ends in a
+// version 7 uuid, so wears of the same time keep the order they were logged.
+func wearKey(itemId string, wear domain.Wear) string {
+	return fmt.Sprintf("%s#%s#%s", itemId, wear.WornAt.UTC().Format(historyTimeFormat), uuid.Must(uuid.NewV7()))
 }
@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"clothes_management/internal/domain"
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type SortField string
+
+const (
+	SortByClothingType SortField = "clothingType"
+	SortByDescription  SortField = "description"
+	SortByBrand        SortField = "brand"
+	SortByStore        SortField = "store"
+	SortBySize         SortField = "size"
+	SortByPrice        SortField = "price"
+)
+
+var sortFields = []SortField{
+	SortByClothingType,
+	SortByDescription,
+	SortByBrand,
+	SortByStore,
+	SortBySize,
+	SortByPrice,
+}
+
+// SortKey orders a listing by one field. Keys are applied in order, and Id is always the final
+// tie-breaker so every ordering is total and repeatable.
+type SortKey struct {
+	Field      SortField
+	Descending bool
+}
+
+func (k SortKey) Validate() error {
+	if !slices.Contains(sortFields, k.Field) {
+		return fmt.Errorf("Unknown sort field '%s'", k.Field)
+	}
+
+	return nil
+}
+
+func (k SortKey) String() string {
+	if k.Descending {
+		return "-" + string(k.Field)
+	}
+
+	return string(k.Field)
+}
+
+// compareClothing orders two items by the sort keys, then by Id. Text compares case-insensitively
+// first so that "adidas" and "Adidas" sit together, falling back to a byte comparison to stay total.
+func compareClothing(a, b domain.Clothing, keys []SortKey) int {
+	for _, key := range keys {
+		var result int
+
+		switch key.Field {
+		case SortByClothingType:
+			result = compareText(a.ClothingType, b.ClothingType)
+		case SortByDescription:
+			result = compareText(a.Description, b.Description)
+		case SortByBrand:
+			result = compareText(a.Brand, b.Brand)
+		case SortByStore:
+			result = compareText(a.Store, b.Store)
+		case SortBySize:
+			result = compareText(a.Size, b.Size)
+		case SortByPrice:
+			// Amounts in different currencies cannot be compared, so items are grouped by currency.
+			result = cmp.Or(cmp.Compare(a.PriceMoney().Currency, b.PriceMoney().Currency), cmp.Compare(a.Price, b.Price))
+		}
+
+		if key.Descending {
+			result = -result
+		}
+
+		if result != 0 {
+			return result
+		}
+	}
+
+	return cmp.Compare(a.Id, b.Id)
+}
+
+func compareText(a, b string) int {
+	if result := cmp.Compare(strings.ToLower(a), strings.ToLower(b)); result != 0 {
+		return result
+	}
+
+	return cmp.Compare(a, b)
+}
+
+func sortSpec(keys []SortKey) string {
+	parts := make([]string, 0, len(keys))
+
+	for _, key := range keys {
+		parts = append(parts, key.String())
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// sortCursor is a keyset position: the sort values of the last item returned. Resuming from the
+// values rather than an offset means items added or removed before the cursor do not shift pages.
+type sortCursor struct {
+	Sort         string          `json:"s,omitempty"`
+	Id           string          `json:"id"`
+	ClothingType string          `json:"t,omitempty"`
+	Description  string          `json:"d,omitempty"`
+	Brand        string          `json:"b,omitempty"`
+	Store        string          `json:"st,omitempty"`
+	Size         string          `json:"sz,omitempty"`
+	Price        domain.Pence    `json:"p,omitempty"`
+	Currency     domain.Currency `json:"c,omitempty"`
+}
+
+func newSortCursor(last domain.Clothing, keys []SortKey) sortCursor {
+	position := sortCursor{Sort: sortSpec(keys), Id: last.Id}
+
+	for _, key := range keys {
+		switch key.Field {
+		case SortByClothingType:
+			position.ClothingType = last.ClothingType
+		case SortByDescription:
+			position.Description = last.Description
+		case SortByBrand:
+			position.Brand = last.Brand
+		case SortByStore:
+			position.Store = last.Store
+		case SortBySize:
+			position.Size = last.Size
+		case SortByPrice:
+			position.Price = last.Price
+			position.Currency = last.PriceMoney().Currency
+		}
+	}
+
+	return position
+}
+
+func (c sortCursor) clothing() domain.Clothing {
+	return domain.Clothing{
+		Id:           c.Id,
+		ClothingType: c.ClothingType,
+		Description:  c.Description,
+		Brand:        c.Brand,
+		Store:        c.Store,
+		Size:         c.Size,
+		Price:        c.Price,
+		Currency:     c.Currency,
+	}
+}
+
+// sortedPage sorts items that have already been filtered and cuts out the page after the cursor.
+// It is shared by every backend that has to order results in process.
+func sortedPage(items []domain.Clothing, page PageRequest) (Page, error) {
+	for _, key := range page.Sort {
+		if err := key.Validate(); err != nil {
+			return Page{Items: []domain.Clothing{}}, &ValidationError{Err: err}
+		}
+	}
+
+	slices.SortFunc(items, func(a, b domain.Clothing) int {
+		return compareClothing(a, b, page.Sort)
+	})
+
+	start := 0
+
+	if page.Cursor != "" {
+		var position sortCursor
+
+		if err := decodeCursor(page.Cursor, &position); err != nil {
+			return Page{Items: []domain.Clothing{}}, err
+		}
+
+		if position.Sort != sortSpec(page.Sort) || position.Id == "" {
+			return Page{Items: []domain.Clothing{}}, fmt.Errorf("%w: cursor was issued for a different sort order", ErrInvalidCursor)
+		}
+
+		after := position.clothing()
+
+		start, _ = slices.BinarySearchFunc(items, after, func(item, target domain.Clothing) int {
+			if compareClothing(item, target, page.Sort) <= 0 {
+				return -1
+			}
+
+			return 1
+		})
+	}
+
+	limit := page.limit()
+	end := min(start+limit, len(items))
+
+	result := Page{Items: append([]domain.Clothing{}, items[start:end]...)}
+
+	if end < len(items) {
+		cursor, err := encodeCursor(newSortCursor(items[end-1], page.Sort))
+
+		if err != nil {
+			return Page{Items: []domain.Clothing{}}, err
+		}
+
+		result.NextCursor = cursor
+	}
+
+	return result, nil
+}
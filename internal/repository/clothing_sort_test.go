@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"clothes_management/internal/domain"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func sortTestItems() []domain.Clothing {
+	return []domain.Clothing{
+		{Id: "id-1", Brand: "b", Description: "Shirt", Price: 2000},
+		{Id: "id-2", Brand: "A", Description: "Jeans", Price: 1000},
+		{Id: "id-3", Brand: "a", Description: "Jumper", Price: 2000},
+		{Id: "id-4", Brand: "C", Description: "Coat", Price: 1000},
+		{Id: "id-5", Brand: "b", Description: "Socks", Price: 500},
+	}
+}
+
+func ids(items []domain.Clothing) []string {
+	result := []string{}
+
+	for _, item := range items {
+		result = append(result, item.Id)
+	}
+
+	return result
+}
+
+func TestSortKeyValidate(t *testing.T) {
+	t.Run("Given a known field, should return nil", func(t *testing.T) {
+		if err := (SortKey{Field: SortByPrice, Descending: true}).Validate(); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+
+	t.Run("Given an unknown field, should return an error", func(t *testing.T) {
+		if err := (SortKey{Field: "colour"}).Validate(); err == nil {
+			t.Error("Expected an error, got nil")
+		}
+	})
+}
+
+func TestSortedPage(t *testing.T) {
+	t.Run("Given no sort keys, should order by Id", func(t *testing.T) {
+		items := sortTestItems()
+		slices.Reverse(items)
+
+		page, err := sortedPage(items, PageRequest{})
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		expected := []string{"id-1", "id-2", "id-3", "id-4", "id-5"}
+
+		if !slices.Equal(ids(page.Items), expected) {
+			t.Errorf("Expected %v got %v", expected, ids(page.Items))
+		}
+	})
+
+	t.Run("Given several sort keys, should apply them in order with Id as the tie-breaker", func(t *testing.T) {
+		page, err := sortedPage(sortTestItems(), PageRequest{
+			Sort: []SortKey{{Field: SortByPrice}, {Field: SortByBrand, Descending: true}},
+		})
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		expected := []string{"id-5", "id-4", "id-2", "id-1", "id-3"}
+
+		if !slices.Equal(ids(page.Items), expected) {
+			t.Errorf("Expected %v got %v", expected, ids(page.Items))
+		}
+	})
+
+	t.Run("Given a limit, should page through the sorted items without skipping or repeating", func(t *testing.T) {
+		request := PageRequest{Limit: 2, Sort: []SortKey{{Field: SortByDescription, Descending: true}}}
+
+		var seen []string
+
+		for {
+			page, err := sortedPage(sortTestItems(), request)
+
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			seen = append(seen, ids(page.Items)...)
+
+			if page.NextCursor == "" {
+				break
+			}
+
+			request.Cursor = page.NextCursor
+		}
+
+		expected := []string{"id-5", "id-1", "id-3", "id-2", "id-4"}
+
+		if !slices.Equal(seen, expected) {
+			t.Errorf("Expected %v got %v", expected, seen)
+		}
+	})
+
+	t.Run("Given the last item of a page is removed, the next page should resume after its position", func(t *testing.T) {
+		request := PageRequest{Limit: 2, Sort: []SortKey{{Field: SortByPrice}}}
+
+		first, err := sortedPage(sortTestItems(), request)
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		remaining := slices.DeleteFunc(sortTestItems(), func(item domain.Clothing) bool {
+			return item.Id == first.Items[1].Id
+		})
+
+		request.Cursor = first.NextCursor
+		second, err := sortedPage(remaining, request)
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		expected := []string{"id-4", "id-1"}
+
+		if !slices.Equal(ids(second.Items), expected) {
+			t.Errorf("Expected %v got %v", expected, ids(second.Items))
+		}
+	})
+
+	t.Run("Given a cursor issued for a different sort, should return ErrInvalidCursor", func(t *testing.T) {
+		first, err := sortedPage(sortTestItems(), PageRequest{Limit: 1, Sort: []SortKey{{Field: SortByPrice}}})
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		_, err = sortedPage(sortTestItems(), PageRequest{Limit: 1, Cursor: first.NextCursor, Sort: []SortKey{{Field: SortByBrand}}})
+
+		if !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("Expected ErrInvalidCursor, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,253 @@
+package repository_test
+
+import (
+	"clothes_management/internal/repository"
+	"clothes_management/internal/repository/repositorytest"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestInMemoryConformance(t *testing.T) {
+	repositorytest.RunConformance(t, func(t *testing.T) repository.ClothingRepository {
+		return repository.NewInMemoryClothingRepository()
+	})
+}
+
+func TestBoltConformance(t *testing.T) {
+	repositorytest.RunConformance(t, func(t *testing.T) repository.ClothingRepository {
+		return repository.NewBoltTestRepository(t)
+	})
+}
+
+func TestSQLiteConformance(t *testing.T) {
+	runSQLConformance(t, repository.SQLiteDialect, repository.SetupSQLiteDatabase)
+}
+
+func TestPostgresConformance(t *testing.T) {
+	runSQLConformance(t, repository.PostgresDialect, repository.SetupPostgresDatabase)
+}
+
+func runSQLConformance(t *testing.T, dialect repository.SQLDialect, setup func(t *testing.T) *sql.DB) {
+	repositorytest.RunConformance(t, func(t *testing.T) repository.ClothingRepository {
+		repo, err := repository.NewSQLClothingRepository(setup(t), dialect)
+
+		if err != nil {
+			t.Fatalf("Expected no err on NewSQLClothingRepository, got %v", err)
+		}
+
+		return repo
+	})
+}
+
+func TestDynamoConformance(t *testing.T) {
+	repositorytest.RunConformance(t, func(t *testing.T) repository.ClothingRepository {
+		client := repository.SetupLocalStackDynamoDBClient(t, true)
+
+		dynamoTableName := os.Getenv("DYNAMODB_TABLE_NAME")
+		if dynamoTableName == "" {
+			t.Fatal("ERROR: DYNAMODB_TABLE_NAME environment variable not set. Please set it in .env_test or your shell.")
+		}
+
+		repo, err := repository.NewDynamoDBClothingRepository(client, dynamoTableName)
+
+		if err != nil {
+			t.Fatalf("Expected no err on NewDynamoDBClothingRepository, got %v", err)
+		}
+
+		return repo
+	})
+}
+
+func TestHistoryClothingRepositoryConformance(t *testing.T) {
+	repositorytest.RunConformance(t, func(t *testing.T) repository.ClothingRepository {
+		repo, err := repository.NewHistoryClothingRepository(repository.NewInMemoryClothingRepository(), repository.NewInMemoryHistoryRepository())
+
+		if err != nil {
+			t.Fatalf("Expected no err on NewHistoryClothingRepository, got %v", err)
+		}
+
+		return repo
+	})
+}
+
+func TestInMemoryHistoryConformance(t *testing.T) {
+	repositorytest.RunHistoryConformance(t, func(t *testing.T) repository.HistoryRepository {
+		return repository.NewInMemoryHistoryRepository()
+	})
+}
+
+func TestBoltHistoryConformance(t *testing.T) {
+	repositorytest.RunHistoryConformance(t, func(t *testing.T) repository.HistoryRepository {
+		db := repository.OpenBoltDatabase(t, filepath.Join(t.TempDir(), "clothes.db"))
+		t.Cleanup(func() { db.Close() })
+
+		history, err := repository.NewBoltHistoryRepository(db)
+
+		if err != nil {
+			t.Fatalf("Expected no err on NewBoltHistoryRepository, got %v", err)
+		}
+
+		return history
+	})
+}
+
+func TestSQLiteHistoryConformance(t *testing.T) {
+	runSQLHistoryConformance(t, repository.SQLiteDialect, repository.SetupSQLiteDatabase)
+}
+
+func TestPostgresHistoryConformance(t *testing.T) {
+	runSQLHistoryConformance(t, repository.PostgresDialect, repository.SetupPostgresDatabase)
+}
+
+func runSQLHistoryConformance(t *testing.T, dialect repository.SQLDialect, setup func(t *testing.T) *sql.DB) {
+	repositorytest.RunHistoryConformance(t, func(t *testing.T) repository.HistoryRepository {
+		history, err := repository.NewSQLHistoryRepository(setup(t), dialect)
+
+		if err != nil {
+			t.Fatalf("Expected no err on NewSQLHistoryRepository, got %v", err)
+		}
+
+		return history
+	})
+}
+
+func TestDynamoHistoryConformance(t *testing.T) {
+	repositorytest.RunHistoryConformance(t, func(t *testing.T) repository.HistoryRepository {
+		client := repository.SetupLocalStackDynamoDBClient(t, true)
+
+		dynamoTableName := os.Getenv("DYNAMODB_TABLE_NAME")
+		if dynamoTableName == "" {
+			t.Fatal("ERROR: DYNAMODB_TABLE_NAME environment variable not set. Please set it in .env_test or your shell.")
+		}
+
+		history, err := repository.NewDynamoDBHistoryRepository(client, dynamoTableName)
+
+		if err != nil {
+			t.Fatalf("Expected no err on NewDynamoDBHistoryRepository, got %v", err)
+		}
+
+		return history
+	})
+}
+
+func TestInMemoryOutfitConformance(t *testing.T) {
+	repositorytest.RunOutfitConformance(t, func(t *testing.T) repository.OutfitRepository {
+		return repository.NewInMemoryOutfitRepository()
+	})
+}
+
+func TestBoltOutfitConformance(t *testing.T) {
+	repositorytest.RunOutfitConformance(t, func(t *testing.T) repository.OutfitRepository {
+		db := repository.OpenBoltDatabase(t, filepath.Join(t.TempDir(), "clothes.db"))
+		t.Cleanup(func() { db.Close() })
+
+		outfits, err := repository.NewBoltOutfitRepository(db)
+
+		if err != nil {
+			t.Fatalf("Expected no err on NewBoltOutfitRepository, got %v", err)
+		}
+
+		return outfits
+	})
+}
+
+func TestSQLiteOutfitConformance(t *testing.T) {
+	runSQLOutfitConformance(t, repository.SQLiteDialect, repository.SetupSQLiteDatabase)
+}
+
+func TestPostgresOutfitConformance(t *testing.T) {
+	runSQLOutfitConformance(t, repository.PostgresDialect, repository.SetupPostgresDatabase)
+}
+
+func runSQLOutfitConformance(t *testing.T, dialect repository.SQLDialect, setup func(t *testing.T) *sql.DB) {
+	repositorytest.RunOutfitConformance(t, func(t *testing.T) repository.OutfitRepository {
+		outfits, err := repository.NewSQLOutfitRepository(setup(t), dialect)
+
+		if err != nil {
+			t.Fatalf("Expected no err on NewSQLOutfitRepository, got %v", err)
+		}
+
+		return outfits
+	})
+}
+
+func TestDynamoOutfitConformance(t *testing.T) {
+	repositorytest.RunOutfitConformance(t, func(t *testing.T) repository.OutfitRepository {
+		client := repository.SetupLocalStackDynamoDBClient(t, true)
+
+		dynamoTableName := os.Getenv("DYNAMODB_TABLE_NAME")
+		if dynamoTableName == "" {
+			t.Fatal("ERROR: DYNAMODB_TABLE_NAME environment variable not set. Please set it in .env_test or your shell.")
+		}
+
+		outfits, err := repository.NewDynamoDBOutfitRepository(client, dynamoTableName)
+
+		if err != nil {
+			t.Fatalf("Expected no err on NewDynamoDBOutfitRepository, got %v", err)
+		}
+
+		return outfits
+	})
+}
+
+func TestInMemorySettingsConformance(t *testing.T) {
+	repositorytest.RunSettingsConformance(t, func(t *testing.T) repository.SettingsRepository {
+		return repository.NewInMemorySettingsRepository()
+	})
+}
+
+func TestBoltSettingsConformance(t *testing.T) {
+	repositorytest.RunSettingsConformance(t, func(t *testing.T) repository.SettingsRepository {
+		db := repository.OpenBoltDatabase(t, filepath.Join(t.TempDir(), "clothes.db"))
+		t.Cleanup(func() { db.Close() })
+
+		settings, err := repository.NewBoltSettingsRepository(db)
+
+		if err != nil {
+			t.Fatalf("Expected no err on NewBoltSettingsRepository, got %v", err)
+		}
+
+		return settings
+	})
+}
+
+func TestSQLiteSettingsConformance(t *testing.T) {
+	runSQLSettingsConformance(t, repository.SQLiteDialect, repository.SetupSQLiteDatabase)
+}
+
+func TestPostgresSettingsConformance(t *testing.T) {
+	runSQLSettingsConformance(t, repository.PostgresDialect, repository.SetupPostgresDatabase)
+}
+
+func runSQLSettingsConformance(t *testing.T, dialect repository.SQLDialect, setup func(t *testing.T) *sql.DB) {
+	repositorytest.RunSettingsConformance(t, func(t *testing.T) repository.SettingsRepository {
+		settings, err := repository.NewSQLSettingsRepository(setup(t), dialect)
+
+		if err != nil {
+			t.Fatalf("Expected no err on NewSQLSettingsRepository, got %v", err)
+		}
+
+		return settings
+	})
+}
+
+func TestDynamoSettingsConformance(t *testing.T) {
+	repositorytest.RunSettingsConformance(t, func(t *testing.T) repository.SettingsRepository {
+		client := repository.SetupLocalStackDynamoDBClient(t, true)
+
+		dynamoTableName := os.Getenv("DYNAMODB_TABLE_NAME")
+		if dynamoTableName == "" {
+			t.Fatal("ERROR: DYNAMODB_TABLE_NAME environment variable not set. Please set it in .env_test or your shell.")
+		}
+
+		settings, err := repository.NewDynamoDBSettingsRepository(client, dynamoTableName)
+
+		if err != nil {
+			t.Fatalf("Expected no err on NewDynamoDBSettingsRepository, got %v", err)
+		}
+
+		return settings
+	})
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// encodeCursor turns a backend specific position into an opaque string for clients.
+func encodeCursor(position any) (string, error) {
+	raw, err := json.Marshal(position)
+
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cursor: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// decodeCursor reverses encodeCursor, returning ErrInvalidCursor for anything it did not produce.
+func decodeCursor(cursor string, position any) error {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+
+	if err := json.Unmarshal(raw, position); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCursor(t *testing.T) {
+	t.Run("Given an encoded position, decoding should return the same position", func(t *testing.T) {
+		cursor, err := encodeCursor(sortCursor{Id: "dummy-id-123"})
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		var position sortCursor
+
+		if err := decodeCursor(cursor, &position); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if position.Id != "dummy-id-123" {
+			t.Errorf("Expected dummy-id-123, got %s", position.Id)
+		}
+	})
+
+	t.Run("Given a cursor that is not base64, should return ErrInvalidCursor", func(t *testing.T) {
+		var position sortCursor
+
+		err := decodeCursor("%%%", &position)
+
+		if !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("Expected ErrInvalidCursor, got %v", err)
+		}
+	})
+
+	t.Run("Given a cursor that is base64 but not JSON, should return ErrInvalidCursor", func(t *testing.T) {
+		var position sortCursor
+
+		err := decodeCursor("bm90LWpzb24", &position)
+
+		if !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("Expected ErrInvalidCursor, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// catalogPartitionPrefix keeps catalog entries in the clothing table under UserId values that can never
+// be a Cognito sub, so they are not returned by any user's queries.
+const catalogPartitionPrefix = "CATALOG#"
+
+type catalogEntry struct {
+	UserId string `dynamodbav:"UserId"`
+	Id     string `dynamodbav:"Id"`
+	Value  string `dynamodbav:"Value"`
+}
+
+type DynamoDBCatalogRepository struct {
+	client    *dynamodb.Client
+	tableName string
+}
+
+func NewDynamoDBCatalogRepository(client *dynamodb.Client, tableName string) (*DynamoDBCatalogRepository, error) {
+	if client == nil {
+		return nil, fmt.Errorf("client should not be nil")
+	}
+
+	if strings.TrimSpace(tableName) == "" {
+		return nil, fmt.Errorf("tableName should not be empty or whitespace")
+	}
+
+	return &DynamoDBCatalogRepository{
+		client:    client,
+		tableName: tableName,
+	}, nil
+}
+
+func (d *DynamoDBCatalogRepository) Register(ctx context.Context, kind CatalogKind, value string) error {
+	if err := validateCatalogEntry(kind, value); err != nil {
+		return err
+	}
+
+	item, err := attributevalue.MarshalMap(catalogEntry{
+		UserId: catalogPartitionPrefix + string(kind),
+		Id:     catalogKey(value),
+		Value:  strings.TrimSpace(value),
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to marshal catalog entry for DynamoDB: %w", err)
+	}
+
+	// The condition keeps the first spelling registered; a failed check just means the value is known.
+	_, err = d.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(d.tableName),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(Id)"),
+	})
+
+	if err != nil && !isConditionalCheckFailed(err) {
+		return fmt.Errorf("failed to put catalog entry into DynamoDB: %w", err)
+	}
+
+	return nil
+}
+
+func (d *DynamoDBCatalogRepository) List(ctx context.Context, kind CatalogKind) ([]string, error) {
+	if _, ok := ParseCatalogKind(string(kind)); !ok {
+		return []string{}, newValidationError("Unknown catalog " + string(kind))
+	}
+
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(d.tableName),
+		KeyConditionExpression: aws.String("UserId = :partition"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":partition": &types.AttributeValueMemberS{Value: catalogPartitionPrefix + string(kind)},
+		},
+	}
+
+	values := []string{}
+
+	for {
+		result, err := d.client.Query(ctx, input)
+
+		if err != nil {
+			return []string{}, fmt.Errorf("failed to query catalog from DynamoDB: %w", err)
+		}
+
+		var entries []catalogEntry
+
+		if err := attributevalue.UnmarshalListOfMaps(result.Items, &entries); err != nil {
+			return []string{}, fmt.Errorf("failed to unmarshal catalog entries from DynamoDB: %w", err)
+		}
+
+		for _, entry := range entries {
+			values = append(values, entry.Value)
+		}
+
+		if len(result.LastEvaluatedKey) == 0 {
+			break
+		}
+
+		input.ExclusiveStartKey = result.LastEvaluatedKey
+	}
+
+	sortCatalogValues(values)
+
+	return values, nil
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"slices"
+	"testing"
+)
+
+func TestDynamoCatalog(t *testing.T) {
+	t.Run("Given values differing only in case, should keep the first spelling and list them sorted", func(t *testing.T) {
+		client := setupLocalStackDynamoDBClient(t, true)
+
+		dynamoTableName := os.Getenv("DYNAMODB_TABLE_NAME")
+		if dynamoTableName == "" {
+			t.Fatal("ERROR: DYNAMODB_TABLE_NAME environment variable not set. Please set it in .env_test or your shell.")
+		}
+
+		repo, err := NewDynamoDBCatalogRepository(client, dynamoTableName)
+
+		if err != nil {
+			t.Fatalf("Expected no err on NewDynamoDBCatalogRepository, got %v", err)
+		}
+
+		for _, value := range []string{"XYZ", "xyz", "Abc"} {
+			if err := repo.Register(context.Background(), CatalogBrands, value); err != nil {
+				t.Fatalf("Expected no error registering %q, got %v", value, err)
+			}
+		}
+
+		values, err := repo.List(context.Background(), CatalogBrands)
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if !slices.Equal(values, []string{"Abc", "XYZ"}) {
+			t.Errorf("Expected [Abc XYZ], got %v", values)
+		}
+	})
+
+	t.Run("Given catalog entries in the table, should not return them as a user's clothing", func(t *testing.T) {
+		client := setupLocalStackDynamoDBClient(t, true)
+
+		dynamoTableName := os.Getenv("DYNAMODB_TABLE_NAME")
+		if dynamoTableName == "" {
+			t.Fatal("ERROR: DYNAMODB_TABLE_NAME environment variable not set. Please set it in .env_test or your shell.")
+		}
+
+		catalog, err := NewDynamoDBCatalogRepository(client, dynamoTableName)
+
+		if err != nil {
+			t.Fatalf("Expected no err on NewDynamoDBCatalogRepository, got %v", err)
+		}
+
+		repo, err := NewDynamoDBClothingRepository(client, dynamoTableName)
+
+		if err != nil {
+			t.Fatalf("Expected no err on NewDynamoDBClothingRepository, got %v", err)
+		}
+
+		if err := catalog.Register(context.Background(), CatalogTypes, "Jumper"); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		items, err := repo.GetAll(context.Background(), "test-user-id")
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(items) != 0 {
+			t.Errorf("Expected no clothing, got %v", items)
+		}
+	})
+}
@@ -5,7 +5,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
@@ -15,6 +19,24 @@ import (
 	"github.com/google/uuid"
 )
 
+// metadataSortKeyPrefix starts the Id of every row kept in a user's partition that is not an item, such
+// as history entries and wears. '~' sorts above any character of a uuid, so item queries can stop short
+// of the prefix.
+const metadataSortKeyPrefix = "~"
+
+// isMetadataId reports whether id names one of the rows under metadataSortKeyPrefix rather than an item,
+// so that single item calls treat it as not found instead of reading or writing that row.
+func isMetadataId(id string) bool {
+	return strings.HasPrefix(id, metadataSortKeyPrefix)
+}
+
+// wearSortKeyPrefix keeps an item's wears in its user's partition, after every item.
+const wearSortKeyPrefix = metadataSortKeyPrefix + "WEAR#"
+
+// maxTransactAttempts bounds how often RecordWear and TrashMany retry a transaction when an item changes
+// between reading and writing it.
+const maxTransactAttempts = 3
+
 type DynamoDBClothingRepository struct {
 	client    *dynamodb.Client
 	tableName string
@@ -35,21 +57,24 @@ func NewDynamoDBClothingRepository(client *dynamodb.Client, tableName string) (*
 	}, nil
 }
 
-func (d *DynamoDBClothingRepository) Save(userId string, clothing domain.Clothing) (domain.Clothing, error) {
+func (d *DynamoDBClothingRepository) Save(ctx context.Context, userId string, clothing domain.Clothing) (domain.Clothing, error) {
 
 	if strings.TrimSpace(userId) == "" {
-		return domain.Clothing{}, errors.New("User ID must not be empty or whitespace")
+		return domain.Clothing{}, newValidationError("User ID must not be empty or whitespace")
 	}
 
 	if err := clothing.Validate(); err != nil {
-		return domain.Clothing{}, err
+		return domain.Clothing{}, &ValidationError{Err: err}
 	}
 
-	if clothing.Id == "" {
-		clothing.Id = uuid.New().String()
-	}
+	// Ids are always generated here so callers can never choose, or collide with, another item's id.
+	clothing.Id = uuid.New().String()
 
 	clothing.UserId = userId
+	clothing.Version = 1
+	clothing.DeletedAt = nil
+	clothing.WearCount = 0
+	clothing.LastWornAt = nil
 
 	item, err := attributevalue.MarshalMap(clothing)
 
@@ -59,11 +84,17 @@ func (d *DynamoDBClothingRepository) Save(userId string, clothing domain.Clothin
 	}
 
 	putItemInput := &dynamodb.PutItemInput{
-		TableName: aws.String(d.tableName),
-		Item:      item,
+		TableName:           aws.String(d.tableName),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(Id)"),
+	}
+
+	_, err = d.client.PutItem(ctx, putItemInput)
+
+	if isConditionalCheckFailed(err) {
+		return domain.Clothing{}, newConflictError("Item with id %s already exists", clothing.Id)
 	}
 
-	_, err = d.client.PutItem(context.TODO(), putItemInput)
 	if err != nil {
 		return domain.Clothing{}, fmt.Errorf("failed to put item into DynamoDB: %w", err)
 	}
@@ -72,29 +103,108 @@ func (d *DynamoDBClothingRepository) Save(userId string, clothing domain.Clothin
 
 }
 
-func (d *DynamoDBClothingRepository) GetAll(userId string) ([]domain.Clothing, error) {
+// SaveMany writes with BatchWriteItem, which cannot carry a condition, so unlike Save it relies on the
+// generated ids being unique rather than checking for an existing item.
+func (d *DynamoDBClothingRepository) SaveMany(ctx context.Context, userId string, clothing []domain.Clothing) ([]domain.Clothing, error) {
+	prepared, err := prepareNewItems(userId, clothing)
+
+	if err != nil {
+		return nil, err
+	}
+
+	requests := make([]types.WriteRequest, 0, len(prepared))
+
+	for _, item := range prepared {
+		av, err := attributevalue.MarshalMap(item)
+
+		// this shouldn't ever be possible, but as a guard. As a result, will not unit test.
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal clothing item for DynamoDB: %w", err)
+		}
+
+		requests = append(requests, types.WriteRequest{PutRequest: &types.PutRequest{Item: av}})
+	}
+
+	if err := batchWrite(ctx, d.client, d.tableName, requests); err != nil {
+		return nil, err
+	}
+
+	return prepared, nil
+}
+
+const (
+	// maxBatchWriteItems, maxBatchGetItems and maxTransactItems are the most keys DynamoDB accepts in one
+	// BatchWriteItem, BatchGetItem or TransactWriteItems call.
+	maxBatchWriteItems = 25
+	maxBatchGetItems   = 100
+	maxTransactItems   = 100
+	maxBatchAttempts   = 5
+	batchRetryBackoff  = 50 * time.Millisecond
+)
+
+// waitForBatchRetry backs off exponentially before each retry of a batch call's unprocessed items, and
+// gives up once maxBatchAttempts have been made.
+func waitForBatchRetry(ctx context.Context, attempt int) error {
+	if attempt > maxBatchAttempts {
+		return fmt.Errorf("items still unprocessed after %d attempts", maxBatchAttempts)
+	}
+
+	if attempt == 1 {
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(batchRetryBackoff << (attempt - 2)):
+		return nil
+	}
+}
+
+// batchWrite sends requests in BatchWriteItem calls of at most maxBatchWriteItems, resending any
+// UnprocessedItems with an exponential backoff until they are written or the attempts run out.
+func batchWrite(ctx context.Context, client *dynamodb.Client, tableName string, requests []types.WriteRequest) error {
+	for chunk := range slices.Chunk(requests, maxBatchWriteItems) {
+		pending := map[string][]types.WriteRequest{tableName: chunk}
+
+		for attempt := 1; len(pending[tableName]) > 0; attempt++ {
+			if err := waitForBatchRetry(ctx, attempt); err != nil {
+				return fmt.Errorf("failed to write %d items to DynamoDB table '%s': %w", len(pending[tableName]), tableName, err)
+			}
+
+			output, err := client.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{RequestItems: pending})
+
+			if err != nil {
+				return fmt.Errorf("failed to batch write items to DynamoDB: %w", err)
+			}
+
+			pending = output.UnprocessedItems
+		}
+	}
+
+	return nil
+}
+
+func (d *DynamoDBClothingRepository) GetAll(ctx context.Context, userId string) ([]domain.Clothing, error) {
 
 	if strings.TrimSpace(userId) == "" {
-		return []domain.Clothing{}, errors.New("User ID must not be empty or whitespace")
+		return []domain.Clothing{}, newValidationError("User ID must not be empty or whitespace")
 	}
 
+	return d.queryAll(ctx, d.userQueryInput(userId, ClothingFilter{}))
+}
+
+// queryAll follows LastEvaluatedKey until the whole result set has been read.
+func (d *DynamoDBClothingRepository) queryAll(ctx context.Context, queryInput *dynamodb.QueryInput) ([]domain.Clothing, error) {
 	var allClothes []domain.Clothing
 	var lastEvaluatedKey map[string]types.AttributeValue
 
-	queryInput := &dynamodb.QueryInput{
-		TableName:              aws.String(d.tableName),
-		KeyConditionExpression: aws.String("UserId = :uid"),
-		ExpressionAttributeValues: map[string]types.AttributeValue{
-			":uid": &types.AttributeValueMemberS{Value: userId},
-		},
-	}
-
 	for {
 		if lastEvaluatedKey != nil {
 			queryInput.ExclusiveStartKey = lastEvaluatedKey
 		}
 
-		output, err := d.client.Query(context.TODO(), queryInput)
+		output, err := d.client.Query(ctx, queryInput)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan DynamoDB table '%s': %w", d.tableName, err)
 		}
@@ -119,14 +229,260 @@ func (d *DynamoDBClothingRepository) GetAll(userId string) ([]domain.Clothing, e
 	return allClothes, nil
 }
 
-func (d *DynamoDBClothingRepository) GetById(userId, id string) (domain.Clothing, error) {
+// GetPage queries one page at a time when no sort is requested. The cursor wraps DynamoDB's
+// LastEvaluatedKey, so reading a page only consumes capacity for that page. A filter is applied by
+// DynamoDB after Limit, so the query is repeated until the page is full or the partition is exhausted.
+func (d *DynamoDBClothingRepository) GetPage(ctx context.Context, userId string, page PageRequest) (Page, error) {
+
+	if strings.TrimSpace(userId) == "" {
+		return Page{Items: []domain.Clothing{}}, newValidationError("User ID must not be empty or whitespace")
+	}
+
+	if err := page.Filter.Validate(); err != nil {
+		return Page{Items: []domain.Clothing{}}, &ValidationError{Err: err}
+	}
+
+	queryInput := d.userQueryInput(userId, page.Filter)
+
+	// DynamoDB can only order by the sort key, so any other ordering has to read every matching item, as
+	// does matching sizes, which the filter expression leaves out.
+	if len(page.Sort) > 0 || page.Filter.Size != "" {
+		items, err := d.queryAll(ctx, queryInput)
+
+		if err != nil {
+			return Page{Items: []domain.Clothing{}}, err
+		}
+
+		return sortedPage(slices.DeleteFunc(items, func(item domain.Clothing) bool { return !page.Filter.Matches(item) }), page)
+	}
+
+	if page.Cursor != "" {
+		startKey, err := d.decodeStartKey(userId, page.Cursor)
+
+		if err != nil {
+			return Page{Items: []domain.Clothing{}}, err
+		}
+
+		queryInput.ExclusiveStartKey = startKey
+	}
+
+	limit := page.limit()
+	result := Page{Items: []domain.Clothing{}}
+
+	for {
+		queryInput.Limit = aws.Int32(int32(limit - len(result.Items)))
+
+		output, err := d.client.Query(ctx, queryInput)
+		if err != nil {
+			return Page{Items: []domain.Clothing{}}, fmt.Errorf("failed to query DynamoDB table '%s': %w", d.tableName, err)
+		}
+
+		var clothesPage []domain.Clothing
+		err = attributevalue.UnmarshalListOfMaps(output.Items, &clothesPage)
+
+		if err != nil {
+			// this shouldn't ever be possible, but as a guard. As a result, will not unit test.
+			return Page{Items: []domain.Clothing{}}, fmt.Errorf("failed to unmarshal DynamoDB items from query result: %w", err)
+		}
+
+		result.Items = append(result.Items, clothesPage...)
+
+		if output.LastEvaluatedKey == nil {
+			break
+		}
+
+		if len(result.Items) >= limit {
+			result.NextCursor, err = encodeStartKey(output.LastEvaluatedKey)
+
+			if err != nil {
+				return Page{Items: []domain.Clothing{}}, err
+			}
+
+			break
+		}
+
+		queryInput.ExclusiveStartKey = output.LastEvaluatedKey
+	}
+
+	return result, nil
+}
+
+// userQueryInput builds a Query over a single user's partition, with the filter translated into a
+// FilterExpression, apart from Size, which GetPage matches in process. Size is also a DynamoDB reserved
+// word, so every attribute goes through a name placeholder. The expression always selects either the
+// live or the trashed items, and the key condition stops short of the history entries and wears kept
+// after the items in the same partition.
+func (d *DynamoDBClothingRepository) userQueryInput(userId string, filter ClothingFilter) *dynamodb.QueryInput {
+	queryInput := &dynamodb.QueryInput{
+		TableName:              aws.String(d.tableName),
+		KeyConditionExpression: aws.String("UserId = :uid AND Id < :metadataPrefix"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":uid":            &types.AttributeValueMemberS{Value: userId},
+			":metadataPrefix": &types.AttributeValueMemberS{Value: metadataSortKeyPrefix},
+		},
+	}
+
+	conditions := []string{"attribute_not_exists(#DeletedAt)"}
+	names := map[string]string{"#DeletedAt": "DeletedAt"}
+
+	if filter.Trashed {
+		conditions[0] = "attribute_exists(#DeletedAt)"
+	}
+
+	equals := func(attribute, value string) {
+		if value == "" {
+			return
+		}
+
+		names["#"+attribute] = attribute
+		queryInput.ExpressionAttributeValues[":"+attribute] = &types.AttributeValueMemberS{Value: value}
+		conditions = append(conditions, fmt.Sprintf("#%s = :%s", attribute, attribute))
+	}
+
+	equals("ClothingType", filter.ClothingType)
+	equals("Brand", filter.Brand)
+	equals("Store", filter.Store)
+
+	if filter.Currency != "" {
+		names["#Currency"] = "Currency"
+		queryInput.ExpressionAttributeValues[":currency"] = &types.AttributeValueMemberS{Value: string(filter.Currency)}
+		condition := "#Currency = :currency"
+
+		// Items stored before currencies were recorded are in the default currency, and hold an empty
+		// Currency, which is marshalled as NULL, or none at all.
+		if filter.Currency == domain.DefaultCurrency {
+			queryInput.ExpressionAttributeValues[":null"] = &types.AttributeValueMemberS{Value: "NULL"}
+			condition = "(#Currency = :currency OR attribute_not_exists(#Currency) OR attribute_type(#Currency, :null))"
+		}
+
+		conditions = append(conditions, condition)
+	}
+
+	if filter.MinPricePence != nil {
+		names["#PricePence"] = "PricePence"
+		queryInput.ExpressionAttributeValues[":minPrice"] = &types.AttributeValueMemberN{Value: strconv.FormatInt(int64(*filter.MinPricePence), 10)}
+		conditions = append(conditions, "#PricePence >= :minPrice")
+	}
+
+	if filter.MaxPricePence != nil {
+		names["#PricePence"] = "PricePence"
+		queryInput.ExpressionAttributeValues[":maxPrice"] = &types.AttributeValueMemberN{Value: strconv.FormatInt(int64(*filter.MaxPricePence), 10)}
+		conditions = append(conditions, "#PricePence <= :maxPrice")
+	}
+
+	// Tags are a list of strings, which contains tests for an element.
+	if len(filter.Tags) > 0 {
+		names["#Tags"] = "Tags"
+		tagConditions := make([]string, len(filter.Tags))
+
+		for i, tag := range filter.Tags {
+			placeholder := fmt.Sprintf(":tag%d", i)
+			queryInput.ExpressionAttributeValues[placeholder] = &types.AttributeValueMemberS{Value: tag}
+			tagConditions[i] = fmt.Sprintf("contains(#Tags, %s)", placeholder)
+		}
+
+		join := " AND "
+
+		if filter.AnyTag {
+			join = " OR "
+		}
+
+		conditions = append(conditions, "("+strings.Join(tagConditions, join)+")")
+	}
+
+	// Secondary colours are a list of strings too, so are tested the same way.
+	if len(filter.Colours) > 0 {
+		names["#Colour"] = "Colour"
+		placeholders := make([]string, len(filter.Colours))
+
+		for i, colour := range filter.Colours {
+			placeholders[i] = fmt.Sprintf(":colour%d", i)
+			queryInput.ExpressionAttributeValues[placeholders[i]] = &types.AttributeValueMemberS{Value: string(colour)}
+		}
+
+		colourConditions := []string{"#Colour IN (" + strings.Join(placeholders, ", ") + ")"}
+
+		if !filter.PrimaryColour {
+			names["#SecondaryColours"] = "SecondaryColours"
+
+			for _, placeholder := range placeholders {
+				colourConditions = append(colourConditions, fmt.Sprintf("contains(#SecondaryColours, %s)", placeholder))
+			}
+		}
+
+		conditions = append(conditions, "("+strings.Join(colourConditions, " OR ")+")")
+	}
+
+	for _, list := range []struct {
+		attribute string
+		values    []string
+	}{
+		{attribute: "Seasons", values: choiceStrings(filter.Seasons)},
+		{attribute: "Occasions", values: choiceStrings(filter.Occasions)},
+	} {
+		if len(list.values) == 0 {
+			continue
+		}
+
+		names["#"+list.attribute] = list.attribute
+		listConditions := make([]string, len(list.values))
+
+		for i, value := range list.values {
+			placeholder := fmt.Sprintf(":%s%d", strings.ToLower(list.attribute), i)
+			queryInput.ExpressionAttributeValues[placeholder] = &types.AttributeValueMemberS{Value: value}
+			listConditions[i] = fmt.Sprintf("contains(#%s, %s)", list.attribute, placeholder)
+		}
+
+		conditions = append(conditions, "("+strings.Join(listConditions, " OR ")+")")
+	}
+
+	queryInput.FilterExpression = aws.String(strings.Join(conditions, " AND "))
+	queryInput.ExpressionAttributeNames = names
+
+	return queryInput
+}
+
+// encodeStartKey flattens a LastEvaluatedKey into a cursor. Both key attributes are strings.
+func encodeStartKey(key map[string]types.AttributeValue) (string, error) {
+	position := map[string]string{}
+
+	if err := attributevalue.UnmarshalMap(key, &position); err != nil {
+		return "", fmt.Errorf("failed to encode cursor: %w", err)
+	}
+
+	return encodeCursor(position)
+}
+
+// decodeStartKey rebuilds an ExclusiveStartKey from a cursor, rejecting cursors issued for another user.
+func (d *DynamoDBClothingRepository) decodeStartKey(userId, cursor string) (map[string]types.AttributeValue, error) {
+	position := map[string]string{}
+
+	if err := decodeCursor(cursor, &position); err != nil {
+		return nil, err
+	}
+
+	if position["UserId"] != userId || position["Id"] == "" {
+		return nil, fmt.Errorf("%w: cursor does not belong to this user", ErrInvalidCursor)
+	}
+
+	return map[string]types.AttributeValue{
+		"UserId": &types.AttributeValueMemberS{Value: position["UserId"]},
+		"Id":     &types.AttributeValueMemberS{Value: position["Id"]},
+	}, nil
+}
+
+func (d *DynamoDBClothingRepository) GetById(ctx context.Context, userId, id string) (domain.Clothing, error) {
 
 	if strings.TrimSpace(userId) == "" {
-		return domain.Clothing{}, errors.New("User ID must not be empty or whitespace")
+		return domain.Clothing{}, newValidationError("User ID must not be empty or whitespace")
 	}
 
 	if strings.TrimSpace(id) == "" {
-		return domain.Clothing{}, errors.New("ID must not be empty or whitespace")
+		return domain.Clothing{}, newValidationError("ID must not be empty or whitespace")
+	}
+
+	if isMetadataId(id) {
+		return domain.Clothing{}, newNotFoundError("No item found for id %s", id)
 	}
 
 	getItemInput := &dynamodb.GetItemInput{
@@ -139,35 +495,39 @@ func (d *DynamoDBClothingRepository) GetById(userId, id string) (domain.Clothing
 		},
 	}
 
-	getItemOutput, err := d.client.GetItem(context.TODO(), getItemInput)
+	getItemOutput, err := d.client.GetItem(ctx, getItemInput)
 
 	if err != nil {
-		return domain.Clothing{}, fmt.Errorf("Failed to GetItem for id %s %v", id, err)
+		return domain.Clothing{}, fmt.Errorf("Failed to GetItem for id %s %w", id, err)
 	}
 
 	var item domain.Clothing
 
 	if len(getItemOutput.Item) == 0 {
-		return domain.Clothing{}, fmt.Errorf("No item found for id %s", id)
+		return domain.Clothing{}, newNotFoundError("No item found for id %s", id)
 	}
 
 	attributevalue.UnmarshalMap(getItemOutput.Item, &item)
 
+	if item.DeletedAt != nil {
+		return domain.Clothing{}, newNotFoundError("No item found for id %s", id)
+	}
+
 	return item, nil
 }
 
-func (d *DynamoDBClothingRepository) Update(userId string, clothing domain.Clothing) (domain.Clothing, error) {
+func (d *DynamoDBClothingRepository) Update(ctx context.Context, userId string, clothing domain.Clothing) (domain.Clothing, error) {
 
 	if strings.TrimSpace(userId) == "" {
-		return domain.Clothing{}, errors.New("User ID must not be empty or whitespace")
+		return domain.Clothing{}, newValidationError("User ID must not be empty or whitespace")
 	}
 
 	if err := clothing.Validate(); err != nil {
-		return domain.Clothing{}, err
+		return domain.Clothing{}, &ValidationError{Err: err}
 	}
 
 	if clothing.Id == "" {
-		return domain.Clothing{}, fmt.Errorf("cannot update clothing without ID")
+		return domain.Clothing{}, newValidationError("cannot update clothing without ID")
 	}
 
 	if clothing.UserId == "" {
@@ -175,9 +535,16 @@ func (d *DynamoDBClothingRepository) Update(userId string, clothing domain.Cloth
 	}
 
 	if clothing.UserId != userId {
-		return domain.Clothing{}, fmt.Errorf("Mismatch of user ID")
+		return domain.Clothing{}, newValidationError("Mismatch of user ID")
+	}
+
+	if isMetadataId(clothing.Id) {
+		return domain.Clothing{}, newNotFoundError("Item with id %s does not exist", clothing.Id)
 	}
 
+	expectedVersion := clothing.Version
+	clothing.DeletedAt = nil
+
 	item, err := attributevalue.MarshalMap(clothing)
 
 	// this shouldn't ever be possible, but as a guard. As a result, will not unit test.
@@ -185,84 +552,566 @@ func (d *DynamoDBClothingRepository) Update(userId string, clothing domain.Cloth
 		return domain.Clothing{}, fmt.Errorf("failed to marshal clothing item for DynamoDB: %w", err)
 	}
 
-	putItemInput := &dynamodb.PutItemInput{
+	delete(item, "UserId")
+	delete(item, "Id")
+	delete(item, "Version")
+	delete(item, "WearCount")
+	delete(item, "LastWornAt")
+
+	// UpdateItem rather than PutItem so Version can be incremented atomically with ADD, whether or not
+	// the caller asked for a version check. The wear attributes are left as stored.
+	names := map[string]string{"#Version": "Version"}
+	values := map[string]types.AttributeValue{
+		":one": &types.AttributeValueMemberN{Value: "1"},
+	}
+	var assignments []string
+
+	for _, name := range slices.Sorted(maps.Keys(item)) {
+		names["#"+name] = name
+		values[":"+name] = item[name]
+		assignments = append(assignments, fmt.Sprintf("#%s = :%s", name, name))
+	}
+
+	update := "SET " + strings.Join(assignments, ", ")
+
+	// omitempty leaves a cleared PurchasedAt, list or colour out of the item, so they have to be removed
+	// explicitly.
+	var removals []string
+
+	for _, name := range []string{"PurchasedAt", "Tags", "Colour", "SecondaryColours", "Seasons", "Occasions"} {
+		if _, set := item[name]; !set {
+			names["#"+name] = name
+			removals = append(removals, "#"+name)
+		}
+	}
+
+	if len(removals) > 0 {
+		update += " REMOVE " + strings.Join(removals, ", ")
+	}
+
+	condition := "attribute_exists(Id) AND attribute_not_exists(DeletedAt)"
+
+	if expectedVersion != 0 {
+		condition += " AND #Version = :expectedVersion"
+		values[":expectedVersion"] = &types.AttributeValueMemberN{Value: strconv.FormatInt(expectedVersion, 10)}
+	}
+
+	updateItemInput := &dynamodb.UpdateItemInput{
 		TableName: aws.String(d.tableName),
-		Item:      item,
+		Key: map[string]types.AttributeValue{
+			"UserId": &types.AttributeValueMemberS{Value: userId},
+			"Id":     &types.AttributeValueMemberS{Value: clothing.Id},
+		},
+		UpdateExpression:                    aws.String(update + " ADD #Version :one"),
+		ConditionExpression:                 aws.String(condition),
+		ExpressionAttributeNames:            names,
+		ExpressionAttributeValues:           values,
+		ReturnValues:                        types.ReturnValueAllNew,
+		ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
+	}
+
+	output, err := d.client.UpdateItem(ctx, updateItemInput)
+
+	if err := conditionFailure(err, clothing.Id, expectedVersion, false); err != nil {
+		return domain.Clothing{}, err
 	}
 
-	_, err = d.client.PutItem(context.TODO(), putItemInput)
 	if err != nil {
 		return domain.Clothing{}, fmt.Errorf("failed to update item into DynamoDB: %w", err)
 	}
 
-	return clothing, nil
+	var updated domain.Clothing
+
+	if err := attributevalue.UnmarshalMap(output.Attributes, &updated); err != nil {
+		// this shouldn't ever be possible, but as a guard. As a result, will not unit test.
+		return domain.Clothing{}, fmt.Errorf("failed to unmarshal updated DynamoDB item: %w", err)
+	}
+
+	return updated, nil
 }
 
-func (d *DynamoDBClothingRepository) Delete(userId, id string) error {
+// TrashMany reads the live items among ids with BatchGetItem, then trashes up to maxTransactItems of them
+// at a time in a transaction conditional on the versions read. A transaction cancelled because another
+// write got in between is read and tried again, so an item trashed meanwhile is skipped, as are ids of
+// metadata rows.
+func (d *DynamoDBClothingRepository) TrashMany(ctx context.Context, userId string, ids []string) ([]domain.Clothing, error) {
+	unique, err := uniqueIds(userId, ids)
+
+	if err != nil {
+		return nil, err
+	}
+
+	unique = slices.DeleteFunc(unique, isMetadataId)
+	trashed := []domain.Clothing{}
+
+	for chunk := range slices.Chunk(unique, maxTransactItems) {
+		items, err := d.trashChunk(ctx, userId, chunk)
+
+		if err != nil {
+			return nil, err
+		}
+
+		trashed = append(trashed, items...)
+	}
+
+	return trashed, nil
+}
+
+// trashChunk trashes the live items among at most maxTransactItems ids in one transaction.
+func (d *DynamoDBClothingRepository) trashChunk(ctx context.Context, userId string, ids []string) ([]domain.Clothing, error) {
+	for attempt := 1; ; attempt++ {
+		live, err := d.liveItems(ctx, userId, ids)
+
+		if err != nil {
+			return nil, err
+		}
+
+		deletedAt := trashedNow()
+		trashed := make([]domain.Clothing, 0, len(live))
+		writes := make([]types.TransactWriteItem, 0, len(live))
+
+		for _, id := range ids {
+			item, ok := live[id]
+
+			if !ok {
+				continue
+			}
+
+			writes = append(writes, types.TransactWriteItem{
+				Update: &types.Update{
+					TableName: aws.String(d.tableName),
+					Key: map[string]types.AttributeValue{
+						"UserId": &types.AttributeValueMemberS{Value: userId},
+						"Id":     &types.AttributeValueMemberS{Value: id},
+					},
+					UpdateExpression:         aws.String("SET DeletedAt = :deletedAt ADD #Version :one"),
+					ConditionExpression:      aws.String("attribute_exists(Id) AND attribute_not_exists(DeletedAt) AND #Version = :version"),
+					ExpressionAttributeNames: map[string]string{"#Version": "Version"},
+					ExpressionAttributeValues: map[string]types.AttributeValue{
+						":one":       &types.AttributeValueMemberN{Value: "1"},
+						":version":   &types.AttributeValueMemberN{Value: strconv.FormatInt(item.Version, 10)},
+						":deletedAt": &types.AttributeValueMemberN{Value: strconv.FormatInt(deletedAt.Unix(), 10)},
+					},
+				},
+			})
+
+			item.DeletedAt = deletedAt
+			item.Version++
+			trashed = append(trashed, item)
+		}
+
+		if len(writes) == 0 {
+			return trashed, nil
+		}
+
+		_, err = d.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: writes})
+
+		if err == nil {
+			return trashed, nil
+		}
+
+		var cancelled *types.TransactionCanceledException
+
+		if !errors.As(err, &cancelled) {
+			return nil, fmt.Errorf("failed to trash items in DynamoDB: %w", err)
+		}
+
+		if attempt == maxTransactAttempts {
+			return nil, fmt.Errorf("failed to trash items in DynamoDB after %d attempts: %w", attempt, err)
+		}
+	}
+}
+
+// liveItems returns the user's items among ids that are stored and not in the trash, keyed by id, reading
+// them with BatchGetItem.
+func (d *DynamoDBClothingRepository) liveItems(ctx context.Context, userId string, ids []string) (map[string]domain.Clothing, error) {
+	live := make(map[string]domain.Clothing, len(ids))
+
+	for chunk := range slices.Chunk(ids, maxBatchGetItems) {
+		keys := make([]map[string]types.AttributeValue, 0, len(chunk))
+
+		for _, id := range chunk {
+			keys = append(keys, map[string]types.AttributeValue{
+				"Id":     &types.AttributeValueMemberS{Value: id},
+				"UserId": &types.AttributeValueMemberS{Value: userId},
+			})
+		}
+
+		pending := map[string]types.KeysAndAttributes{d.tableName: {Keys: keys}}
+
+		for attempt := 1; len(pending[d.tableName].Keys) > 0; attempt++ {
+			if err := waitForBatchRetry(ctx, attempt); err != nil {
+				return nil, fmt.Errorf("failed to read %d keys from DynamoDB table '%s': %w", len(pending[d.tableName].Keys), d.tableName, err)
+			}
+
+			output, err := d.client.BatchGetItem(ctx, &dynamodb.BatchGetItemInput{RequestItems: pending})
+
+			if err != nil {
+				return nil, fmt.Errorf("failed to batch get items from DynamoDB: %w", err)
+			}
+
+			var items []domain.Clothing
+
+			if err := attributevalue.UnmarshalListOfMaps(output.Responses[d.tableName], &items); err != nil {
+				// this shouldn't ever be possible, but as a guard. As a result, will not unit test.
+				return nil, fmt.Errorf("failed to unmarshal DynamoDB items: %w", err)
+			}
+
+			for _, item := range items {
+				if item.DeletedAt == nil {
+					live[item.Id] = item
+				}
+			}
+
+			pending = output.UnprocessedKeys
+		}
+	}
+
+	return live, nil
+}
+
+func (d *DynamoDBClothingRepository) Exists(ctx context.Context, userId, id string) (bool, error) {
 
 	if strings.TrimSpace(userId) == "" {
-		return errors.New("User ID must not be empty or whitespace")
+		return false, newValidationError("User ID must not be empty or whitespace")
 	}
 
 	if strings.TrimSpace(id) == "" {
-		return fmt.Errorf("ID cannot be empty or whitespace")
+		return false, newValidationError("ID cannot be empty or whitespace")
+	}
+
+	if isMetadataId(id) {
+		return false, nil
 	}
 
-	exists, err := d.Exists(userId, id)
+	input := &dynamodb.GetItemInput{
+		TableName: aws.String(d.tableName),
+		Key: map[string]types.AttributeValue{
+			"Id":     &types.AttributeValueMemberS{Value: id},
+			"UserId": &types.AttributeValueMemberS{Value: userId},
+		},
+		// Only fetch the key back, and whether the item is in the trash
+		ProjectionExpression: aws.String("Id, DeletedAt"),
+	}
 
+	out, err := d.client.GetItem(ctx, input)
 	if err != nil {
-		return fmt.Errorf("Failed to DeleteItem for id %s %v", id, err)
+		return false, fmt.Errorf("failed to check existence for id %s: %w", id, err)
 	}
 
-	if !exists {
-		return fmt.Errorf("Item with id %s does not exist", id)
+	_, trashed := out.Item["DeletedAt"]
+
+	return len(out.Item) != 0 && !trashed, nil
+}
+
+// isConditionalCheckFailed reports whether a write was rejected by its ConditionExpression.
+func isConditionalCheckFailed(err error) bool {
+	var conditionalCheckFailed *types.ConditionalCheckFailedException
+	return errors.As(err, &conditionalCheckFailed)
+}
+
+// conditionFailure explains a write rejected by an "attribute_exists(Id) AND Version = ..." condition,
+// which also required the item to be in the trash or not, as trashed says. DynamoDB returns the stored
+// item on failure, so a missing item can be told apart from a stale version. It returns nil when err is
+// not a conditional check failure.
+func conditionFailure(err error, id string, expectedVersion int64, trashed bool) error {
+	var conditionalCheckFailed *types.ConditionalCheckFailedException
+
+	if !errors.As(err, &conditionalCheckFailed) {
+		return nil
 	}
 
-	deleteItemInput := &dynamodb.DeleteItemInput{
-		TableName: &d.tableName,
+	if len(conditionalCheckFailed.Item) == 0 {
+		return newNotFoundError("Item with id %s does not exist", id)
+	}
+
+	var stored domain.Clothing
+
+	if err := attributevalue.UnmarshalMap(conditionalCheckFailed.Item, &stored); err != nil {
+		// this shouldn't ever be possible, but as a guard. As a result, will not unit test.
+		return fmt.Errorf("failed to unmarshal DynamoDB item from failed condition: %w", err)
+	}
+
+	if (stored.DeletedAt != nil) != trashed {
+		return newNotFoundError("Item with id %s does not exist", id)
+	}
+
+	return newPreconditionFailedError("Item with id %s is at version %d, not %d", id, stored.Version, expectedVersion)
+}
+
+func (d *DynamoDBClothingRepository) Trash(ctx context.Context, userId, id string, expectedVersion int64) (domain.Clothing, error) {
+	return d.moveTrash(ctx, userId, id, expectedVersion, true)
+}
+
+func (d *DynamoDBClothingRepository) Restore(ctx context.Context, userId, id string, expectedVersion int64) (domain.Clothing, error) {
+	return d.moveTrash(ctx, userId, id, expectedVersion, false)
+}
+
+// moveTrash moves a live item into the trash when trash is true, or a trashed item out of it when false,
+// with a single conditional UpdateItem.
+func (d *DynamoDBClothingRepository) moveTrash(ctx context.Context, userId, id string, expectedVersion int64, trash bool) (domain.Clothing, error) {
+	if err := validateTrashArgs(userId, id); err != nil {
+		return domain.Clothing{}, err
+	}
+
+	if isMetadataId(id) {
+		return domain.Clothing{}, newNotFoundError("Item with id %s does not exist", id)
+	}
+
+	values := map[string]types.AttributeValue{
+		":one": &types.AttributeValueMemberN{Value: "1"},
+	}
+	update := "REMOVE DeletedAt ADD #Version :one"
+	condition := "attribute_exists(DeletedAt)"
+
+	if trash {
+		values[":deletedAt"] = &types.AttributeValueMemberN{Value: strconv.FormatInt(trashedNow().Unix(), 10)}
+		update = "SET DeletedAt = :deletedAt ADD #Version :one"
+		condition = "attribute_exists(Id) AND attribute_not_exists(DeletedAt)"
+	}
+
+	if expectedVersion != 0 {
+		condition += " AND #Version = :expectedVersion"
+		values[":expectedVersion"] = &types.AttributeValueMemberN{Value: strconv.FormatInt(expectedVersion, 10)}
+	}
+
+	output, err := d.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(d.tableName),
 		Key: map[string]types.AttributeValue{
-			"Id": &types.AttributeValueMemberS{
-				Value: id,
-			},
-			"UserId": &types.AttributeValueMemberS{
-				Value: userId,
-			},
+			"UserId": &types.AttributeValueMemberS{Value: userId},
+			"Id":     &types.AttributeValueMemberS{Value: id},
 		},
+		UpdateExpression:                    aws.String(update),
+		ConditionExpression:                 aws.String(condition),
+		ExpressionAttributeNames:            map[string]string{"#Version": "Version"},
+		ExpressionAttributeValues:           values,
+		ReturnValues:                        types.ReturnValueAllNew,
+		ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
+	})
+
+	if err := conditionFailure(err, id, expectedVersion, !trash); err != nil {
+		return domain.Clothing{}, err
 	}
 
-	_, err = d.client.DeleteItem(context.TODO(), deleteItemInput)
-
 	if err != nil {
-		return fmt.Errorf("Failed to DeleteItem for id %s %v", id, err)
+		return domain.Clothing{}, fmt.Errorf("failed to update item into DynamoDB: %w", err)
 	}
 
-	return nil
-}
+	var updated domain.Clothing
 
-func (d *DynamoDBClothingRepository) Exists(userId, id string) (bool, error) {
+	if err := attributevalue.UnmarshalMap(output.Attributes, &updated); err != nil {
+		// this shouldn't ever be possible, but as a guard. As a result, will not unit test.
+		return domain.Clothing{}, fmt.Errorf("failed to unmarshal updated DynamoDB item: %w", err)
+	}
 
+	return updated, nil
+}
+
+func (d *DynamoDBClothingRepository) PurgeTrash(ctx context.Context, userId string, trashedBefore time.Time) ([]string, error) {
 	if strings.TrimSpace(userId) == "" {
-		return false, errors.New("User ID must not be empty or whitespace")
+		return nil, newValidationError("User ID must not be empty or whitespace")
 	}
 
-	if strings.TrimSpace(id) == "" {
-		return false, fmt.Errorf("ID cannot be empty or whitespace")
+	trashed, err := d.queryAll(ctx, d.userQueryInput(userId, ClothingFilter{Trashed: true}))
+
+	if err != nil {
+		return nil, err
 	}
 
-	input := &dynamodb.GetItemInput{
+	purged := []string{}
+
+	for _, item := range trashed {
+		if item.DeletedAt.After(trashedBefore) {
+			continue
+		}
+
+		deleted, err := d.deleteTrashed(ctx, item, trashedBefore)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if deleted {
+			purged = append(purged, item.Id)
+		}
+	}
+
+	return purged, nil
+}
+
+// PurgeExpiredTrash scans the whole table, as trashed items are not indexed. It is meant to run rarely,
+// in the background.
+func (d *DynamoDBClothingRepository) PurgeExpiredTrash(ctx context.Context, trashedBefore time.Time) ([]domain.Clothing, error) {
+	paginator := dynamodb.NewScanPaginator(d.client, &dynamodb.ScanInput{
+		TableName:        aws.String(d.tableName),
+		FilterExpression: aws.String("DeletedAt <= :trashedBefore"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":trashedBefore": &types.AttributeValueMemberN{Value: strconv.FormatInt(trashedBefore.Unix(), 10)},
+		},
+	})
+
+	purged := []domain.Clothing{}
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan DynamoDB table '%s': %w", d.tableName, err)
+		}
+
+		var trashed []domain.Clothing
+
+		if err := attributevalue.UnmarshalListOfMaps(output.Items, &trashed); err != nil {
+			// this shouldn't ever be possible, but as a guard. As a result, will not unit test.
+			return nil, fmt.Errorf("failed to unmarshal DynamoDB items from scan result: %w", err)
+		}
+
+		for _, item := range trashed {
+			deleted, err := d.deleteTrashed(ctx, item, trashedBefore)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if deleted {
+				purged = append(purged, item)
+			}
+		}
+	}
+
+	return purged, nil
+}
+
+// deleteTrashed deletes an item on condition it is still trashed at or before trashedBefore, reporting
+// false rather than an error when it has been restored since it was read.
+func (d *DynamoDBClothingRepository) deleteTrashed(ctx context.Context, item domain.Clothing, trashedBefore time.Time) (bool, error) {
+	_, err := d.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
 		TableName: aws.String(d.tableName),
 		Key: map[string]types.AttributeValue{
-			"Id":     &types.AttributeValueMemberS{Value: id},
-			"UserId": &types.AttributeValueMemberS{Value: userId},
+			"Id":     &types.AttributeValueMemberS{Value: item.Id},
+			"UserId": &types.AttributeValueMemberS{Value: item.UserId},
+		},
+		ConditionExpression: aws.String("DeletedAt <= :trashedBefore"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":trashedBefore": &types.AttributeValueMemberN{Value: strconv.FormatInt(trashedBefore.Unix(), 10)},
 		},
-		// Only fetch the key back
-		ProjectionExpression: aws.String("Id"),
+	})
+
+	if isConditionalCheckFailed(err) {
+		return false, nil
 	}
 
-	out, err := d.client.GetItem(context.TODO(), input)
 	if err != nil {
-		return false, fmt.Errorf("failed to check existence for id %s: %w", id, err)
+		return false, fmt.Errorf("Failed to DeleteItem for id %s %w", item.Id, err)
+	}
+
+	return true, nil
+}
+
+// RecordWear reads the item to work out its LastWornAt, then writes it with the wear in a single
+// transaction conditional on the version read, retrying when another write got in between.
+func (d *DynamoDBClothingRepository) RecordWear(ctx context.Context, userId, id string, wornAt time.Time) (domain.Clothing, error) {
+	wear, err := validateWear(userId, id, wornAt)
+
+	if err != nil {
+		return domain.Clothing{}, err
+	}
+
+	wearItem, err := attributevalue.MarshalMap(wear)
+
+	if err != nil {
+		// this shouldn't ever be possible, but as a guard. As a result, will not unit test.
+		return domain.Clothing{}, fmt.Errorf("failed to marshal wear for DynamoDB: %w", err)
+	}
+
+	wearItem["UserId"] = &types.AttributeValueMemberS{Value: userId}
+	wearItem["Id"] = &types.AttributeValueMemberS{Value: wearSortKeyPrefix + wearKey(id, wear)}
+
+	for attempt := 1; ; attempt++ {
+		stored, err := d.GetById(ctx, userId, id)
+
+		if err != nil {
+			return domain.Clothing{}, err
+		}
+
+		worn := applyWear(stored, wear)
+
+		_, err = d.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+			TransactItems: []types.TransactWriteItem{
+				{
+					Update: &types.Update{
+						TableName: aws.String(d.tableName),
+						Key: map[string]types.AttributeValue{
+							"UserId": &types.AttributeValueMemberS{Value: userId},
+							"Id":     &types.AttributeValueMemberS{Value: id},
+						},
+						UpdateExpression:         aws.String("SET LastWornAt = :lastWornAt ADD WearCount :one, #Version :one"),
+						ConditionExpression:      aws.String("attribute_exists(Id) AND attribute_not_exists(DeletedAt) AND #Version = :version"),
+						ExpressionAttributeNames: map[string]string{"#Version": "Version"},
+						ExpressionAttributeValues: map[string]types.AttributeValue{
+							":one":        &types.AttributeValueMemberN{Value: "1"},
+							":version":    &types.AttributeValueMemberN{Value: strconv.FormatInt(stored.Version, 10)},
+							":lastWornAt": &types.AttributeValueMemberN{Value: strconv.FormatInt(worn.LastWornAt.Unix(), 10)},
+						},
+					},
+				},
+				{Put: &types.Put{TableName: aws.String(d.tableName), Item: wearItem}},
+			},
+		})
+
+		if err == nil {
+			return worn, nil
+		}
+
+		var cancelled *types.TransactionCanceledException
+
+		if !errors.As(err, &cancelled) {
+			return domain.Clothing{}, fmt.Errorf("failed to record wear in DynamoDB: %w", err)
+		}
+
+		if attempt == maxTransactAttempts {
+			return domain.Clothing{}, fmt.Errorf("failed to record wear in DynamoDB after %d attempts: %w", attempt, err)
+		}
+	}
+}
+
+func (d *DynamoDBClothingRepository) ListWears(ctx context.Context, userId, id string) ([]domain.Wear, error) {
+	if err := validateTrashArgs(userId, id); err != nil {
+		return []domain.Wear{}, err
+	}
+
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(d.tableName),
+		KeyConditionExpression: aws.String("UserId = :uid AND begins_with(Id, :prefix)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":uid":    &types.AttributeValueMemberS{Value: userId},
+			":prefix": &types.AttributeValueMemberS{Value: wearSortKeyPrefix + id + "#"},
+		},
+	}
+
+	wears := []domain.Wear{}
+
+	for {
+		result, err := d.client.Query(ctx, input)
+
+		if err != nil {
+			return []domain.Wear{}, fmt.Errorf("failed to query wears from DynamoDB: %w", err)
+		}
+
+		var page []domain.Wear
+
+		if err := attributevalue.UnmarshalListOfMaps(result.Items, &page); err != nil {
+			return []domain.Wear{}, fmt.Errorf("failed to unmarshal wears from DynamoDB: %w", err)
+		}
+
+		wears = append(wears, page...)
+
+		if len(result.LastEvaluatedKey) == 0 {
+			break
+		}
+
+		input.ExclusiveStartKey = result.LastEvaluatedKey
 	}
 
-	return len(out.Item) != 0, nil
+	return wears, nil
 }
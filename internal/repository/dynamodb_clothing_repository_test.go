@@ -3,8 +3,10 @@ package repository
 import (
 	"clothes_management/internal/domain"
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 	"sync"
 	"testing"
@@ -124,6 +126,35 @@ func setupLocalStackDynamoDBClient(t *testing.T, shouldClear bool) *dynamodb.Cli
 	return client
 }
 
+// saveSettingsRow saves the test user's settings, giving their partition a metadata row under
+// settingsSortKey for the single item calls to leave alone.
+func saveSettingsRow(t *testing.T, client *dynamodb.Client, tableName string) *DynamoDBSettingsRepository {
+	t.Helper()
+
+	settings, err := NewDynamoDBSettingsRepository(client, tableName)
+
+	if err != nil {
+		t.Fatalf("Expected no err on NewDynamoDBSettingsRepository, got %v", err)
+	}
+
+	if _, err := settings.Save(context.Background(), "test-user-id", domain.Settings{Hemisphere: domain.SouthernHemisphere}); err != nil {
+		t.Fatalf("Expected no err saving settings, got %v", err)
+	}
+
+	return settings
+}
+
+// expectSettingsRowKept fails the test if the row saved by saveSettingsRow was changed.
+func expectSettingsRowKept(t *testing.T, settings *DynamoDBSettingsRepository) {
+	t.Helper()
+
+	stored, err := settings.Get(context.Background(), "test-user-id")
+
+	if err != nil || stored.Hemisphere != domain.SouthernHemisphere {
+		t.Errorf("Expected the settings row to be kept, got %+v and %v", stored, err)
+	}
+}
+
 func TestNewDynamoDBClothingRepository(t *testing.T) {
 
 	t.Run("Given client is nil, should error", func(t *testing.T) {
@@ -221,7 +252,7 @@ func TestDynamoSave(t *testing.T) {
 			Price:        2000,
 		}
 
-		item, err := repo.Save("", clothingItem)
+		item, err := repo.Save(context.Background(), "", clothingItem)
 
 		if err == nil {
 			t.Error("Expected an error, got nil")
@@ -264,7 +295,7 @@ func TestDynamoSave(t *testing.T) {
 			Price:        -2000,
 		}
 
-		item, err := repo.Save("test-user-id", clothingItem)
+		item, err := repo.Save(context.Background(), "test-user-id", clothingItem)
 
 		if err == nil {
 			t.Error("Expected an error, got nil")
@@ -309,7 +340,7 @@ func TestDynamoSave(t *testing.T) {
 			Price:        2000,
 		}
 
-		_, err = repo.Save("test-user-id", clothingItem)
+		_, err = repo.Save(context.Background(), "test-user-id", clothingItem)
 
 		if err == nil {
 			t.Error("Expected to error")
@@ -350,7 +381,7 @@ func TestDynamoSave(t *testing.T) {
 			Price:        2000,
 		}
 
-		item, err := repo.Save("test-user-id", clothingItem)
+		item, err := repo.Save(context.Background(), "test-user-id", clothingItem)
 
 		if err != nil {
 			t.Errorf("Expected not error, got %v", err)
@@ -391,7 +422,7 @@ func TestDynamoGetAll(t *testing.T) {
 			t.Fatal("repo should not be null")
 		}
 
-		items, err := repo.GetAll("")
+		items, err := repo.GetAll(context.Background(), "")
 
 		if len(items) > 0 {
 			t.Errorf("Expected no items, got %d", len(items))
@@ -426,7 +457,7 @@ func TestDynamoGetAll(t *testing.T) {
 			t.Fatal("repo should not be null")
 		}
 
-		items, err := repo.GetAll("test-user-id")
+		items, err := repo.GetAll(context.Background(), "test-user-id")
 
 		if err != nil {
 			t.Errorf("Expected not error, got %v", err)
@@ -470,13 +501,13 @@ func TestDynamoGetAll(t *testing.T) {
 			Price:        2000,
 		}
 
-		_, err = repo.Save("test-user-id", item)
+		_, err = repo.Save(context.Background(), "test-user-id", item)
 
 		if err == nil {
 			t.Errorf("Expected error when saving")
 		}
 
-		_, err = repo.GetAll("test-user-id")
+		_, err = repo.GetAll(context.Background(), "test-user-id")
 
 		if err == nil {
 			t.Errorf("Expected error on GetAll")
@@ -517,13 +548,13 @@ func TestDynamoGetAll(t *testing.T) {
 			Price:        2000,
 		}
 
-		_, err = repo.Save("test-user-id", item)
+		_, err = repo.Save(context.Background(), "test-user-id", item)
 
 		if err != nil {
 			t.Errorf("Expected no error when saving %s, got %v", item.Description, err)
 		}
 
-		items, err := repo.GetAll("test-user-id")
+		items, err := repo.GetAll(context.Background(), "test-user-id")
 
 		if err != nil {
 			t.Errorf("Expected not error, got %v", err)
@@ -566,7 +597,7 @@ func TestDynamoGetAll(t *testing.T) {
 			Price:        2000,
 		}
 
-		_, err = repo.Save("test-user-id", item)
+		_, err = repo.Save(context.Background(), "test-user-id", item)
 
 		if err != nil {
 			t.Errorf("Expected no error when saving %s, got %v", item.Description, err)
@@ -581,13 +612,13 @@ func TestDynamoGetAll(t *testing.T) {
 			Price:        2000,
 		}
 
-		_, err = repo.Save("test-user-id", item)
+		_, err = repo.Save(context.Background(), "test-user-id", item)
 
 		if err != nil {
 			t.Errorf("Expected no error when saving %s, got %v", item.Description, err)
 		}
 
-		items, err := repo.GetAll("test-user-id")
+		items, err := repo.GetAll(context.Background(), "test-user-id")
 
 		if err != nil {
 			t.Errorf("Expected not error, got %v", err)
@@ -629,13 +660,13 @@ func TestDynamoGetAll(t *testing.T) {
 			Price:        2000,
 		}
 
-		_, err = repo.Save("test-user-id", item)
+		_, err = repo.Save(context.Background(), "test-user-id", item)
 
 		if err != nil {
 			t.Errorf("Expected no error when saving %s, got %v", item.Description, err)
 		}
 
-		items, err := repo.GetAll("test-user-id-1")
+		items, err := repo.GetAll(context.Background(), "test-user-id-1")
 
 		if err != nil {
 			t.Errorf("Expected not error, got %v", err)
@@ -652,6 +683,254 @@ func TestDynamoGetAll(t *testing.T) {
 	})
 }
 
+func TestDynamoGetPage(t *testing.T) {
+
+	t.Run("Given empty user Id, should return an empty page and error", func(t *testing.T) {
+		client := setupLocalStackDynamoDBClient(t, true)
+
+		dynamoTableName := os.Getenv("DYNAMODB_TABLE_NAME")
+		if dynamoTableName == "" {
+			t.Fatal("ERROR: DYNAMODB_TABLE_NAME environment variable not set. Please set it in .env_test or your shell.")
+		}
+
+		repo, err := NewDynamoDBClothingRepository(client, dynamoTableName)
+
+		if err != nil {
+			t.Fatalf("Expected no err on NewDynamoDBClothingRepository, got %v", err)
+		}
+
+		page, err := repo.GetPage(context.Background(), "", PageRequest{})
+
+		if len(page.Items) > 0 {
+			t.Errorf("Expected no items, got %d", len(page.Items))
+		}
+
+		if err == nil {
+			t.Fatalf("Expected to get err, but didn't")
+		}
+
+		expectedMessage := "User ID must not be empty or whitespace"
+
+		if !strings.Contains(err.Error(), expectedMessage) {
+			t.Errorf("Expected %s got %s", expectedMessage, err.Error())
+		}
+	})
+
+	t.Run("Given a cursor issued for another user, should return ErrInvalidCursor", func(t *testing.T) {
+		client := setupLocalStackDynamoDBClient(t, true)
+
+		dynamoTableName := os.Getenv("DYNAMODB_TABLE_NAME")
+		if dynamoTableName == "" {
+			t.Fatal("ERROR: DYNAMODB_TABLE_NAME environment variable not set. Please set it in .env_test or your shell.")
+		}
+
+		repo, err := NewDynamoDBClothingRepository(client, dynamoTableName)
+
+		if err != nil {
+			t.Fatalf("Expected no err on NewDynamoDBClothingRepository, got %v", err)
+		}
+
+		cursor, err := encodeCursor(map[string]string{"UserId": "other-user-id", "Id": "some-id"})
+
+		if err != nil {
+			t.Fatalf("Expected no err on encodeCursor, got %v", err)
+		}
+
+		_, err = repo.GetPage(context.Background(), "test-user-id", PageRequest{Cursor: cursor})
+
+		if !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("Expected ErrInvalidCursor, got %v", err)
+		}
+	})
+
+	t.Run("Given more items than the limit, should page through every item once", func(t *testing.T) {
+		client := setupLocalStackDynamoDBClient(t, true)
+
+		dynamoTableName := os.Getenv("DYNAMODB_TABLE_NAME")
+		if dynamoTableName == "" {
+			t.Fatal("ERROR: DYNAMODB_TABLE_NAME environment variable not set. Please set it in .env_test or your shell.")
+		}
+
+		repo, err := NewDynamoDBClothingRepository(client, dynamoTableName)
+
+		if err != nil {
+			t.Fatalf("Expected no err on NewDynamoDBClothingRepository, got %v", err)
+		}
+
+		numItems := 7
+
+		for i := 0; i < numItems; i++ {
+			_, err := repo.Save(context.Background(), "test-user-id", domain.Clothing{
+				ClothingType: "Jumper",
+				Description:  fmt.Sprintf("Jumper %d", i),
+				Store:        "This Store",
+				Size:         "L",
+				Brand:        "XYZ",
+				Price:        2000,
+			})
+
+			if err != nil {
+				t.Fatalf("Expected no error on Save, got %v", err)
+			}
+		}
+
+		seen := map[string]bool{}
+		cursor := ""
+
+		for {
+			page, err := repo.GetPage(context.Background(), "test-user-id", PageRequest{Limit: 3, Cursor: cursor})
+
+			if err != nil {
+				t.Fatalf("Expected no error on GetPage, got %v", err)
+			}
+
+			if len(page.Items) > 3 {
+				t.Errorf("Expected at most 3 items, got %d", len(page.Items))
+			}
+
+			for _, item := range page.Items {
+				if seen[item.Id] {
+					t.Errorf("Item %s returned on more than one page", item.Id)
+				}
+				seen[item.Id] = true
+			}
+
+			if page.NextCursor == "" {
+				break
+			}
+
+			cursor = page.NextCursor
+		}
+
+		if len(seen) != numItems {
+			t.Errorf("Expected %d items, got %d", numItems, len(seen))
+		}
+
+		t.Cleanup(func() {
+			clearDynamoDBTable(t, client, dynamoTableName)
+		})
+	})
+
+	t.Run("Given a filter, should only return matching items and fill each page", func(t *testing.T) {
+		client := setupLocalStackDynamoDBClient(t, true)
+
+		dynamoTableName := os.Getenv("DYNAMODB_TABLE_NAME")
+		if dynamoTableName == "" {
+			t.Fatal("ERROR: DYNAMODB_TABLE_NAME environment variable not set. Please set it in .env_test or your shell.")
+		}
+
+		repo, err := NewDynamoDBClothingRepository(client, dynamoTableName)
+
+		if err != nil {
+			t.Fatalf("Expected no err on NewDynamoDBClothingRepository, got %v", err)
+		}
+
+		for i := 0; i < 6; i++ {
+			brand := "XYZ"
+			if i%2 == 0 {
+				brand = "ABC"
+			}
+
+			_, err := repo.Save(context.Background(), "test-user-id", domain.Clothing{
+				ClothingType: "Jumper",
+				Description:  fmt.Sprintf("Jumper %d", i),
+				Store:        "This Store",
+				Size:         "L",
+				Brand:        brand,
+				Price:        domain.Pence(1000 * i),
+			})
+
+			if err != nil {
+				t.Fatalf("Expected no error on Save, got %v", err)
+			}
+		}
+
+		page, err := repo.GetPage(context.Background(), "test-user-id", PageRequest{
+			Limit:  2,
+			Filter: ClothingFilter{Brand: "ABC", Size: "L", MinPricePence: pencePointer(1000), Currency: domain.DefaultCurrency},
+		})
+
+		if err != nil {
+			t.Fatalf("Expected no error on GetPage, got %v", err)
+		}
+
+		if len(page.Items) != 2 {
+			t.Fatalf("Expected 2 items, got %d", len(page.Items))
+		}
+
+		for _, item := range page.Items {
+			if item.Brand != "ABC" || item.Price < 1000 {
+				t.Errorf("Expected only matching items, got %+v", item)
+			}
+		}
+
+		t.Cleanup(func() {
+			clearDynamoDBTable(t, client, dynamoTableName)
+		})
+	})
+
+	t.Run("Given sort keys and a limit, should page through the items in that order", func(t *testing.T) {
+		client := setupLocalStackDynamoDBClient(t, true)
+
+		dynamoTableName := os.Getenv("DYNAMODB_TABLE_NAME")
+		if dynamoTableName == "" {
+			t.Fatal("ERROR: DYNAMODB_TABLE_NAME environment variable not set. Please set it in .env_test or your shell.")
+		}
+
+		repo, err := NewDynamoDBClothingRepository(client, dynamoTableName)
+
+		if err != nil {
+			t.Fatalf("Expected no err on NewDynamoDBClothingRepository, got %v", err)
+		}
+
+		for _, price := range []domain.Pence{1500, 500, 2500, 1000} {
+			_, err := repo.Save(context.Background(), "test-user-id", domain.Clothing{
+				ClothingType: "Jumper",
+				Description:  "Jumper",
+				Store:        "This Store",
+				Size:         "L",
+				Brand:        "XYZ",
+				Price:        price,
+			})
+
+			if err != nil {
+				t.Fatalf("Expected no error on Save, got %v", err)
+			}
+		}
+
+		request := PageRequest{Limit: 3, Sort: []SortKey{{Field: SortByPrice}}}
+
+		first, err := repo.GetPage(context.Background(), "test-user-id", request)
+
+		if err != nil {
+			t.Fatalf("Expected no error on GetPage, got %v", err)
+		}
+
+		request.Cursor = first.NextCursor
+		second, err := repo.GetPage(context.Background(), "test-user-id", request)
+
+		if err != nil {
+			t.Fatalf("Expected no error on GetPage, got %v", err)
+		}
+
+		var prices []domain.Pence
+
+		for _, item := range append(first.Items, second.Items...) {
+			prices = append(prices, item.Price)
+		}
+
+		expected := []domain.Pence{500, 1000, 1500, 2500}
+
+		if !slices.Equal(prices, expected) {
+			t.Errorf("Expected %v, got %v", expected, prices)
+		}
+
+		t.Cleanup(func() {
+			clearDynamoDBTable(t, client, dynamoTableName)
+		})
+	})
+}
+
 func TestDynamoGetById(t *testing.T) {
 
 	t.Run("When table doesn't exist, should return an error", func(t *testing.T) {
@@ -674,7 +953,7 @@ func TestDynamoGetById(t *testing.T) {
 			t.Fatal("repo should not be null")
 		}
 
-		_, err = repo.GetById("test-user-id", "dummy-id-123")
+		_, err = repo.GetById(context.Background(), "test-user-id", "dummy-id-123")
 
 		expectedMessage := "Failed to GetItem for id"
 
@@ -706,7 +985,7 @@ func TestDynamoGetById(t *testing.T) {
 			t.Fatal("repo should not be null")
 		}
 
-		_, err = repo.GetById("", "dummy-id-123")
+		_, err = repo.GetById(context.Background(), "", "dummy-id-123")
 
 		if err == nil {
 			t.Errorf("Expected an error %v", err)
@@ -737,7 +1016,7 @@ func TestDynamoGetById(t *testing.T) {
 			t.Fatal("repo should not be null")
 		}
 
-		_, err = repo.GetById("test-user-id", "dummy-id-123")
+		_, err = repo.GetById(context.Background(), "test-user-id", "dummy-id-123")
 
 		if err == nil {
 			t.Errorf("Expected an error")
@@ -769,7 +1048,7 @@ func TestDynamoGetById(t *testing.T) {
 			t.Fatal("repo should not be null")
 		}
 
-		savedItem, err := repo.Save("test-user-id", domain.Clothing{
+		savedItem, err := repo.Save(context.Background(), "test-user-id", domain.Clothing{
 			ClothingType: "Jumper",
 			Description:  "This Jumper",
 			Store:        "This Store",
@@ -782,7 +1061,7 @@ func TestDynamoGetById(t *testing.T) {
 			t.Errorf("Expected no error on save item, got %v", err)
 		}
 
-		_, err = repo.GetById("test-user-id-1", savedItem.Id)
+		_, err = repo.GetById(context.Background(), "test-user-id-1", savedItem.Id)
 
 		if err == nil {
 			t.Errorf("Expected an error")
@@ -815,7 +1094,7 @@ func TestDynamoGetById(t *testing.T) {
 
 		dummyId := "dummy-id-123"
 
-		savedItem, err := repo.Save("test-user-id", domain.Clothing{
+		savedItem, err := repo.Save(context.Background(), "test-user-id", domain.Clothing{
 			ClothingType: "Jumper",
 			Description:  "This Jumper",
 			Store:        "This Store",
@@ -828,7 +1107,7 @@ func TestDynamoGetById(t *testing.T) {
 			t.Errorf("Expected no error on save item, got %v", err)
 		}
 
-		item, err := repo.GetById("test-user-id", savedItem.Id)
+		item, err := repo.GetById(context.Background(), "test-user-id", savedItem.Id)
 
 		if err != nil {
 			t.Errorf("Expected not error, got %v", err)
@@ -838,6 +1117,35 @@ func TestDynamoGetById(t *testing.T) {
 			t.Errorf("Expected item.Id = %s, got %s", dummyId, item.Id)
 		}
 	})
+
+	t.Run("Given the id of a metadata row, should return ErrNotFound", func(t *testing.T) {
+		client := setupLocalStackDynamoDBClient(t, true)
+
+		dynamoTableName := os.Getenv("DYNAMODB_TABLE_NAME")
+		if dynamoTableName == "" {
+			t.Fatal("ERROR: DYNAMODB_TABLE_NAME environment variable not set. Please set it in .env_test or your shell.")
+		}
+
+		repo, err := NewDynamoDBClothingRepository(client, dynamoTableName)
+
+		if err != nil {
+			t.Fatalf("Expected no err on NewDynamoDBClothingRepository, got %v", err)
+		}
+
+		settings := saveSettingsRow(t, client, dynamoTableName)
+
+		_, err = repo.GetById(context.Background(), "test-user-id", settingsSortKey)
+
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("Expected ErrNotFound, got %v", err)
+		}
+
+		expectSettingsRowKept(t, settings)
+
+		t.Cleanup(func() {
+			clearDynamoDBTable(t, client, dynamoTableName)
+		})
+	})
 }
 
 func TestDynamoUpdate(t *testing.T) {
@@ -872,7 +1180,7 @@ func TestDynamoUpdate(t *testing.T) {
 			Price:        -2000,
 		}
 
-		_, err = repo.Update("test-user-id", item)
+		_, err = repo.Update(context.Background(), "test-user-id", item)
 
 		if err == nil {
 			t.Errorf("Expected an error")
@@ -913,7 +1221,7 @@ func TestDynamoUpdate(t *testing.T) {
 			Price:        domain.Pence(2000),
 		}
 
-		_, err = repo.Update("", item)
+		_, err = repo.Update(context.Background(), "", item)
 
 		if err == nil {
 			t.Errorf("Expected an error %v", err)
@@ -953,7 +1261,7 @@ func TestDynamoUpdate(t *testing.T) {
 			Price:        2000,
 		}
 
-		_, err = repo.Update("test-user-id", item)
+		_, err = repo.Update(context.Background(), "test-user-id", item)
 
 		if err == nil {
 			t.Errorf("Expected an error")
@@ -997,7 +1305,7 @@ func TestDynamoUpdate(t *testing.T) {
 			Price:        2000,
 		}
 
-		_, err = repo.Update("test-user-id", item)
+		_, err = repo.Update(context.Background(), "test-user-id", item)
 
 		expectedMessage := "failed to update item into DynamoDB:"
 
@@ -1040,7 +1348,7 @@ func TestDynamoUpdate(t *testing.T) {
 			Price:        originalPrice,
 		}
 
-		item, err = repo.Save("test-user-id", item)
+		item, err = repo.Save(context.Background(), "test-user-id", item)
 
 		if err != nil {
 			t.Fatalf("Expected not to err, got %v", err)
@@ -1050,13 +1358,13 @@ func TestDynamoUpdate(t *testing.T) {
 
 		item.Price = newPrice
 
-		item, err = repo.Update("test-user-id", item)
+		item, err = repo.Update(context.Background(), "test-user-id", item)
 
 		if item.Price == originalPrice {
 			t.Errorf("Expected %d got %d", newPrice, originalPrice)
 		}
 
-		item, err = repo.GetById("test-user-id", item.Id)
+		item, err = repo.GetById(context.Background(), "test-user-id", item.Id)
 
 		if err != nil {
 			t.Fatalf("Expected not to err, got %v", err)
@@ -1072,9 +1380,37 @@ func TestDynamoUpdate(t *testing.T) {
 
 	})
 
+	t.Run("Given the id of a metadata row, should return ErrNotFound and not write it", func(t *testing.T) {
+		client := setupLocalStackDynamoDBClient(t, true)
+
+		dynamoTableName := os.Getenv("DYNAMODB_TABLE_NAME")
+		if dynamoTableName == "" {
+			t.Fatal("ERROR: DYNAMODB_TABLE_NAME environment variable not set. Please set it in .env_test or your shell.")
+		}
+
+		repo, err := NewDynamoDBClothingRepository(client, dynamoTableName)
+
+		if err != nil {
+			t.Fatalf("Expected no err on NewDynamoDBClothingRepository, got %v", err)
+		}
+
+		settings := saveSettingsRow(t, client, dynamoTableName)
+
+		_, err = repo.Update(context.Background(), "test-user-id", domain.Clothing{Id: settingsSortKey, ClothingType: "Jumper", Description: "This Jumper", Store: "This Store", Size: "L", Brand: "XYZ", Price: 2000, Version: 1})
+
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("Expected ErrNotFound, got %v", err)
+		}
+
+		expectSettingsRowKept(t, settings)
+
+		t.Cleanup(func() {
+			clearDynamoDBTable(t, client, dynamoTableName)
+		})
+	})
 }
 
-func TestDynamoDelete(t *testing.T) {
+func TestDynamoTrash(t *testing.T) {
 
 	t.Run("When ID is empty, should return an error", func(t *testing.T) {
 		client := setupLocalStackDynamoDBClient(t, true)
@@ -1096,7 +1432,7 @@ func TestDynamoDelete(t *testing.T) {
 
 		dummyId := ""
 
-		err = repo.Delete("test-user-id", dummyId)
+		_, err = repo.Trash(context.Background(), "test-user-id", dummyId, 0)
 
 		if err == nil {
 			t.Fatalf("Expected an error")
@@ -1110,7 +1446,7 @@ func TestDynamoDelete(t *testing.T) {
 
 	})
 
-	t.Run("When userId is empty, Delete should return an error", func(t *testing.T) {
+	t.Run("When userId is empty, Trash should return an error", func(t *testing.T) {
 		client := setupLocalStackDynamoDBClient(t, true)
 
 		dynamoTableName := os.Getenv("DYNAMODB_TABLE_NAME")
@@ -1128,7 +1464,7 @@ func TestDynamoDelete(t *testing.T) {
 			t.Fatal("repo should not be null")
 		}
 
-		err = repo.Delete("", "dummy-id-123")
+		_, err = repo.Trash(context.Background(), "", "dummy-id-123", 0)
 
 		if err == nil {
 			t.Errorf("Expected an error %v", err)
@@ -1161,13 +1497,13 @@ func TestDynamoDelete(t *testing.T) {
 			t.Fatal("repo should not be null")
 		}
 
-		err = repo.Delete("test-user-id", "dummy-id-123")
+		_, err = repo.Trash(context.Background(), "test-user-id", "dummy-id-123", 0)
 
 		if err == nil {
 			t.Fatal("Expected an error")
 		}
 
-		expectedMessage := "Failed to DeleteItem for id"
+		expectedMessage := "failed to update item into DynamoDB"
 		if !strings.Contains(err.Error(), expectedMessage) {
 			t.Errorf("Expected error to contain %q, got %q", expectedMessage, err.Error())
 		}
@@ -1193,7 +1529,7 @@ func TestDynamoDelete(t *testing.T) {
 
 		dummyId := "dummy-id-123"
 
-		err = repo.Delete("test-user-id", dummyId)
+		_, err = repo.Trash(context.Background(), "test-user-id", dummyId, 0)
 
 		if err == nil {
 			t.Fatalf("Expected an error")
@@ -1207,7 +1543,7 @@ func TestDynamoDelete(t *testing.T) {
 
 	})
 
-	t.Run("When the item doesn't exist for user, Delete should return error", func(t *testing.T) {
+	t.Run("When the item doesn't exist for user, Trash should return error", func(t *testing.T) {
 		client := setupLocalStackDynamoDBClient(t, true)
 
 		dynamoTableName := os.Getenv("DYNAMODB_TABLE_NAME")
@@ -1225,7 +1561,7 @@ func TestDynamoDelete(t *testing.T) {
 			t.Fatal("repo should not be null")
 		}
 
-		savedItem, err := repo.Save("test-user-id", domain.Clothing{
+		savedItem, err := repo.Save(context.Background(), "test-user-id", domain.Clothing{
 			ClothingType: "Jumper",
 			Description:  "This Jumper",
 			Store:        "This Store",
@@ -1238,7 +1574,7 @@ func TestDynamoDelete(t *testing.T) {
 			t.Errorf("Expected no error on save item, got %v", err)
 		}
 
-		err = repo.Delete("test-user-id-1", savedItem.Id)
+		_, err = repo.Trash(context.Background(), "test-user-id-1", savedItem.Id, 0)
 
 		if err == nil {
 			t.Errorf("Expected an error")
@@ -1251,7 +1587,7 @@ func TestDynamoDelete(t *testing.T) {
 		}
 	})
 
-	t.Run("When ID does exist, should delete succesfully", func(t *testing.T) {
+	t.Run("When ID does exist, should trash succesfully", func(t *testing.T) {
 		client := setupLocalStackDynamoDBClient(t, true)
 
 		dynamoTableName := os.Getenv("DYNAMODB_TABLE_NAME")
@@ -1278,7 +1614,7 @@ func TestDynamoDelete(t *testing.T) {
 			Price:        2000,
 		}
 
-		saved, err := repo.Save("test-user-id", item)
+		saved, err := repo.Save(context.Background(), "test-user-id", item)
 		if err != nil {
 			t.Fatalf("Expected no error saving item, got %v", err)
 		}
@@ -1286,7 +1622,7 @@ func TestDynamoDelete(t *testing.T) {
 			t.Fatal("Expected saved item to have an ID")
 		}
 
-		items, err := repo.GetAll("test-user-id")
+		items, err := repo.GetAll(context.Background(), "test-user-id")
 
 		if err != nil {
 			t.Fatalf("Expected no error getting items after save, got %v", err)
@@ -1294,22 +1630,22 @@ func TestDynamoDelete(t *testing.T) {
 
 		itemCountPostSave := len(items)
 
-		err = repo.Delete("test-user-id", saved.Id)
+		_, err = repo.Trash(context.Background(), "test-user-id", saved.Id, 0)
 
 		if err != nil {
 			t.Fatalf("Expected no error, got %v", err)
 		}
 
-		items, err = repo.GetAll("test-user-id")
+		items, err = repo.GetAll(context.Background(), "test-user-id")
 
 		if err != nil {
-			t.Fatalf("Expected no error getting items after delete, got %v", err)
+			t.Fatalf("Expected no error getting items after trash, got %v", err)
 		}
 
-		itemCountPostDelete := len(items)
+		itemCountPostTrash := len(items)
 
-		if itemCountPostDelete != itemCountPostSave-1 {
-			t.Errorf("Post Save Count = %d, Post Delete Count = %d, Expected Post Delete Count = %d", itemCountPostSave, itemCountPostDelete, itemCountPostSave-1)
+		if itemCountPostTrash != itemCountPostSave-1 {
+			t.Errorf("Post Save Count = %d, Post Trash Count = %d, Expected Post Trash Count = %d", itemCountPostSave, itemCountPostTrash, itemCountPostSave-1)
 		}
 
 		t.Cleanup(func() {
@@ -1317,6 +1653,42 @@ func TestDynamoDelete(t *testing.T) {
 		})
 	})
 
+	t.Run("Given the id of a metadata row, Trash, Restore and TrashMany should not move it", func(t *testing.T) {
+		client := setupLocalStackDynamoDBClient(t, true)
+
+		dynamoTableName := os.Getenv("DYNAMODB_TABLE_NAME")
+		if dynamoTableName == "" {
+			t.Fatal("ERROR: DYNAMODB_TABLE_NAME environment variable not set. Please set it in .env_test or your shell.")
+		}
+
+		repo, err := NewDynamoDBClothingRepository(client, dynamoTableName)
+
+		if err != nil {
+			t.Fatalf("Expected no err on NewDynamoDBClothingRepository, got %v", err)
+		}
+
+		settings := saveSettingsRow(t, client, dynamoTableName)
+
+		if _, err := repo.Trash(context.Background(), "test-user-id", settingsSortKey, 0); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Expected ErrNotFound from Trash, got %v", err)
+		}
+
+		if _, err := repo.Restore(context.Background(), "test-user-id", settingsSortKey, 0); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Expected ErrNotFound from Restore, got %v", err)
+		}
+
+		trashed, err := repo.TrashMany(context.Background(), "test-user-id", []string{settingsSortKey})
+
+		if err != nil || len(trashed) != 0 {
+			t.Errorf("Expected TrashMany to trash nothing, got %v and %v", trashed, err)
+		}
+
+		expectSettingsRowKept(t, settings)
+
+		t.Cleanup(func() {
+			clearDynamoDBTable(t, client, dynamoTableName)
+		})
+	})
 }
 
 func TestDynamoExists(t *testing.T) {
@@ -1338,7 +1710,7 @@ func TestDynamoExists(t *testing.T) {
 
 		id := ""
 
-		exists, err := repo.Exists("test-user-id", id)
+		exists, err := repo.Exists(context.Background(), "test-user-id", id)
 
 		if exists {
 			t.Error("Expected exists = false")
@@ -1371,7 +1743,7 @@ func TestDynamoExists(t *testing.T) {
 			t.Fatal("repo should not be null")
 		}
 
-		exists, err := repo.Exists("", "dummy-id-123")
+		exists, err := repo.Exists(context.Background(), "", "dummy-id-123")
 		if exists {
 			t.Error("Expected exists = false")
 		}
@@ -1404,7 +1776,7 @@ func TestDynamoExists(t *testing.T) {
 			t.Fatal("repo should not be null")
 		}
 
-		exists, err := repo.Exists("test-user-id", "dummy-id-123")
+		exists, err := repo.Exists(context.Background(), "test-user-id", "dummy-id-123")
 
 		if exists {
 			t.Error("Expected exists = false")
@@ -1435,7 +1807,7 @@ func TestDynamoExists(t *testing.T) {
 			t.Fatal("repo should not be null")
 		}
 
-		exists, err := repo.Exists("test-user-id", "does-not-exist")
+		exists, err := repo.Exists(context.Background(), "test-user-id", "does-not-exist")
 
 		if err != nil {
 			t.Fatalf("Expected no error, got %v", err)
@@ -1474,7 +1846,7 @@ func TestDynamoExists(t *testing.T) {
 			Price:        2000,
 		}
 
-		saved, err := repo.Save("test-user-id", item)
+		saved, err := repo.Save(context.Background(), "test-user-id", item)
 		if err != nil {
 			t.Fatalf("Expected no error saving item, got %v", err)
 		}
@@ -1482,7 +1854,7 @@ func TestDynamoExists(t *testing.T) {
 			t.Fatal("Expected saved item to have an ID")
 		}
 
-		exists, err := repo.Exists("test-user-id-1", saved.Id)
+		exists, err := repo.Exists(context.Background(), "test-user-id-1", saved.Id)
 
 		if err != nil {
 			t.Fatalf("Expected no error, got %v", err)
@@ -1521,7 +1893,7 @@ func TestDynamoExists(t *testing.T) {
 			Price:        2000,
 		}
 
-		saved, err := repo.Save("test-user-id", item)
+		saved, err := repo.Save(context.Background(), "test-user-id", item)
 		if err != nil {
 			t.Fatalf("Expected no error saving item, got %v", err)
 		}
@@ -1529,7 +1901,7 @@ func TestDynamoExists(t *testing.T) {
 			t.Fatal("Expected saved item to have an ID")
 		}
 
-		exists, err := repo.Exists("test-user-id", saved.Id)
+		exists, err := repo.Exists(context.Background(), "test-user-id", saved.Id)
 
 		if err != nil {
 			t.Fatalf("Expected no error, got %v", err)
@@ -1542,6 +1914,278 @@ func TestDynamoExists(t *testing.T) {
 			clearDynamoDBTable(t, client, dynamoTableName)
 		})
 	})
+
+	t.Run("Given the id of a metadata row, should return false", func(t *testing.T) {
+		client := setupLocalStackDynamoDBClient(t, true)
+
+		dynamoTableName := os.Getenv("DYNAMODB_TABLE_NAME")
+		if dynamoTableName == "" {
+			t.Fatal("ERROR: DYNAMODB_TABLE_NAME environment variable not set. Please set it in .env_test or your shell.")
+		}
+
+		repo, err := NewDynamoDBClothingRepository(client, dynamoTableName)
+
+		if err != nil {
+			t.Fatalf("Expected no err on NewDynamoDBClothingRepository, got %v", err)
+		}
+
+		settings := saveSettingsRow(t, client, dynamoTableName)
+
+		exists, err := repo.Exists(context.Background(), "test-user-id", settingsSortKey)
+
+		if err != nil || exists {
+			t.Errorf("Expected the row not to exist as an item, got %v and %v", exists, err)
+		}
+
+		expectSettingsRowKept(t, settings)
+
+		t.Cleanup(func() {
+			clearDynamoDBTable(t, client, dynamoTableName)
+		})
+	})
+}
+
+func TestDynamoTypedErrors(t *testing.T) {
+	t.Run("Given invalid clothing, Save should return a ValidationError", func(t *testing.T) {
+		client := setupLocalStackDynamoDBClient(t, true)
+
+		dynamoTableName := os.Getenv("DYNAMODB_TABLE_NAME")
+		if dynamoTableName == "" {
+			t.Fatal("ERROR: DYNAMODB_TABLE_NAME environment variable not set. Please set it in .env_test or your shell.")
+		}
+
+		repo, err := NewDynamoDBClothingRepository(client, dynamoTableName)
+
+		if err != nil {
+			t.Fatalf("Expected no err on NewDynamoDBClothingRepository, got %v", err)
+		}
+
+		_, err = repo.Save(context.Background(), "test-user-id", domain.Clothing{Price: -1})
+
+		var validationErr *ValidationError
+
+		if !errors.As(err, &validationErr) {
+			t.Errorf("Expected ValidationError, got %v", err)
+		}
+	})
+
+	t.Run("Given a caller supplied Id that already exists, Save should ignore it and generate a new one", func(t *testing.T) {
+		client := setupLocalStackDynamoDBClient(t, true)
+
+		dynamoTableName := os.Getenv("DYNAMODB_TABLE_NAME")
+		if dynamoTableName == "" {
+			t.Fatal("ERROR: DYNAMODB_TABLE_NAME environment variable not set. Please set it in .env_test or your shell.")
+		}
+
+		repo, err := NewDynamoDBClothingRepository(client, dynamoTableName)
+
+		if err != nil {
+			t.Fatalf("Expected no err on NewDynamoDBClothingRepository, got %v", err)
+		}
+
+		item := domain.Clothing{
+			ClothingType: "Jumper",
+			Description:  "This Jumper",
+			Store:        "This Store",
+			Size:         "L",
+			Brand:        "XYZ",
+			Price:        2000,
+		}
+
+		item, err = repo.Save(context.Background(), "test-user-id", item)
+
+		if err != nil {
+			t.Fatalf("Expected no error on Save, got %v", err)
+		}
+
+		second, err := repo.Save(context.Background(), "test-user-id", item)
+
+		if err != nil {
+			t.Fatalf("Expected no error on Save, got %v", err)
+		}
+
+		if second.Id == item.Id {
+			t.Errorf("Expected a new id, got the caller's %s", second.Id)
+		}
+
+		t.Cleanup(func() {
+			clearDynamoDBTable(t, client, dynamoTableName)
+		})
+	})
+
+	t.Run("Given a missing item, GetById, Update and Trash should return ErrNotFound", func(t *testing.T) {
+		client := setupLocalStackDynamoDBClient(t, true)
+
+		dynamoTableName := os.Getenv("DYNAMODB_TABLE_NAME")
+		if dynamoTableName == "" {
+			t.Fatal("ERROR: DYNAMODB_TABLE_NAME environment variable not set. Please set it in .env_test or your shell.")
+		}
+
+		repo, err := NewDynamoDBClothingRepository(client, dynamoTableName)
+
+		if err != nil {
+			t.Fatalf("Expected no err on NewDynamoDBClothingRepository, got %v", err)
+		}
+
+		item := domain.Clothing{
+			Id:           "missing-id",
+			ClothingType: "Jumper",
+			Description:  "This Jumper",
+			Store:        "This Store",
+			Size:         "L",
+			Brand:        "XYZ",
+			Price:        2000,
+		}
+
+		_, err = repo.GetById(context.Background(), "test-user-id", item.Id)
+
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("Expected ErrNotFound from GetById, got %v", err)
+		}
+
+		_, err = repo.Update(context.Background(), "test-user-id", item)
+
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("Expected ErrNotFound from Update, got %v", err)
+		}
+
+		_, err = repo.Trash(context.Background(), "test-user-id", item.Id, 0)
+
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("Expected ErrNotFound from Trash, got %v", err)
+		}
+
+		t.Cleanup(func() {
+			clearDynamoDBTable(t, client, dynamoTableName)
+		})
+	})
+}
+
+func TestDynamoVersions(t *testing.T) {
+	t.Run("Given a saved item, Save should set version 1 and every Update should increment it", func(t *testing.T) {
+		client := setupLocalStackDynamoDBClient(t, true)
+
+		dynamoTableName := os.Getenv("DYNAMODB_TABLE_NAME")
+		if dynamoTableName == "" {
+			t.Fatal("ERROR: DYNAMODB_TABLE_NAME environment variable not set. Please set it in .env_test or your shell.")
+		}
+
+		repo, err := NewDynamoDBClothingRepository(client, dynamoTableName)
+
+		if err != nil {
+			t.Fatalf("Expected no err on NewDynamoDBClothingRepository, got %v", err)
+		}
+
+		item, err := repo.Save(context.Background(), "test-user-id", domain.Clothing{
+			ClothingType: "Jumper",
+			Description:  "This Jumper",
+			Store:        "This Store",
+			Size:         "L",
+			Brand:        "XYZ",
+			Price:        2000,
+			Version:      7,
+		})
+
+		if err != nil {
+			t.Fatalf("Expected no error on Save, got %v", err)
+		}
+
+		if item.Version != 1 {
+			t.Fatalf("Expected version 1, got %d", item.Version)
+		}
+
+		item.Price = 1500
+		item, err = repo.Update(context.Background(), "test-user-id", item)
+
+		if err != nil {
+			t.Fatalf("Expected no error on Update, got %v", err)
+		}
+
+		if item.Version != 2 {
+			t.Errorf("Expected version 2, got %d", item.Version)
+		}
+
+		item.Version = 0
+		item, err = repo.Update(context.Background(), "test-user-id", item)
+
+		if err != nil {
+			t.Fatalf("Expected no error on unconditional Update, got %v", err)
+		}
+
+		if item.Version != 3 {
+			t.Errorf("Expected version 3, got %d", item.Version)
+		}
+
+		t.Cleanup(func() {
+			clearDynamoDBTable(t, client, dynamoTableName)
+		})
+	})
+
+	t.Run("Given a stale version, Update and Trash should return ErrPreconditionFailed and leave the item", func(t *testing.T) {
+		client := setupLocalStackDynamoDBClient(t, true)
+
+		dynamoTableName := os.Getenv("DYNAMODB_TABLE_NAME")
+		if dynamoTableName == "" {
+			t.Fatal("ERROR: DYNAMODB_TABLE_NAME environment variable not set. Please set it in .env_test or your shell.")
+		}
+
+		repo, err := NewDynamoDBClothingRepository(client, dynamoTableName)
+
+		if err != nil {
+			t.Fatalf("Expected no err on NewDynamoDBClothingRepository, got %v", err)
+		}
+
+		item, err := repo.Save(context.Background(), "test-user-id", domain.Clothing{
+			ClothingType: "Jumper",
+			Description:  "This Jumper",
+			Store:        "This Store",
+			Size:         "L",
+			Brand:        "XYZ",
+			Price:        2000,
+		})
+
+		if err != nil {
+			t.Fatalf("Expected no error on Save, got %v", err)
+		}
+
+		stale := item
+
+		item.Price = 1500
+		if _, err := repo.Update(context.Background(), "test-user-id", item); err != nil {
+			t.Fatalf("Expected no error on Update, got %v", err)
+		}
+
+		stale.Price = 999
+		_, err = repo.Update(context.Background(), "test-user-id", stale)
+
+		if !errors.Is(err, ErrPreconditionFailed) {
+			t.Errorf("Expected ErrPreconditionFailed from Update, got %v", err)
+		}
+
+		_, err = repo.Trash(context.Background(), "test-user-id", item.Id, stale.Version)
+
+		if !errors.Is(err, ErrPreconditionFailed) {
+			t.Errorf("Expected ErrPreconditionFailed from Trash, got %v", err)
+		}
+
+		stored, err := repo.GetById(context.Background(), "test-user-id", item.Id)
+
+		if err != nil {
+			t.Fatalf("Expected item to still exist, got %v", err)
+		}
+
+		if stored.Price != 1500 || stored.Version != 2 {
+			t.Errorf("Expected price 1500 at version 2, got %d at version %d", stored.Price, stored.Version)
+		}
+
+		if _, err := repo.Trash(context.Background(), "test-user-id", item.Id, 2); err != nil {
+			t.Errorf("Expected Trash with the current version to succeed, got %v", err)
+		}
+
+		t.Cleanup(func() {
+			clearDynamoDBTable(t, client, dynamoTableName)
+		})
+	})
 }
 
 func TestDynamoConcurrentSaves(t *testing.T) {
@@ -1581,7 +2225,7 @@ func TestDynamoConcurrentSaves(t *testing.T) {
 					Price:        domain.Pence(1000 + index),
 				}
 
-				_, err := repo.Save("test-user-id", clothingItem)
+				_, err := repo.Save(context.Background(), "test-user-id", clothingItem)
 
 				if err != nil {
 					t.Errorf("Goroutine %d: Failed to save item: %v", index, err)
@@ -1593,7 +2237,7 @@ func TestDynamoConcurrentSaves(t *testing.T) {
 
 		wg.Wait()
 
-		allSavedItems, err := repo.GetAll("test-user-id")
+		allSavedItems, err := repo.GetAll(context.Background(), "test-user-id")
 		if err != nil {
 			t.Fatalf("Failed to retrieve all items after concurrent saves: %v", err)
 		}
@@ -1638,7 +2282,7 @@ func TestDynamoConcurrentGetAll(t *testing.T) {
 				Size:         "S",
 				Price:        domain.Pence(500 + i),
 			}
-			_, err := repo.Save("test-user-id", clothingItem)
+			_, err := repo.Save(context.Background(), "test-user-id", clothingItem)
 			if err != nil {
 				t.Fatalf("Failed to setup initial item for concurrent GetAll test: %v", err)
 			}
@@ -1651,7 +2295,7 @@ func TestDynamoConcurrentGetAll(t *testing.T) {
 			go func(readIndex int) {
 				defer wg.Done()
 
-				items, err := repo.GetAll("test-user-id")
+				items, err := repo.GetAll(context.Background(), "test-user-id")
 				if err != nil {
 					t.Errorf("Goroutine %d: Failed to retrieve items: %v", readIndex, err)
 					return
@@ -1665,7 +2309,7 @@ func TestDynamoConcurrentGetAll(t *testing.T) {
 
 		wg.Wait()
 
-		finalItems, err := repo.GetAll("test-user-id")
+		finalItems, err := repo.GetAll(context.Background(), "test-user-id")
 		if err != nil {
 			t.Fatalf("Failed final GetAll check: %v", err)
 		}
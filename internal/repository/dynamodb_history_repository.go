@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"clothes_management/internal/domain"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// historySortKeyPrefix keeps history entries in their user's partition of the clothing table, after
+// every item.
+const historySortKeyPrefix = metadataSortKeyPrefix + "HISTORY#"
+
+type DynamoDBHistoryRepository struct {
+	client    *dynamodb.Client
+	tableName string
+}
+
+func NewDynamoDBHistoryRepository(client *dynamodb.Client, tableName string) (*DynamoDBHistoryRepository, error) {
+	if client == nil {
+		return nil, fmt.Errorf("client should not be nil")
+	}
+
+	if strings.TrimSpace(tableName) == "" {
+		return nil, fmt.Errorf("tableName should not be empty or whitespace")
+	}
+
+	return &DynamoDBHistoryRepository{
+		client:    client,
+		tableName: tableName,
+	}, nil
+}
+
+func (d *DynamoDBHistoryRepository) Append(ctx context.Context, entries ...domain.HistoryEntry) error {
+	if err := validateHistoryEntries(entries); err != nil {
+		return err
+	}
+
+	requests := make([]types.WriteRequest, len(entries))
+
+	for i, entry := range entries {
+		item, err := attributevalue.MarshalMap(entry)
+
+		if err != nil {
+			return fmt.Errorf("failed to marshal history entry for DynamoDB: %w", err)
+		}
+
+		item["Id"] = &types.AttributeValueMemberS{Value: historySortKeyPrefix + historyKey(entry)}
+		requests[i] = types.WriteRequest{PutRequest: &types.PutRequest{Item: item}}
+	}
+
+	return batchWrite(ctx, d.client, d.tableName, requests)
+}
+
+func (d *DynamoDBHistoryRepository) List(ctx context.Context, userId, itemId string) ([]domain.HistoryEntry, error) {
+	if err := validateHistoryQuery(userId, itemId); err != nil {
+		return []domain.HistoryEntry{}, err
+	}
+
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(d.tableName),
+		KeyConditionExpression: aws.String("UserId = :uid AND begins_with(Id, :prefix)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":uid":    &types.AttributeValueMemberS{Value: userId},
+			":prefix": &types.AttributeValueMemberS{Value: historySortKeyPrefix + itemId + "#"},
+		},
+	}
+
+	entries := []domain.HistoryEntry{}
+
+	for {
+		result, err := d.client.Query(ctx, input)
+
+		if err != nil {
+			return []domain.HistoryEntry{}, fmt.Errorf("failed to query history from DynamoDB: %w", err)
+		}
+
+		var page []domain.HistoryEntry
+
+		if err := attributevalue.UnmarshalListOfMaps(result.Items, &page); err != nil {
+			return []domain.HistoryEntry{}, fmt.Errorf("failed to unmarshal history entries from DynamoDB: %w", err)
+		}
+
+		entries = append(entries, page...)
+
+		if len(result.LastEvaluatedKey) == 0 {
+			break
+		}
+
+		input.ExclusiveStartKey = result.LastEvaluatedKey
+	}
+
+	return normaliseHistory(entries), nil
+}
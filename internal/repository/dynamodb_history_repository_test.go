@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"clothes_management/internal/domain"
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestDynamoHistory(t *testing.T) {
+	t.Run("Given history entries in a user's partition, should not return them as the user's clothing", func(t *testing.T) {
+		client := setupLocalStackDynamoDBClient(t, true)
+
+		dynamoTableName := os.Getenv("DYNAMODB_TABLE_NAME")
+		if dynamoTableName == "" {
+			t.Fatal("ERROR: DYNAMODB_TABLE_NAME environment variable not set. Please set it in .env_test or your shell.")
+		}
+
+		history, err := NewDynamoDBHistoryRepository(client, dynamoTableName)
+
+		if err != nil {
+			t.Fatalf("Expected no err on NewDynamoDBHistoryRepository, got %v", err)
+		}
+
+		repo, err := NewDynamoDBClothingRepository(client, dynamoTableName)
+
+		if err != nil {
+			t.Fatalf("Expected no err on NewDynamoDBClothingRepository, got %v", err)
+		}
+
+		entry := domain.HistoryEntry{UserId: "test-user-id", ItemId: "item", Action: domain.HistoryCreated, ActedBy: "test-user-id", Timestamp: time.Now()}
+
+		if err := history.Append(context.Background(), entry); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		items, err := repo.GetAll(context.Background(), "test-user-id")
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(items) != 0 {
+			t.Errorf("Expected no clothing, got %v", items)
+		}
+
+		page, err := repo.GetPage(context.Background(), "test-user-id", PageRequest{Filter: ClothingFilter{Trashed: true}})
+
+		if err != nil || len(page.Items) != 0 {
+			t.Errorf("Expected an empty trash, got %v and %v", page.Items, err)
+		}
+	})
+}
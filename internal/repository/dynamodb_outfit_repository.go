@@ -0,0 +1,234 @@
+package repository
+
+import (
+	"clothes_management/internal/domain"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/uuid"
+)
+
+// outfitSortKeyPrefix keeps outfits in their user's partition of the clothing table, after every item.
+const outfitSortKeyPrefix = metadataSortKeyPrefix + "OUTFIT#"
+
+type DynamoDBOutfitRepository struct {
+	client    *dynamodb.Client
+	tableName string
+}
+
+func NewDynamoDBOutfitRepository(client *dynamodb.Client, tableName string) (*DynamoDBOutfitRepository, error) {
+	if client == nil {
+		return nil, fmt.Errorf("client should not be nil")
+	}
+
+	if strings.TrimSpace(tableName) == "" {
+		return nil, fmt.Errorf("tableName should not be empty or whitespace")
+	}
+
+	return &DynamoDBOutfitRepository{
+		client:    client,
+		tableName: tableName,
+	}, nil
+}
+
+func (d *DynamoDBOutfitRepository) key(userId, id string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"UserId": &types.AttributeValueMemberS{Value: userId},
+		"Id":     &types.AttributeValueMemberS{Value: outfitSortKeyPrefix + id},
+	}
+}
+
+// put writes outfit on condition, which decides whether it must be new or already stored.
+func (d *DynamoDBOutfitRepository) put(ctx context.Context, outfit domain.Outfit, condition string) error {
+	item, err := attributevalue.MarshalMap(outfit)
+
+	if err != nil {
+		// this shouldn't ever be possible, but as a guard. As a result, will not unit test.
+		return fmt.Errorf("failed to marshal outfit for DynamoDB: %w", err)
+	}
+
+	item["Id"] = &types.AttributeValueMemberS{Value: outfitSortKeyPrefix + outfit.Id}
+
+	_, err = d.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(d.tableName),
+		Item:                item,
+		ConditionExpression: aws.String(condition),
+	})
+
+	return err
+}
+
+func (d *DynamoDBOutfitRepository) Save(ctx context.Context, userId string, outfit domain.Outfit) (domain.Outfit, error) {
+	if err := validateOutfitWrite(userId, outfit, false); err != nil {
+		return domain.Outfit{}, err
+	}
+
+	outfit.Id = uuid.New().String()
+	outfit.UserId = userId
+
+	err := d.put(ctx, outfit, "attribute_not_exists(Id)")
+
+	if isConditionalCheckFailed(err) {
+		return domain.Outfit{}, newConflictError("Outfit with id %s already exists", outfit.Id)
+	}
+
+	if err != nil {
+		return domain.Outfit{}, fmt.Errorf("failed to put outfit into DynamoDB: %w", err)
+	}
+
+	return outfit, nil
+}
+
+// query runs an outfit query to the end, returning the outfits with their ids unprefixed.
+func (d *DynamoDBOutfitRepository) query(ctx context.Context, input *dynamodb.QueryInput) ([]domain.Outfit, error) {
+	outfits := []domain.Outfit{}
+
+	for {
+		result, err := d.client.Query(ctx, input)
+
+		if err != nil {
+			return []domain.Outfit{}, fmt.Errorf("failed to query outfits from DynamoDB: %w", err)
+		}
+
+		var page []domain.Outfit
+
+		if err := attributevalue.UnmarshalListOfMaps(result.Items, &page); err != nil {
+			return []domain.Outfit{}, fmt.Errorf("failed to unmarshal outfits from DynamoDB: %w", err)
+		}
+
+		for _, outfit := range page {
+			outfit.Id = strings.TrimPrefix(outfit.Id, outfitSortKeyPrefix)
+			outfits = append(outfits, outfit)
+		}
+
+		if len(result.LastEvaluatedKey) == 0 {
+			break
+		}
+
+		input.ExclusiveStartKey = result.LastEvaluatedKey
+	}
+
+	sortOutfits(outfits)
+
+	return outfits, nil
+}
+
+func (d *DynamoDBOutfitRepository) userQueryInput(userId string) *dynamodb.QueryInput {
+	return &dynamodb.QueryInput{
+		TableName:              aws.String(d.tableName),
+		KeyConditionExpression: aws.String("UserId = :uid AND begins_with(Id, :prefix)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":uid":    &types.AttributeValueMemberS{Value: userId},
+			":prefix": &types.AttributeValueMemberS{Value: outfitSortKeyPrefix},
+		},
+	}
+}
+
+func (d *DynamoDBOutfitRepository) GetAll(ctx context.Context, userId string) ([]domain.Outfit, error) {
+	if strings.TrimSpace(userId) == "" {
+		return []domain.Outfit{}, newValidationError("User ID must not be empty or whitespace")
+	}
+
+	return d.query(ctx, d.userQueryInput(userId))
+}
+
+func (d *DynamoDBOutfitRepository) GetById(ctx context.Context, userId, id string) (domain.Outfit, error) {
+	if strings.TrimSpace(userId) == "" {
+		return domain.Outfit{}, newValidationError("User ID must not be empty or whitespace")
+	}
+
+	if strings.TrimSpace(id) == "" {
+		return domain.Outfit{}, newValidationError("ID must not be empty or whitespace")
+	}
+
+	output, err := d.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(d.tableName),
+		Key:       d.key(userId, id),
+	})
+
+	if err != nil {
+		return domain.Outfit{}, fmt.Errorf("failed to get outfit %s from DynamoDB: %w", id, err)
+	}
+
+	if len(output.Item) == 0 {
+		return domain.Outfit{}, newNotFoundError("No outfit exists for id %s", id)
+	}
+
+	var outfit domain.Outfit
+
+	if err := attributevalue.UnmarshalMap(output.Item, &outfit); err != nil {
+		return domain.Outfit{}, fmt.Errorf("failed to unmarshal outfit from DynamoDB: %w", err)
+	}
+
+	outfit.Id = id
+
+	return outfit, nil
+}
+
+func (d *DynamoDBOutfitRepository) Update(ctx context.Context, userId string, outfit domain.Outfit) (domain.Outfit, error) {
+	if err := validateOutfitWrite(userId, outfit, true); err != nil {
+		return domain.Outfit{}, err
+	}
+
+	outfit.UserId = userId
+
+	err := d.put(ctx, outfit, "attribute_exists(Id)")
+
+	if isConditionalCheckFailed(err) {
+		return domain.Outfit{}, newNotFoundError("No outfit exists for id %s", outfit.Id)
+	}
+
+	if err != nil {
+		return domain.Outfit{}, fmt.Errorf("failed to put outfit into DynamoDB: %w", err)
+	}
+
+	return outfit, nil
+}
+
+func (d *DynamoDBOutfitRepository) Delete(ctx context.Context, userId, id string) error {
+	if strings.TrimSpace(userId) == "" {
+		return newValidationError("User ID must not be empty or whitespace")
+	}
+
+	if strings.TrimSpace(id) == "" {
+		return newValidationError("ID cannot be empty or whitespace")
+	}
+
+	_, err := d.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName:           aws.String(d.tableName),
+		Key:                 d.key(userId, id),
+		ConditionExpression: aws.String("attribute_exists(Id)"),
+	})
+
+	if isConditionalCheckFailed(err) {
+		return newNotFoundError("Outfit with id %s does not exist", id)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to delete outfit %s from DynamoDB: %w", id, err)
+	}
+
+	return nil
+}
+
+// ListUsing filters the user's outfits in DynamoDB, which can test list membership with contains.
+func (d *DynamoDBOutfitRepository) ListUsing(ctx context.Context, userId, itemId string) ([]domain.Outfit, error) {
+	if strings.TrimSpace(userId) == "" {
+		return []domain.Outfit{}, newValidationError("User ID must not be empty or whitespace")
+	}
+
+	if strings.TrimSpace(itemId) == "" {
+		return []domain.Outfit{}, newValidationError("Item ID must not be empty or whitespace")
+	}
+
+	input := d.userQueryInput(userId)
+	input.FilterExpression = aws.String("contains(ItemIds, :itemId)")
+	input.ExpressionAttributeValues[":itemId"] = &types.AttributeValueMemberS{Value: itemId}
+
+	return d.query(ctx, input)
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"clothes_management/internal/domain"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// settingsSortKey keeps a user's settings in their partition of the clothing table, after every item.
+const settingsSortKey = metadataSortKeyPrefix + "SETTINGS"
+
+type DynamoDBSettingsRepository struct {
+	client    *dynamodb.Client
+	tableName string
+}
+
+func NewDynamoDBSettingsRepository(client *dynamodb.Client, tableName string) (*DynamoDBSettingsRepository, error) {
+	if client == nil {
+		return nil, fmt.Errorf("client should not be nil")
+	}
+
+	if strings.TrimSpace(tableName) == "" {
+		return nil, fmt.Errorf("tableName should not be empty or whitespace")
+	}
+
+	return &DynamoDBSettingsRepository{
+		client:    client,
+		tableName: tableName,
+	}, nil
+}
+
+func (d *DynamoDBSettingsRepository) Get(ctx context.Context, userId string) (domain.Settings, error) {
+	if strings.TrimSpace(userId) == "" {
+		return domain.Settings{}, newValidationError("User ID must not be empty or whitespace")
+	}
+
+	result, err := d.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(d.tableName),
+		Key: map[string]types.AttributeValue{
+			"UserId": &types.AttributeValueMemberS{Value: userId},
+			"Id":     &types.AttributeValueMemberS{Value: settingsSortKey},
+		},
+	})
+
+	if err != nil {
+		return domain.Settings{}, fmt.Errorf("failed to get settings from DynamoDB: %w", err)
+	}
+
+	if result.Item == nil {
+		return domain.DefaultSettings(userId), nil
+	}
+
+	var settings domain.Settings
+
+	if err := attributevalue.UnmarshalMap(result.Item, &settings); err != nil {
+		return domain.Settings{}, fmt.Errorf("failed to unmarshal settings from DynamoDB: %w", err)
+	}
+
+	return settings, nil
+}
+
+func (d *DynamoDBSettingsRepository) Save(ctx context.Context, userId string, settings domain.Settings) (domain.Settings, error) {
+	if err := validateSettingsWrite(userId, settings); err != nil {
+		return domain.Settings{}, err
+	}
+
+	settings.UserId = userId
+
+	item, err := attributevalue.MarshalMap(settings)
+
+	if err != nil {
+		// this shouldn't ever be possible, but as a guard. As a result, will not unit test.
+		return domain.Settings{}, fmt.Errorf("failed to marshal settings for DynamoDB: %w", err)
+	}
+
+	item["Id"] = &types.AttributeValueMemberS{Value: settingsSortKey}
+
+	_, err = d.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(d.tableName),
+		Item:      item,
+	})
+
+	if err != nil {
+		return domain.Settings{}, fmt.Errorf("failed to put settings into DynamoDB: %w", err)
+	}
+
+	return settings, nil
+}
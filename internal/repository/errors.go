@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrNotFound is returned when the requested item does not exist for the user.
+	ErrNotFound = errors.New("not found")
+	// ErrConflict is returned when a write would clash with an item that already exists.
+	ErrConflict = errors.New("conflict")
+	// ErrPreconditionFailed is returned when the stored item's Version differs from the expected version.
+	ErrPreconditionFailed = errors.New("precondition failed")
+)
+
+// ValidationError reports input the repository refused to store, such as an empty user ID or
+// clothing that fails domain.Clothing.Validate. The wrapped error carries the original message.
+type ValidationError struct {
+	Err error
+}
+
+func (e *ValidationError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+func newValidationError(message string) error {
+	return &ValidationError{Err: errors.New(message)}
+}
+
+// kindError carries a backend's own message while matching one of the sentinel errors with errors.Is.
+type kindError struct {
+	kind    error
+	message string
+}
+
+func (e *kindError) Error() string {
+	return e.message
+}
+
+func (e *kindError) Is(target error) bool {
+	return target == e.kind
+}
+
+func newNotFoundError(format string, args ...any) error {
+	return &kindError{kind: ErrNotFound, message: fmt.Sprintf(format, args...)}
+}
+
+func newConflictError(format string, args ...any) error {
+	return &kindError{kind: ErrConflict, message: fmt.Sprintf(format, args...)}
+}
+
+func newPreconditionFailedError(format string, args ...any) error {
+	return &kindError{kind: ErrPreconditionFailed, message: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestErrors(t *testing.T) {
+	t.Run("Given a ValidationError, errors.As should find it and it should keep the original message", func(t *testing.T) {
+		cause := errors.New("Clothing Price must be greater than or equal to 0")
+		var err error = &ValidationError{Err: cause}
+
+		var validationErr *ValidationError
+
+		if !errors.As(err, &validationErr) {
+			t.Fatal("Expected errors.As to find a ValidationError")
+		}
+
+		if !errors.Is(err, cause) {
+			t.Error("Expected ValidationError to unwrap to its cause")
+		}
+
+		if err.Error() != cause.Error() {
+			t.Errorf("Expected %s got %s", cause.Error(), err.Error())
+		}
+	})
+
+	t.Run("Given a not found error, should match ErrNotFound and keep its message", func(t *testing.T) {
+		err := newNotFoundError("No item exists for id %s", "dummy-id-123")
+
+		if !errors.Is(err, ErrNotFound) {
+			t.Error("Expected error to match ErrNotFound")
+		}
+
+		if errors.Is(err, ErrConflict) {
+			t.Error("Expected error not to match ErrConflict")
+		}
+
+		if err.Error() != "No item exists for id dummy-id-123" {
+			t.Errorf("Expected message to be preserved, got %s", err.Error())
+		}
+	})
+
+	t.Run("Given a conflict error, should match ErrConflict", func(t *testing.T) {
+		err := newConflictError("Item with id %s already exists", "dummy-id-123")
+
+		if !errors.Is(err, ErrConflict) {
+			t.Error("Expected error to match ErrConflict")
+		}
+
+		if errors.Is(err, ErrNotFound) {
+			t.Error("Expected error not to match ErrNotFound")
+		}
+	})
+}
@@ -0,0 +1,11 @@
+package repository
+
+// Test helpers shared with the external repository_test package, which can import repositorytest
+// without an import cycle.
+var (
+	SetupLocalStackDynamoDBClient = setupLocalStackDynamoDBClient
+	SetupSQLiteDatabase           = setupSQLiteDatabase
+	SetupPostgresDatabase         = setupPostgresDatabase
+	NewBoltTestRepository         = newBoltTestRepository
+	OpenBoltDatabase              = openBoltDatabase
+)
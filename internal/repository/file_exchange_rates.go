@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"clothes_management/internal/domain"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// FileExchangeRates is a domain.ExchangeRateProvider with fixed rates read from a JSON file such as
+//
+//	{"base": "GBP", "rates": {"EUR": 1.17, "JPY": 190.5}}
+//
+// where each rate is how many units of that currency one unit of base is worth. Rates between two
+// currencies other than base are derived through it.
+type FileExchangeRates struct {
+	rates map[domain.Currency]float64
+}
+
+type exchangeRatesFile struct {
+	Base  string             `json:"base"`
+	Rates map[string]float64 `json:"rates"`
+}
+
+func NewFileExchangeRates(path string) (*FileExchangeRates, error) {
+	data, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read exchange rates: %w", err)
+	}
+
+	var file exchangeRatesFile
+
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse exchange rates %s: %w", path, err)
+	}
+
+	base, ok := domain.ParseCurrency(file.Base)
+
+	if !ok {
+		return nil, fmt.Errorf("exchange rates %s: unsupported base currency %q", path, file.Base)
+	}
+
+	rates := map[domain.Currency]float64{base: 1}
+
+	for code, rate := range file.Rates {
+		currency, ok := domain.ParseCurrency(code)
+
+		if !ok {
+			return nil, fmt.Errorf("exchange rates %s: unsupported currency %q", path, code)
+		}
+
+		if rate <= 0 {
+			return nil, fmt.Errorf("exchange rates %s: rate for %s must be greater than 0", path, currency)
+		}
+
+		rates[currency] = rate
+	}
+
+	return &FileExchangeRates{rates: rates}, nil
+}
+
+func (f *FileExchangeRates) Rate(ctx context.Context, from, to domain.Currency) (float64, error) {
+	fromRate, fromOk := f.rates[from]
+	toRate, toOk := f.rates[to]
+
+	if !fromOk || !toOk {
+		return 0, fmt.Errorf("%w from %s to %s", domain.ErrNoExchangeRate, from, to)
+	}
+
+	return toRate / fromRate, nil
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"clothes_management/internal/domain"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeExchangeRates(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "rates.json")
+
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write exchange rates: %v", err)
+	}
+
+	return path
+}
+
+func TestFileExchangeRates(t *testing.T) {
+	t.Run("Given rates from a base, should convert to, from and between them", func(t *testing.T) {
+		rates, err := NewFileExchangeRates(writeExchangeRates(t, `{"base": "gbp", "rates": {"EUR": 1.25, "usd": 1.5}}`))
+
+		if err != nil {
+			t.Fatalf("Expected no err on NewFileExchangeRates, got %v", err)
+		}
+
+		for _, tc := range []struct {
+			from, to domain.Currency
+			expected float64
+		}{
+			{"GBP", "EUR", 1.25},
+			{"EUR", "GBP", 0.8},
+			{"EUR", "USD", 1.2},
+			{"USD", "USD", 1},
+		} {
+			if rate, err := rates.Rate(context.Background(), tc.from, tc.to); err != nil || rate != tc.expected {
+				t.Errorf("%s to %s: Expected %v, got %v and %v", tc.from, tc.to, tc.expected, rate, err)
+			}
+		}
+	})
+
+	t.Run("Given a currency without a rate, should return ErrNoExchangeRate", func(t *testing.T) {
+		rates, _ := NewFileExchangeRates(writeExchangeRates(t, `{"base": "GBP", "rates": {"EUR": 1.25}}`))
+
+		if _, err := rates.Rate(context.Background(), "JPY", "GBP"); !errors.Is(err, domain.ErrNoExchangeRate) {
+			t.Errorf("Expected ErrNoExchangeRate, got %v", err)
+		}
+	})
+
+	t.Run("Given a missing or invalid file, should return an error", func(t *testing.T) {
+		for _, path := range []string{
+			filepath.Join(t.TempDir(), "missing.json"),
+			writeExchangeRates(t, `not json`),
+			writeExchangeRates(t, `{"base": "XXX", "rates": {}}`),
+			writeExchangeRates(t, `{"base": "GBP", "rates": {"XXX": 1}}`),
+			writeExchangeRates(t, `{"base": "GBP", "rates": {"EUR": 0}}`),
+		} {
+			if _, err := NewFileExchangeRates(path); err == nil {
+				t.Errorf("%s: Expected an error", path)
+			}
+		}
+	})
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileSystemImageStore keeps images under root as root/<userId>/<id>. The content type is sniffed
+// from the stored bytes when read back, so only formats http.DetectContentType knows round-trip.
+type FileSystemImageStore struct {
+	root string
+}
+
+func NewFileSystemImageStore(root string) (*FileSystemImageStore, error) {
+	if strings.TrimSpace(root) == "" {
+		return nil, fmt.Errorf("root should not be empty or whitespace")
+	}
+
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create image directory: %w", err)
+	}
+
+	return &FileSystemImageStore{root: root}, nil
+}
+
+func (s *FileSystemImageStore) Put(ctx context.Context, userId, id, contentType string, data []byte) error {
+	if err := validateImageKey(userId, id); err != nil {
+		return err
+	}
+
+	dir := filepath.Join(s.root, userId)
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create image directory: %w", err)
+	}
+
+	// Write to a temporary file and rename it into place so readers never see a partial image.
+	tmp, err := os.CreateTemp(dir, id+".*.tmp")
+
+	if err != nil {
+		return fmt.Errorf("failed to create image file: %w", err)
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write image file: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write image file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), filepath.Join(dir, id)); err != nil {
+		return fmt.Errorf("failed to store image file: %w", err)
+	}
+
+	return nil
+}
+
+func (s *FileSystemImageStore) Get(ctx context.Context, userId, id string) (Image, error) {
+	if err := validateImageKey(userId, id); err != nil {
+		return Image{}, err
+	}
+
+	file, err := os.Open(filepath.Join(s.root, userId, id))
+
+	if errors.Is(err, fs.ErrNotExist) {
+		return Image{}, newNotFoundError("No image exists for id %s", id)
+	}
+
+	if err != nil {
+		return Image{}, fmt.Errorf("failed to open image file: %w", err)
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		file.Close()
+		return Image{}, fmt.Errorf("failed to read image file: %w", err)
+	}
+
+	return Image{
+		ContentType: http.DetectContentType(head[:n]),
+		Body: struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(head[:n]), file), file},
+	}, nil
+}
+
+func (s *FileSystemImageStore) Delete(ctx context.Context, userId, id string) error {
+	if err := validateImageKey(userId, id); err != nil {
+		return err
+	}
+
+	err := os.Remove(filepath.Join(s.root, userId, id))
+
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete image file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+)
+
+// pngHeader is enough of a PNG for http.DetectContentType to recognise it.
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestFileSystemImageStore(t *testing.T) {
+	t.Run("Given a stored image, Get should return its bytes and sniffed content type", func(t *testing.T) {
+		store, err := NewFileSystemImageStore(t.TempDir())
+
+		if err != nil {
+			t.Fatalf("Expected no err on NewFileSystemImageStore, got %v", err)
+		}
+
+		data := append(append([]byte{}, pngHeader...), bytes.Repeat([]byte{1}, 1024)...)
+
+		if err := store.Put(context.Background(), "test-user-id", "item-1", "image/png", data); err != nil {
+			t.Fatalf("Expected no error on Put, got %v", err)
+		}
+
+		image, err := store.Get(context.Background(), "test-user-id", "item-1")
+
+		if err != nil {
+			t.Fatalf("Expected no error on Get, got %v", err)
+		}
+
+		defer image.Body.Close()
+
+		got, err := io.ReadAll(image.Body)
+
+		if err != nil {
+			t.Fatalf("Expected no error reading image, got %v", err)
+		}
+
+		if !bytes.Equal(got, data) {
+			t.Errorf("Expected the stored bytes back, got %d bytes", len(got))
+		}
+
+		if image.ContentType != "image/png" {
+			t.Errorf("Expected image/png, got %s", image.ContentType)
+		}
+	})
+
+	t.Run("Given a second Put, should replace the image", func(t *testing.T) {
+		store, _ := NewFileSystemImageStore(t.TempDir())
+
+		store.Put(context.Background(), "test-user-id", "item-1", "image/png", []byte("first"))
+		store.Put(context.Background(), "test-user-id", "item-1", "image/png", []byte("second"))
+
+		image, err := store.Get(context.Background(), "test-user-id", "item-1")
+
+		if err != nil {
+			t.Fatalf("Expected no error on Get, got %v", err)
+		}
+
+		defer image.Body.Close()
+
+		got, _ := io.ReadAll(image.Body)
+
+		if string(got) != "second" {
+			t.Errorf("Expected second, got %s", got)
+		}
+	})
+
+	t.Run("Given no image, or a deleted image, Get should return ErrNotFound", func(t *testing.T) {
+		store, _ := NewFileSystemImageStore(t.TempDir())
+
+		if _, err := store.Get(context.Background(), "test-user-id", "item-1"); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Expected ErrNotFound, got %v", err)
+		}
+
+		store.Put(context.Background(), "test-user-id", "item-1", "image/png", pngHeader)
+
+		if err := store.Delete(context.Background(), "test-user-id", "item-1"); err != nil {
+			t.Fatalf("Expected no error on Delete, got %v", err)
+		}
+
+		if _, err := store.Get(context.Background(), "test-user-id", "item-1"); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Expected ErrNotFound after Delete, got %v", err)
+		}
+
+		if err := store.Delete(context.Background(), "test-user-id", "item-1"); err != nil {
+			t.Errorf("Expected deleting a missing image to succeed, got %v", err)
+		}
+	})
+
+	t.Run("Given another user's id, should not return their image", func(t *testing.T) {
+		store, _ := NewFileSystemImageStore(t.TempDir())
+
+		store.Put(context.Background(), "test-user-id", "item-1", "image/png", pngHeader)
+
+		if _, err := store.Get(context.Background(), "other-user-id", "item-1"); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Expected ErrNotFound, got %v", err)
+		}
+	})
+
+	t.Run("Given ids that could escape the directory, should return a ValidationError", func(t *testing.T) {
+		store, _ := NewFileSystemImageStore(t.TempDir())
+		var validationErr *ValidationError
+
+		for _, id := range []string{"", " ", ".", "..", "../item", `a\b`} {
+			if err := store.Put(context.Background(), "test-user-id", id, "image/png", pngHeader); !errors.As(err, &validationErr) {
+				t.Errorf("%q: Expected ValidationError, got %v", id, err)
+			}
+		}
+
+		if _, err := store.Get(context.Background(), "..", "item-1"); !errors.As(err, &validationErr) {
+			t.Errorf("Expected ValidationError for user id '..', got %v", err)
+		}
+	})
+}
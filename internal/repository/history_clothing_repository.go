@@ -0,0 +1,262 @@
+package repository
+
+import (
+	"clothes_management/internal/domain"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+)
+
+// HistoryClothingRepository records every change made through a ClothingRepository in a
+// HistoryRepository, so each backend gets history without knowing about it. Reads pass straight through.
+//
+// A change is recorded after it succeeds, and a failure to record it is logged rather than returned, as
+// the change itself cannot be undone. Update and RecordWear read the item first to diff against it, so a
+// concurrent write without a version check can make the recorded old values stale.
+type HistoryClothingRepository struct {
+	repo    ClothingRepository
+	history HistoryRepository
+}
+
+func NewHistoryClothingRepository(repo ClothingRepository, history HistoryRepository) (*HistoryClothingRepository, error) {
+	if repo == nil {
+		return nil, fmt.Errorf("repo should not be nil")
+	}
+
+	if history == nil {
+		return nil, fmt.Errorf("history should not be nil")
+	}
+
+	return &HistoryClothingRepository{
+		repo:    repo,
+		history: history,
+	}, nil
+}
+
+func (h *HistoryClothingRepository) Save(ctx context.Context, userId string, clothing domain.Clothing) (domain.Clothing, error) {
+	saved, err := h.repo.Save(ctx, userId, clothing)
+
+	if err != nil {
+		return saved, err
+	}
+
+	h.record(ctx, newHistoryEntry(userId, saved.Id, domain.HistoryCreated, userId, domain.DiffClothing(nil, &saved)))
+
+	return saved, nil
+}
+
+func (h *HistoryClothingRepository) SaveMany(ctx context.Context, userId string, clothing []domain.Clothing) ([]domain.Clothing, error) {
+	saved, err := h.repo.SaveMany(ctx, userId, clothing)
+
+	if err != nil {
+		return saved, err
+	}
+
+	entries := make([]domain.HistoryEntry, len(saved))
+
+	for i := range saved {
+		entries[i] = newHistoryEntry(userId, saved[i].Id, domain.HistoryCreated, userId, domain.DiffClothing(nil, &saved[i]))
+	}
+
+	h.record(ctx, entries...)
+
+	return saved, nil
+}
+
+func (h *HistoryClothingRepository) GetAll(ctx context.Context, userId string) ([]domain.Clothing, error) {
+	return h.repo.GetAll(ctx, userId)
+}
+
+func (h *HistoryClothingRepository) GetPage(ctx context.Context, userId string, page PageRequest) (Page, error) {
+	return h.repo.GetPage(ctx, userId, page)
+}
+
+func (h *HistoryClothingRepository) GetById(ctx context.Context, userId, id string) (domain.Clothing, error) {
+	return h.repo.GetById(ctx, userId, id)
+}
+
+func (h *HistoryClothingRepository) Update(ctx context.Context, userId string, clothing domain.Clothing) (domain.Clothing, error) {
+	old, err := h.repo.GetById(ctx, userId, clothing.Id)
+
+	if err != nil {
+		return domain.Clothing{}, err
+	}
+
+	updated, err := h.repo.Update(ctx, userId, clothing)
+
+	if err != nil {
+		return updated, err
+	}
+
+	h.record(ctx, newHistoryEntry(userId, updated.Id, domain.HistoryUpdated, userId, domain.DiffClothing(&old, &updated)))
+
+	return updated, nil
+}
+
+func (h *HistoryClothingRepository) Exists(ctx context.Context, userId, id string) (bool, error) {
+	return h.repo.Exists(ctx, userId, id)
+}
+
+func (h *HistoryClothingRepository) Trash(ctx context.Context, userId, id string, expectedVersion int64) (domain.Clothing, error) {
+	trashed, err := h.repo.Trash(ctx, userId, id, expectedVersion)
+
+	if err != nil {
+		return trashed, err
+	}
+
+	old := trashed
+	old.DeletedAt = nil
+
+	h.record(ctx, newHistoryEntry(userId, id, domain.HistoryDeleted, userId, domain.DiffClothing(&old, &trashed)))
+
+	return trashed, nil
+}
+
+func (h *HistoryClothingRepository) TrashMany(ctx context.Context, userId string, ids []string) ([]domain.Clothing, error) {
+	trashed, err := h.repo.TrashMany(ctx, userId, ids)
+
+	if err != nil {
+		return trashed, err
+	}
+
+	entries := make([]domain.HistoryEntry, len(trashed))
+
+	for i := range trashed {
+		old := trashed[i]
+		old.DeletedAt = nil
+		entries[i] = newHistoryEntry(userId, old.Id, domain.HistoryDeleted, userId, domain.DiffClothing(&old, &trashed[i]))
+	}
+
+	h.record(ctx, entries...)
+
+	return trashed, nil
+}
+
+func (h *HistoryClothingRepository) Restore(ctx context.Context, userId, id string, expectedVersion int64) (domain.Clothing, error) {
+	restored, err := h.repo.Restore(ctx, userId, id, expectedVersion)
+
+	if err != nil {
+		return restored, err
+	}
+
+	old := restored
+	old.DeletedAt = h.lastTrashedAt(ctx, userId, id)
+
+	h.record(ctx, newHistoryEntry(userId, id, domain.HistoryRestored, userId, domain.DiffClothing(&old, &restored)))
+
+	return restored, nil
+}
+
+func (h *HistoryClothingRepository) PurgeTrash(ctx context.Context, userId string, trashedBefore time.Time) ([]string, error) {
+	purged, err := h.repo.PurgeTrash(ctx, userId, trashedBefore)
+
+	if err != nil {
+		return purged, err
+	}
+
+	h.recordPurged(ctx, userId, purged)
+
+	return purged, nil
+}
+
+func (h *HistoryClothingRepository) PurgeExpiredTrash(ctx context.Context, trashedBefore time.Time) ([]domain.Clothing, error) {
+	purged, err := h.repo.PurgeExpiredTrash(ctx, trashedBefore)
+
+	if err != nil {
+		return purged, err
+	}
+
+	entries := make([]domain.HistoryEntry, len(purged))
+
+	for i := range purged {
+		entries[i] = newHistoryEntry(purged[i].UserId, purged[i].Id, domain.HistoryPurged, domain.SystemActor, domain.DiffClothing(&purged[i], nil))
+	}
+
+	h.record(ctx, entries...)
+
+	return purged, nil
+}
+
+func (h *HistoryClothingRepository) RecordWear(ctx context.Context, userId, id string, wornAt time.Time) (domain.Clothing, error) {
+	old, err := h.repo.GetById(ctx, userId, id)
+
+	if err != nil {
+		return domain.Clothing{}, err
+	}
+
+	worn, err := h.repo.RecordWear(ctx, userId, id, wornAt)
+
+	if err != nil {
+		return worn, err
+	}
+
+	h.record(ctx, newHistoryEntry(userId, id, domain.HistoryWorn, userId, domain.DiffClothing(&old, &worn)))
+
+	return worn, nil
+}
+
+func (h *HistoryClothingRepository) ListWears(ctx context.Context, userId, id string) ([]domain.Wear, error) {
+	return h.repo.ListWears(ctx, userId, id)
+}
+
+// recordPurged records ids the user purged without reading them first, so with no changes.
+func (h *HistoryClothingRepository) recordPurged(ctx context.Context, userId string, ids []string) {
+	entries := make([]domain.HistoryEntry, len(ids))
+
+	for i, id := range ids {
+		entries[i] = newHistoryEntry(userId, id, domain.HistoryPurged, userId, []domain.FieldChange{})
+	}
+
+	h.record(ctx, entries...)
+}
+
+// lastTrashedAt finds when a restored item was trashed from the history, as Restore no longer returns it.
+// It is nil when the item was trashed before history was recorded.
+func (h *HistoryClothingRepository) lastTrashedAt(ctx context.Context, userId, id string) *time.Time {
+	entries, err := h.history.List(ctx, userId, id)
+
+	if err != nil {
+		log.Printf("ERROR: Failed to read history for ID %s: %v", id, err)
+		return nil
+	}
+
+	for _, entry := range slices.Backward(entries) {
+		if entry.Action != domain.HistoryDeleted {
+			continue
+		}
+
+		for _, change := range entry.Changes {
+			var trashedAt time.Time
+
+			if change.Field == "deletedAt" && json.Unmarshal(change.New, &trashedAt) == nil {
+				return &trashedAt
+			}
+		}
+	}
+
+	return nil
+}
+
+func (h *HistoryClothingRepository) record(ctx context.Context, entries ...domain.HistoryEntry) {
+	if len(entries) == 0 {
+		return
+	}
+
+	if err := h.history.Append(ctx, entries...); err != nil {
+		log.Printf("ERROR: Failed to record %d history entries: %v", len(entries), err)
+	}
+}
+
+func newHistoryEntry(userId, itemId string, action domain.HistoryAction, actedBy string, changes []domain.FieldChange) domain.HistoryEntry {
+	return domain.HistoryEntry{
+		UserId:    userId,
+		ItemId:    itemId,
+		Action:    action,
+		ActedBy:   actedBy,
+		Timestamp: historyTimestamp(),
+		Changes:   changes,
+	}
+}
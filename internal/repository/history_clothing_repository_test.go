@@ -0,0 +1,250 @@
+package repository
+
+import (
+	"clothes_management/internal/domain"
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+// failingHistoryRepository fails every Append.
+type failingHistoryRepository struct {
+	InMemoryHistoryRepository
+}
+
+func (f *failingHistoryRepository) Append(ctx context.Context, entries ...domain.HistoryEntry) error {
+	return errors.New("boom")
+}
+
+func newHistoryTestRepository(t *testing.T, history HistoryRepository) *HistoryClothingRepository {
+	t.Helper()
+
+	repo, err := NewHistoryClothingRepository(NewInMemoryClothingRepository(), history)
+
+	if err != nil {
+		t.Fatalf("Expected no err on NewHistoryClothingRepository, got %v", err)
+	}
+
+	return repo
+}
+
+func historyActions(entries []domain.HistoryEntry) []domain.HistoryAction {
+	result := make([]domain.HistoryAction, 0, len(entries))
+
+	for _, entry := range entries {
+		result = append(result, entry.Action)
+	}
+
+	return result
+}
+
+func historyFields(entry domain.HistoryEntry) []string {
+	fields := make([]string, 0, len(entry.Changes))
+
+	for _, change := range entry.Changes {
+		fields = append(fields, change.Field)
+	}
+
+	return fields
+}
+
+func TestNewHistoryClothingRepository(t *testing.T) {
+	t.Run("Given a nil repository or history, should return an error", func(t *testing.T) {
+		if _, err := NewHistoryClothingRepository(nil, NewInMemoryHistoryRepository()); err == nil {
+			t.Errorf("Expected an error for a nil repository")
+		}
+
+		if _, err := NewHistoryClothingRepository(NewInMemoryClothingRepository(), nil); err == nil {
+			t.Errorf("Expected an error for a nil history")
+		}
+	})
+}
+
+func TestHistoryClothingRepository(t *testing.T) {
+	jumper := domain.Clothing{ClothingType: "Jumper", Description: "Blue", Brand: "XYZ", Store: "This Store", Size: "L", Price: 2000}
+
+	t.Run("Given an item's whole lifecycle, should record each change with its fields", func(t *testing.T) {
+		history := NewInMemoryHistoryRepository()
+		repo := newHistoryTestRepository(t, history)
+		ctx := context.Background()
+
+		saved, err := repo.Save(ctx, "user", jumper)
+
+		if err != nil {
+			t.Fatalf("Expected no error on Save, got %v", err)
+		}
+
+		update := saved
+		update.Description = "Red"
+
+		if _, err := repo.Update(ctx, "user", update); err != nil {
+			t.Fatalf("Expected no error on Update, got %v", err)
+		}
+
+		trashed, err := repo.Trash(ctx, "user", saved.Id, 0)
+
+		if err != nil {
+			t.Fatalf("Expected no error on Trash, got %v", err)
+		}
+
+		if _, err := repo.Restore(ctx, "user", saved.Id, 0); err != nil {
+			t.Fatalf("Expected no error on Restore, got %v", err)
+		}
+
+		if _, err := repo.TrashMany(ctx, "user", []string{saved.Id}); err != nil {
+			t.Fatalf("Expected no error on TrashMany, got %v", err)
+		}
+
+		if _, err := repo.PurgeTrash(ctx, "user", time.Now().Add(time.Minute)); err != nil {
+			t.Fatalf("Expected no error on PurgeTrash, got %v", err)
+		}
+
+		entries, _ := history.List(ctx, "user", saved.Id)
+		expected := []domain.HistoryAction{domain.HistoryCreated, domain.HistoryUpdated, domain.HistoryDeleted, domain.HistoryRestored, domain.HistoryDeleted, domain.HistoryPurged}
+
+		if !slices.Equal(historyActions(entries), expected) {
+			t.Fatalf("Expected %v, got %v", expected, historyActions(entries))
+		}
+
+		for _, entry := range entries {
+			if entry.UserId != "user" || entry.ActedBy != "user" || entry.Timestamp.IsZero() {
+				t.Errorf("Expected an entry acted by user with a timestamp, got %+v", entry)
+			}
+		}
+
+		fieldCount := len(domain.DiffClothing(nil, &saved))
+
+		if len(entries[0].Changes) != fieldCount || entries[0].Changes[1].Old != nil || string(entries[0].Changes[1].New) != `"Blue"` {
+			t.Errorf("Expected create to list every field, got %+v", entries[0].Changes)
+		}
+
+		if changes := entries[1].Changes; len(changes) != 1 || string(changes[0].Old) != `"Blue"` || string(changes[0].New) != `"Red"` {
+			t.Errorf("Expected update to change only the description, got %+v", changes)
+		}
+
+		if !slices.Equal(historyFields(entries[2]), []string{"deletedAt"}) || !slices.Equal(historyFields(entries[3]), []string{"deletedAt"}) {
+			t.Fatalf("Expected delete and restore to change only deletedAt, got %+v and %+v", entries[2].Changes, entries[3].Changes)
+		}
+
+		if string(entries[3].Changes[0].Old) != string(entries[2].Changes[0].New) || entries[3].Changes[0].New != nil {
+			t.Errorf("Expected restore to clear the deletedAt %v, got %+v", trashed.DeletedAt, entries[3].Changes[0])
+		}
+
+		if !slices.Equal(historyFields(entries[4]), []string{"deletedAt"}) {
+			t.Errorf("Expected the batch trash to change only deletedAt, got %+v", entries[4].Changes)
+		}
+
+		if len(entries[5].Changes) != 0 {
+			t.Errorf("Expected the purge to list no changes, got %+v", entries[5].Changes)
+		}
+	})
+
+	t.Run("Given a write that fails, should record nothing", func(t *testing.T) {
+		history := NewInMemoryHistoryRepository()
+		repo := newHistoryTestRepository(t, history)
+
+		saved, _ := repo.Save(context.Background(), "user", jumper)
+
+		if _, err := repo.Trash(context.Background(), "user", saved.Id, saved.Version+1); !errors.Is(err, ErrPreconditionFailed) {
+			t.Fatalf("Expected ErrPreconditionFailed, got %v", err)
+		}
+
+		if _, err := repo.Trash(context.Background(), "user", "missing", 0); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("Expected ErrNotFound, got %v", err)
+		}
+
+		if trashed, err := repo.TrashMany(context.Background(), "user", []string{"missing"}); err != nil || len(trashed) != 0 {
+			t.Fatalf("Expected nothing trashed, got %+v and %v", trashed, err)
+		}
+
+		if entries, _ := history.List(context.Background(), "user", saved.Id); len(entries) != 1 {
+			t.Errorf("Expected only the create, got %+v", entries)
+		}
+
+		if entries, _ := history.List(context.Background(), "user", "missing"); len(entries) != 0 {
+			t.Errorf("Expected no entries for the missing item, got %+v", entries)
+		}
+	})
+
+	t.Run("Given batch writes and purges, should record an entry per item", func(t *testing.T) {
+		history := NewInMemoryHistoryRepository()
+		repo := newHistoryTestRepository(t, history)
+		ctx := context.Background()
+
+		saved, err := repo.SaveMany(ctx, "user", []domain.Clothing{jumper, jumper, jumper})
+
+		if err != nil {
+			t.Fatalf("Expected no error on SaveMany, got %v", err)
+		}
+
+		repo.TrashMany(ctx, "user", []string{saved[0].Id})
+		repo.Trash(ctx, "user", saved[1].Id, 0)
+		repo.Trash(ctx, "other-user", saved[2].Id, 0)
+		repo.PurgeTrash(ctx, "user", time.Now().Add(time.Minute))
+
+		for i, expected := range [][]domain.HistoryAction{
+			{domain.HistoryCreated, domain.HistoryDeleted, domain.HistoryPurged},
+			{domain.HistoryCreated, domain.HistoryDeleted, domain.HistoryPurged},
+			{domain.HistoryCreated},
+		} {
+			if entries, _ := history.List(ctx, "user", saved[i].Id); !slices.Equal(historyActions(entries), expected) {
+				t.Errorf("Item %d: Expected %v, got %v", i, expected, historyActions(entries))
+			}
+		}
+	})
+
+	t.Run("Given expired trash, should record the purges as acted by the system", func(t *testing.T) {
+		history := NewInMemoryHistoryRepository()
+		repo := newHistoryTestRepository(t, history)
+		ctx := context.Background()
+
+		saved, _ := repo.Save(ctx, "user", jumper)
+		repo.Trash(ctx, "user", saved.Id, 0)
+
+		if _, err := repo.PurgeExpiredTrash(ctx, time.Now().Add(time.Minute)); err != nil {
+			t.Fatalf("Expected no error on PurgeExpiredTrash, got %v", err)
+		}
+
+		entries, _ := history.List(ctx, "user", saved.Id)
+		last := entries[len(entries)-1]
+
+		if last.Action != domain.HistoryPurged || last.ActedBy != domain.SystemActor || last.UserId != "user" || len(last.Changes) == 0 {
+			t.Errorf("Expected a purge by %s listing the item's fields, got %+v", domain.SystemActor, last)
+		}
+	})
+
+	t.Run("Given a wear, should record it with the wear fields it changed", func(t *testing.T) {
+		history := NewInMemoryHistoryRepository()
+		repo := newHistoryTestRepository(t, history)
+		ctx := context.Background()
+
+		saved, _ := repo.Save(ctx, "user", jumper)
+
+		if _, err := repo.RecordWear(ctx, "user", saved.Id, time.Now()); err != nil {
+			t.Fatalf("Expected no error on RecordWear, got %v", err)
+		}
+
+		entries, _ := history.List(ctx, "user", saved.Id)
+		last := entries[len(entries)-1]
+
+		if last.Action != domain.HistoryWorn || !slices.Equal(historyFields(last), []string{"wearCount", "lastWornAt"}) {
+			t.Errorf("Expected a wear changing wearCount and lastWornAt, got %+v", last)
+		}
+	})
+
+	t.Run("Given the history fails, should still make the change", func(t *testing.T) {
+		repo := newHistoryTestRepository(t, &failingHistoryRepository{})
+
+		saved, err := repo.Save(context.Background(), "user", jumper)
+
+		if err != nil {
+			t.Fatalf("Expected no error on Save, got %v", err)
+		}
+
+		if exists, _ := repo.Exists(context.Background(), "user", saved.Id); !exists {
+			t.Errorf("Expected the item to be saved")
+		}
+	})
+}
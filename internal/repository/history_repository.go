@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"clothes_management/internal/domain"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// HistoryRepository stores the change history of each user's items. List returns an item's entries
+// oldest first, and an empty list for an item with no history.
+type HistoryRepository interface {
+	Append(ctx context.Context, entries ...domain.HistoryEntry) error
+	List(ctx context.Context, userId, itemId string) ([]domain.HistoryEntry, error)
+}
+
+// historyTimeFormat is a fixed width UTC timestamp, so keys built from it sort in time order.
+const historyTimeFormat = "2006-01-02T15:04:05.000000000Z"
+
+// historyKey builds a new, unique key ordering an item's entries by time. It ends in a version 7 uuid,
+// which this process generates in increasing order, so entries recorded in the same nanosecond keep
+// the order they were appended.
+func historyKey(entry domain.HistoryEntry) string {
+	return fmt.Sprintf("%s#%s#%s", entry.ItemId, entry.Timestamp.UTC().Format(historyTimeFormat), uuid.Must(uuid.NewV7()))
+}
+
+func validateHistoryEntries(entries []domain.HistoryEntry) error {
+	for i, entry := range entries {
+		if strings.TrimSpace(entry.UserId) == "" {
+			return newValidationError(fmt.Sprintf("Entry %d: User ID must not be empty or whitespace", i))
+		}
+
+		if strings.TrimSpace(entry.ItemId) == "" {
+			return newValidationError(fmt.Sprintf("Entry %d: Item ID must not be empty or whitespace", i))
+		}
+	}
+
+	return nil
+}
+
+func validateHistoryQuery(userId, itemId string) error {
+	if strings.TrimSpace(userId) == "" {
+		return newValidationError("User ID must not be empty or whitespace")
+	}
+
+	if strings.TrimSpace(itemId) == "" {
+		return newValidationError("ID must not be empty or whitespace")
+	}
+
+	return nil
+}
+
+// historyTimestamp is when an entry appended now is recorded.
+func historyTimestamp() time.Time {
+	return time.Now().UTC()
+}
+
+// normaliseHistory gives every entry a non-nil Changes, as stores that keep an empty list as null
+// would otherwise return it.
+func normaliseHistory(entries []domain.HistoryEntry) []domain.HistoryEntry {
+	for i := range entries {
+		if entries[i].Changes == nil {
+			entries[i].Changes = []domain.FieldChange{}
+		}
+	}
+
+	return entries
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"io"
+	"strings"
+)
+
+// Image is a stored image. Callers must close Body.
+type Image struct {
+	ContentType string
+	Body        io.ReadCloser
+}
+
+// ImageStore holds at most one image per clothing item, keyed by user and item id.
+type ImageStore interface {
+	Put(ctx context.Context, userId, id, contentType string, data []byte) error
+	// Get returns ErrNotFound if the item has no image.
+	Get(ctx context.Context, userId, id string) (Image, error)
+	// Delete removes the item's image, and succeeds if there is none.
+	Delete(ctx context.Context, userId, id string) error
+}
+
+// validateImageKey rejects ids that could escape a user's prefix or directory once used as a path.
+func validateImageKey(userId, id string) error {
+	if strings.TrimSpace(userId) == "" {
+		return newValidationError("User ID must not be empty or whitespace")
+	}
+
+	if strings.TrimSpace(id) == "" {
+		return newValidationError("ID cannot be empty or whitespace")
+	}
+
+	for _, part := range []string{userId, id} {
+		if part == "." || part == ".." || strings.ContainsAny(part, `/\`) {
+			return newValidationError("IDs must not contain path separators or be '.' or '..'")
+		}
+	}
+
+	return nil
+}
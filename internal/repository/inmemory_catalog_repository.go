@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+type InMemoryCatalogRepository struct {
+	// entries contains a key for each kind, which maps the de-duplication key to the stored spelling
+	entries map[CatalogKind]map[string]string
+	mu      sync.Mutex
+}
+
+func (r *InMemoryCatalogRepository) Register(ctx context.Context, kind CatalogKind, value string) error {
+	if err := validateCatalogEntry(kind, value); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.entries[kind]; !exists {
+		r.entries[kind] = map[string]string{}
+	}
+
+	key := catalogKey(value)
+
+	if _, exists := r.entries[kind][key]; !exists {
+		r.entries[kind][key] = strings.TrimSpace(value)
+	}
+
+	return nil
+}
+
+func (r *InMemoryCatalogRepository) List(ctx context.Context, kind CatalogKind) ([]string, error) {
+	if _, ok := ParseCatalogKind(string(kind)); !ok {
+		return []string{}, newValidationError("Unknown catalog " + string(kind))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	values := make([]string, 0, len(r.entries[kind]))
+
+	for _, value := range r.entries[kind] {
+		values = append(values, value)
+	}
+
+	sortCatalogValues(values)
+
+	return values, nil
+}
+
+func NewInMemoryCatalogRepository() *InMemoryCatalogRepository {
+	return &InMemoryCatalogRepository{
+		entries: make(map[CatalogKind]map[string]string),
+	}
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestInMemoryCatalog(t *testing.T) {
+	t.Run("Given values differing only in case or surrounding space, should keep the first spelling", func(t *testing.T) {
+		repo := NewInMemoryCatalogRepository()
+
+		for _, value := range []string{"Jumper", "jumper", " JUMPER ", "Coat"} {
+			if err := repo.Register(context.Background(), CatalogTypes, value); err != nil {
+				t.Fatalf("Expected no error registering %q, got %v", value, err)
+			}
+		}
+
+		values, err := repo.List(context.Background(), CatalogTypes)
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if !slices.Equal(values, []string{"Coat", "Jumper"}) {
+			t.Errorf("Expected [Coat Jumper], got %v", values)
+		}
+	})
+
+	t.Run("Given values in different catalogs, should keep the catalogs separate", func(t *testing.T) {
+		repo := NewInMemoryCatalogRepository()
+
+		if err := repo.Register(context.Background(), CatalogBrands, "XYZ"); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		values, err := repo.List(context.Background(), CatalogStores)
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(values) != 0 {
+			t.Errorf("Expected no stores, got %v", values)
+		}
+	})
+
+	t.Run("Given values registered out of order, should list them case-insensitively sorted", func(t *testing.T) {
+		repo := NewInMemoryCatalogRepository()
+
+		for _, value := range []string{"zara", "Asos", "H&M", "boden"} {
+			if err := repo.Register(context.Background(), CatalogStores, value); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+		}
+
+		values, _ := repo.List(context.Background(), CatalogStores)
+
+		if !slices.Equal(values, []string{"Asos", "boden", "H&M", "zara"}) {
+			t.Errorf("Expected [Asos boden H&M zara], got %v", values)
+		}
+	})
+
+	t.Run("Given an empty value or unknown catalog, should return a ValidationError", func(t *testing.T) {
+		repo := NewInMemoryCatalogRepository()
+		var validationErr *ValidationError
+
+		if err := repo.Register(context.Background(), CatalogTypes, "  "); !errors.As(err, &validationErr) {
+			t.Errorf("Expected ValidationError for an empty value, got %v", err)
+		}
+
+		if err := repo.Register(context.Background(), CatalogKind("colours"), "Red"); !errors.As(err, &validationErr) {
+			t.Errorf("Expected ValidationError for an unknown catalog on Register, got %v", err)
+		}
+
+		if _, err := repo.List(context.Background(), CatalogKind("colours")); !errors.As(err, &validationErr) {
+			t.Errorf("Expected ValidationError for an unknown catalog on List, got %v", err)
+		}
+	})
+}
@@ -2,10 +2,11 @@ package repository
 
 import (
 	"clothes_management/internal/domain"
-	"errors"
-	"fmt"
+	"context"
+	"slices"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -13,17 +14,19 @@ import (
 type InMemoryClothingRepository struct {
 	// items contains a key for userId, which contains a map of clothingItems keyed by its own id
 	items map[string]map[string]domain.Clothing
+	// wears is keyed by userId and then item id, each item's wears in the order they were logged
+	wears map[string]map[string][]domain.Wear
 	mu    sync.Mutex
 }
 
-func (r *InMemoryClothingRepository) Save(userId string, clothing domain.Clothing) (domain.Clothing, error) {
+func (r *InMemoryClothingRepository) Save(ctx context.Context, userId string, clothing domain.Clothing) (domain.Clothing, error) {
 
 	if strings.TrimSpace(userId) == "" {
-		return domain.Clothing{}, errors.New("User ID must not be empty or whitespace")
+		return domain.Clothing{}, newValidationError("User ID must not be empty or whitespace")
 	}
 
 	if err := clothing.Validate(); err != nil {
-		return domain.Clothing{}, err
+		return domain.Clothing{}, &ValidationError{Err: err}
 	}
 
 	r.mu.Lock()
@@ -32,6 +35,15 @@ func (r *InMemoryClothingRepository) Save(userId string, clothing domain.Clothin
 	id := uuid.New().String()
 	clothing.UserId = userId
 	clothing.Id = id
+	clothing.Version = 1
+	clothing.DeletedAt = nil
+	clothing.WearCount = 0
+	clothing.LastWornAt = nil
+	// Cloned so the caller cannot change the stored item through its slice.
+	clothing.Tags = slices.Clone(clothing.Tags)
+	clothing.SecondaryColours = slices.Clone(clothing.SecondaryColours)
+	clothing.Seasons = slices.Clone(clothing.Seasons)
+	clothing.Occasions = slices.Clone(clothing.Occasions)
 
 	_, exists := r.items[userId]
 
@@ -44,9 +56,34 @@ func (r *InMemoryClothingRepository) Save(userId string, clothing domain.Clothin
 	return clothing, nil
 }
 
-func (r *InMemoryClothingRepository) GetAll(userId string) ([]domain.Clothing, error) {
+func (r *InMemoryClothingRepository) SaveMany(ctx context.Context, userId string, clothing []domain.Clothing) ([]domain.Clothing, error) {
+	prepared, err := prepareNewItems(userId, clothing)
+
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.items[userId]; !exists {
+		r.items[userId] = map[string]domain.Clothing{}
+	}
+
+	for _, item := range prepared {
+		item.Tags = slices.Clone(item.Tags)
+		item.SecondaryColours = slices.Clone(item.SecondaryColours)
+		item.Seasons = slices.Clone(item.Seasons)
+		item.Occasions = slices.Clone(item.Occasions)
+		r.items[userId][item.Id] = item
+	}
+
+	return prepared, nil
+}
+
+func (r *InMemoryClothingRepository) GetAll(ctx context.Context, userId string) ([]domain.Clothing, error) {
 	if strings.TrimSpace(userId) == "" {
-		return []domain.Clothing{}, errors.New("User ID must not be empty or whitespace")
+		return []domain.Clothing{}, newValidationError("User ID must not be empty or whitespace")
 	}
 
 	r.mu.Lock()
@@ -61,15 +98,51 @@ func (r *InMemoryClothingRepository) GetAll(userId string) ([]domain.Clothing, e
 	}
 
 	for _, item := range userItems {
-		items = append(items, item)
+		if item.DeletedAt == nil {
+			items = append(items, item)
+		}
 	}
 
+	// Map iteration order is random, so order by Id to give callers a stable listing.
+	slices.SortFunc(items, func(a, b domain.Clothing) int {
+		return strings.Compare(a.Id, b.Id)
+	})
+
 	return items, nil
 }
 
-func (r *InMemoryClothingRepository) GetById(userId, id string) (domain.Clothing, error) {
+// GetPage returns the user's matching items ordered by the requested sort keys, or by Id when none are
+// given, which mirrors the order DynamoDB returns them in.
+func (r *InMemoryClothingRepository) GetPage(ctx context.Context, userId string, page PageRequest) (Page, error) {
 	if strings.TrimSpace(userId) == "" {
-		return domain.Clothing{}, errors.New("User ID must not be empty or whitespace")
+		return Page{Items: []domain.Clothing{}}, newValidationError("User ID must not be empty or whitespace")
+	}
+
+	if err := page.Filter.Validate(); err != nil {
+		return Page{Items: []domain.Clothing{}}, &ValidationError{Err: err}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	items := make([]domain.Clothing, 0, len(r.items[userId]))
+
+	for _, item := range r.items[userId] {
+		if page.Filter.Matches(item) {
+			items = append(items, item)
+		}
+	}
+
+	return sortedPage(items, page)
+}
+
+func (r *InMemoryClothingRepository) GetById(ctx context.Context, userId, id string) (domain.Clothing, error) {
+	if strings.TrimSpace(userId) == "" {
+		return domain.Clothing{}, newValidationError("User ID must not be empty or whitespace")
+	}
+
+	if strings.TrimSpace(id) == "" {
+		return domain.Clothing{}, newValidationError("ID must not be empty or whitespace")
 	}
 
 	r.mu.Lock()
@@ -78,25 +151,33 @@ func (r *InMemoryClothingRepository) GetById(userId, id string) (domain.Clothing
 	userItems, exists := r.items[userId]
 
 	if !exists {
-		return domain.Clothing{}, fmt.Errorf("No item exists for id %s for user %s", id, userId)
+		return domain.Clothing{}, newNotFoundError("No item exists for id %s for user %s", id, userId)
 	}
 
 	item, exists := userItems[id]
 
-	if !exists {
-		return domain.Clothing{}, fmt.Errorf("No item exists for id %s for user %s", id, userId)
+	if !exists || item.DeletedAt != nil {
+		return domain.Clothing{}, newNotFoundError("No item exists for id %s for user %s", id, userId)
 	}
 
 	return item, nil
 }
 
-func (r *InMemoryClothingRepository) Update(userId string, clothing domain.Clothing) (domain.Clothing, error) {
+func (r *InMemoryClothingRepository) Update(ctx context.Context, userId string, clothing domain.Clothing) (domain.Clothing, error) {
 	if strings.TrimSpace(userId) == "" {
-		return domain.Clothing{}, errors.New("User ID must not be empty or whitespace")
+		return domain.Clothing{}, newValidationError("User ID must not be empty or whitespace")
 	}
 
 	if err := clothing.Validate(); err != nil {
-		return domain.Clothing{}, err
+		return domain.Clothing{}, &ValidationError{Err: err}
+	}
+
+	if strings.TrimSpace(clothing.Id) == "" {
+		return domain.Clothing{}, newValidationError("cannot update clothing without ID")
+	}
+
+	if clothing.UserId != "" && clothing.UserId != userId {
+		return domain.Clothing{}, newValidationError("Mismatch of user ID")
 	}
 
 	r.mu.Lock()
@@ -105,69 +186,221 @@ func (r *InMemoryClothingRepository) Update(userId string, clothing domain.Cloth
 	_, exists := r.items[userId]
 
 	if !exists {
-		return domain.Clothing{}, fmt.Errorf("User ID %s not found", userId)
+		return domain.Clothing{}, newNotFoundError("No item exists for id %s", clothing.Id)
 	}
 
-	_, exists = r.items[userId][clothing.Id]
+	stored, exists := r.items[userId][clothing.Id]
 
-	if !exists {
-		return domain.Clothing{}, fmt.Errorf("No item exists for id %s", clothing.Id)
+	if !exists || stored.DeletedAt != nil {
+		return domain.Clothing{}, newNotFoundError("No item exists for id %s", clothing.Id)
 	}
 
+	// Compare-and-swap under the lock: the caller's Version is the version it last read.
+	if clothing.Version != 0 && clothing.Version != stored.Version {
+		return domain.Clothing{}, newPreconditionFailedError("Item with id %s is at version %d, not %d", clothing.Id, stored.Version, clothing.Version)
+	}
+
+	clothing.UserId = userId
+	clothing.Version = stored.Version + 1
+	clothing.DeletedAt = nil
+	clothing.WearCount = stored.WearCount
+	clothing.LastWornAt = stored.LastWornAt
+	clothing.Tags = slices.Clone(clothing.Tags)
+	clothing.SecondaryColours = slices.Clone(clothing.SecondaryColours)
+	clothing.Seasons = slices.Clone(clothing.Seasons)
+	clothing.Occasions = slices.Clone(clothing.Occasions)
 	r.items[userId][clothing.Id] = clothing
 
 	return clothing, nil
 }
 
-func (r *InMemoryClothingRepository) Delete(userId, id string) error {
+func (r *InMemoryClothingRepository) Exists(ctx context.Context, userId, id string) (bool, error) {
 	if strings.TrimSpace(userId) == "" {
-		return errors.New("User ID must not be empty or whitespace")
+		return false, newValidationError("User ID must not be empty or whitespace")
 	}
 
 	if strings.TrimSpace(id) == "" {
-		return fmt.Errorf("ID cannot be empty or whitespace")
+		return false, newValidationError("ID cannot be empty or whitespace")
 	}
-
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	_, exists := r.items[userId]
 
 	if !exists {
-		return fmt.Errorf("User ID %s not found", userId)
+		return false, nil
 	}
 
-	_, exists = r.items[userId][id]
+	item, exists := r.items[userId][id]
 
-	if !exists {
-		return fmt.Errorf("Item with id %s does not exist", id)
+	return exists && item.DeletedAt == nil, nil
+}
+
+func (r *InMemoryClothingRepository) Trash(ctx context.Context, userId, id string, expectedVersion int64) (domain.Clothing, error) {
+	return r.moveTrash(userId, id, expectedVersion, true)
+}
+
+func (r *InMemoryClothingRepository) TrashMany(ctx context.Context, userId string, ids []string) ([]domain.Clothing, error) {
+	unique, err := uniqueIds(userId, ids)
+
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	trashed := []domain.Clothing{}
+	deletedAt := trashedNow()
+
+	for _, id := range unique {
+		stored, exists := r.items[userId][id]
+
+		if !exists || stored.DeletedAt != nil {
+			continue
+		}
+
+		stored.DeletedAt = deletedAt
+		stored.Version++
+		r.items[userId][id] = stored
+		trashed = append(trashed, stored)
+	}
+
+	return trashed, nil
+}
+
+func (r *InMemoryClothingRepository) Restore(ctx context.Context, userId, id string, expectedVersion int64) (domain.Clothing, error) {
+	return r.moveTrash(userId, id, expectedVersion, false)
+}
+
+// moveTrash moves a live item into the trash when trash is true, or a trashed item out of it when false.
+func (r *InMemoryClothingRepository) moveTrash(userId, id string, expectedVersion int64, trash bool) (domain.Clothing, error) {
+	if err := validateTrashArgs(userId, id); err != nil {
+		return domain.Clothing{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, exists := r.items[userId][id]
+
+	if !exists || (stored.DeletedAt == nil) != trash {
+		return domain.Clothing{}, newNotFoundError("No item exists for id %s", id)
+	}
+
+	if expectedVersion != 0 && expectedVersion != stored.Version {
+		return domain.Clothing{}, newPreconditionFailedError("Item with id %s is at version %d, not %d", id, stored.Version, expectedVersion)
+	}
+
+	stored.DeletedAt = nil
+
+	if trash {
+		stored.DeletedAt = trashedNow()
 	}
 
-	delete(r.items[userId], id)
+	stored.Version++
+	r.items[userId][id] = stored
 
-	return nil
+	return stored, nil
 }
 
-func (r *InMemoryClothingRepository) Exists(userId, id string) (bool, error) {
+func (r *InMemoryClothingRepository) PurgeTrash(ctx context.Context, userId string, trashedBefore time.Time) ([]string, error) {
 	if strings.TrimSpace(userId) == "" {
-		return false, errors.New("User ID must not be empty or whitespace")
+		return nil, newValidationError("User ID must not be empty or whitespace")
 	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	_, exists := r.items[userId]
+	purged := []string{}
 
-	if !exists {
-		return false, nil
+	for _, item := range r.purge(userId, trashedBefore) {
+		purged = append(purged, item.Id)
 	}
 
-	_, exists = r.items[userId][id]
+	return purged, nil
+}
+
+func (r *InMemoryClothingRepository) PurgeExpiredTrash(ctx context.Context, trashedBefore time.Time) ([]domain.Clothing, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	purged := []domain.Clothing{}
+
+	for userId := range r.items {
+		purged = append(purged, r.purge(userId, trashedBefore)...)
+	}
+
+	return purged, nil
+}
+
+// purge deletes the user's items trashed at or before trashedBefore. Callers must hold the lock.
+func (r *InMemoryClothingRepository) purge(userId string, trashedBefore time.Time) []domain.Clothing {
+	var purged []domain.Clothing
+
+	for id, item := range r.items[userId] {
+		if item.DeletedAt != nil && !item.DeletedAt.After(trashedBefore) {
+			delete(r.items[userId], id)
+			purged = append(purged, item)
+		}
+	}
+
+	return purged
+}
+
+func (r *InMemoryClothingRepository) RecordWear(ctx context.Context, userId, id string, wornAt time.Time) (domain.Clothing, error) {
+	wear, err := validateWear(userId, id, wornAt)
+
+	if err != nil {
+		return domain.Clothing{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, exists := r.items[userId][id]
+
+	if !exists || stored.DeletedAt != nil {
+		return domain.Clothing{}, newNotFoundError("No item exists for id %s", id)
+	}
+
+	stored = applyWear(stored, wear)
+	r.items[userId][id] = stored
+
+	if _, exists := r.wears[userId]; !exists {
+		r.wears[userId] = map[string][]domain.Wear{}
+	}
+
+	r.wears[userId][id] = append(r.wears[userId][id], wear)
+
+	return stored, nil
+}
+
+func (r *InMemoryClothingRepository) ListWears(ctx context.Context, userId, id string) ([]domain.Wear, error) {
+	if err := validateTrashArgs(userId, id); err != nil {
+		return []domain.Wear{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	wears := slices.Clone(r.wears[userId][id])
+
+	// A stable sort, so wears of the same time stay in the order they were logged.
+	slices.SortStableFunc(wears, func(a, b domain.Wear) int {
+		return a.WornAt.Compare(b.WornAt)
+	})
+
+	if wears == nil {
+		return []domain.Wear{}, nil
+	}
 
-	return exists, nil
+	return wears, nil
 }
 
 func NewInMemoryClothingRepository() *InMemoryClothingRepository {
 	return &InMemoryClothingRepository{
 		items: make(map[string]map[string]domain.Clothing),
+		wears: make(map[string]map[string][]domain.Wear),
 	}
 }
@@ -2,7 +2,10 @@ package repository
 
 import (
 	"clothes_management/internal/domain"
+	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
 	"testing"
@@ -51,7 +54,7 @@ func TestInMemorySave(t *testing.T) {
 			Price:        -2000,
 		}
 
-		item, err := repo.Save("test-user-id", clothingItem)
+		item, err := repo.Save(context.Background(), "test-user-id", clothingItem)
 
 		if err == nil {
 			t.Error("Expected an error, got nil")
@@ -91,7 +94,7 @@ func TestInMemorySave(t *testing.T) {
 			Price:        2000,
 		}
 
-		item, err := repo.Save("", clothingItem)
+		item, err := repo.Save(context.Background(), "", clothingItem)
 
 		if err == nil {
 			t.Error("Expected an error, got nil")
@@ -127,7 +130,7 @@ func TestInMemorySave(t *testing.T) {
 			Price:        2000,
 		}
 
-		item, err := repo.Save("test-user-id", clothingItem)
+		item, err := repo.Save(context.Background(), "test-user-id", clothingItem)
 
 		if err != nil {
 			t.Errorf("Expected not error, got %v", err)
@@ -164,7 +167,7 @@ func TestInMemoryGetAll(t *testing.T) {
 			t.Errorf("Expected repo.items.length = 0, got %d", len(repo.items))
 		}
 
-		items, err := repo.GetAll("test-user-id")
+		items, err := repo.GetAll(context.Background(), "test-user-id")
 
 		if err != nil {
 			t.Errorf("Expected not error, got %v", err)
@@ -190,7 +193,7 @@ func TestInMemoryGetAll(t *testing.T) {
 			t.Errorf("Expected repo.items.length = 0, got %d", len(repo.items))
 		}
 
-		items, err := repo.GetAll("")
+		items, err := repo.GetAll(context.Background(), "")
 
 		if len(items) > 0 {
 			t.Errorf("Expected no items, got %d", len(items))
@@ -238,7 +241,7 @@ func TestInMemoryGetAll(t *testing.T) {
 
 		repo.mu.Unlock()
 
-		items, err := repo.GetAll("test-user-id")
+		items, err := repo.GetAll(context.Background(), "test-user-id")
 
 		if err != nil {
 			t.Errorf("Expected not error, got %v", err)
@@ -290,7 +293,7 @@ func TestInMemoryGetAll(t *testing.T) {
 
 		repo.mu.Unlock()
 
-		items, err := repo.GetAll("test-user-id")
+		items, err := repo.GetAll(context.Background(), "test-user-id")
 
 		if err != nil {
 			t.Errorf("Expected not error, got %v", err)
@@ -331,7 +334,7 @@ func TestInMemoryGetAll(t *testing.T) {
 		}
 
 		repo.mu.Unlock()
-		items, err := repo.GetAll("test-user-id-1")
+		items, err := repo.GetAll(context.Background(), "test-user-id-1")
 
 		if len(items) > 0 {
 			t.Errorf("Expected no items, got %d", len(items))
@@ -342,6 +345,274 @@ func TestInMemoryGetAll(t *testing.T) {
 		}
 
 	})
+
+	t.Run("When there are several clothing items, GetAll should return them in the same order every time", func(t *testing.T) {
+		repo := NewInMemoryClothingRepository()
+
+		for i := 0; i < 20; i++ {
+			_, err := repo.Save(context.Background(), "test-user-id", domain.Clothing{
+				ClothingType: "Jumper",
+				Description:  fmt.Sprintf("Jumper %d", i),
+				Store:        "This Store",
+				Size:         "L",
+				Brand:        "XYZ",
+				Price:        2000,
+			})
+
+			if err != nil {
+				t.Fatalf("Expected no error on Save, got %v", err)
+			}
+		}
+
+		first, err := repo.GetAll(context.Background(), "test-user-id")
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		for i := 0; i < 5; i++ {
+			again, err := repo.GetAll(context.Background(), "test-user-id")
+
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if !slices.EqualFunc(first, again, func(a, b domain.Clothing) bool { return a.Id == b.Id }) {
+				t.Fatal("Expected GetAll to return items in a stable order")
+			}
+		}
+	})
+}
+
+func TestInMemoryGetPage(t *testing.T) {
+	t.Run("Given empty user Id, should return an empty page and error", func(t *testing.T) {
+		repo := NewInMemoryClothingRepository()
+
+		page, err := repo.GetPage(context.Background(), "", PageRequest{})
+
+		if len(page.Items) > 0 {
+			t.Errorf("Expected no items, got %d", len(page.Items))
+		}
+
+		if err == nil {
+			t.Fatalf("Expected to get err, but didn't")
+		}
+
+		expectedMessage := "User ID must not be empty or whitespace"
+
+		if !strings.Contains(err.Error(), expectedMessage) {
+			t.Errorf("Expected %s got %s", expectedMessage, err.Error())
+		}
+	})
+
+	t.Run("Given an invalid cursor, should return ErrInvalidCursor", func(t *testing.T) {
+		repo := NewInMemoryClothingRepository()
+
+		_, err := repo.GetPage(context.Background(), "test-user-id", PageRequest{Cursor: "not a cursor!"})
+
+		if !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("Expected ErrInvalidCursor, got %v", err)
+		}
+	})
+
+	t.Run("Given more items than the limit, should page through every item once in Id order", func(t *testing.T) {
+		repo := NewInMemoryClothingRepository()
+
+		numItems := 7
+
+		for i := 0; i < numItems; i++ {
+			_, err := repo.Save(context.Background(), "test-user-id", domain.Clothing{
+				ClothingType: "Jumper",
+				Description:  fmt.Sprintf("Jumper %d", i),
+				Store:        "This Store",
+				Size:         "L",
+				Brand:        "XYZ",
+				Price:        2000,
+			})
+
+			if err != nil {
+				t.Fatalf("Expected no error on Save, got %v", err)
+			}
+		}
+
+		_, err := repo.Save(context.Background(), "other-user-id", domain.Clothing{
+			ClothingType: "Jumper",
+			Description:  "Other user's Jumper",
+			Store:        "This Store",
+			Size:         "L",
+			Brand:        "XYZ",
+			Price:        2000,
+		})
+
+		if err != nil {
+			t.Fatalf("Expected no error on Save, got %v", err)
+		}
+
+		var seen []string
+		cursor := ""
+		pages := 0
+
+		for {
+			page, err := repo.GetPage(context.Background(), "test-user-id", PageRequest{Limit: 3, Cursor: cursor})
+
+			if err != nil {
+				t.Fatalf("Expected no error on GetPage, got %v", err)
+			}
+
+			pages++
+
+			if len(page.Items) > 3 {
+				t.Errorf("Expected at most 3 items, got %d", len(page.Items))
+			}
+
+			for _, item := range page.Items {
+				seen = append(seen, item.Id)
+			}
+
+			if page.NextCursor == "" {
+				break
+			}
+
+			cursor = page.NextCursor
+		}
+
+		if pages != 3 {
+			t.Errorf("Expected 3 pages, got %d", pages)
+		}
+
+		if len(seen) != numItems {
+			t.Fatalf("Expected %d items, got %d", numItems, len(seen))
+		}
+
+		if !slices.IsSorted(seen) {
+			t.Errorf("Expected items in Id order, got %v", seen)
+		}
+	})
+
+	t.Run("Given a filter, should only return matching items and fill each page", func(t *testing.T) {
+		repo := NewInMemoryClothingRepository()
+
+		for i := 0; i < 6; i++ {
+			brand := "XYZ"
+			if i%2 == 0 {
+				brand = "ABC"
+			}
+
+			_, err := repo.Save(context.Background(), "test-user-id", domain.Clothing{
+				ClothingType: "Jumper",
+				Description:  fmt.Sprintf("Jumper %d", i),
+				Store:        "This Store",
+				Size:         "L",
+				Brand:        brand,
+				Price:        domain.Pence(1000 * i),
+			})
+
+			if err != nil {
+				t.Fatalf("Expected no error on Save, got %v", err)
+			}
+		}
+
+		page, err := repo.GetPage(context.Background(), "test-user-id", PageRequest{
+			Limit:  2,
+			Filter: ClothingFilter{Brand: "ABC", MinPricePence: pencePointer(1000), Currency: domain.DefaultCurrency},
+		})
+
+		if err != nil {
+			t.Fatalf("Expected no error on GetPage, got %v", err)
+		}
+
+		if len(page.Items) != 2 {
+			t.Fatalf("Expected 2 items, got %d", len(page.Items))
+		}
+
+		for _, item := range page.Items {
+			if item.Brand != "ABC" || item.Price < 1000 {
+				t.Errorf("Expected only matching items, got %+v", item)
+			}
+		}
+
+		if page.NextCursor != "" {
+			t.Errorf("Expected no next cursor, got %s", page.NextCursor)
+		}
+	})
+
+	t.Run("Given sort keys, should return items in that order", func(t *testing.T) {
+		repo := NewInMemoryClothingRepository()
+
+		for _, price := range []domain.Pence{1500, 500, 2500} {
+			_, err := repo.Save(context.Background(), "test-user-id", domain.Clothing{
+				ClothingType: "Jumper",
+				Description:  "Jumper",
+				Store:        "This Store",
+				Size:         "L",
+				Brand:        "XYZ",
+				Price:        price,
+			})
+
+			if err != nil {
+				t.Fatalf("Expected no error on Save, got %v", err)
+			}
+		}
+
+		page, err := repo.GetPage(context.Background(), "test-user-id", PageRequest{
+			Sort: []SortKey{{Field: SortByPrice, Descending: true}},
+		})
+
+		if err != nil {
+			t.Fatalf("Expected no error on GetPage, got %v", err)
+		}
+
+		if len(page.Items) != 3 || page.Items[0].Price != 2500 || page.Items[1].Price != 1500 || page.Items[2].Price != 500 {
+			t.Errorf("Expected items ordered by descending price, got %+v", page.Items)
+		}
+	})
+
+	t.Run("Given an invalid filter, should return an error", func(t *testing.T) {
+		repo := NewInMemoryClothingRepository()
+
+		_, err := repo.GetPage(context.Background(), "test-user-id", PageRequest{
+			Filter: ClothingFilter{MinPricePence: pencePointer(-1)},
+		})
+
+		if err == nil {
+			t.Error("Expected an error, got nil")
+		}
+	})
+
+	t.Run("Given an item is deleted between pages, should not skip or repeat the remaining items", func(t *testing.T) {
+		repo := NewInMemoryClothingRepository()
+
+		repo.mu.Lock()
+		repo.items["test-user-id"] = map[string]domain.Clothing{}
+		for _, id := range []string{"a", "b", "c", "d"} {
+			repo.items["test-user-id"][id] = domain.Clothing{Id: id}
+		}
+		repo.mu.Unlock()
+
+		first, err := repo.GetPage(context.Background(), "test-user-id", PageRequest{Limit: 2})
+
+		if err != nil {
+			t.Fatalf("Expected no error on GetPage, got %v", err)
+		}
+
+		if _, err := repo.Trash(context.Background(), "test-user-id", "a", 0); err != nil {
+			t.Fatalf("Expected no error on Trash, got %v", err)
+		}
+
+		second, err := repo.GetPage(context.Background(), "test-user-id", PageRequest{Limit: 2, Cursor: first.NextCursor})
+
+		if err != nil {
+			t.Fatalf("Expected no error on GetPage, got %v", err)
+		}
+
+		if len(second.Items) != 2 || second.Items[0].Id != "c" || second.Items[1].Id != "d" {
+			t.Errorf("Expected items c and d, got %v", second.Items)
+		}
+
+		if second.NextCursor != "" {
+			t.Errorf("Expected no next cursor, got %s", second.NextCursor)
+		}
+	})
 }
 
 func TestInMemoryGetById(t *testing.T) {
@@ -360,7 +631,7 @@ func TestInMemoryGetById(t *testing.T) {
 			t.Errorf("Expected repo.items.length = 0, got %d", len(repo.items))
 		}
 
-		_, err := repo.GetById("test-user-id", "dummy-id-123")
+		_, err := repo.GetById(context.Background(), "test-user-id", "dummy-id-123")
 
 		if err == nil {
 			t.Errorf("Expected an error")
@@ -389,7 +660,7 @@ func TestInMemoryGetById(t *testing.T) {
 			t.Errorf("Expected repo.items.length = 0, got %d", len(repo.items))
 		}
 
-		_, err := repo.GetById("", "dummy-id-123")
+		_, err := repo.GetById(context.Background(), "", "dummy-id-123")
 
 		if err == nil {
 			t.Errorf("Expected an error %v", err)
@@ -435,7 +706,7 @@ func TestInMemoryGetById(t *testing.T) {
 
 		repo.mu.Unlock()
 
-		_, err := repo.GetById("test-user-id-2", dummyId)
+		_, err := repo.GetById(context.Background(), "test-user-id-2", dummyId)
 
 		if err == nil {
 			t.Errorf("Expected an error")
@@ -482,7 +753,7 @@ func TestInMemoryGetById(t *testing.T) {
 
 		repo.mu.Unlock()
 
-		item, err := repo.GetById("test-user-id", dummyId)
+		item, err := repo.GetById(context.Background(), "test-user-id", dummyId)
 
 		if err != nil {
 			t.Errorf("Expected not error, got %v", err)
@@ -522,7 +793,7 @@ func TestInMemoryUpdate(t *testing.T) {
 			Price:        -2000,
 		}
 
-		_, err := repo.Update("test-user-id", item)
+		_, err := repo.Update(context.Background(), "test-user-id", item)
 
 		if err == nil {
 			t.Errorf("Expected an error")
@@ -560,7 +831,7 @@ func TestInMemoryUpdate(t *testing.T) {
 			Price:        domain.Pence(2000),
 		}
 
-		_, err := repo.Update("", item)
+		_, err := repo.Update(context.Background(), "", item)
 
 		if err == nil {
 			t.Errorf("Expected an error %v", err)
@@ -604,7 +875,7 @@ func TestInMemoryUpdate(t *testing.T) {
 		repo.items["test-user-id"] = map[string]domain.Clothing{}
 		repo.mu.Unlock()
 
-		_, err := repo.Update("test-user-id", item)
+		_, err := repo.Update(context.Background(), "test-user-id", item)
 
 		if err == nil {
 			t.Errorf("Expected an error")
@@ -642,7 +913,7 @@ func TestInMemoryUpdate(t *testing.T) {
 			Price:        originalPrice,
 		}
 
-		item, err := repo.Save("test-user-id", item)
+		item, err := repo.Save(context.Background(), "test-user-id", item)
 
 		itemId := item.Id
 
@@ -650,7 +921,7 @@ func TestInMemoryUpdate(t *testing.T) {
 		newPrice := domain.Pence(1500)
 		updatedItem.Price = newPrice
 
-		updatedItem, err = repo.Update("test-user-id", updatedItem)
+		updatedItem, err = repo.Update(context.Background(), "test-user-id", updatedItem)
 
 		if err != nil {
 			t.Errorf("Expected no error, got %v", err)
@@ -667,7 +938,7 @@ func TestInMemoryUpdate(t *testing.T) {
 	})
 }
 
-func TestInMemoryDelete(t *testing.T) {
+func TestInMemoryTrash(t *testing.T) {
 
 	t.Run("When ID is empty, should return an error", func(t *testing.T) {
 		repo := NewInMemoryClothingRepository()
@@ -686,7 +957,7 @@ func TestInMemoryDelete(t *testing.T) {
 
 		dummyId := ""
 
-		err := repo.Delete("test-user-id", dummyId)
+		_, err := repo.Trash(context.Background(), "test-user-id", dummyId, 0)
 
 		if err == nil {
 			t.Fatalf("Expected an error")
@@ -700,7 +971,7 @@ func TestInMemoryDelete(t *testing.T) {
 
 	})
 
-	t.Run("When userId is empty, Delete should return an error", func(t *testing.T) {
+	t.Run("When userId is empty, Trash should return an error", func(t *testing.T) {
 		repo := NewInMemoryClothingRepository()
 
 		if repo == nil {
@@ -715,7 +986,7 @@ func TestInMemoryDelete(t *testing.T) {
 			t.Errorf("Expected repo.items.length = 0, got %d", len(repo.items))
 		}
 
-		err := repo.Delete("", "dummy-id-123")
+		_, err := repo.Trash(context.Background(), "", "dummy-id-123", 0)
 
 		if err == nil {
 			t.Errorf("Expected an error %v", err)
@@ -750,13 +1021,13 @@ func TestInMemoryDelete(t *testing.T) {
 
 		dummyId := "dummy-id-123"
 
-		err := repo.Delete("test-user-id", dummyId)
+		_, err := repo.Trash(context.Background(), "test-user-id", dummyId, 0)
 
 		if err == nil {
 			t.Fatalf("Expected an error")
 		}
 
-		expectedMessage := fmt.Sprintf("Item with id %s does not exist", dummyId)
+		expectedMessage := fmt.Sprintf("No item exists for id %s", dummyId)
 
 		if err.Error() != expectedMessage {
 			t.Errorf("Expected %s got %s", expectedMessage, err.Error())
@@ -764,7 +1035,7 @@ func TestInMemoryDelete(t *testing.T) {
 
 	})
 
-	t.Run("When the item doesn't exist for user, Delete should return error", func(t *testing.T) {
+	t.Run("When the item doesn't exist for user, Trash should return error", func(t *testing.T) {
 		repo := NewInMemoryClothingRepository()
 
 		if repo == nil {
@@ -788,7 +1059,7 @@ func TestInMemoryDelete(t *testing.T) {
 			Price:        2000,
 		}
 
-		item, err := repo.Save("test-user-id", item)
+		item, err := repo.Save(context.Background(), "test-user-id", item)
 
 		if err != nil {
 			t.Fatalf("Expected no error %v", err)
@@ -803,26 +1074,26 @@ func TestInMemoryDelete(t *testing.T) {
 			Price:        2000,
 		}
 
-		item2, err = repo.Save("test-user-id-2", item2)
+		item2, err = repo.Save(context.Background(), "test-user-id-2", item2)
 
 		if err != nil {
 			t.Fatalf("Expected no error %v", err)
 		}
 
-		err = repo.Delete("test-user-id-2", item.Id)
+		_, err = repo.Trash(context.Background(), "test-user-id-2", item.Id, 0)
 
 		if err == nil {
 			t.Errorf("Expected an error")
 		}
 
-		expectedMessage := fmt.Sprintf("Item with id %s does not exist", item.Id)
+		expectedMessage := fmt.Sprintf("No item exists for id %s", item.Id)
 
 		if err.Error() != expectedMessage {
 			t.Errorf("Expected %s got %s", expectedMessage, err.Error())
 		}
 	})
 
-	t.Run("When ID exists, should delete successfully", func(t *testing.T) {
+	t.Run("When ID exists, should trash successfully", func(t *testing.T) {
 		repo := NewInMemoryClothingRepository()
 
 		if repo == nil {
@@ -846,13 +1117,13 @@ func TestInMemoryDelete(t *testing.T) {
 			Price:        2000,
 		}
 
-		item, err := repo.Save("test-user-id", item)
+		item, err := repo.Save(context.Background(), "test-user-id", item)
 
 		if err != nil {
 			t.Fatalf("Expected no error %v", err)
 		}
 
-		items, err := repo.GetAll("test-user-id")
+		items, err := repo.GetAll(context.Background(), "test-user-id")
 
 		if err != nil {
 			t.Fatalf("Expected no error %v", err)
@@ -860,13 +1131,13 @@ func TestInMemoryDelete(t *testing.T) {
 
 		itemCount := len(items)
 
-		err = repo.Delete("test-user-id", item.Id)
+		_, err = repo.Trash(context.Background(), "test-user-id", item.Id, 0)
 
 		if err != nil {
 			t.Fatalf("Expected no error %v", err)
 		}
 
-		items, err = repo.GetAll("test-user-id")
+		items, err = repo.GetAll(context.Background(), "test-user-id")
 
 		if err != nil {
 			t.Fatalf("Expected no error %v", err)
@@ -896,7 +1167,7 @@ func TestInMemoryExists(t *testing.T) {
 			t.Errorf("Expected repo.items.length = 0, got %d", len(repo.items))
 		}
 
-		exists, err := repo.Exists("test-user-id", "test-123")
+		exists, err := repo.Exists(context.Background(), "test-user-id", "test-123")
 
 		if err != nil {
 			t.Errorf("Expected no error")
@@ -923,7 +1194,7 @@ func TestInMemoryExists(t *testing.T) {
 			t.Errorf("Expected repo.items.length = 0, got %d", len(repo.items))
 		}
 
-		exists, err := repo.Exists("", "dummy-id-123")
+		exists, err := repo.Exists(context.Background(), "", "dummy-id-123")
 		if exists {
 			t.Error("Expected exists = false")
 		}
@@ -971,7 +1242,7 @@ func TestInMemoryExists(t *testing.T) {
 
 		repo.mu.Unlock()
 
-		exists, err := repo.Exists("test-user-id-2", "test-123")
+		exists, err := repo.Exists(context.Background(), "test-user-id-2", "test-123")
 
 		if err != nil {
 			t.Errorf("Expected no error")
@@ -1016,7 +1287,7 @@ func TestInMemoryExists(t *testing.T) {
 
 		repo.mu.Unlock()
 
-		exists, err := repo.Exists("test-user-id", dummyId)
+		exists, err := repo.Exists(context.Background(), "test-user-id", dummyId)
 
 		if err != nil {
 			t.Errorf("Expected no error")
@@ -1030,6 +1301,166 @@ func TestInMemoryExists(t *testing.T) {
 
 }
 
+func TestInMemoryTypedErrors(t *testing.T) {
+	t.Run("Given invalid input, should return a ValidationError", func(t *testing.T) {
+		repo := NewInMemoryClothingRepository()
+
+		var validationErr *ValidationError
+
+		_, err := repo.Save(context.Background(), "test-user-id", domain.Clothing{Price: -1})
+
+		if !errors.As(err, &validationErr) {
+			t.Errorf("Expected ValidationError from Save, got %v", err)
+		}
+
+		_, err = repo.Update(context.Background(), "", domain.Clothing{})
+
+		if !errors.As(err, &validationErr) {
+			t.Errorf("Expected ValidationError from Update, got %v", err)
+		}
+
+		_, err = repo.Trash(context.Background(), "test-user-id", " ", 0)
+
+		if !errors.As(err, &validationErr) {
+			t.Errorf("Expected ValidationError from Trash, got %v", err)
+		}
+	})
+
+	t.Run("Given a missing item, GetById, Update and Trash should return ErrNotFound", func(t *testing.T) {
+		repo := NewInMemoryClothingRepository()
+
+		item, err := repo.Save(context.Background(), "test-user-id", domain.Clothing{
+			ClothingType: "Jumper",
+			Description:  "This Jumper",
+			Store:        "This Store",
+			Size:         "L",
+			Brand:        "XYZ",
+			Price:        2000,
+		})
+
+		if err != nil {
+			t.Fatalf("Expected no error on Save, got %v", err)
+		}
+
+		_, err = repo.GetById(context.Background(), "other-user-id", item.Id)
+
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("Expected ErrNotFound from GetById, got %v", err)
+		}
+
+		item.Id = "missing-id"
+
+		_, err = repo.Update(context.Background(), "test-user-id", item)
+
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("Expected ErrNotFound from Update, got %v", err)
+		}
+
+		_, err = repo.Trash(context.Background(), "test-user-id", "missing-id", 0)
+
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("Expected ErrNotFound from Trash, got %v", err)
+		}
+	})
+}
+
+func TestInMemoryVersions(t *testing.T) {
+	t.Run("Given a saved item, Save should set version 1 and every Update should increment it", func(t *testing.T) {
+		repo := NewInMemoryClothingRepository()
+
+		item, err := repo.Save(context.Background(), "test-user-id", domain.Clothing{
+			ClothingType: "Jumper",
+			Description:  "This Jumper",
+			Store:        "This Store",
+			Size:         "L",
+			Brand:        "XYZ",
+			Price:        2000,
+			Version:      7,
+		})
+
+		if err != nil {
+			t.Fatalf("Expected no error on Save, got %v", err)
+		}
+
+		if item.Version != 1 {
+			t.Fatalf("Expected version 1, got %d", item.Version)
+		}
+
+		item.Price = 1500
+		item, err = repo.Update(context.Background(), "test-user-id", item)
+
+		if err != nil {
+			t.Fatalf("Expected no error on Update, got %v", err)
+		}
+
+		if item.Version != 2 {
+			t.Errorf("Expected version 2, got %d", item.Version)
+		}
+
+		item.Version = 0
+		item, err = repo.Update(context.Background(), "test-user-id", item)
+
+		if err != nil {
+			t.Fatalf("Expected no error on unconditional Update, got %v", err)
+		}
+
+		if item.Version != 3 {
+			t.Errorf("Expected version 3, got %d", item.Version)
+		}
+	})
+
+	t.Run("Given a stale version, Update and Trash should return ErrPreconditionFailed and leave the item", func(t *testing.T) {
+		repo := NewInMemoryClothingRepository()
+
+		item, err := repo.Save(context.Background(), "test-user-id", domain.Clothing{
+			ClothingType: "Jumper",
+			Description:  "This Jumper",
+			Store:        "This Store",
+			Size:         "L",
+			Brand:        "XYZ",
+			Price:        2000,
+		})
+
+		if err != nil {
+			t.Fatalf("Expected no error on Save, got %v", err)
+		}
+
+		stale := item
+
+		item.Price = 1500
+		if _, err := repo.Update(context.Background(), "test-user-id", item); err != nil {
+			t.Fatalf("Expected no error on Update, got %v", err)
+		}
+
+		stale.Price = 999
+		_, err = repo.Update(context.Background(), "test-user-id", stale)
+
+		if !errors.Is(err, ErrPreconditionFailed) {
+			t.Errorf("Expected ErrPreconditionFailed from Update, got %v", err)
+		}
+
+		_, err = repo.Trash(context.Background(), "test-user-id", item.Id, stale.Version)
+
+		if !errors.Is(err, ErrPreconditionFailed) {
+			t.Errorf("Expected ErrPreconditionFailed from Trash, got %v", err)
+		}
+
+		stored, err := repo.GetById(context.Background(), "test-user-id", item.Id)
+
+		if err != nil {
+			t.Fatalf("Expected item to still exist, got %v", err)
+		}
+
+		if stored.Price != 1500 || stored.Version != 2 {
+			t.Errorf("Expected price 1500 at version 2, got %d at version %d", stored.Price, stored.Version)
+		}
+
+		if _, err := repo.Trash(context.Background(), "test-user-id", item.Id, 2); err != nil {
+			t.Errorf("Expected Trash with the current version to succeed, got %v", err)
+		}
+	})
+}
+
 func TestSaveAndGetAll(t *testing.T) {
 	t.Run("When items are saved, GetAll should show that a new item has been added", func(t *testing.T) {
 		repo := NewInMemoryClothingRepository()
@@ -1046,7 +1477,7 @@ func TestSaveAndGetAll(t *testing.T) {
 			t.Errorf("Expected repo.items.length = 0, got %d", len(repo.items))
 		}
 
-		items, err := repo.GetAll("test-user-id")
+		items, err := repo.GetAll(context.Background(), "test-user-id")
 
 		if err != nil {
 			t.Errorf("Expected not error (Get All 1), got %v", err)
@@ -1065,13 +1496,13 @@ func TestSaveAndGetAll(t *testing.T) {
 			Price:        2000,
 		}
 
-		_, err = repo.Save("test-user-id", clothingItem)
+		_, err = repo.Save(context.Background(), "test-user-id", clothingItem)
 
 		if err != nil {
 			t.Errorf("Expected not error (Save 1), got %v", err)
 		}
 
-		items, err = repo.GetAll("test-user-id")
+		items, err = repo.GetAll(context.Background(), "test-user-id")
 
 		if err != nil {
 			t.Errorf("Expected not error (Get All 2), got %v", err)
@@ -1090,13 +1521,13 @@ func TestSaveAndGetAll(t *testing.T) {
 			Price:        1500,
 		}
 
-		_, err = repo.Save("test-user-id", clothingItem)
+		_, err = repo.Save(context.Background(), "test-user-id", clothingItem)
 
 		if err != nil {
 			t.Errorf("Expected not error (Save 2), got %v", err)
 		}
 
-		items, err = repo.GetAll("test-user-id")
+		items, err = repo.GetAll(context.Background(), "test-user-id")
 
 		if err != nil {
 			t.Errorf("Expected not error (Get All 3), got %v", err)
@@ -1143,7 +1574,7 @@ func TestInMemoryConcurrentSaves(t *testing.T) {
 					Price:        domain.Pence(1000 + index),
 				}
 
-				_, err := repo.Save("test-user-id", clothingItem)
+				_, err := repo.Save(context.Background(), "test-user-id", clothingItem)
 
 				if err != nil {
 					t.Errorf("Goroutine %d: Failed to save item: %v", index, err)
@@ -1155,7 +1586,7 @@ func TestInMemoryConcurrentSaves(t *testing.T) {
 
 		wg.Wait()
 
-		allSavedItems, err := repo.GetAll("test-user-id")
+		allSavedItems, err := repo.GetAll(context.Background(), "test-user-id")
 		if err != nil {
 			t.Fatalf("Failed to retrieve all items after concurrent saves: %v", err)
 		}
@@ -1180,7 +1611,7 @@ func TestInMemoryConcurrentGetAll(t *testing.T) {
 				Size:         "S",
 				Price:        domain.Pence(500 + i),
 			}
-			_, err := repo.Save("test-user-id", clothingItem)
+			_, err := repo.Save(context.Background(), "test-user-id", clothingItem)
 			if err != nil {
 				t.Fatalf("Failed to setup initial item for concurrent GetAll test: %v", err)
 			}
@@ -1193,7 +1624,7 @@ func TestInMemoryConcurrentGetAll(t *testing.T) {
 			go func(readIndex int) {
 				defer wg.Done()
 
-				items, err := repo.GetAll("test-user-id")
+				items, err := repo.GetAll(context.Background(), "test-user-id")
 				if err != nil {
 					t.Errorf("Goroutine %d: Failed to retrieve items: %v", readIndex, err)
 					return
@@ -1207,7 +1638,7 @@ func TestInMemoryConcurrentGetAll(t *testing.T) {
 
 		wg.Wait()
 
-		finalItems, err := repo.GetAll("test-user-id")
+		finalItems, err := repo.GetAll(context.Background(), "test-user-id")
 		if err != nil {
 			t.Fatalf("Failed final GetAll check: %v", err)
 		}
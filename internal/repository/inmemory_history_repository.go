@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"clothes_management/internal/domain"
+	"context"
+	"slices"
+	"sync"
+)
+
+type InMemoryHistoryRepository struct {
+	// history holds every entry in the order it was appended
+	history []domain.HistoryEntry
+	mu      sync.Mutex
+}
+
+func (r *InMemoryHistoryRepository) Append(ctx context.Context, entries ...domain.HistoryEntry) error {
+	if err := validateHistoryEntries(entries); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.history = append(r.history, entries...)
+
+	return nil
+}
+
+func (r *InMemoryHistoryRepository) List(ctx context.Context, userId, itemId string) ([]domain.HistoryEntry, error) {
+	if err := validateHistoryQuery(userId, itemId); err != nil {
+		return []domain.HistoryEntry{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entries := []domain.HistoryEntry{}
+
+	for _, entry := range r.history {
+		if entry.UserId == userId && entry.ItemId == itemId {
+			entries = append(entries, entry)
+		}
+	}
+
+	// Entries are appended in time order, except when callers race, so a stable sort restores it.
+	slices.SortStableFunc(entries, func(a, b domain.HistoryEntry) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+
+	return normaliseHistory(entries), nil
+}
+
+func NewInMemoryHistoryRepository() *InMemoryHistoryRepository {
+	return &InMemoryHistoryRepository{}
+}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"clothes_management/internal/domain"
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+type InMemoryOutfitRepository struct {
+	// outfits contains a key for userId, which contains a map of outfits keyed by their own id
+	outfits map[string]map[string]domain.Outfit
+	mu      sync.Mutex
+}
+
+func (r *InMemoryOutfitRepository) Save(ctx context.Context, userId string, outfit domain.Outfit) (domain.Outfit, error) {
+	if err := validateOutfitWrite(userId, outfit, false); err != nil {
+		return domain.Outfit{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	outfit.Id = uuid.New().String()
+	outfit.UserId = userId
+	// Cloned so the caller cannot change the stored outfit through its slice.
+	outfit.ItemIds = slices.Clone(outfit.ItemIds)
+
+	if _, exists := r.outfits[userId]; !exists {
+		r.outfits[userId] = map[string]domain.Outfit{}
+	}
+
+	r.outfits[userId][outfit.Id] = outfit
+
+	return outfit, nil
+}
+
+func (r *InMemoryOutfitRepository) GetAll(ctx context.Context, userId string) ([]domain.Outfit, error) {
+	if strings.TrimSpace(userId) == "" {
+		return []domain.Outfit{}, newValidationError("User ID must not be empty or whitespace")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	outfits := make([]domain.Outfit, 0, len(r.outfits[userId]))
+
+	for _, outfit := range r.outfits[userId] {
+		outfit.ItemIds = slices.Clone(outfit.ItemIds)
+		outfits = append(outfits, outfit)
+	}
+
+	sortOutfits(outfits)
+
+	return outfits, nil
+}
+
+func (r *InMemoryOutfitRepository) GetById(ctx context.Context, userId, id string) (domain.Outfit, error) {
+	if strings.TrimSpace(userId) == "" {
+		return domain.Outfit{}, newValidationError("User ID must not be empty or whitespace")
+	}
+
+	if strings.TrimSpace(id) == "" {
+		return domain.Outfit{}, newValidationError("ID must not be empty or whitespace")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	outfit, exists := r.outfits[userId][id]
+
+	if !exists {
+		return domain.Outfit{}, newNotFoundError("No outfit exists for id %s", id)
+	}
+
+	outfit.ItemIds = slices.Clone(outfit.ItemIds)
+
+	return outfit, nil
+}
+
+func (r *InMemoryOutfitRepository) Update(ctx context.Context, userId string, outfit domain.Outfit) (domain.Outfit, error) {
+	if err := validateOutfitWrite(userId, outfit, true); err != nil {
+		return domain.Outfit{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.outfits[userId][outfit.Id]; !exists {
+		return domain.Outfit{}, newNotFoundError("No outfit exists for id %s", outfit.Id)
+	}
+
+	outfit.UserId = userId
+	outfit.ItemIds = slices.Clone(outfit.ItemIds)
+	r.outfits[userId][outfit.Id] = outfit
+
+	return outfit, nil
+}
+
+func (r *InMemoryOutfitRepository) Delete(ctx context.Context, userId, id string) error {
+	if strings.TrimSpace(userId) == "" {
+		return newValidationError("User ID must not be empty or whitespace")
+	}
+
+	if strings.TrimSpace(id) == "" {
+		return newValidationError("ID cannot be empty or whitespace")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.outfits[userId][id]; !exists {
+		return newNotFoundError("Outfit with id %s does not exist", id)
+	}
+
+	delete(r.outfits[userId], id)
+
+	return nil
+}
+
+func (r *InMemoryOutfitRepository) ListUsing(ctx context.Context, userId, itemId string) ([]domain.Outfit, error) {
+	if strings.TrimSpace(itemId) == "" {
+		return []domain.Outfit{}, newValidationError("Item ID must not be empty or whitespace")
+	}
+
+	outfits, err := r.GetAll(ctx, userId)
+
+	if err != nil {
+		return []domain.Outfit{}, err
+	}
+
+	return outfitsUsing(outfits, itemId), nil
+}
+
+func NewInMemoryOutfitRepository() *InMemoryOutfitRepository {
+	return &InMemoryOutfitRepository{
+		outfits: make(map[string]map[string]domain.Outfit),
+	}
+}
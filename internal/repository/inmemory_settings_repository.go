@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"clothes_management/internal/domain"
+	"context"
+	"strings"
+	"sync"
+)
+
+type InMemorySettingsRepository struct {
+	// settings are keyed by userId
+	settings map[string]domain.Settings
+	mu       sync.Mutex
+}
+
+func (r *InMemorySettingsRepository) Get(ctx context.Context, userId string) (domain.Settings, error) {
+	if strings.TrimSpace(userId) == "" {
+		return domain.Settings{}, newValidationError("User ID must not be empty or whitespace")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	settings, exists := r.settings[userId]
+
+	if !exists {
+		return domain.DefaultSettings(userId), nil
+	}
+
+	return settings, nil
+}
+
+func (r *InMemorySettingsRepository) Save(ctx context.Context, userId string, settings domain.Settings) (domain.Settings, error) {
+	if err := validateSettingsWrite(userId, settings); err != nil {
+		return domain.Settings{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	settings.UserId = userId
+	r.settings[userId] = settings
+
+	return settings, nil
+}
+
+func NewInMemorySettingsRepository() *InMemorySettingsRepository {
+	return &InMemorySettingsRepository{
+		settings: make(map[string]domain.Settings),
+	}
+}
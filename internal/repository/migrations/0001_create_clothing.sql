@@ -0,0 +1,13 @@
+CREATE TABLE clothing (
+    user_id TEXT NOT NULL,
+    id TEXT NOT NULL,
+    clothing_type TEXT NOT NULL,
+    description TEXT NOT NULL,
+    brand TEXT NOT NULL,
+    store TEXT NOT NULL,
+    image_url TEXT NOT NULL DEFAULT '',
+    price_pence BIGINT NOT NULL,
+    size TEXT NOT NULL,
+    version BIGINT NOT NULL,
+    PRIMARY KEY (user_id, id)
+);
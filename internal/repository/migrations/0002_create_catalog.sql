@@ -0,0 +1,6 @@
+CREATE TABLE catalog (
+    kind TEXT NOT NULL,
+    entry_key TEXT NOT NULL,
+    value TEXT NOT NULL,
+    PRIMARY KEY (kind, entry_key)
+);
@@ -0,0 +1 @@
+ALTER TABLE clothing ADD COLUMN deleted_at BIGINT;
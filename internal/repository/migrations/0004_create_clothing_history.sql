@@ -0,0 +1,10 @@
+CREATE TABLE clothing_history (
+    user_id TEXT NOT NULL,
+    item_id TEXT NOT NULL,
+    id TEXT NOT NULL,
+    action TEXT NOT NULL,
+    acted_by TEXT NOT NULL,
+    recorded_at BIGINT NOT NULL,
+    changes TEXT NOT NULL,
+    PRIMARY KEY (user_id, item_id, id)
+);
@@ -0,0 +1 @@
+ALTER TABLE clothing ADD COLUMN currency TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,11 @@
+ALTER TABLE clothing ADD COLUMN purchased_at BIGINT;
+ALTER TABLE clothing ADD COLUMN wear_count BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE clothing ADD COLUMN last_worn_at BIGINT;
+CREATE TABLE clothing_wears (
+    user_id TEXT NOT NULL,
+    item_id TEXT NOT NULL,
+    id TEXT NOT NULL,
+    worn_at BIGINT NOT NULL,
+    recorded_at BIGINT NOT NULL,
+    PRIMARY KEY (user_id, item_id, id)
+);
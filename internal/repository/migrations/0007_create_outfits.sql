@@ -0,0 +1,8 @@
+CREATE TABLE outfits (
+    user_id TEXT NOT NULL,
+    id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    occasion TEXT NOT NULL,
+    item_ids TEXT NOT NULL,
+    PRIMARY KEY (user_id, id)
+);
@@ -0,0 +1 @@
+ALTER TABLE clothing ADD COLUMN tags TEXT NOT NULL DEFAULT '[]';
@@ -0,0 +1,2 @@
+ALTER TABLE clothing ADD COLUMN colour TEXT NOT NULL DEFAULT '';
+ALTER TABLE clothing ADD COLUMN secondary_colours TEXT NOT NULL DEFAULT '[]';
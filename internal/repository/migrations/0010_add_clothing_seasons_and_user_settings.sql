@@ -0,0 +1,6 @@
+ALTER TABLE clothing ADD COLUMN seasons TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE clothing ADD COLUMN occasions TEXT NOT NULL DEFAULT '[]';
+CREATE TABLE user_settings (
+    user_id TEXT NOT NULL PRIMARY KEY,
+    hemisphere TEXT NOT NULL
+);
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"clothes_management/internal/domain"
+	"context"
+	"slices"
+	"strings"
+)
+
+// OutfitRepository stores each user's outfits. GetAll and ListUsing return outfits ordered by name,
+// case-insensitively, then by id. Save and Update validate the outfit itself but not that its items
+// exist, which is for the caller to check against the ClothingRepository. Update replaces the whole
+// outfit, as outfits are small and carry no version.
+type OutfitRepository interface {
+	Save(ctx context.Context, userId string, outfit domain.Outfit) (domain.Outfit, error)
+	GetAll(ctx context.Context, userId string) ([]domain.Outfit, error)
+	GetById(ctx context.Context, userId, id string) (domain.Outfit, error)
+	Update(ctx context.Context, userId string, outfit domain.Outfit) (domain.Outfit, error)
+	Delete(ctx context.Context, userId, id string) error
+	ListUsing(ctx context.Context, userId, itemId string) ([]domain.Outfit, error)
+}
+
+// validateOutfitWrite validates the arguments of Save and Update, which also needs the outfit's id.
+func validateOutfitWrite(userId string, outfit domain.Outfit, update bool) error {
+	if strings.TrimSpace(userId) == "" {
+		return newValidationError("User ID must not be empty or whitespace")
+	}
+
+	if err := outfit.Validate(); err != nil {
+		return &ValidationError{Err: err}
+	}
+
+	if update && strings.TrimSpace(outfit.Id) == "" {
+		return newValidationError("cannot update outfit without ID")
+	}
+
+	if update && outfit.UserId != "" && outfit.UserId != userId {
+		return newValidationError("Mismatch of user ID")
+	}
+
+	return nil
+}
+
+// sortOutfits orders outfits as GetAll returns them.
+func sortOutfits(outfits []domain.Outfit) {
+	slices.SortFunc(outfits, func(a, b domain.Outfit) int {
+		if c := strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.Id, b.Id)
+	})
+}
+
+// outfitsUsing returns the outfits that contain itemId, keeping their order.
+func outfitsUsing(outfits []domain.Outfit, itemId string) []domain.Outfit {
+	using := []domain.Outfit{}
+
+	for _, outfit := range outfits {
+		if outfit.Uses(itemId) {
+			using = append(using, outfit)
+		}
+	}
+
+	return using
+}
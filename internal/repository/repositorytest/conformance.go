@@ -0,0 +1,1719 @@
+// Package repositorytest holds the behaviour every repository.ClothingRepository, HistoryRepository and
+// OutfitRepository must share, so each backend proves it with the same tests instead of its own drifting
+// copy.
+package repositorytest
+
+import (
+	"clothes_management/internal/domain"
+	"clothes_management/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Factory returns an empty repository for a single test. Backends that need cleaning up afterwards
+// should register it with t.Cleanup.
+type Factory func(t *testing.T) repository.ClothingRepository
+
+const (
+	userId      = "conformance-user"
+	otherUserId = "conformance-other-user"
+)
+
+func validItem(description string) domain.Clothing {
+	return domain.Clothing{
+		ClothingType: "Jumper",
+		Description:  description,
+		Store:        "This Store",
+		Size:         "L",
+		Brand:        "XYZ",
+		Price:        2000,
+	}
+}
+
+func save(t *testing.T, repo repository.ClothingRepository, userId string, item domain.Clothing) domain.Clothing {
+	t.Helper()
+
+	saved, err := repo.Save(context.Background(), userId, item)
+
+	if err != nil {
+		t.Fatalf("Expected no error on Save, got %v", err)
+	}
+
+	return saved
+}
+
+func ids(items []domain.Clothing) []string {
+	result := make([]string, 0, len(items))
+
+	for _, item := range items {
+		result = append(result, item.Id)
+	}
+
+	return result
+}
+
+func expectValidationError(t *testing.T, operation string, err error) {
+	t.Helper()
+
+	var validationErr *repository.ValidationError
+
+	if !errors.As(err, &validationErr) {
+		t.Errorf("%s: Expected ValidationError, got %v", operation, err)
+	}
+}
+
+// RunConformance runs the shared ClothingRepository contract against repositories from newRepo.
+func RunConformance(t *testing.T, newRepo Factory) {
+	t.Run("Save", func(t *testing.T) { testSave(t, newRepo) })
+	t.Run("SaveMany", func(t *testing.T) { testSaveMany(t, newRepo) })
+	t.Run("TrashMany", func(t *testing.T) { testTrashMany(t, newRepo) })
+	t.Run("Validation", func(t *testing.T) { testValidation(t, newRepo) })
+	t.Run("NotFound", func(t *testing.T) { testNotFound(t, newRepo) })
+	t.Run("UserIsolation", func(t *testing.T) { testUserIsolation(t, newRepo) })
+	t.Run("Versions", func(t *testing.T) { testVersions(t, newRepo) })
+	t.Run("Listing", func(t *testing.T) { testListing(t, newRepo) })
+	t.Run("Trash", func(t *testing.T) { testTrash(t, newRepo) })
+	t.Run("Wears", func(t *testing.T) { testWears(t, newRepo) })
+	t.Run("Tags", func(t *testing.T) { testTags(t, newRepo) })
+	t.Run("Colours", func(t *testing.T) { testColours(t, newRepo) })
+	t.Run("Sizes", func(t *testing.T) { testSizes(t, newRepo) })
+	t.Run("Seasons and occasions", func(t *testing.T) { testSeasonsAndOccasions(t, newRepo) })
+	t.Run("ConcurrentWrites", func(t *testing.T) { testConcurrentWrites(t, newRepo) })
+}
+
+func testSave(t *testing.T, newRepo Factory) {
+	t.Run("Given a valid item, should generate an id, set the user id and version 1, and store it", func(t *testing.T) {
+		repo := newRepo(t)
+
+		saved := save(t, repo, userId, validItem("Saved"))
+
+		if strings.TrimSpace(saved.Id) == "" {
+			t.Errorf("Expected a generated id")
+		}
+
+		if saved.UserId != userId || saved.Version != 1 {
+			t.Errorf("Expected user id %s and version 1, got %+v", userId, saved)
+		}
+
+		got, err := repo.GetById(context.Background(), userId, saved.Id)
+
+		if err != nil {
+			t.Fatalf("Expected no error on GetById, got %v", err)
+		}
+
+		if !reflect.DeepEqual(got, saved) {
+			t.Errorf("Expected %+v got %+v", saved, got)
+		}
+	})
+
+	t.Run("Given a caller supplied Id, UserId and Version, should ignore them", func(t *testing.T) {
+		repo := newRepo(t)
+
+		item := validItem("Caller ids")
+		item.Id = "caller-id"
+		item.UserId = otherUserId
+		item.Version = 7
+
+		first := save(t, repo, userId, item)
+		second := save(t, repo, userId, item)
+
+		if first.Id == "caller-id" || second.Id == "caller-id" || first.Id == second.Id {
+			t.Errorf("Expected two new, distinct ids, got %s and %s", first.Id, second.Id)
+		}
+
+		if first.UserId != userId || first.Version != 1 {
+			t.Errorf("Expected user id %s and version 1, got %+v", userId, first)
+		}
+
+		if exists, _ := repo.Exists(context.Background(), userId, "caller-id"); exists {
+			t.Errorf("Expected nothing to be stored under the caller's id")
+		}
+	})
+}
+
+func testSaveMany(t *testing.T, newRepo Factory) {
+	t.Run("Given valid items, should store each under a new id and return them in order", func(t *testing.T) {
+		repo := newRepo(t)
+
+		// More than DynamoDB's 25 item BatchWriteItem limit, so batching backends write several chunks.
+		items := make([]domain.Clothing, 30)
+
+		for i := range items {
+			items[i] = validItem(fmt.Sprintf("Item %d", i))
+			items[i].Id = "caller-id"
+		}
+
+		saved, err := repo.SaveMany(context.Background(), userId, items)
+
+		if err != nil {
+			t.Fatalf("Expected no error on SaveMany, got %v", err)
+		}
+
+		if len(saved) != len(items) {
+			t.Fatalf("Expected %d saved items, got %d", len(items), len(saved))
+		}
+
+		for i, item := range saved {
+			if item.Description != items[i].Description || item.UserId != userId || item.Version != 1 || item.Id == "caller-id" {
+				t.Errorf("Expected item %d to be %s with a new id, user id %s and version 1, got %+v", i, items[i].Description, userId, item)
+			}
+		}
+
+		stored, _ := repo.GetAll(context.Background(), userId)
+		unique := slices.Compact(slices.Sorted(slices.Values(ids(stored))))
+
+		if len(stored) != len(items) || len(unique) != len(items) {
+			t.Errorf("Expected %d stored items with distinct ids, got %d items and %d ids", len(items), len(stored), len(unique))
+		}
+
+		for _, item := range saved {
+			got, err := repo.GetById(context.Background(), userId, item.Id)
+
+			if err != nil || !reflect.DeepEqual(got, item) {
+				t.Errorf("Expected stored %+v, got %+v and %v", item, got, err)
+			}
+		}
+	})
+
+	t.Run("Given any invalid item, should return a ValidationError and store none of them", func(t *testing.T) {
+		repo := newRepo(t)
+
+		invalid := validItem("Invalid")
+		invalid.Size = " "
+
+		_, err := repo.SaveMany(context.Background(), userId, []domain.Clothing{validItem("Valid"), invalid})
+		expectValidationError(t, "SaveMany", err)
+
+		if items, _ := repo.GetAll(context.Background(), userId); len(items) != 0 {
+			t.Errorf("Expected nothing stored, got %+v", items)
+		}
+	})
+
+	t.Run("Given no items, should store nothing and return no error", func(t *testing.T) {
+		repo := newRepo(t)
+
+		saved, err := repo.SaveMany(context.Background(), userId, nil)
+
+		if err != nil || len(saved) != 0 {
+			t.Errorf("Expected nothing saved and no error, got %v and %v", saved, err)
+		}
+	})
+}
+
+func testTrashMany(t *testing.T, newRepo Factory) {
+	t.Run("Given live, trashed, missing, repeated and another user's ids, should trash and return only the user's live items", func(t *testing.T) {
+		repo := newRepo(t)
+		first := save(t, repo, userId, validItem("First"))
+		second := save(t, repo, userId, validItem("Second"))
+		kept := save(t, repo, userId, validItem("Kept"))
+		earlier := trash(t, repo, userId, save(t, repo, userId, validItem("Earlier")))
+		theirs := save(t, repo, otherUserId, validItem("Theirs"))
+
+		before := time.Now().Add(-time.Second)
+		trashed, err := repo.TrashMany(context.Background(), userId, []string{second.Id, "missing-id", earlier.Id, theirs.Id, first.Id, second.Id})
+
+		if err != nil {
+			t.Fatalf("Expected no error on TrashMany, got %v", err)
+		}
+
+		if !slices.Equal(ids(trashed), []string{second.Id, first.Id}) {
+			t.Errorf("Expected %v, got %v", []string{second.Id, first.Id}, ids(trashed))
+		}
+
+		for _, item := range trashed {
+			if item.DeletedAt == nil || item.DeletedAt.Before(before) || item.Version != 2 {
+				t.Errorf("Expected %s trashed now at version 2, got %+v", item.Id, item)
+			}
+		}
+
+		items, _ := repo.GetAll(context.Background(), userId)
+
+		if !slices.Equal(ids(items), []string{kept.Id}) {
+			t.Errorf("Expected only %s to remain, got %v", kept.Id, ids(items))
+		}
+
+		inTrash := trashedPage(t, repo, userId)
+
+		if len(inTrash) != 3 {
+			t.Errorf("Expected 3 items in the trash, got %v", ids(inTrash))
+		}
+
+		for _, item := range inTrash {
+			if item.Id == earlier.Id && item.Version != earlier.Version {
+				t.Errorf("Expected the earlier trashed item to be untouched, got %+v", item)
+			}
+		}
+
+		if exists, _ := repo.Exists(context.Background(), otherUserId, theirs.Id); !exists {
+			t.Errorf("Expected the other user's item to still exist")
+		}
+	})
+
+	t.Run("Given more ids than a single batch, should trash them all", func(t *testing.T) {
+		repo := newRepo(t)
+
+		items := make([]domain.Clothing, 30)
+
+		for i := range items {
+			items[i] = validItem(fmt.Sprintf("Item %d", i))
+		}
+
+		saved, err := repo.SaveMany(context.Background(), userId, items)
+
+		if err != nil {
+			t.Fatalf("Expected no error on SaveMany, got %v", err)
+		}
+
+		trashed, err := repo.TrashMany(context.Background(), userId, ids(saved))
+
+		if err != nil || !slices.Equal(ids(trashed), ids(saved)) {
+			t.Errorf("Expected %d trashed in order, got %d and %v", len(saved), len(trashed), err)
+		}
+
+		if remaining, _ := repo.GetAll(context.Background(), userId); len(remaining) != 0 {
+			t.Errorf("Expected nothing to remain, got %d items", len(remaining))
+		}
+	})
+
+	t.Run("Given an empty or whitespace id, should return a ValidationError and trash nothing", func(t *testing.T) {
+		repo := newRepo(t)
+		saved := save(t, repo, userId, validItem("Saved"))
+
+		_, err := repo.TrashMany(context.Background(), userId, []string{saved.Id, " "})
+		expectValidationError(t, "TrashMany", err)
+
+		if exists, _ := repo.Exists(context.Background(), userId, saved.Id); !exists {
+			t.Errorf("Expected %s to still exist", saved.Id)
+		}
+	})
+
+	t.Run("Given no ids, should trash nothing and return no error", func(t *testing.T) {
+		repo := newRepo(t)
+		save(t, repo, userId, validItem("Saved"))
+
+		trashed, err := repo.TrashMany(context.Background(), userId, nil)
+
+		if err != nil || len(trashed) != 0 {
+			t.Errorf("Expected nothing trashed and no error, got %v and %v", trashed, err)
+		}
+	})
+}
+
+func testValidation(t *testing.T, newRepo Factory) {
+	t.Run("Given an empty or whitespace user id, every method should return a ValidationError", func(t *testing.T) {
+		repo := newRepo(t)
+		stored := save(t, repo, userId, validItem("Stored"))
+
+		for _, blank := range []string{"", "   "} {
+			_, err := repo.Save(context.Background(), blank, validItem("Blank user"))
+			expectValidationError(t, "Save", err)
+
+			_, err = repo.SaveMany(context.Background(), blank, []domain.Clothing{validItem("Blank user")})
+			expectValidationError(t, "SaveMany", err)
+
+			_, err = repo.GetAll(context.Background(), blank)
+			expectValidationError(t, "GetAll", err)
+
+			_, err = repo.GetPage(context.Background(), blank, repository.PageRequest{})
+			expectValidationError(t, "GetPage", err)
+
+			_, err = repo.GetById(context.Background(), blank, stored.Id)
+			expectValidationError(t, "GetById", err)
+
+			_, err = repo.Update(context.Background(), blank, stored)
+			expectValidationError(t, "Update", err)
+
+			_, err = repo.TrashMany(context.Background(), blank, []string{stored.Id})
+			expectValidationError(t, "TrashMany", err)
+
+			_, err = repo.Exists(context.Background(), blank, stored.Id)
+			expectValidationError(t, "Exists", err)
+
+			_, err = repo.Trash(context.Background(), blank, stored.Id, 0)
+			expectValidationError(t, "Trash", err)
+
+			_, err = repo.Restore(context.Background(), blank, stored.Id, 0)
+			expectValidationError(t, "Restore", err)
+
+			_, err = repo.PurgeTrash(context.Background(), blank, time.Now())
+			expectValidationError(t, "PurgeTrash", err)
+
+			_, err = repo.RecordWear(context.Background(), blank, stored.Id, time.Now())
+			expectValidationError(t, "RecordWear", err)
+
+			_, err = repo.ListWears(context.Background(), blank, stored.Id)
+			expectValidationError(t, "ListWears", err)
+		}
+	})
+
+	t.Run("Given an empty or whitespace id, GetById, Update, Exists and the trash methods should return a ValidationError", func(t *testing.T) {
+		repo := newRepo(t)
+
+		for _, blank := range []string{"", "   "} {
+			_, err := repo.GetById(context.Background(), userId, blank)
+			expectValidationError(t, "GetById", err)
+
+			item := validItem("Blank id")
+			item.Id = blank
+			_, err = repo.Update(context.Background(), userId, item)
+			expectValidationError(t, "Update", err)
+
+			_, err = repo.Exists(context.Background(), userId, blank)
+			expectValidationError(t, "Exists", err)
+
+			_, err = repo.Trash(context.Background(), userId, blank, 0)
+			expectValidationError(t, "Trash", err)
+
+			_, err = repo.Restore(context.Background(), userId, blank, 0)
+			expectValidationError(t, "Restore", err)
+
+			_, err = repo.RecordWear(context.Background(), userId, blank, time.Now())
+			expectValidationError(t, "RecordWear", err)
+
+			_, err = repo.ListWears(context.Background(), userId, blank)
+			expectValidationError(t, "ListWears", err)
+		}
+	})
+
+	t.Run("Given an item that fails domain validation, Save and Update should return a ValidationError and store nothing", func(t *testing.T) {
+		repo := newRepo(t)
+		stored := save(t, repo, userId, validItem("Stored"))
+
+		invalid := validItem("Invalid")
+		invalid.Price = -1
+
+		_, err := repo.Save(context.Background(), userId, invalid)
+		expectValidationError(t, "Save", err)
+
+		invalid.Id = stored.Id
+		_, err = repo.Update(context.Background(), userId, invalid)
+		expectValidationError(t, "Update", err)
+
+		items, _ := repo.GetAll(context.Background(), userId)
+
+		if len(items) != 1 || !reflect.DeepEqual(items[0], stored) {
+			t.Errorf("Expected only the untouched %+v, got %+v", stored, items)
+		}
+	})
+
+	t.Run("Given an invalid filter, GetPage should return a ValidationError", func(t *testing.T) {
+		repo := newRepo(t)
+		low, high := domain.Pence(500), domain.Pence(100)
+
+		for _, filter := range []repository.ClothingFilter{
+			{MinPricePence: &low, MaxPricePence: &high, Currency: domain.DefaultCurrency},
+			{MinPricePence: &high},
+			{Currency: "XXX"},
+		} {
+			_, err := repo.GetPage(context.Background(), userId, repository.PageRequest{Filter: filter})
+
+			expectValidationError(t, "GetPage", err)
+		}
+	})
+}
+
+func testNotFound(t *testing.T, newRepo Factory) {
+	t.Run("Given an id that does not exist, GetById, Update and Trash should return ErrNotFound and Exists false", func(t *testing.T) {
+		repo := newRepo(t)
+		save(t, repo, userId, validItem("Someone else"))
+
+		if _, err := repo.GetById(context.Background(), userId, "missing-id"); !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("GetById: Expected ErrNotFound, got %v", err)
+		}
+
+		missing := validItem("Missing")
+		missing.Id = "missing-id"
+
+		if _, err := repo.Update(context.Background(), userId, missing); !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("Update: Expected ErrNotFound, got %v", err)
+		}
+
+		missing.Version = 3
+
+		if _, err := repo.Update(context.Background(), userId, missing); !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("Update with a version: Expected ErrNotFound, got %v", err)
+		}
+
+		if _, err := repo.Trash(context.Background(), userId, "missing-id", 0); !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("Trash: Expected ErrNotFound, got %v", err)
+		}
+
+		if _, err := repo.Trash(context.Background(), userId, "missing-id", 3); !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("Trash with a version: Expected ErrNotFound, got %v", err)
+		}
+
+		exists, err := repo.Exists(context.Background(), userId, "missing-id")
+
+		if err != nil || exists {
+			t.Errorf("Exists: Expected false and no error, got %v and %v", exists, err)
+		}
+	})
+
+	t.Run("Given a purged item, should behave as if it never existed", func(t *testing.T) {
+		repo := newRepo(t)
+		saved := trash(t, repo, userId, save(t, repo, userId, validItem("Purged")))
+
+		if _, err := repo.PurgeTrash(context.Background(), userId, time.Now()); err != nil {
+			t.Fatalf("Expected no error on PurgeTrash, got %v", err)
+		}
+
+		if _, err := repo.GetById(context.Background(), userId, saved.Id); !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("GetById: Expected ErrNotFound, got %v", err)
+		}
+
+		if _, err := repo.Restore(context.Background(), userId, saved.Id, 0); !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("Restore: Expected ErrNotFound, got %v", err)
+		}
+
+		if items, _ := repo.GetAll(context.Background(), userId); len(items) != 0 {
+			t.Errorf("Expected no items, got %+v", items)
+		}
+	})
+}
+
+func testUserIsolation(t *testing.T, newRepo Factory) {
+	t.Run("Given another user's item, should not list, read, update or trash it", func(t *testing.T) {
+		repo := newRepo(t)
+		theirs := save(t, repo, otherUserId, validItem("Theirs"))
+		mine := save(t, repo, userId, validItem("Mine"))
+
+		items, err := repo.GetAll(context.Background(), userId)
+
+		if err != nil || !slices.Equal(ids(items), []string{mine.Id}) {
+			t.Errorf("GetAll: Expected only %s, got %v and %v", mine.Id, ids(items), err)
+		}
+
+		page, err := repo.GetPage(context.Background(), userId, repository.PageRequest{})
+
+		if err != nil || !slices.Equal(ids(page.Items), []string{mine.Id}) {
+			t.Errorf("GetPage: Expected only %s, got %v and %v", mine.Id, ids(page.Items), err)
+		}
+
+		if _, err := repo.GetById(context.Background(), userId, theirs.Id); !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("GetById: Expected ErrNotFound, got %v", err)
+		}
+
+		if exists, _ := repo.Exists(context.Background(), userId, theirs.Id); exists {
+			t.Errorf("Exists: Expected false for another user's item")
+		}
+
+		hijack := theirs
+		hijack.UserId = ""
+		hijack.Description = "Hijacked"
+
+		if _, err := repo.Update(context.Background(), userId, hijack); !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("Update: Expected ErrNotFound, got %v", err)
+		}
+
+		if _, err := repo.Trash(context.Background(), userId, theirs.Id, 0); !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("Trash: Expected ErrNotFound, got %v", err)
+		}
+
+		got, err := repo.GetById(context.Background(), otherUserId, theirs.Id)
+
+		if err != nil || !reflect.DeepEqual(got, theirs) {
+			t.Errorf("Expected the other user's item to be untouched, got %+v and %v", got, err)
+		}
+	})
+
+	t.Run("Given an item whose UserId is another user's, Update should return a ValidationError", func(t *testing.T) {
+		repo := newRepo(t)
+		mine := save(t, repo, userId, validItem("Mine"))
+
+		moved := mine
+		moved.UserId = otherUserId
+
+		_, err := repo.Update(context.Background(), userId, moved)
+		expectValidationError(t, "Update", err)
+
+		if exists, _ := repo.Exists(context.Background(), otherUserId, mine.Id); exists {
+			t.Errorf("Expected the item not to move to the other user")
+		}
+	})
+}
+
+func testVersions(t *testing.T, newRepo Factory) {
+	t.Run("Given updates, should increment the version and return the stored item", func(t *testing.T) {
+		repo := newRepo(t)
+		saved := save(t, repo, userId, validItem("Versioned"))
+
+		saved.Price = 1500
+		saved.UserId = ""
+		updated, err := repo.Update(context.Background(), userId, saved)
+
+		if err != nil {
+			t.Fatalf("Expected no error on Update, got %v", err)
+		}
+
+		if updated.Version != 2 || updated.Price != 1500 || updated.UserId != userId {
+			t.Errorf("Expected version 2, price 1500 and user id %s, got %+v", userId, updated)
+		}
+
+		got, _ := repo.GetById(context.Background(), userId, saved.Id)
+
+		if !reflect.DeepEqual(got, updated) {
+			t.Errorf("Expected stored %+v to equal returned %+v", got, updated)
+		}
+	})
+
+	t.Run("Given a currency, Save and Update should store it with the price", func(t *testing.T) {
+		repo := newRepo(t)
+
+		item := validItem("Priced abroad")
+		item.Currency = "JPY"
+		saved := save(t, repo, userId, item)
+
+		if got, _ := repo.GetById(context.Background(), userId, saved.Id); got.Currency != "JPY" {
+			t.Errorf("Expected JPY, got %+v", got)
+		}
+
+		saved.Currency = "BHD"
+		saved.Price = 1250
+
+		if _, err := repo.Update(context.Background(), userId, saved); err != nil {
+			t.Fatalf("Expected no error on Update, got %v", err)
+		}
+
+		if got, _ := repo.GetById(context.Background(), userId, saved.Id); got.PriceMoney() != (domain.Money{Amount: 1250, Currency: "BHD"}) {
+			t.Errorf("Expected 1250 BHD, got %+v", got)
+		}
+	})
+
+	t.Run("Given a stale version, Update and Trash should return ErrPreconditionFailed and change nothing", func(t *testing.T) {
+		repo := newRepo(t)
+		saved := save(t, repo, userId, validItem("Versioned"))
+		current, _ := repo.Update(context.Background(), userId, saved)
+
+		stale := saved
+		stale.Description = "Stale"
+
+		if _, err := repo.Update(context.Background(), userId, stale); !errors.Is(err, repository.ErrPreconditionFailed) {
+			t.Errorf("Update: Expected ErrPreconditionFailed, got %v", err)
+		}
+
+		if _, err := repo.Trash(context.Background(), userId, saved.Id, saved.Version); !errors.Is(err, repository.ErrPreconditionFailed) {
+			t.Errorf("Trash: Expected ErrPreconditionFailed, got %v", err)
+		}
+
+		got, _ := repo.GetById(context.Background(), userId, saved.Id)
+
+		if !reflect.DeepEqual(got, current) {
+			t.Errorf("Expected %+v to be unchanged, got %+v", current, got)
+		}
+
+		if _, err := repo.Trash(context.Background(), userId, saved.Id, current.Version); err != nil {
+			t.Errorf("Trash at the current version: Expected no error, got %v", err)
+		}
+	})
+
+	t.Run("Given version 0, Update and Trash should skip the check", func(t *testing.T) {
+		repo := newRepo(t)
+		saved := save(t, repo, userId, validItem("Unconditional"))
+		repo.Update(context.Background(), userId, saved)
+
+		saved.Version = 0
+		updated, err := repo.Update(context.Background(), userId, saved)
+
+		if err != nil || updated.Version != 3 {
+			t.Errorf("Update: Expected version 3, got %+v and %v", updated, err)
+		}
+
+		if _, err := repo.Trash(context.Background(), userId, saved.Id, 0); err != nil {
+			t.Errorf("Trash: Expected no error, got %v", err)
+		}
+	})
+}
+
+func testListing(t *testing.T, newRepo Factory) {
+	t.Run("Given no items, GetAll and GetPage should return nothing", func(t *testing.T) {
+		repo := newRepo(t)
+
+		items, err := repo.GetAll(context.Background(), userId)
+
+		if err != nil || len(items) != 0 {
+			t.Errorf("GetAll: Expected no items, got %v and %v", items, err)
+		}
+
+		page, err := repo.GetPage(context.Background(), userId, repository.PageRequest{})
+
+		if err != nil || len(page.Items) != 0 || page.NextCursor != "" {
+			t.Errorf("GetPage: Expected an empty last page, got %+v and %v", page, err)
+		}
+	})
+
+	t.Run("Given several items, GetAll should return them in Id order and GetPage should page through each once", func(t *testing.T) {
+		repo := newRepo(t)
+
+		for i := 0; i < 7; i++ {
+			save(t, repo, userId, validItem(fmt.Sprintf("Item %d", i)))
+		}
+
+		all, err := repo.GetAll(context.Background(), userId)
+
+		if err != nil || len(all) != 7 {
+			t.Fatalf("GetAll: Expected 7 items, got %d and %v", len(all), err)
+		}
+
+		if !slices.IsSorted(ids(all)) {
+			t.Errorf("GetAll: Expected Id order, got %v", ids(all))
+		}
+
+		var paged []domain.Clothing
+		cursor := ""
+
+		for pages := 0; pages < 10; pages++ {
+			page, err := repo.GetPage(context.Background(), userId, repository.PageRequest{Limit: 3, Cursor: cursor})
+
+			if err != nil {
+				t.Fatalf("GetPage: Expected no error, got %v", err)
+			}
+
+			paged = append(paged, page.Items...)
+
+			if cursor = page.NextCursor; cursor == "" {
+				break
+			}
+		}
+
+		if !slices.Equal(ids(paged), ids(all)) {
+			t.Errorf("GetPage: Expected %v, got %v", ids(all), ids(paged))
+		}
+	})
+
+	t.Run("Given a filter and sort, GetPage should return only matching items in order across pages", func(t *testing.T) {
+		repo := newRepo(t)
+
+		for _, price := range []domain.Pence{3000, 1000, 2000, 5000, 2000} {
+			item := validItem("Priced")
+			item.Price = price
+			save(t, repo, userId, item)
+		}
+
+		coat := validItem("Coat")
+		coat.ClothingType = "Coat"
+		coat.Price = 1500
+		save(t, repo, userId, coat)
+
+		maxPrice := domain.Pence(3000)
+		var prices []domain.Pence
+		cursor := ""
+
+		for pages := 0; pages < 10; pages++ {
+			page, err := repo.GetPage(context.Background(), userId, repository.PageRequest{
+				Limit:  2,
+				Cursor: cursor,
+				Filter: repository.ClothingFilter{ClothingType: "Jumper", MaxPricePence: &maxPrice, Currency: domain.DefaultCurrency},
+				Sort:   []repository.SortKey{{Field: repository.SortByPrice, Descending: true}},
+			})
+
+			if err != nil {
+				t.Fatalf("GetPage: Expected no error, got %v", err)
+			}
+
+			for _, item := range page.Items {
+				prices = append(prices, item.Price)
+			}
+
+			if cursor = page.NextCursor; cursor == "" {
+				break
+			}
+		}
+
+		if !slices.Equal(prices, []domain.Pence{3000, 2000, 2000, 1000}) {
+			t.Errorf("Expected prices [3000 2000 2000 1000], got %v", prices)
+		}
+	})
+
+	t.Run("Given items in several currencies, price filters and sorting should only compare prices within a currency", func(t *testing.T) {
+		repo := newRepo(t)
+
+		for _, money := range []domain.Money{{Amount: 5000, Currency: "JPY"}, {Amount: 3000, Currency: "GBP"}, {Amount: 2500, Currency: "USD"}, {Amount: 2000}} {
+			item := validItem("Priced")
+			item.Price = domain.Pence(money.Amount)
+			item.Currency = money.Currency
+			save(t, repo, userId, item)
+		}
+
+		pricesOf := func(page repository.PageRequest) []domain.Money {
+			var prices []domain.Money
+
+			for pages := 0; pages < 10; pages++ {
+				result, err := repo.GetPage(context.Background(), userId, page)
+
+				if err != nil {
+					t.Fatalf("GetPage: Expected no error, got %v", err)
+				}
+
+				for _, item := range result.Items {
+					prices = append(prices, item.PriceMoney())
+				}
+
+				if page.Cursor = result.NextCursor; page.Cursor == "" {
+					break
+				}
+			}
+
+			return prices
+		}
+
+		minPrice, maxPrice := domain.Pence(1000), domain.Pence(4000)
+		sort := []repository.SortKey{{Field: repository.SortByPrice}}
+
+		// The item without a currency is in the default currency, and the yen item is outside the bounds
+		// only because ¥5000 is not 5000 pence.
+		gbp := pricesOf(repository.PageRequest{Limit: 1, Sort: sort, Filter: repository.ClothingFilter{MinPricePence: &minPrice, MaxPricePence: &maxPrice, Currency: "GBP"}})
+
+		if !slices.Equal(gbp, []domain.Money{{Amount: 2000, Currency: "GBP"}, {Amount: 3000, Currency: "GBP"}}) {
+			t.Errorf("Expected the £20 and £30 items, got %v", gbp)
+		}
+
+		if jpy := pricesOf(repository.PageRequest{Filter: repository.ClothingFilter{MinPricePence: &minPrice, Currency: "JPY"}}); !slices.Equal(jpy, []domain.Money{{Amount: 5000, Currency: "JPY"}}) {
+			t.Errorf("Expected the ¥5000 item, got %v", jpy)
+		}
+
+		expected := []domain.Money{{Amount: 2000, Currency: "GBP"}, {Amount: 3000, Currency: "GBP"}, {Amount: 5000, Currency: "JPY"}, {Amount: 2500, Currency: "USD"}}
+
+		if all := pricesOf(repository.PageRequest{Limit: 1, Sort: sort}); !slices.Equal(all, expected) {
+			t.Errorf("Expected prices grouped by currency %v, got %v", expected, all)
+		}
+	})
+
+	t.Run("Given a cursor that was not issued by GetPage, should return ErrInvalidCursor", func(t *testing.T) {
+		repo := newRepo(t)
+		save(t, repo, userId, validItem("Item"))
+
+		for _, sort := range [][]repository.SortKey{nil, {{Field: repository.SortByPrice}}} {
+			_, err := repo.GetPage(context.Background(), userId, repository.PageRequest{Cursor: "not a cursor!", Sort: sort})
+
+			if !errors.Is(err, repository.ErrInvalidCursor) {
+				t.Errorf("Sort %v: Expected ErrInvalidCursor, got %v", sort, err)
+			}
+		}
+	})
+}
+
+func trash(t *testing.T, repo repository.ClothingRepository, userId string, item domain.Clothing) domain.Clothing {
+	t.Helper()
+
+	trashed, err := repo.Trash(context.Background(), userId, item.Id, item.Version)
+
+	if err != nil {
+		t.Fatalf("Expected no error on Trash, got %v", err)
+	}
+
+	return trashed
+}
+
+func trashedPage(t *testing.T, repo repository.ClothingRepository, userId string) []domain.Clothing {
+	t.Helper()
+
+	page, err := repo.GetPage(context.Background(), userId, repository.PageRequest{Filter: repository.ClothingFilter{Trashed: true}})
+
+	if err != nil {
+		t.Fatalf("Expected no error listing the trash, got %v", err)
+	}
+
+	return page.Items
+}
+
+func testTrash(t *testing.T, newRepo Factory) {
+	t.Run("Given a trashed item, should set DeletedAt, bump the version and list it only in the trash", func(t *testing.T) {
+		repo := newRepo(t)
+		saved := save(t, repo, userId, validItem("Trashed"))
+		live := save(t, repo, userId, validItem("Live"))
+
+		before := time.Now().Add(-time.Second)
+		trashed := trash(t, repo, userId, saved)
+
+		if trashed.DeletedAt == nil || trashed.DeletedAt.Before(before) || trashed.DeletedAt.After(time.Now()) {
+			t.Errorf("Expected DeletedAt to be now, got %v", trashed.DeletedAt)
+		}
+
+		if trashed.Version != saved.Version+1 || trashed.Description != saved.Description {
+			t.Errorf("Expected the item at version %d, got %+v", saved.Version+1, trashed)
+		}
+
+		if items, _ := repo.GetAll(context.Background(), userId); !slices.Equal(ids(items), []string{live.Id}) {
+			t.Errorf("GetAll: Expected only the live item, got %+v", items)
+		}
+
+		if page, _ := repo.GetPage(context.Background(), userId, repository.PageRequest{}); !slices.Equal(ids(page.Items), []string{live.Id}) {
+			t.Errorf("GetPage: Expected only the live item, got %+v", page.Items)
+		}
+
+		items := trashedPage(t, repo, userId)
+
+		if len(items) != 1 || items[0].Id != saved.Id || items[0].DeletedAt == nil || !items[0].DeletedAt.Equal(*trashed.DeletedAt) {
+			t.Errorf("Expected the trash to hold %+v, got %+v", trashed, items)
+		}
+	})
+
+	t.Run("Given a trashed item, every other method should treat it as missing", func(t *testing.T) {
+		repo := newRepo(t)
+		trashed := trash(t, repo, userId, save(t, repo, userId, validItem("Trashed")))
+
+		if _, err := repo.GetById(context.Background(), userId, trashed.Id); !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("GetById: Expected ErrNotFound, got %v", err)
+		}
+
+		if exists, err := repo.Exists(context.Background(), userId, trashed.Id); err != nil || exists {
+			t.Errorf("Exists: Expected false and no error, got %v and %v", exists, err)
+		}
+
+		update := validItem("Updated")
+		update.Id = trashed.Id
+
+		for _, version := range []int64{0, trashed.Version} {
+			update.Version = version
+
+			if _, err := repo.Update(context.Background(), userId, update); !errors.Is(err, repository.ErrNotFound) {
+				t.Errorf("Update at version %d: Expected ErrNotFound, got %v", version, err)
+			}
+
+			if _, err := repo.Trash(context.Background(), userId, trashed.Id, version); !errors.Is(err, repository.ErrNotFound) {
+				t.Errorf("Trash at version %d: Expected ErrNotFound, got %v", version, err)
+			}
+		}
+
+		if again, err := repo.TrashMany(context.Background(), userId, []string{trashed.Id}); err != nil || len(again) != 0 {
+			t.Errorf("TrashMany: Expected nothing trashed, got %v and %v", ids(again), err)
+		}
+
+		if items := trashedPage(t, repo, userId); len(items) != 1 || items[0].Description != "Trashed" {
+			t.Errorf("Expected the trashed item to be untouched, got %+v", items)
+		}
+	})
+
+	t.Run("Given a restored item, should clear DeletedAt, bump the version and list it again", func(t *testing.T) {
+		repo := newRepo(t)
+		trashed := trash(t, repo, userId, save(t, repo, userId, validItem("Restored")))
+
+		restored, err := repo.Restore(context.Background(), userId, trashed.Id, trashed.Version)
+
+		if err != nil || restored.DeletedAt != nil || restored.Version != trashed.Version+1 {
+			t.Fatalf("Expected the item live at version %d, got %+v and %v", trashed.Version+1, restored, err)
+		}
+
+		if got, err := repo.GetById(context.Background(), userId, trashed.Id); err != nil || !reflect.DeepEqual(got, restored) {
+			t.Errorf("Expected GetById to return %+v, got %+v and %v", restored, got, err)
+		}
+
+		if items := trashedPage(t, repo, userId); len(items) != 0 {
+			t.Errorf("Expected an empty trash, got %+v", items)
+		}
+	})
+
+	t.Run("Given a live or missing item, Restore should return ErrNotFound", func(t *testing.T) {
+		repo := newRepo(t)
+		live := save(t, repo, userId, validItem("Live"))
+
+		for _, id := range []string{live.Id, "missing-id"} {
+			if _, err := repo.Restore(context.Background(), userId, id, 0); !errors.Is(err, repository.ErrNotFound) {
+				t.Errorf("Restore %s: Expected ErrNotFound, got %v", id, err)
+			}
+		}
+
+		if _, err := repo.Trash(context.Background(), userId, "missing-id", 0); !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("Trash: Expected ErrNotFound, got %v", err)
+		}
+	})
+
+	t.Run("Given a stale version, Trash and Restore should return ErrPreconditionFailed", func(t *testing.T) {
+		repo := newRepo(t)
+		saved := save(t, repo, userId, validItem("Versioned"))
+
+		if _, err := repo.Trash(context.Background(), userId, saved.Id, saved.Version+1); !errors.Is(err, repository.ErrPreconditionFailed) {
+			t.Errorf("Trash: Expected ErrPreconditionFailed, got %v", err)
+		}
+
+		trashed := trash(t, repo, userId, saved)
+
+		if _, err := repo.Restore(context.Background(), userId, saved.Id, saved.Version); !errors.Is(err, repository.ErrPreconditionFailed) {
+			t.Errorf("Restore: Expected ErrPreconditionFailed, got %v", err)
+		}
+
+		if _, err := repo.Restore(context.Background(), userId, saved.Id, 0); err != nil {
+			t.Errorf("Restore at version 0: Expected no error, got %v", err)
+		}
+
+		if trashed.Version != saved.Version+1 {
+			t.Errorf("Expected Trash to return version %d, got %d", saved.Version+1, trashed.Version)
+		}
+	})
+
+	t.Run("Given a Save or Update carrying DeletedAt, should ignore it", func(t *testing.T) {
+		repo := newRepo(t)
+		deletedAt := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+
+		item := validItem("Not trashed")
+		item.DeletedAt = &deletedAt
+		saved := save(t, repo, userId, item)
+
+		many, err := repo.SaveMany(context.Background(), userId, []domain.Clothing{item})
+
+		if err != nil || saved.DeletedAt != nil || many[0].DeletedAt != nil {
+			t.Fatalf("Expected both saves to be live, got %+v, %+v and %v", saved, many, err)
+		}
+
+		saved.DeletedAt = &deletedAt
+		updated, err := repo.Update(context.Background(), userId, saved)
+
+		if err != nil || updated.DeletedAt != nil {
+			t.Errorf("Expected the update to be live, got %+v and %v", updated, err)
+		}
+
+		if items, _ := repo.GetAll(context.Background(), userId); len(items) != 2 {
+			t.Errorf("Expected both items to be listed, got %+v", items)
+		}
+	})
+
+	t.Run("Given PurgeTrash, should delete only the user's items trashed at or before the time given", func(t *testing.T) {
+		repo := newRepo(t)
+		live := save(t, repo, userId, validItem("Live"))
+		first := trash(t, repo, userId, save(t, repo, userId, validItem("First")))
+		second := trash(t, repo, userId, save(t, repo, userId, validItem("Second")))
+		theirs := trash(t, repo, otherUserId, save(t, repo, otherUserId, validItem("Theirs")))
+
+		purged, err := repo.PurgeTrash(context.Background(), userId, first.DeletedAt.Add(-time.Second))
+
+		if err != nil || len(purged) != 0 {
+			t.Errorf("Expected nothing trashed before the first item to be purged, got %v and %v", purged, err)
+		}
+
+		purged, err = repo.PurgeTrash(context.Background(), userId, time.Now())
+		slices.Sort(purged)
+		expected := []string{first.Id, second.Id}
+		slices.Sort(expected)
+
+		if err != nil || !slices.Equal(purged, expected) {
+			t.Errorf("Expected %v to be purged, got %v and %v", expected, purged, err)
+		}
+
+		if items := trashedPage(t, repo, userId); len(items) != 0 {
+			t.Errorf("Expected an empty trash, got %+v", items)
+		}
+
+		if _, err := repo.Restore(context.Background(), userId, first.Id, 0); !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("Restore of a purged item: Expected ErrNotFound, got %v", err)
+		}
+
+		if items, _ := repo.GetAll(context.Background(), userId); !slices.Equal(ids(items), []string{live.Id}) {
+			t.Errorf("Expected the live item to be kept, got %+v", items)
+		}
+
+		if items := trashedPage(t, repo, otherUserId); len(items) != 1 || items[0].Id != theirs.Id {
+			t.Errorf("Expected the other user's trash to be kept, got %+v", items)
+		}
+	})
+
+	t.Run("Given PurgeExpiredTrash, should delete every user's items trashed at or before the time given", func(t *testing.T) {
+		repo := newRepo(t)
+		live := save(t, repo, userId, validItem("Live"))
+		mine := trash(t, repo, userId, save(t, repo, userId, validItem("Mine")))
+		theirs := trash(t, repo, otherUserId, save(t, repo, otherUserId, validItem("Theirs")))
+
+		purged, err := repo.PurgeExpiredTrash(context.Background(), mine.DeletedAt.Add(-time.Second))
+
+		if err != nil || len(purged) != 0 {
+			t.Errorf("Expected nothing to have expired, got %+v and %v", purged, err)
+		}
+
+		purged, err = repo.PurgeExpiredTrash(context.Background(), time.Now())
+		got := ids(purged)
+		slices.Sort(got)
+		expected := []string{mine.Id, theirs.Id}
+		slices.Sort(expected)
+
+		if err != nil || !slices.Equal(got, expected) {
+			t.Errorf("Expected %v to be purged, got %v and %v", expected, got, err)
+		}
+
+		for _, item := range purged {
+			if item.UserId == "" || item.DeletedAt == nil {
+				t.Errorf("Expected each purged item to carry its user and DeletedAt, got %+v", item)
+			}
+		}
+
+		if items, _ := repo.GetAll(context.Background(), userId); !slices.Equal(ids(items), []string{live.Id}) {
+			t.Errorf("Expected the live item to be kept, got %+v", items)
+		}
+	})
+}
+
+func recordWear(t *testing.T, repo repository.ClothingRepository, id string, wornAt time.Time) domain.Clothing {
+	t.Helper()
+
+	worn, err := repo.RecordWear(context.Background(), userId, id, wornAt)
+
+	if err != nil {
+		t.Fatalf("Expected no error on RecordWear, got %v", err)
+	}
+
+	return worn
+}
+
+func listWears(t *testing.T, repo repository.ClothingRepository, id string) []domain.Wear {
+	t.Helper()
+
+	wears, err := repo.ListWears(context.Background(), userId, id)
+
+	if err != nil {
+		t.Fatalf("Expected no error on ListWears, got %v", err)
+	}
+
+	return wears
+}
+
+func testWears(t *testing.T, newRepo Factory) {
+	// Whole seconds, as wears are stored to the second.
+	wornAt := time.Date(2026, 3, 14, 9, 30, 0, 0, time.UTC)
+
+	t.Run("Given wears, should count them, keep the latest as LastWornAt and bump the version", func(t *testing.T) {
+		repo := newRepo(t)
+		saved := save(t, repo, userId, validItem("Worn"))
+
+		recordWear(t, repo, saved.Id, wornAt)
+		worn := recordWear(t, repo, saved.Id, wornAt.Add(-48*time.Hour))
+
+		if worn.WearCount != 2 || worn.Version != 3 || worn.LastWornAt == nil || !worn.LastWornAt.Equal(wornAt) {
+			t.Errorf("Expected 2 wears at version 3, last worn at %v, got %+v", wornAt, worn)
+		}
+
+		got, _ := repo.GetById(context.Background(), userId, saved.Id)
+
+		if got.WearCount != worn.WearCount || got.Version != worn.Version || got.LastWornAt == nil || !got.LastWornAt.Equal(*worn.LastWornAt) {
+			t.Errorf("Expected stored %+v to match returned %+v", got, worn)
+		}
+	})
+
+	t.Run("Given wears logged out of order, ListWears should return them by when they were worn", func(t *testing.T) {
+		repo := newRepo(t)
+		saved := save(t, repo, userId, validItem("Worn"))
+
+		recordWear(t, repo, saved.Id, wornAt.Add(time.Hour))
+		recordWear(t, repo, saved.Id, wornAt.Add(500*time.Millisecond))
+		recordWear(t, repo, saved.Id, wornAt.Add(2*time.Hour))
+
+		wears := listWears(t, repo, saved.Id)
+		expected := []time.Time{wornAt, wornAt.Add(time.Hour), wornAt.Add(2 * time.Hour)}
+
+		if len(wears) != len(expected) {
+			t.Fatalf("Expected %d wears, got %+v", len(expected), wears)
+		}
+
+		for i, wear := range wears {
+			if !wear.WornAt.Equal(expected[i]) || wear.RecordedAt.IsZero() {
+				t.Errorf("Wear %d: Expected worn at %v and a recorded time, got %+v", i, expected[i], wear)
+			}
+		}
+	})
+
+	t.Run("Given an update, should keep the stored wear count and LastWornAt", func(t *testing.T) {
+		repo := newRepo(t)
+		worn := recordWear(t, repo, save(t, repo, userId, validItem("Worn")).Id, wornAt)
+
+		worn.Description = "Updated"
+		worn.WearCount = 40
+		worn.LastWornAt = nil
+
+		updated, err := repo.Update(context.Background(), userId, worn)
+
+		if err != nil {
+			t.Fatalf("Expected no error on Update, got %v", err)
+		}
+
+		got, _ := repo.GetById(context.Background(), userId, worn.Id)
+
+		for _, item := range []domain.Clothing{updated, got} {
+			if item.Description != "Updated" || item.WearCount != 1 || item.LastWornAt == nil || !item.LastWornAt.Equal(wornAt) {
+				t.Errorf("Expected the update with 1 wear at %v, got %+v", wornAt, item)
+			}
+		}
+	})
+
+	t.Run("Given a Save or SaveMany carrying wears, should start the new items unworn", func(t *testing.T) {
+		repo := newRepo(t)
+
+		item := validItem("Secondhand")
+		item.WearCount = 12
+		item.LastWornAt = &wornAt
+
+		saved := save(t, repo, userId, item)
+		many, err := repo.SaveMany(context.Background(), userId, []domain.Clothing{item})
+
+		if err != nil {
+			t.Fatalf("Expected no error on SaveMany, got %v", err)
+		}
+
+		for _, returned := range []domain.Clothing{saved, many[0]} {
+			got, _ := repo.GetById(context.Background(), userId, returned.Id)
+
+			for _, item := range []domain.Clothing{returned, got} {
+				if item.WearCount != 0 || item.LastWornAt != nil {
+					t.Errorf("Expected no wears, got %d last worn at %v", item.WearCount, item.LastWornAt)
+				}
+			}
+		}
+	})
+
+	t.Run("Given a purchase time, Save and Update should store it and clear it", func(t *testing.T) {
+		repo := newRepo(t)
+
+		item := validItem("Bought")
+		purchasedAt := wornAt.Add(-24 * time.Hour)
+		item.PurchasedAt = &purchasedAt
+		saved := save(t, repo, userId, item)
+
+		if got, _ := repo.GetById(context.Background(), userId, saved.Id); got.PurchasedAt == nil || !got.PurchasedAt.Equal(purchasedAt) {
+			t.Errorf("Expected purchased at %v, got %+v", purchasedAt, got)
+		}
+
+		saved.PurchasedAt = nil
+
+		if _, err := repo.Update(context.Background(), userId, saved); err != nil {
+			t.Fatalf("Expected no error on Update, got %v", err)
+		}
+
+		if got, _ := repo.GetById(context.Background(), userId, saved.Id); got.PurchasedAt != nil {
+			t.Errorf("Expected no purchase time, got %v", got.PurchasedAt)
+		}
+	})
+
+	t.Run("Given a missing, trashed or another user's item, RecordWear should return ErrNotFound", func(t *testing.T) {
+		repo := newRepo(t)
+		trashed := trash(t, repo, userId, save(t, repo, userId, validItem("Trashed")))
+		other := save(t, repo, otherUserId, validItem("Other"))
+
+		for _, id := range []string{"missing", trashed.Id, other.Id} {
+			if _, err := repo.RecordWear(context.Background(), userId, id, wornAt); !errors.Is(err, repository.ErrNotFound) {
+				t.Errorf("RecordWear of %s: Expected ErrNotFound, got %v", id, err)
+			}
+		}
+
+		if wears := listWears(t, repo, trashed.Id); len(wears) != 0 {
+			t.Errorf("Expected no wears to be logged, got %+v", wears)
+		}
+	})
+
+	t.Run("Given wears of other items and users, ListWears should return only the item's", func(t *testing.T) {
+		repo := newRepo(t)
+		saved := save(t, repo, userId, validItem("Worn"))
+		sibling := save(t, repo, userId, validItem("Sibling"))
+
+		recordWear(t, repo, saved.Id, wornAt)
+		recordWear(t, repo, sibling.Id, wornAt)
+
+		if wears := listWears(t, repo, saved.Id); len(wears) != 1 {
+			t.Errorf("Expected 1 wear, got %+v", wears)
+		}
+
+		if wears, err := repo.ListWears(context.Background(), otherUserId, saved.Id); err != nil || wears == nil || len(wears) != 0 {
+			t.Errorf("Expected an empty list for another user, got %#v and %v", wears, err)
+		}
+
+		if items, _ := repo.GetAll(context.Background(), userId); len(items) != 2 {
+			t.Errorf("Expected the wears not to be listed as items, got %+v", items)
+		}
+	})
+
+	t.Run("Given a zero worn at time, RecordWear should return a ValidationError", func(t *testing.T) {
+		repo := newRepo(t)
+		saved := save(t, repo, userId, validItem("Worn"))
+
+		_, err := repo.RecordWear(context.Background(), userId, saved.Id, time.Time{})
+		expectValidationError(t, "RecordWear", err)
+	})
+}
+
+func tagged(t *testing.T, repo repository.ClothingRepository, description string, tags ...string) domain.Clothing {
+	t.Helper()
+
+	item := validItem(description)
+	item.Tags = tags
+
+	return save(t, repo, userId, item)
+}
+
+func testTags(t *testing.T, newRepo Factory) {
+	t.Run("Given tags, should store them in order, and an item without tags should have none", func(t *testing.T) {
+		repo := newRepo(t)
+		saved := tagged(t, repo, "Tagged", "summer", "work wear")
+		untagged := tagged(t, repo, "Untagged")
+
+		got, err := repo.GetById(context.Background(), userId, saved.Id)
+
+		if err != nil || !slices.Equal(got.Tags, []string{"summer", "work wear"}) {
+			t.Errorf("Expected tags [summer work wear], got %q and %v", got.Tags, err)
+		}
+
+		if got, _ := repo.GetById(context.Background(), userId, untagged.Id); got.Tags != nil {
+			t.Errorf("Expected no tags, got %#v", got.Tags)
+		}
+	})
+
+	t.Run("Given an update, should replace or clear the tags", func(t *testing.T) {
+		repo := newRepo(t)
+		saved := tagged(t, repo, "Tagged", "summer", "work")
+
+		saved.Tags = []string{"winter"}
+
+		updated, err := repo.Update(context.Background(), userId, saved)
+
+		if err != nil || !slices.Equal(updated.Tags, []string{"winter"}) {
+			t.Fatalf("Expected tags [winter], got %q and %v", updated.Tags, err)
+		}
+
+		updated.Tags = nil
+
+		if _, err := repo.Update(context.Background(), userId, updated); err != nil {
+			t.Fatalf("Expected no error on Update, got %v", err)
+		}
+
+		if got, _ := repo.GetById(context.Background(), userId, saved.Id); got.Tags != nil {
+			t.Errorf("Expected the tags to be cleared, got %#v", got.Tags)
+		}
+	})
+
+	t.Run("Given a tag filter, GetPage should match items with all the tags, or any of them", func(t *testing.T) {
+		repo := newRepo(t)
+		both := tagged(t, repo, "Both", "summer", "work")
+		work := tagged(t, repo, "Work", "work")
+		summer := tagged(t, repo, "Summer", "summer")
+		tagged(t, repo, "Other", "winter", "workwear")
+		tagged(t, repo, "Untagged")
+		trash(t, repo, userId, tagged(t, repo, "Trashed", "work"))
+
+		other := validItem("Theirs")
+		other.Tags = []string{"work"}
+		save(t, repo, otherUserId, other)
+
+		for _, tc := range []struct {
+			filter   repository.ClothingFilter
+			expected []string
+		}{
+			{repository.ClothingFilter{Tags: []string{"work"}}, []string{both.Id, work.Id}},
+			{repository.ClothingFilter{Tags: []string{"summer", "work"}}, []string{both.Id}},
+			{repository.ClothingFilter{Tags: []string{"summer", "work"}, AnyTag: true}, []string{both.Id, work.Id, summer.Id}},
+			{repository.ClothingFilter{Tags: []string{"missing"}, AnyTag: true}, []string{}},
+		} {
+			page, err := repo.GetPage(context.Background(), userId, repository.PageRequest{Filter: tc.filter})
+			slices.Sort(tc.expected)
+
+			if err != nil || !slices.Equal(ids(page.Items), tc.expected) {
+				t.Errorf("Filter %+v: Expected %v, got %v and %v", tc.filter, tc.expected, ids(page.Items), err)
+			}
+		}
+	})
+
+	t.Run("Given tags that are not normalised, Save and Update should return a ValidationError", func(t *testing.T) {
+		repo := newRepo(t)
+		saved := tagged(t, repo, "Tagged", "work")
+
+		item := validItem("Shouting")
+		item.Tags = []string{"WORK"}
+
+		_, err := repo.Save(context.Background(), userId, item)
+		expectValidationError(t, "Save", err)
+
+		saved.Tags = []string{"work", "work"}
+
+		_, err = repo.Update(context.Background(), userId, saved)
+		expectValidationError(t, "Update", err)
+
+		_, err = repo.GetPage(context.Background(), userId, repository.PageRequest{Filter: repository.ClothingFilter{Tags: []string{" work"}}})
+		expectValidationError(t, "GetPage", err)
+	})
+}
+
+func coloured(t *testing.T, repo repository.ClothingRepository, description string, colour domain.Colour, secondary ...domain.Colour) domain.Clothing {
+	t.Helper()
+
+	item := validItem(description)
+	item.Colour = colour
+	item.SecondaryColours = secondary
+
+	return save(t, repo, userId, item)
+}
+
+func testColours(t *testing.T, newRepo Factory) {
+	t.Run("Given colours, should store them in order, and an item without colours should have none", func(t *testing.T) {
+		repo := newRepo(t)
+		saved := coloured(t, repo, "Striped", "navy", "white", "red")
+		plain := coloured(t, repo, "Plain", "")
+
+		got, err := repo.GetById(context.Background(), userId, saved.Id)
+
+		if err != nil || got.Colour != "navy" || !slices.Equal(got.SecondaryColours, []domain.Colour{"white", "red"}) {
+			t.Errorf("Expected navy with white and red, got %q %q and %v", got.Colour, got.SecondaryColours, err)
+		}
+
+		if got, _ := repo.GetById(context.Background(), userId, plain.Id); got.Colour != "" || got.SecondaryColours != nil {
+			t.Errorf("Expected no colours, got %q %#v", got.Colour, got.SecondaryColours)
+		}
+	})
+
+	t.Run("Given an update, should replace or clear the colours", func(t *testing.T) {
+		repo := newRepo(t)
+		saved := coloured(t, repo, "Striped", "navy", "white")
+
+		saved.Colour = "black"
+		saved.SecondaryColours = []domain.Colour{"grey"}
+
+		updated, err := repo.Update(context.Background(), userId, saved)
+
+		if err != nil || updated.Colour != "black" || !slices.Equal(updated.SecondaryColours, []domain.Colour{"grey"}) {
+			t.Fatalf("Expected black with grey, got %q %q and %v", updated.Colour, updated.SecondaryColours, err)
+		}
+
+		updated.Colour = ""
+		updated.SecondaryColours = nil
+
+		if _, err := repo.Update(context.Background(), userId, updated); err != nil {
+			t.Fatalf("Expected no error on Update, got %v", err)
+		}
+
+		if got, _ := repo.GetById(context.Background(), userId, saved.Id); got.Colour != "" || got.SecondaryColours != nil {
+			t.Errorf("Expected the colours to be cleared, got %q %#v", got.Colour, got.SecondaryColours)
+		}
+	})
+
+	t.Run("Given a colour filter, GetPage should match items with any of the colours, or only as their primary colour", func(t *testing.T) {
+		repo := newRepo(t)
+		navy := coloured(t, repo, "Navy", "navy")
+		trimmed := coloured(t, repo, "Trimmed", "white", "navy")
+		red := coloured(t, repo, "Red", "red", "white")
+		coloured(t, repo, "Plain", "")
+		trash(t, repo, userId, coloured(t, repo, "Trashed", "navy"))
+
+		other := validItem("Theirs")
+		other.Colour = "navy"
+		save(t, repo, otherUserId, other)
+
+		for _, tc := range []struct {
+			filter   repository.ClothingFilter
+			expected []string
+		}{
+			{repository.ClothingFilter{Colours: []domain.Colour{"navy"}}, []string{navy.Id, trimmed.Id}},
+			{repository.ClothingFilter{Colours: []domain.Colour{"navy"}, PrimaryColour: true}, []string{navy.Id}},
+			{repository.ClothingFilter{Colours: []domain.Colour{"navy", "red"}}, []string{navy.Id, trimmed.Id, red.Id}},
+			{repository.ClothingFilter{Colours: []domain.Colour{"white"}, PrimaryColour: true}, []string{trimmed.Id}},
+			{repository.ClothingFilter{Colours: []domain.Colour{"olive"}}, []string{}},
+		} {
+			page, err := repo.GetPage(context.Background(), userId, repository.PageRequest{Filter: tc.filter})
+			slices.Sort(tc.expected)
+
+			if err != nil || !slices.Equal(ids(page.Items), tc.expected) {
+				t.Errorf("Filter %+v: Expected %v, got %v and %v", tc.filter, tc.expected, ids(page.Items), err)
+			}
+		}
+	})
+
+	t.Run("Given colours outside the palette, Save, Update and GetPage should return a ValidationError", func(t *testing.T) {
+		repo := newRepo(t)
+		saved := coloured(t, repo, "Navy", "navy")
+
+		item := validItem("Sparkly")
+		item.Colour = "sparkly"
+
+		_, err := repo.Save(context.Background(), userId, item)
+		expectValidationError(t, "Save", err)
+
+		saved.SecondaryColours = []domain.Colour{"navy"}
+
+		_, err = repo.Update(context.Background(), userId, saved)
+		expectValidationError(t, "Update", err)
+
+		_, err = repo.GetPage(context.Background(), userId, repository.PageRequest{Filter: repository.ClothingFilter{Colours: []domain.Colour{"Navy"}}})
+		expectValidationError(t, "GetPage", err)
+	})
+}
+
+func sized(t *testing.T, repo repository.ClothingRepository, clothingType, size string) domain.Clothing {
+	t.Helper()
+
+	item := validItem(clothingType + " " + size)
+	item.ClothingType = clothingType
+	item.Size = size
+
+	return save(t, repo, userId, item)
+}
+
+func testSizes(t *testing.T, newRepo Factory) {
+	t.Run("Given a size filter, GetPage should match the same size in any region or spelling, for each item's type", func(t *testing.T) {
+		repo := newRepo(t)
+		ukDress := sized(t, repo, "Dress", "UK 10")
+		euDress := sized(t, repo, "Dress", "38")
+		sized(t, repo, "Dress", "UK 12")
+		euShoes := sized(t, repo, "Shoes", "EU 42")
+		ukShoes := sized(t, repo, "Shoes", "8")
+		medium := sized(t, repo, "Jumper", "Medium")
+		jeans := sized(t, repo, "Jeans", "32/34")
+		oneSize := sized(t, repo, "Hat", "One Size")
+		trash(t, repo, userId, sized(t, repo, "Dress", "UK 10"))
+
+		for _, tc := range []struct {
+			filter   repository.ClothingFilter
+			expected []string
+		}{
+			{repository.ClothingFilter{Size: "US 6"}, []string{ukDress.Id, euDress.Id}},
+			{repository.ClothingFilter{Size: "UK 8"}, []string{ukShoes.Id, euShoes.Id}},
+			{repository.ClothingFilter{Size: "M"}, []string{medium.Id}},
+			{repository.ClothingFilter{Size: "W32"}, []string{jeans.Id}},
+			{repository.ClothingFilter{Size: "one size"}, []string{oneSize.Id}},
+			{repository.ClothingFilter{Size: "EU 42", ClothingType: "Shoes"}, []string{ukShoes.Id, euShoes.Id}},
+		} {
+			for _, sort := range [][]repository.SortKey{nil, {{Field: repository.SortByPrice}}} {
+				page, err := repo.GetPage(context.Background(), userId, repository.PageRequest{Filter: tc.filter, Sort: sort})
+				slices.Sort(tc.expected)
+
+				if got := slices.Sorted(slices.Values(ids(page.Items))); err != nil || !slices.Equal(got, tc.expected) {
+					t.Errorf("Filter %+v sorted by %v: Expected %v, got %v and %v", tc.filter, sort, tc.expected, got, err)
+				}
+			}
+		}
+	})
+
+	t.Run("Given a size filter and a limit, GetPage should page through the matching items", func(t *testing.T) {
+		repo := newRepo(t)
+		var expected []string
+
+		for range 3 {
+			expected = append(expected, sized(t, repo, "Dress", "UK 10").Id)
+			sized(t, repo, "Dress", "UK 14")
+		}
+
+		var got []string
+		request := repository.PageRequest{Filter: repository.ClothingFilter{Size: "EU 38"}, Limit: 2}
+
+		for {
+			page, err := repo.GetPage(context.Background(), userId, request)
+
+			if err != nil {
+				t.Fatalf("Expected no error on GetPage, got %v", err)
+			}
+
+			got = append(got, ids(page.Items)...)
+
+			if page.NextCursor == "" {
+				break
+			}
+
+			request.Cursor = page.NextCursor
+		}
+
+		slices.Sort(expected)
+
+		if !slices.Equal(got, expected) {
+			t.Errorf("Expected %v, got %v", expected, got)
+		}
+	})
+}
+
+func classified(t *testing.T, repo repository.ClothingRepository, description string, seasons []domain.Season, occasions ...domain.Occasion) domain.Clothing {
+	t.Helper()
+
+	item := validItem(description)
+	item.Seasons = seasons
+	item.Occasions = occasions
+
+	return save(t, repo, userId, item)
+}
+
+func testSeasonsAndOccasions(t *testing.T, newRepo Factory) {
+	t.Run("Given seasons and occasions, should store them in order, and replace or clear them on update", func(t *testing.T) {
+		repo := newRepo(t)
+		saved := classified(t, repo, "Coat", []domain.Season{domain.Winter, domain.Autumn}, domain.Work, domain.Formal)
+
+		got, err := repo.GetById(context.Background(), userId, saved.Id)
+
+		if err != nil || !slices.Equal(got.Seasons, saved.Seasons) || !slices.Equal(got.Occasions, saved.Occasions) {
+			t.Fatalf("Expected %q and %q, got %q, %q and %v", saved.Seasons, saved.Occasions, got.Seasons, got.Occasions, err)
+		}
+
+		got.Seasons = []domain.Season{domain.Spring}
+		got.Occasions = nil
+
+		updated, err := repo.Update(context.Background(), userId, got)
+
+		if err != nil || !slices.Equal(updated.Seasons, []domain.Season{domain.Spring}) || updated.Occasions != nil {
+			t.Fatalf("Expected spring and no occasions, got %q, %#v and %v", updated.Seasons, updated.Occasions, err)
+		}
+
+		updated.Seasons = nil
+
+		if _, err := repo.Update(context.Background(), userId, updated); err != nil {
+			t.Fatalf("Expected no error on Update, got %v", err)
+		}
+
+		if got, _ := repo.GetById(context.Background(), userId, saved.Id); got.Seasons != nil || got.Occasions != nil {
+			t.Errorf("Expected no seasons or occasions, got %#v and %#v", got.Seasons, got.Occasions)
+		}
+	})
+
+	t.Run("Given season and occasion filters, GetPage should match items for any of them", func(t *testing.T) {
+		repo := newRepo(t)
+		coat := classified(t, repo, "Coat", []domain.Season{domain.Autumn, domain.Winter}, domain.Work)
+		shorts := classified(t, repo, "Shorts", []domain.Season{domain.Summer}, domain.Casual, domain.Sport)
+		suit := classified(t, repo, "Suit", []domain.Season{domain.Spring, domain.Autumn}, domain.Formal, domain.Work)
+		classified(t, repo, "Unclassified", nil)
+		trash(t, repo, userId, classified(t, repo, "Trashed", []domain.Season{domain.Winter}))
+
+		for _, tc := range []struct {
+			filter   repository.ClothingFilter
+			expected []string
+		}{
+			{repository.ClothingFilter{Seasons: []domain.Season{domain.Winter}}, []string{coat.Id}},
+			{repository.ClothingFilter{Seasons: []domain.Season{domain.Autumn}}, []string{coat.Id, suit.Id}},
+			{repository.ClothingFilter{Seasons: []domain.Season{domain.Summer, domain.Spring}}, []string{shorts.Id, suit.Id}},
+			{repository.ClothingFilter{Occasions: []domain.Occasion{domain.Work}}, []string{coat.Id, suit.Id}},
+			{repository.ClothingFilter{Seasons: []domain.Season{domain.Autumn}, Occasions: []domain.Occasion{domain.Formal}}, []string{suit.Id}},
+			{repository.ClothingFilter{Occasions: []domain.Occasion{domain.Party}}, []string{}},
+		} {
+			page, err := repo.GetPage(context.Background(), userId, repository.PageRequest{Filter: tc.filter})
+			slices.Sort(tc.expected)
+
+			if err != nil || !slices.Equal(ids(page.Items), tc.expected) {
+				t.Errorf("Filter %+v: Expected %v, got %v and %v", tc.filter, tc.expected, ids(page.Items), err)
+			}
+		}
+	})
+
+	t.Run("Given unknown seasons or occasions, Save and GetPage should return a ValidationError", func(t *testing.T) {
+		repo := newRepo(t)
+
+		item := validItem("Monsoon")
+		item.Seasons = []domain.Season{"monsoon"}
+
+		_, err := repo.Save(context.Background(), userId, item)
+		expectValidationError(t, "Save", err)
+
+		_, err = repo.GetPage(context.Background(), userId, repository.PageRequest{Filter: repository.ClothingFilter{Occasions: []domain.Occasion{"Work"}}})
+		expectValidationError(t, "GetPage", err)
+	})
+}
+
+func testConcurrentWrites(t *testing.T, newRepo Factory) {
+	const writers = 10
+
+	t.Run("Given concurrent saves, should store every item under a distinct id", func(t *testing.T) {
+		repo := newRepo(t)
+
+		var wg sync.WaitGroup
+		errs := make(chan error, writers)
+
+		for i := 0; i < writers; i++ {
+			wg.Add(1)
+
+			go func(i int) {
+				defer wg.Done()
+
+				if _, err := repo.Save(context.Background(), userId, validItem(fmt.Sprintf("Concurrent %d", i))); err != nil {
+					errs <- err
+				}
+			}(i)
+		}
+
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			t.Errorf("Expected no error on concurrent Save, got %v", err)
+		}
+
+		items, _ := repo.GetAll(context.Background(), userId)
+		unique := slices.Compact(slices.Sorted(slices.Values(ids(items))))
+
+		if len(items) != writers || len(unique) != writers {
+			t.Errorf("Expected %d items with distinct ids, got %d items and %d ids", writers, len(items), len(unique))
+		}
+	})
+
+	t.Run("Given concurrent updates at the same version, exactly one should succeed", func(t *testing.T) {
+		repo := newRepo(t)
+		saved := save(t, repo, userId, validItem("Contended"))
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		succeeded, stale := 0, 0
+
+		for i := 0; i < writers; i++ {
+			wg.Add(1)
+
+			go func(i int) {
+				defer wg.Done()
+
+				item := saved
+				item.Description = fmt.Sprintf("Writer %d", i)
+				_, err := repo.Update(context.Background(), userId, item)
+
+				mu.Lock()
+				defer mu.Unlock()
+
+				switch {
+				case err == nil:
+					succeeded++
+				case errors.Is(err, repository.ErrPreconditionFailed):
+					stale++
+				default:
+					t.Errorf("Expected nil or ErrPreconditionFailed, got %v", err)
+				}
+			}(i)
+		}
+
+		wg.Wait()
+
+		if succeeded != 1 || stale != writers-1 {
+			t.Errorf("Expected 1 success and %d precondition failures, got %d and %d", writers-1, succeeded, stale)
+		}
+
+		got, _ := repo.GetById(context.Background(), userId, saved.Id)
+
+		if got.Version != 2 {
+			t.Errorf("Expected version 2, got %d", got.Version)
+		}
+	})
+
+	t.Run("Given concurrent trashes of one item, exactly one should succeed", func(t *testing.T) {
+		repo := newRepo(t)
+		saved := save(t, repo, userId, validItem("Trashed concurrently"))
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		succeeded, missing := 0, 0
+
+		for i := 0; i < writers; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				_, err := repo.Trash(context.Background(), userId, saved.Id, 0)
+
+				mu.Lock()
+				defer mu.Unlock()
+
+				switch {
+				case err == nil:
+					succeeded++
+				case errors.Is(err, repository.ErrNotFound):
+					missing++
+				default:
+					t.Errorf("Expected nil or ErrNotFound, got %v", err)
+				}
+			}()
+		}
+
+		wg.Wait()
+
+		if succeeded != 1 || missing != writers-1 {
+			t.Errorf("Expected 1 success and %d not found, got %d and %d", writers-1, succeeded, missing)
+		}
+	})
+}
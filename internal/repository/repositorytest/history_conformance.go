@@ -0,0 +1,207 @@
+package repositorytest
+
+import (
+	"bytes"
+	"clothes_management/internal/domain"
+	"clothes_management/internal/repository"
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+)
+
+// HistoryFactory returns an empty history repository for a single test.
+type HistoryFactory func(t *testing.T) repository.HistoryRepository
+
+func historyEntry(itemId string, action domain.HistoryAction, timestamp time.Time) domain.HistoryEntry {
+	return domain.HistoryEntry{
+		UserId:    userId,
+		ItemId:    itemId,
+		Action:    action,
+		ActedBy:   userId,
+		Timestamp: timestamp,
+		Changes:   []domain.FieldChange{},
+	}
+}
+
+func actions(entries []domain.HistoryEntry) []domain.HistoryAction {
+	result := make([]domain.HistoryAction, 0, len(entries))
+
+	for _, entry := range entries {
+		result = append(result, entry.Action)
+	}
+
+	return result
+}
+
+// sameJSON compares values as JSON, so a missing value and an explicit null are the same.
+func sameJSON(a, b json.RawMessage) bool {
+	if len(a) == 0 {
+		a = json.RawMessage("null")
+	}
+
+	if len(b) == 0 {
+		b = json.RawMessage("null")
+	}
+
+	return bytes.Equal(a, b)
+}
+
+func list(t *testing.T, history repository.HistoryRepository, userId, itemId string) []domain.HistoryEntry {
+	t.Helper()
+
+	entries, err := history.List(context.Background(), userId, itemId)
+
+	if err != nil {
+		t.Fatalf("Expected no error on List, got %v", err)
+	}
+
+	return entries
+}
+
+// RunHistoryConformance runs the shared HistoryRepository contract against repositories from newHistory.
+func RunHistoryConformance(t *testing.T, newHistory HistoryFactory) {
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	t.Run("Given entries appended out of time order, should list them oldest first", func(t *testing.T) {
+		history := newHistory(t)
+
+		err := history.Append(context.Background(),
+			historyEntry("item", domain.HistoryUpdated, now.Add(time.Minute)),
+			historyEntry("item", domain.HistoryCreated, now))
+
+		if err != nil {
+			t.Fatalf("Expected no error on Append, got %v", err)
+		}
+
+		if err := history.Append(context.Background(), historyEntry("item", domain.HistoryDeleted, now.Add(2*time.Minute))); err != nil {
+			t.Fatalf("Expected no error on Append, got %v", err)
+		}
+
+		entries := list(t, history, userId, "item")
+		expected := []domain.HistoryAction{domain.HistoryCreated, domain.HistoryUpdated, domain.HistoryDeleted}
+
+		if !slices.Equal(actions(entries), expected) {
+			t.Errorf("Expected %v, got %v", expected, actions(entries))
+		}
+	})
+
+	t.Run("Given entries with the same timestamp, should list them in the order they were appended", func(t *testing.T) {
+		history := newHistory(t)
+
+		for _, action := range []domain.HistoryAction{domain.HistoryCreated, domain.HistoryUpdated} {
+			if err := history.Append(context.Background(), historyEntry("item", action, now)); err != nil {
+				t.Fatalf("Expected no error on Append, got %v", err)
+			}
+		}
+
+		entries := list(t, history, userId, "item")
+
+		if !slices.Equal(actions(entries), []domain.HistoryAction{domain.HistoryCreated, domain.HistoryUpdated}) {
+			t.Errorf("Expected create then update, got %v", actions(entries))
+		}
+	})
+
+	t.Run("Given an entry, should return every field as appended", func(t *testing.T) {
+		history := newHistory(t)
+
+		entry := historyEntry("item", domain.HistoryUpdated, now)
+		entry.ActedBy = domain.SystemActor
+		entry.Changes = []domain.FieldChange{
+			{Field: "description", Old: json.RawMessage(`"Old"`), New: json.RawMessage(`"New"`)},
+			{Field: "deletedAt", New: json.RawMessage(`"2026-01-02T03:04:05Z"`)},
+		}
+
+		if err := history.Append(context.Background(), entry); err != nil {
+			t.Fatalf("Expected no error on Append, got %v", err)
+		}
+
+		entries := list(t, history, userId, "item")
+
+		if len(entries) != 1 {
+			t.Fatalf("Expected 1 entry, got %+v", entries)
+		}
+
+		got := entries[0]
+
+		if got.UserId != entry.UserId || got.ItemId != entry.ItemId || got.Action != entry.Action || got.ActedBy != entry.ActedBy || !got.Timestamp.Equal(entry.Timestamp) {
+			t.Errorf("Expected %+v, got %+v", entry, got)
+		}
+
+		if len(got.Changes) != len(entry.Changes) {
+			t.Fatalf("Expected changes %+v, got %+v", entry.Changes, got.Changes)
+		}
+
+		for i, change := range entry.Changes {
+			if got.Changes[i].Field != change.Field || !sameJSON(got.Changes[i].Old, change.Old) || !sameJSON(got.Changes[i].New, change.New) {
+				t.Errorf("Change %d: Expected %+v, got %+v", i, change, got.Changes[i])
+			}
+		}
+	})
+
+	t.Run("Given an entry without changes, should return an empty list of changes", func(t *testing.T) {
+		history := newHistory(t)
+
+		entry := historyEntry("item", domain.HistoryPurged, now)
+		entry.Changes = nil
+
+		if err := history.Append(context.Background(), entry); err != nil {
+			t.Fatalf("Expected no error on Append, got %v", err)
+		}
+
+		if entries := list(t, history, userId, "item"); len(entries) != 1 || entries[0].Changes == nil || len(entries[0].Changes) != 0 {
+			t.Errorf("Expected 1 entry with empty changes, got %+v", entries)
+		}
+	})
+
+	t.Run("Given entries for other items and users, should list only the requested item's", func(t *testing.T) {
+		history := newHistory(t)
+
+		other := historyEntry("item", domain.HistoryCreated, now)
+		other.UserId = otherUserId
+
+		err := history.Append(context.Background(),
+			historyEntry("item", domain.HistoryCreated, now),
+			historyEntry("item-2", domain.HistoryCreated, now),
+			historyEntry("ite", domain.HistoryCreated, now),
+			other)
+
+		if err != nil {
+			t.Fatalf("Expected no error on Append, got %v", err)
+		}
+
+		entries := list(t, history, userId, "item")
+
+		if len(entries) != 1 || entries[0].ItemId != "item" || entries[0].UserId != userId {
+			t.Errorf("Expected only the user's entry for item, got %+v", entries)
+		}
+	})
+
+	t.Run("Given an item without history, should return an empty list", func(t *testing.T) {
+		history := newHistory(t)
+
+		if entries := list(t, history, userId, "missing"); entries == nil || len(entries) != 0 {
+			t.Errorf("Expected an empty list, got %#v", entries)
+		}
+	})
+
+	t.Run("Given a blank user or item id, should return a ValidationError", func(t *testing.T) {
+		history := newHistory(t)
+
+		_, err := history.List(context.Background(), " ", "item")
+		expectValidationError(t, "List with a blank user", err)
+
+		_, err = history.List(context.Background(), userId, "")
+		expectValidationError(t, "List with a blank item", err)
+
+		err = history.Append(context.Background(), historyEntry(" ", domain.HistoryCreated, now))
+		expectValidationError(t, "Append with a blank item", err)
+
+		blankUser := historyEntry("item", domain.HistoryCreated, now)
+		blankUser.UserId = ""
+
+		err = history.Append(context.Background(), blankUser)
+		expectValidationError(t, "Append with a blank user", err)
+	})
+}
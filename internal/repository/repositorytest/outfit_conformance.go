@@ -0,0 +1,237 @@
+package repositorytest
+
+import (
+	"clothes_management/internal/domain"
+	"clothes_management/internal/repository"
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+// OutfitFactory returns an empty outfit repository for a single test.
+type OutfitFactory func(t *testing.T) repository.OutfitRepository
+
+func validOutfit(name string, itemIds ...string) domain.Outfit {
+	return domain.Outfit{
+		Name:     name,
+		Occasion: "work",
+		ItemIds:  itemIds,
+	}
+}
+
+func saveOutfit(t *testing.T, outfits repository.OutfitRepository, userId string, outfit domain.Outfit) domain.Outfit {
+	t.Helper()
+
+	saved, err := outfits.Save(context.Background(), userId, outfit)
+
+	if err != nil {
+		t.Fatalf("Expected no error on Save, got %v", err)
+	}
+
+	return saved
+}
+
+func outfitIds(outfits []domain.Outfit) []string {
+	result := make([]string, 0, len(outfits))
+
+	for _, outfit := range outfits {
+		result = append(result, outfit.Id)
+	}
+
+	return result
+}
+
+func sameOutfit(a, b domain.Outfit) bool {
+	return a.Id == b.Id && a.UserId == b.UserId && a.Name == b.Name && a.Occasion == b.Occasion && slices.Equal(a.ItemIds, b.ItemIds)
+}
+
+// RunOutfitConformance runs the shared OutfitRepository contract against repositories from newOutfits.
+func RunOutfitConformance(t *testing.T, newOutfits OutfitFactory) {
+	t.Run("Given a valid outfit, Save should assign an id and the user, and GetById should return it", func(t *testing.T) {
+		outfits := newOutfits(t)
+		saved := saveOutfit(t, outfits, userId, validOutfit("Office", "shirt", "trousers", "shoes"))
+
+		if saved.Id == "" || saved.UserId != userId {
+			t.Fatalf("Expected an id and user %s, got %+v", userId, saved)
+		}
+
+		got, err := outfits.GetById(context.Background(), userId, saved.Id)
+
+		if err != nil || !sameOutfit(got, saved) {
+			t.Errorf("Expected %+v, got %+v and %v", saved, got, err)
+		}
+	})
+
+	t.Run("Given several outfits, GetAll should order them by name ignoring case", func(t *testing.T) {
+		outfits := newOutfits(t)
+		casual := saveOutfit(t, outfits, userId, validOutfit("casual", "jeans"))
+		wedding := saveOutfit(t, outfits, userId, validOutfit("Wedding", "suit"))
+		beach := saveOutfit(t, outfits, userId, validOutfit("Beach", "shorts"))
+
+		all, err := outfits.GetAll(context.Background(), userId)
+		expected := []string{beach.Id, casual.Id, wedding.Id}
+
+		if err != nil || !slices.Equal(outfitIds(all), expected) {
+			t.Errorf("Expected %v, got %v and %v", expected, outfitIds(all), err)
+		}
+	})
+
+	t.Run("Given a user without outfits, GetAll and ListUsing should return empty lists", func(t *testing.T) {
+		outfits := newOutfits(t)
+
+		if all, err := outfits.GetAll(context.Background(), userId); err != nil || all == nil || len(all) != 0 {
+			t.Errorf("GetAll: Expected an empty list, got %#v and %v", all, err)
+		}
+
+		if using, err := outfits.ListUsing(context.Background(), userId, "shirt"); err != nil || using == nil || len(using) != 0 {
+			t.Errorf("ListUsing: Expected an empty list, got %#v and %v", using, err)
+		}
+	})
+
+	t.Run("Given an update, should replace the outfit and keep its item order", func(t *testing.T) {
+		outfits := newOutfits(t)
+		saved := saveOutfit(t, outfits, userId, validOutfit("Office", "shirt", "trousers"))
+
+		changed := saved
+		changed.Name = "Smart office"
+		changed.Occasion = ""
+		changed.ItemIds = []string{"shoes", "shirt", "blazer"}
+
+		updated, err := outfits.Update(context.Background(), userId, changed)
+
+		if err != nil || !sameOutfit(updated, changed) {
+			t.Fatalf("Expected %+v, got %+v and %v", changed, updated, err)
+		}
+
+		got, err := outfits.GetById(context.Background(), userId, saved.Id)
+
+		if err != nil || !sameOutfit(got, changed) {
+			t.Errorf("Expected %+v, got %+v and %v", changed, got, err)
+		}
+	})
+
+	t.Run("Given outfits sharing an item, ListUsing should return only those containing it", func(t *testing.T) {
+		outfits := newOutfits(t)
+		office := saveOutfit(t, outfits, userId, validOutfit("Office", "shirt", "trousers"))
+		dinner := saveOutfit(t, outfits, userId, validOutfit("Dinner", "blazer", "shirt"))
+		saveOutfit(t, outfits, userId, validOutfit("Gym", "shirts", "shorts"))
+		saveOutfit(t, outfits, otherUserId, validOutfit("Theirs", "shirt"))
+
+		using, err := outfits.ListUsing(context.Background(), userId, "shirt")
+		expected := []string{dinner.Id, office.Id}
+
+		if err != nil || !slices.Equal(outfitIds(using), expected) {
+			t.Errorf("Expected %v, got %v and %v", expected, outfitIds(using), err)
+		}
+	})
+
+	t.Run("Given a deleted outfit, should behave as if it never existed", func(t *testing.T) {
+		outfits := newOutfits(t)
+		saved := saveOutfit(t, outfits, userId, validOutfit("Office", "shirt"))
+
+		if err := outfits.Delete(context.Background(), userId, saved.Id); err != nil {
+			t.Fatalf("Expected no error on Delete, got %v", err)
+		}
+
+		if _, err := outfits.GetById(context.Background(), userId, saved.Id); !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("GetById: Expected ErrNotFound, got %v", err)
+		}
+
+		if err := outfits.Delete(context.Background(), userId, saved.Id); !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("Delete: Expected ErrNotFound, got %v", err)
+		}
+
+		if using, _ := outfits.ListUsing(context.Background(), userId, "shirt"); len(using) != 0 {
+			t.Errorf("ListUsing: Expected no outfits, got %+v", using)
+		}
+	})
+
+	t.Run("Given an id that does not exist, GetById, Update and Delete should return ErrNotFound", func(t *testing.T) {
+		outfits := newOutfits(t)
+
+		if _, err := outfits.GetById(context.Background(), userId, "missing-id"); !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("GetById: Expected ErrNotFound, got %v", err)
+		}
+
+		missing := validOutfit("Missing", "shirt")
+		missing.Id = "missing-id"
+
+		if _, err := outfits.Update(context.Background(), userId, missing); !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("Update: Expected ErrNotFound, got %v", err)
+		}
+
+		if err := outfits.Delete(context.Background(), userId, "missing-id"); !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("Delete: Expected ErrNotFound, got %v", err)
+		}
+	})
+
+	t.Run("Given another user's outfit, should not list, read, update or delete it", func(t *testing.T) {
+		outfits := newOutfits(t)
+		theirs := saveOutfit(t, outfits, otherUserId, validOutfit("Theirs", "shirt"))
+
+		if all, _ := outfits.GetAll(context.Background(), userId); len(all) != 0 {
+			t.Errorf("GetAll: Expected no outfits, got %+v", all)
+		}
+
+		if _, err := outfits.GetById(context.Background(), userId, theirs.Id); !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("GetById: Expected ErrNotFound, got %v", err)
+		}
+
+		hijack := theirs
+		hijack.UserId = ""
+		hijack.Name = "Hijacked"
+
+		if _, err := outfits.Update(context.Background(), userId, hijack); !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("Update: Expected ErrNotFound, got %v", err)
+		}
+
+		if err := outfits.Delete(context.Background(), userId, theirs.Id); !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("Delete: Expected ErrNotFound, got %v", err)
+		}
+
+		got, err := outfits.GetById(context.Background(), otherUserId, theirs.Id)
+
+		if err != nil || !sameOutfit(got, theirs) {
+			t.Errorf("Expected the other user's outfit to be untouched, got %+v and %v", got, err)
+		}
+	})
+
+	t.Run("Given invalid arguments, should return a ValidationError", func(t *testing.T) {
+		outfits := newOutfits(t)
+		saved := saveOutfit(t, outfits, userId, validOutfit("Office", "shirt"))
+
+		_, err := outfits.Save(context.Background(), " ", validOutfit("Office", "shirt"))
+		expectValidationError(t, "Save with a blank user", err)
+
+		_, err = outfits.Save(context.Background(), userId, validOutfit(" ", "shirt"))
+		expectValidationError(t, "Save without a name", err)
+
+		_, err = outfits.Save(context.Background(), userId, validOutfit("Empty"))
+		expectValidationError(t, "Save without items", err)
+
+		_, err = outfits.Save(context.Background(), userId, validOutfit("Twice", "shirt", "shirt"))
+		expectValidationError(t, "Save with a repeated item", err)
+
+		_, err = outfits.Update(context.Background(), userId, validOutfit("No id", "shirt"))
+		expectValidationError(t, "Update without an id", err)
+
+		moved := saved
+		moved.UserId = otherUserId
+
+		_, err = outfits.Update(context.Background(), userId, moved)
+		expectValidationError(t, "Update to another user", err)
+
+		_, err = outfits.GetAll(context.Background(), "")
+		expectValidationError(t, "GetAll with a blank user", err)
+
+		_, err = outfits.GetById(context.Background(), userId, " ")
+		expectValidationError(t, "GetById with a blank id", err)
+
+		err = outfits.Delete(context.Background(), userId, "")
+		expectValidationError(t, "Delete with a blank id", err)
+
+		_, err = outfits.ListUsing(context.Background(), userId, " ")
+		expectValidationError(t, "ListUsing with a blank item", err)
+	})
+}
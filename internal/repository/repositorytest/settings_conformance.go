@@ -0,0 +1,83 @@
+package repositorytest
+
+import (
+	"clothes_management/internal/domain"
+	"clothes_management/internal/repository"
+	"context"
+	"testing"
+)
+
+// SettingsFactory returns an empty settings repository for a single test.
+type SettingsFactory func(t *testing.T) repository.SettingsRepository
+
+func getSettings(t *testing.T, settings repository.SettingsRepository, userId string) domain.Settings {
+	t.Helper()
+
+	got, err := settings.Get(context.Background(), userId)
+
+	if err != nil {
+		t.Fatalf("Expected no error on Get, got %v", err)
+	}
+
+	return got
+}
+
+// RunSettingsConformance runs the shared SettingsRepository contract against repositories from
+// newSettings.
+func RunSettingsConformance(t *testing.T, newSettings SettingsFactory) {
+	t.Run("Given a user who has not saved settings, Get should return the defaults", func(t *testing.T) {
+		settings := newSettings(t)
+
+		if got := getSettings(t, settings, userId); got != domain.DefaultSettings(userId) {
+			t.Errorf("Expected %+v, got %+v", domain.DefaultSettings(userId), got)
+		}
+	})
+
+	t.Run("Given saved settings, Get should return them for that user only", func(t *testing.T) {
+		settings := newSettings(t)
+
+		saved, err := settings.Save(context.Background(), userId, domain.Settings{Hemisphere: domain.SouthernHemisphere})
+
+		if err != nil || saved != (domain.Settings{UserId: userId, Hemisphere: domain.SouthernHemisphere}) {
+			t.Fatalf("Expected the southern hemisphere for %s, got %+v and %v", userId, saved, err)
+		}
+
+		if got := getSettings(t, settings, userId); got != saved {
+			t.Errorf("Expected %+v, got %+v", saved, got)
+		}
+
+		if got := getSettings(t, settings, otherUserId); got != domain.DefaultSettings(otherUserId) {
+			t.Errorf("Expected the defaults for %s, got %+v", otherUserId, got)
+		}
+	})
+
+	t.Run("Given settings saved twice, Get should return the latest", func(t *testing.T) {
+		settings := newSettings(t)
+
+		for _, hemisphere := range []domain.Hemisphere{domain.SouthernHemisphere, domain.NorthernHemisphere} {
+			if _, err := settings.Save(context.Background(), userId, domain.Settings{Hemisphere: hemisphere}); err != nil {
+				t.Fatalf("Expected no error on Save, got %v", err)
+			}
+		}
+
+		if got := getSettings(t, settings, userId); got.Hemisphere != domain.NorthernHemisphere {
+			t.Errorf("Expected the northern hemisphere, got %+v", got)
+		}
+	})
+
+	t.Run("Given invalid settings or a blank user, should return a ValidationError", func(t *testing.T) {
+		settings := newSettings(t)
+
+		_, err := settings.Save(context.Background(), userId, domain.Settings{Hemisphere: "eastern"})
+		expectValidationError(t, "Save with an unknown hemisphere", err)
+
+		_, err = settings.Save(context.Background(), userId, domain.Settings{UserId: otherUserId, Hemisphere: domain.SouthernHemisphere})
+		expectValidationError(t, "Save for another user", err)
+
+		_, err = settings.Save(context.Background(), " ", domain.DefaultSettings(""))
+		expectValidationError(t, "Save with a blank user", err)
+
+		_, err = settings.Get(context.Background(), "")
+		expectValidationError(t, "Get with a blank user", err)
+	})
+}
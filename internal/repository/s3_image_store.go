@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// S3ImageStore keeps images in a bucket under the key <userId>/<id>.
+type S3ImageStore struct {
+	client     *s3.Client
+	bucketName string
+}
+
+func NewS3ImageStore(client *s3.Client, bucketName string) (*S3ImageStore, error) {
+	if client == nil {
+		return nil, fmt.Errorf("client should not be nil")
+	}
+
+	if strings.TrimSpace(bucketName) == "" {
+		return nil, fmt.Errorf("bucketName should not be empty or whitespace")
+	}
+
+	return &S3ImageStore{
+		client:     client,
+		bucketName: bucketName,
+	}, nil
+}
+
+func imageObjectKey(userId, id string) string {
+	return userId + "/" + id
+}
+
+func (s *S3ImageStore) Put(ctx context.Context, userId, id, contentType string, data []byte) error {
+	if err := validateImageKey(userId, id); err != nil {
+		return err
+	}
+
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucketName),
+		Key:         aws.String(imageObjectKey(userId, id)),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String(contentType),
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to put image into S3: %w", err)
+	}
+
+	return nil
+}
+
+func (s *S3ImageStore) Get(ctx context.Context, userId, id string) (Image, error) {
+	if err := validateImageKey(userId, id); err != nil {
+		return Image{}, err
+	}
+
+	result, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucketName),
+		Key:    aws.String(imageObjectKey(userId, id)),
+	})
+
+	var noSuchKey *types.NoSuchKey
+
+	if errors.As(err, &noSuchKey) {
+		return Image{}, newNotFoundError("No image exists for id %s", id)
+	}
+
+	if err != nil {
+		return Image{}, fmt.Errorf("failed to get image from S3: %w", err)
+	}
+
+	return Image{
+		ContentType: aws.ToString(result.ContentType),
+		Body:        result.Body,
+	}, nil
+}
+
+func (s *S3ImageStore) Delete(ctx context.Context, userId, id string) error {
+	if err := validateImageKey(userId, id); err != nil {
+		return err
+	}
+
+	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucketName),
+		Key:    aws.String(imageObjectKey(userId, id)),
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to delete image from S3: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+func setupLocalStackS3Store(t *testing.T) *S3ImageStore {
+	accessKeyId := os.Getenv("AWS_ACCESS_KEY_ID")
+	if accessKeyId == "" {
+		t.Fatal("ERROR: AWS_ACCESS_KEY_ID environment variable not set. Please set it in .env_test or your shell.")
+	}
+	secretAccessKey := os.Getenv("AWS_SECRET_ACCESS_KEY")
+	if secretAccessKey == "" {
+		t.Fatal("ERROR: AWS_SECRET_ACCESS_KEY environment variable not set. Please set it in .env_test or your shell.")
+	}
+	awsRegion := os.Getenv("AWS_REGION")
+	if awsRegion == "" {
+		t.Fatal("ERROR: AWS_REGION environment variable not set. Please set it in .env_test or your shell.")
+	}
+	bucketName := os.Getenv("IMAGE_BUCKET_NAME")
+	if bucketName == "" {
+		t.Fatal("ERROR: IMAGE_BUCKET_NAME environment variable not set. Please set it in .env_test or your shell.")
+	}
+	baseEndoint := os.Getenv("BASE_ENDPOINT")
+	if baseEndoint == "" {
+		t.Fatal("ERROR: BASE_ENDPOINT environment variable not set. Please set it in .env_test or your shell.")
+	}
+
+	cfg, err := config.LoadDefaultConfig(context.TODO(),
+		config.WithRegion(awsRegion),
+		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(accessKeyId, secretAccessKey, "")),
+	)
+	if err != nil {
+		t.Fatalf("Failed to load AWS SDK config for LocalStack: %v", err)
+	}
+
+	// LocalStack serves buckets by path rather than by virtual host.
+	client := s3.NewFromConfig(cfg, func(o *s3.Options) {
+		o.BaseEndpoint = aws.String(baseEndoint)
+		o.UsePathStyle = true
+	})
+
+	_, err = client.CreateBucket(context.TODO(), &s3.CreateBucketInput{
+		Bucket: aws.String(bucketName),
+		CreateBucketConfiguration: &types.CreateBucketConfiguration{
+			LocationConstraint: types.BucketLocationConstraint(awsRegion),
+		},
+	})
+
+	var alreadyOwned *types.BucketAlreadyOwnedByYou
+	if err != nil && !errors.As(err, &alreadyOwned) {
+		t.Fatalf("Failed to create bucket %s: %v", bucketName, err)
+	}
+
+	store, err := NewS3ImageStore(client, bucketName)
+
+	if err != nil {
+		t.Fatalf("Expected no err on NewS3ImageStore, got %v", err)
+	}
+
+	return store
+}
+
+func TestS3ImageStore(t *testing.T) {
+	t.Run("Given a stored image, Get should return its bytes and content type", func(t *testing.T) {
+		store := setupLocalStackS3Store(t)
+		t.Cleanup(func() {
+			store.Delete(context.Background(), "test-user-id", "item-1")
+		})
+
+		if err := store.Put(context.Background(), "test-user-id", "item-1", "image/png", pngHeader); err != nil {
+			t.Fatalf("Expected no error on Put, got %v", err)
+		}
+
+		image, err := store.Get(context.Background(), "test-user-id", "item-1")
+
+		if err != nil {
+			t.Fatalf("Expected no error on Get, got %v", err)
+		}
+
+		defer image.Body.Close()
+
+		got, _ := io.ReadAll(image.Body)
+
+		if !bytes.Equal(got, pngHeader) {
+			t.Errorf("Expected the stored bytes back, got %v", got)
+		}
+
+		if image.ContentType != "image/png" {
+			t.Errorf("Expected image/png, got %s", image.ContentType)
+		}
+	})
+
+	t.Run("Given a deleted image, Get should return ErrNotFound and Delete should still succeed", func(t *testing.T) {
+		store := setupLocalStackS3Store(t)
+
+		store.Put(context.Background(), "test-user-id", "item-2", "image/png", pngHeader)
+
+		if err := store.Delete(context.Background(), "test-user-id", "item-2"); err != nil {
+			t.Fatalf("Expected no error on Delete, got %v", err)
+		}
+
+		if _, err := store.Get(context.Background(), "test-user-id", "item-2"); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Expected ErrNotFound, got %v", err)
+		}
+
+		if err := store.Delete(context.Background(), "test-user-id", "item-2"); err != nil {
+			t.Errorf("Expected deleting a missing image to succeed, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"clothes_management/internal/domain"
+	"context"
+	"strings"
+)
+
+// SettingsRepository stores each user's Settings. Get returns domain.DefaultSettings for a user who has
+// never saved any, so every user has settings. Save replaces the user's settings as a whole.
+type SettingsRepository interface {
+	Get(ctx context.Context, userId string) (domain.Settings, error)
+	Save(ctx context.Context, userId string, settings domain.Settings) (domain.Settings, error)
+}
+
+// validateSettingsWrite validates the arguments of Save.
+func validateSettingsWrite(userId string, settings domain.Settings) error {
+	if strings.TrimSpace(userId) == "" {
+		return newValidationError("User ID must not be empty or whitespace")
+	}
+
+	if err := settings.Validate(); err != nil {
+		return &ValidationError{Err: err}
+	}
+
+	if settings.UserId != "" && settings.UserId != userId {
+		return newValidationError("Mismatch of user ID")
+	}
+
+	return nil
+}
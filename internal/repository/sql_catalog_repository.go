@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// SQLCatalogRepository stores the global catalogs in the catalog table created by MigrateSQLDatabase.
+type SQLCatalogRepository struct {
+	db      *sql.DB
+	dialect SQLDialect
+}
+
+func NewSQLCatalogRepository(db *sql.DB, dialect SQLDialect) (*SQLCatalogRepository, error) {
+	if db == nil {
+		return nil, fmt.Errorf("db should not be nil")
+	}
+
+	if _, ok := ParseSQLDialect(string(dialect)); !ok {
+		return nil, fmt.Errorf("unsupported SQL dialect %q", dialect)
+	}
+
+	return &SQLCatalogRepository{
+		db:      db,
+		dialect: dialect,
+	}, nil
+}
+
+func (s *SQLCatalogRepository) Register(ctx context.Context, kind CatalogKind, value string) error {
+	if err := validateCatalogEntry(kind, value); err != nil {
+		return err
+	}
+
+	// Keeping the existing row on conflict keeps the first spelling registered.
+	_, err := s.db.ExecContext(ctx, s.dialect.rebind("INSERT INTO catalog (kind, entry_key, value) VALUES (?, ?, ?) ON CONFLICT (kind, entry_key) DO NOTHING"),
+		string(kind), catalogKey(value), strings.TrimSpace(value))
+
+	if err != nil {
+		return fmt.Errorf("failed to insert catalog entry: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SQLCatalogRepository) List(ctx context.Context, kind CatalogKind) ([]string, error) {
+	if _, ok := ParseCatalogKind(string(kind)); !ok {
+		return []string{}, newValidationError("Unknown catalog " + string(kind))
+	}
+
+	rows, err := s.db.QueryContext(ctx, s.dialect.rebind("SELECT value FROM catalog WHERE kind = ?"), string(kind))
+
+	if err != nil {
+		return []string{}, fmt.Errorf("failed to query catalog: %w", err)
+	}
+
+	defer rows.Close()
+
+	values := []string{}
+
+	for rows.Next() {
+		var value string
+
+		if err := rows.Scan(&value); err != nil {
+			return []string{}, fmt.Errorf("failed to read catalog row: %w", err)
+		}
+
+		values = append(values, value)
+	}
+
+	if err := rows.Err(); err != nil {
+		return []string{}, fmt.Errorf("failed to query catalog: %w", err)
+	}
+
+	sortCatalogValues(values)
+
+	return values, nil
+}
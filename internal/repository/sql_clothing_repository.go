@@ -0,0 +1,758 @@
+package repository
+
+import (
+	"clothes_management/internal/domain"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const clothingColumns = "user_id, id, clothing_type, description, brand, store, image_url, price_pence, size, version, deleted_at, currency, purchased_at, wear_count, last_worn_at, tags, colour, secondary_colours, seasons, occasions"
+
+// liveOnly restricts a query to items that are not in the trash.
+const liveOnly = " AND deleted_at IS NULL"
+
+// SQLClothingRepository stores clothing in the clothing table created by MigrateSQLDatabase.
+type SQLClothingRepository struct {
+	db      *sql.DB
+	dialect SQLDialect
+}
+
+func NewSQLClothingRepository(db *sql.DB, dialect SQLDialect) (*SQLClothingRepository, error) {
+	if db == nil {
+		return nil, fmt.Errorf("db should not be nil")
+	}
+
+	if _, ok := ParseSQLDialect(string(dialect)); !ok {
+		return nil, fmt.Errorf("unsupported SQL dialect %q", dialect)
+	}
+
+	return &SQLClothingRepository{
+		db:      db,
+		dialect: dialect,
+	}, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanClothing reads a row of clothingColumns. Times are stored as unix seconds: deleted_at is NULL while
+// the item is live, and purchased_at and last_worn_at are NULL until known. Tags, secondary colours,
+// seasons and occasions are JSON arrays.
+func scanClothing(row rowScanner) (domain.Clothing, error) {
+	var clothing domain.Clothing
+	var deletedAt, purchasedAt, lastWornAt sql.NullInt64
+	var tags, secondaryColours, seasons, occasions string
+
+	err := row.Scan(
+		&clothing.UserId,
+		&clothing.Id,
+		&clothing.ClothingType,
+		&clothing.Description,
+		&clothing.Brand,
+		&clothing.Store,
+		&clothing.ImageUrl,
+		&clothing.Price,
+		&clothing.Size,
+		&clothing.Version,
+		&deletedAt,
+		&clothing.Currency,
+		&purchasedAt,
+		&clothing.WearCount,
+		&lastWornAt,
+		&tags,
+		&clothing.Colour,
+		&secondaryColours,
+		&seasons,
+		&occasions,
+	)
+
+	if err != nil {
+		return clothing, err
+	}
+
+	clothing.DeletedAt = nullableTime(deletedAt)
+	clothing.PurchasedAt = nullableTime(purchasedAt)
+	clothing.LastWornAt = nullableTime(lastWornAt)
+
+	if err := json.Unmarshal([]byte(tags), &clothing.Tags); err != nil {
+		return clothing, fmt.Errorf("failed to unmarshal clothing tags: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(secondaryColours), &clothing.SecondaryColours); err != nil {
+		return clothing, fmt.Errorf("failed to unmarshal clothing secondary colours: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(seasons), &clothing.Seasons); err != nil {
+		return clothing, fmt.Errorf("failed to unmarshal clothing seasons: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(occasions), &clothing.Occasions); err != nil {
+		return clothing, fmt.Errorf("failed to unmarshal clothing occasions: %w", err)
+	}
+
+	// An item without any of these lists has none at all, as it does in the other backends.
+	if len(clothing.Tags) == 0 {
+		clothing.Tags = nil
+	}
+
+	if len(clothing.SecondaryColours) == 0 {
+		clothing.SecondaryColours = nil
+	}
+
+	if len(clothing.Seasons) == 0 {
+		clothing.Seasons = nil
+	}
+
+	if len(clothing.Occasions) == 0 {
+		clothing.Occasions = nil
+	}
+
+	return clothing, nil
+}
+
+// jsonArrayColumn is the column value of a list of strings, such as tags, as a JSON array that is empty
+// when there are none.
+func jsonArrayColumn[S ~string](values []S) string {
+	if len(values) == 0 {
+		return "[]"
+	}
+
+	// A list of strings always marshals.
+	encoded, _ := json.Marshal(values)
+
+	return string(encoded)
+}
+
+// choiceStrings converts a filter's list of choices, such as seasons, to plain strings.
+func choiceStrings[S ~string](values []S) []string {
+	converted := make([]string, len(values))
+
+	for i, value := range values {
+		converted[i] = string(value)
+	}
+
+	return converted
+}
+
+func nullableTime(unix sql.NullInt64) *time.Time {
+	if !unix.Valid {
+		return nil
+	}
+
+	t := time.Unix(unix.Int64, 0).UTC()
+	return &t
+}
+
+// nullableUnix is the column value of an optional time, in unix seconds.
+func nullableUnix(t *time.Time) sql.NullInt64 {
+	if t == nil {
+		return sql.NullInt64{}
+	}
+
+	return sql.NullInt64{Int64: t.Unix(), Valid: true}
+}
+
+func (s *SQLClothingRepository) query(ctx context.Context, query string, args ...any) ([]domain.Clothing, error) {
+	rows, err := s.db.QueryContext(ctx, s.dialect.rebind(query), args...)
+
+	if err != nil {
+		return []domain.Clothing{}, fmt.Errorf("failed to query clothing: %w", err)
+	}
+
+	defer rows.Close()
+
+	items := []domain.Clothing{}
+
+	for rows.Next() {
+		item, err := scanClothing(rows)
+
+		if err != nil {
+			return []domain.Clothing{}, fmt.Errorf("failed to read clothing row: %w", err)
+		}
+
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return []domain.Clothing{}, fmt.Errorf("failed to query clothing: %w", err)
+	}
+
+	return items, nil
+}
+
+func (s *SQLClothingRepository) Save(ctx context.Context, userId string, clothing domain.Clothing) (domain.Clothing, error) {
+	if strings.TrimSpace(userId) == "" {
+		return domain.Clothing{}, newValidationError("User ID must not be empty or whitespace")
+	}
+
+	if err := clothing.Validate(); err != nil {
+		return domain.Clothing{}, &ValidationError{Err: err}
+	}
+
+	// Ids are always generated here so callers can never choose, or collide with, another item's id.
+	clothing.Id = uuid.New().String()
+
+	clothing.UserId = userId
+	clothing.Version = 1
+	clothing.DeletedAt = nil
+	clothing.WearCount = 0
+	clothing.LastWornAt = nil
+
+	if err := s.insert(ctx, s.db, clothing); err != nil {
+		return domain.Clothing{}, err
+	}
+
+	return clothing, nil
+}
+
+func (s *SQLClothingRepository) SaveMany(ctx context.Context, userId string, clothing []domain.Clothing) ([]domain.Clothing, error) {
+	prepared, err := prepareNewItems(userId, clothing)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer tx.Rollback()
+
+	for _, item := range prepared {
+		if err := s.insert(ctx, tx, item); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit inserted clothing: %w", err)
+	}
+
+	return prepared, nil
+}
+
+// sqlExecer is satisfied by both *sql.DB and *sql.Tx.
+type sqlExecer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+func (s *SQLClothingRepository) insert(ctx context.Context, db sqlExecer, clothing domain.Clothing) error {
+	result, err := db.ExecContext(ctx, s.dialect.rebind(
+		"INSERT INTO clothing ("+clothingColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NULL, ?, ?, ?, ?, ?, ?, ?, ?, ?) ON CONFLICT (user_id, id) DO NOTHING"),
+		clothing.UserId,
+		clothing.Id,
+		clothing.ClothingType,
+		clothing.Description,
+		clothing.Brand,
+		clothing.Store,
+		clothing.ImageUrl,
+		clothing.Price,
+		clothing.Size,
+		clothing.Version,
+		clothing.Currency,
+		nullableUnix(clothing.PurchasedAt),
+		clothing.WearCount,
+		nullableUnix(clothing.LastWornAt),
+		jsonArrayColumn(clothing.Tags),
+		clothing.Colour,
+		jsonArrayColumn(clothing.SecondaryColours),
+		jsonArrayColumn(clothing.Seasons),
+		jsonArrayColumn(clothing.Occasions),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to insert clothing: %w", err)
+	}
+
+	if inserted, err := result.RowsAffected(); err == nil && inserted == 0 {
+		return newConflictError("Item with id %s already exists", clothing.Id)
+	}
+
+	return nil
+}
+
+func (s *SQLClothingRepository) GetAll(ctx context.Context, userId string) ([]domain.Clothing, error) {
+	if strings.TrimSpace(userId) == "" {
+		return []domain.Clothing{}, newValidationError("User ID must not be empty or whitespace")
+	}
+
+	return s.query(ctx, "SELECT "+clothingColumns+" FROM clothing WHERE user_id = ?"+liveOnly+" ORDER BY "+s.dialect.binary("id"), userId)
+}
+
+// filterClause returns the conditions, each prefixed with AND, that apply filter, apart from Size, which
+// is matched in process.
+func filterClause(dialect SQLDialect, filter ClothingFilter) (string, []any) {
+	var clause strings.Builder
+	args := []any{}
+
+	if filter.Trashed {
+		clause.WriteString(" AND deleted_at IS NOT NULL")
+	} else {
+		clause.WriteString(liveOnly)
+	}
+
+	for _, condition := range []struct {
+		column string
+		value  string
+	}{
+		{column: "clothing_type", value: filter.ClothingType},
+		{column: "brand", value: filter.Brand},
+		{column: "store", value: filter.Store},
+	} {
+		if condition.value != "" {
+			clause.WriteString(" AND " + condition.column + " = ?")
+			args = append(args, condition.value)
+		}
+	}
+
+	// Items stored before currencies were recorded have none, and are in domain.DefaultCurrency.
+	if filter.Currency == domain.DefaultCurrency {
+		clause.WriteString(" AND currency IN (?, '')")
+		args = append(args, filter.Currency)
+	} else if filter.Currency != "" {
+		clause.WriteString(" AND currency = ?")
+		args = append(args, filter.Currency)
+	}
+
+	if filter.MinPricePence != nil {
+		clause.WriteString(" AND price_pence >= ?")
+		args = append(args, *filter.MinPricePence)
+	}
+
+	if filter.MaxPricePence != nil {
+		clause.WriteString(" AND price_pence <= ?")
+		args = append(args, *filter.MaxPricePence)
+	}
+
+	if len(filter.Tags) > 0 {
+		conditions := make([]string, len(filter.Tags))
+
+		for i, tag := range filter.Tags {
+			conditions[i] = dialect.jsonArrayContains("tags")
+			args = append(args, tag)
+		}
+
+		join := " AND "
+
+		if filter.AnyTag {
+			join = " OR "
+		}
+
+		clause.WriteString(" AND (" + strings.Join(conditions, join) + ")")
+	}
+
+	if len(filter.Colours) > 0 {
+		conditions := make([]string, 0, 2*len(filter.Colours))
+
+		for _, colour := range filter.Colours {
+			conditions = append(conditions, "colour = ?")
+			args = append(args, colour)
+		}
+
+		if !filter.PrimaryColour {
+			for _, colour := range filter.Colours {
+				conditions = append(conditions, dialect.jsonArrayContains("secondary_colours"))
+				args = append(args, colour)
+			}
+		}
+
+		clause.WriteString(" AND (" + strings.Join(conditions, " OR ") + ")")
+	}
+
+	for _, list := range []struct {
+		column string
+		values []string
+	}{
+		{column: "seasons", values: choiceStrings(filter.Seasons)},
+		{column: "occasions", values: choiceStrings(filter.Occasions)},
+	} {
+		if len(list.values) == 0 {
+			continue
+		}
+
+		conditions := make([]string, len(list.values))
+
+		for i, value := range list.values {
+			conditions[i] = dialect.jsonArrayContains(list.column)
+			args = append(args, value)
+		}
+
+		clause.WriteString(" AND (" + strings.Join(conditions, " OR ") + ")")
+	}
+
+	return clause.String(), args
+}
+
+// GetPage filters in SQL. Without sort keys or a size filter it pages by id with a keyset condition;
+// otherwise the matching rows are sized and sorted in process so they compare exactly as the other
+// backends do.
+func (s *SQLClothingRepository) GetPage(ctx context.Context, userId string, page PageRequest) (Page, error) {
+	if strings.TrimSpace(userId) == "" {
+		return Page{Items: []domain.Clothing{}}, newValidationError("User ID must not be empty or whitespace")
+	}
+
+	if err := page.Filter.Validate(); err != nil {
+		return Page{Items: []domain.Clothing{}}, &ValidationError{Err: err}
+	}
+
+	filter, filterArgs := filterClause(s.dialect, page.Filter)
+	query := "SELECT " + clothingColumns + " FROM clothing WHERE user_id = ?" + filter
+	args := append([]any{userId}, filterArgs...)
+
+	if len(page.Sort) > 0 || page.Filter.Size != "" {
+		items, err := s.query(ctx, query, args...)
+
+		if err != nil {
+			return Page{Items: []domain.Clothing{}}, err
+		}
+
+		return sortedPage(slices.DeleteFunc(items, func(item domain.Clothing) bool { return !page.Filter.Matches(item) }), page)
+	}
+
+	if page.Cursor != "" {
+		var position sortCursor
+
+		if err := decodeCursor(page.Cursor, &position); err != nil {
+			return Page{Items: []domain.Clothing{}}, err
+		}
+
+		if position.Sort != "" || position.Id == "" {
+			return Page{Items: []domain.Clothing{}}, fmt.Errorf("%w: cursor was issued for a different sort order", ErrInvalidCursor)
+		}
+
+		query += " AND " + s.dialect.binary("id") + " > ?"
+		args = append(args, position.Id)
+	}
+
+	// Read one extra row to learn whether there is another page without a second query.
+	limit := page.limit()
+	query += " ORDER BY " + s.dialect.binary("id") + " LIMIT ?"
+	args = append(args, limit+1)
+
+	items, err := s.query(ctx, query, args...)
+
+	if err != nil {
+		return Page{Items: []domain.Clothing{}}, err
+	}
+
+	if len(items) <= limit {
+		return Page{Items: items}, nil
+	}
+
+	cursor, err := encodeCursor(newSortCursor(items[limit-1], nil))
+
+	if err != nil {
+		return Page{Items: []domain.Clothing{}}, err
+	}
+
+	return Page{Items: items[:limit], NextCursor: cursor}, nil
+}
+
+func (s *SQLClothingRepository) GetById(ctx context.Context, userId, id string) (domain.Clothing, error) {
+	if strings.TrimSpace(userId) == "" {
+		return domain.Clothing{}, newValidationError("User ID must not be empty or whitespace")
+	}
+
+	if strings.TrimSpace(id) == "" {
+		return domain.Clothing{}, newValidationError("ID must not be empty or whitespace")
+	}
+
+	row := s.db.QueryRowContext(ctx, s.dialect.rebind("SELECT "+clothingColumns+" FROM clothing WHERE user_id = ? AND id = ?"+liveOnly), userId, id)
+
+	item, err := scanClothing(row)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Clothing{}, newNotFoundError("No item exists for id %s", id)
+	}
+
+	if err != nil {
+		return domain.Clothing{}, fmt.Errorf("failed to get clothing: %w", err)
+	}
+
+	return item, nil
+}
+
+func (s *SQLClothingRepository) Update(ctx context.Context, userId string, clothing domain.Clothing) (domain.Clothing, error) {
+	if strings.TrimSpace(userId) == "" {
+		return domain.Clothing{}, newValidationError("User ID must not be empty or whitespace")
+	}
+
+	if err := clothing.Validate(); err != nil {
+		return domain.Clothing{}, &ValidationError{Err: err}
+	}
+
+	if strings.TrimSpace(clothing.Id) == "" {
+		return domain.Clothing{}, newValidationError("cannot update clothing without ID")
+	}
+
+	if clothing.UserId != "" && clothing.UserId != userId {
+		return domain.Clothing{}, newValidationError("Mismatch of user ID")
+	}
+
+	query := "UPDATE clothing SET clothing_type = ?, description = ?, brand = ?, store = ?, image_url = ?, price_pence = ?, size = ?, currency = ?, purchased_at = ?, tags = ?, colour = ?, secondary_colours = ?, seasons = ?, occasions = ?, version = version + 1 WHERE user_id = ? AND id = ?" + liveOnly
+	args := []any{
+		clothing.ClothingType,
+		clothing.Description,
+		clothing.Brand,
+		clothing.Store,
+		clothing.ImageUrl,
+		clothing.Price,
+		clothing.Size,
+		clothing.Currency,
+		nullableUnix(clothing.PurchasedAt),
+		jsonArrayColumn(clothing.Tags),
+		clothing.Colour,
+		jsonArrayColumn(clothing.SecondaryColours),
+		jsonArrayColumn(clothing.Seasons),
+		jsonArrayColumn(clothing.Occasions),
+		userId,
+		clothing.Id,
+	}
+
+	if clothing.Version != 0 {
+		query += " AND version = ?"
+		args = append(args, clothing.Version)
+	}
+
+	// The stored row is returned, as the wear columns are left as they were.
+	updated, err := scanClothing(s.db.QueryRowContext(ctx, s.dialect.rebind(query+" RETURNING "+clothingColumns), args...))
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Clothing{}, s.missingOrStale(ctx, userId, clothing.Id, clothing.Version, false)
+	}
+
+	if err != nil {
+		return domain.Clothing{}, fmt.Errorf("failed to update clothing: %w", err)
+	}
+
+	return updated, nil
+}
+
+// missingOrStale explains why a conditional write matched no rows: either the item does not exist, is
+// not in the trash or not, as trashed requires, or it is no longer at expectedVersion.
+func (s *SQLClothingRepository) missingOrStale(ctx context.Context, userId, id string, expectedVersion int64, trashed bool) error {
+	var version int64
+	var deletedAt sql.NullInt64
+	err := s.db.QueryRowContext(ctx, s.dialect.rebind("SELECT version, deleted_at FROM clothing WHERE user_id = ? AND id = ?"), userId, id).Scan(&version, &deletedAt)
+
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && deletedAt.Valid != trashed) {
+		return newNotFoundError("No item exists for id %s", id)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to read clothing version: %w", err)
+	}
+
+	return newPreconditionFailedError("Item with id %s is at version %d, not %d", id, version, expectedVersion)
+}
+
+func (s *SQLClothingRepository) Exists(ctx context.Context, userId, id string) (bool, error) {
+	if strings.TrimSpace(userId) == "" {
+		return false, newValidationError("User ID must not be empty or whitespace")
+	}
+
+	if strings.TrimSpace(id) == "" {
+		return false, newValidationError("ID cannot be empty or whitespace")
+	}
+
+	var found int
+	err := s.db.QueryRowContext(ctx, s.dialect.rebind("SELECT 1 FROM clothing WHERE user_id = ? AND id = ?"+liveOnly), userId, id).Scan(&found)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("failed to check clothing exists: %w", err)
+	}
+
+	return true, nil
+}
+
+func (s *SQLClothingRepository) Trash(ctx context.Context, userId, id string, expectedVersion int64) (domain.Clothing, error) {
+	return s.moveTrash(ctx, userId, id, expectedVersion, true)
+}
+
+// TrashMany trashes the live items among ids with a single UPDATE, then puts what it returns back in the
+// order the ids were given.
+func (s *SQLClothingRepository) TrashMany(ctx context.Context, userId string, ids []string) ([]domain.Clothing, error) {
+	unique, err := uniqueIds(userId, ids)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(unique) == 0 {
+		return []domain.Clothing{}, nil
+	}
+
+	args := []any{trashedNow().Unix(), userId}
+
+	for _, id := range unique {
+		args = append(args, id)
+	}
+
+	query := "UPDATE clothing SET deleted_at = ?, version = version + 1 WHERE user_id = ? AND id IN (" +
+		strings.TrimSuffix(strings.Repeat("?, ", len(unique)), ", ") + ")" + liveOnly + " RETURNING " + clothingColumns
+
+	items, err := s.query(ctx, query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(items, func(a, b domain.Clothing) int {
+		return slices.Index(unique, a.Id) - slices.Index(unique, b.Id)
+	})
+
+	return items, nil
+}
+
+func (s *SQLClothingRepository) Restore(ctx context.Context, userId, id string, expectedVersion int64) (domain.Clothing, error) {
+	return s.moveTrash(ctx, userId, id, expectedVersion, false)
+}
+
+// moveTrash moves a live item into the trash when trash is true, or a trashed item out of it when false.
+func (s *SQLClothingRepository) moveTrash(ctx context.Context, userId, id string, expectedVersion int64, trash bool) (domain.Clothing, error) {
+	if err := validateTrashArgs(userId, id); err != nil {
+		return domain.Clothing{}, err
+	}
+
+	query := "UPDATE clothing SET deleted_at = NULL, version = version + 1 WHERE user_id = ? AND id = ? AND deleted_at IS NOT NULL"
+	args := []any{userId, id}
+
+	if trash {
+		query = "UPDATE clothing SET deleted_at = ?, version = version + 1 WHERE user_id = ? AND id = ?" + liveOnly
+		args = append([]any{trashedNow().Unix()}, args...)
+	}
+
+	if expectedVersion != 0 {
+		query += " AND version = ?"
+		args = append(args, expectedVersion)
+	}
+
+	item, err := scanClothing(s.db.QueryRowContext(ctx, s.dialect.rebind(query+" RETURNING "+clothingColumns), args...))
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Clothing{}, s.missingOrStale(ctx, userId, id, expectedVersion, !trash)
+	}
+
+	if err != nil {
+		return domain.Clothing{}, fmt.Errorf("failed to update clothing: %w", err)
+	}
+
+	return item, nil
+}
+
+func (s *SQLClothingRepository) PurgeTrash(ctx context.Context, userId string, trashedBefore time.Time) ([]string, error) {
+	if strings.TrimSpace(userId) == "" {
+		return nil, newValidationError("User ID must not be empty or whitespace")
+	}
+
+	items, err := s.query(ctx, "DELETE FROM clothing WHERE user_id = ? AND deleted_at <= ? RETURNING "+clothingColumns, userId, trashedBefore.Unix())
+
+	if err != nil {
+		return nil, err
+	}
+
+	purged := make([]string, len(items))
+
+	for i, item := range items {
+		purged[i] = item.Id
+	}
+
+	return purged, nil
+}
+
+func (s *SQLClothingRepository) PurgeExpiredTrash(ctx context.Context, trashedBefore time.Time) ([]domain.Clothing, error) {
+	return s.query(ctx, "DELETE FROM clothing WHERE deleted_at <= ? RETURNING "+clothingColumns, trashedBefore.Unix())
+}
+
+func (s *SQLClothingRepository) RecordWear(ctx context.Context, userId, id string, wornAt time.Time) (domain.Clothing, error) {
+	wear, err := validateWear(userId, id, wornAt)
+
+	if err != nil {
+		return domain.Clothing{}, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+
+	if err != nil {
+		return domain.Clothing{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer tx.Rollback()
+
+	worn := wear.WornAt.Unix()
+	query := "UPDATE clothing SET wear_count = wear_count + 1, " +
+		"last_worn_at = CASE WHEN last_worn_at IS NULL OR last_worn_at < ? THEN ? ELSE last_worn_at END, " +
+		"version = version + 1 WHERE user_id = ? AND id = ?" + liveOnly + " RETURNING " + clothingColumns
+
+	item, err := scanClothing(tx.QueryRowContext(ctx, s.dialect.rebind(query), worn, worn, userId, id))
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Clothing{}, newNotFoundError("No item exists for id %s", id)
+	}
+
+	if err != nil {
+		return domain.Clothing{}, fmt.Errorf("failed to record wear: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, s.dialect.rebind("INSERT INTO clothing_wears (user_id, item_id, id, worn_at, recorded_at) VALUES (?, ?, ?, ?, ?)"),
+		userId, id, wearKey(id, wear), worn, wear.RecordedAt.Unix())
+
+	if err != nil {
+		return domain.Clothing{}, fmt.Errorf("failed to insert wear: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return domain.Clothing{}, fmt.Errorf("failed to commit wear: %w", err)
+	}
+
+	return item, nil
+}
+
+func (s *SQLClothingRepository) ListWears(ctx context.Context, userId, id string) ([]domain.Wear, error) {
+	if err := validateTrashArgs(userId, id); err != nil {
+		return []domain.Wear{}, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, s.dialect.rebind(
+		"SELECT worn_at, recorded_at FROM clothing_wears WHERE user_id = ? AND item_id = ? ORDER BY "+s.dialect.binary("id")),
+		userId, id)
+
+	if err != nil {
+		return []domain.Wear{}, fmt.Errorf("failed to query wears: %w", err)
+	}
+
+	defer rows.Close()
+
+	wears := []domain.Wear{}
+
+	for rows.Next() {
+		var wornAt, recordedAt int64
+
+		if err := rows.Scan(&wornAt, &recordedAt); err != nil {
+			return []domain.Wear{}, fmt.Errorf("failed to read wear row: %w", err)
+		}
+
+		wears = append(wears, domain.Wear{WornAt: time.Unix(wornAt, 0).UTC(), RecordedAt: time.Unix(recordedAt, 0).UTC()})
+	}
+
+	if err := rows.Err(); err != nil {
+		return []domain.Wear{}, fmt.Errorf("failed to query wears: %w", err)
+	}
+
+	return wears, nil
+}
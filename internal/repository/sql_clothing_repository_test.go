@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"slices"
+	"testing"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	_ "modernc.org/sqlite"
+)
+
+// setupSQLiteDatabase returns a migrated in-memory database. It is limited to one connection because
+// every SQLite connection to :memory: opens a separate, empty database.
+func setupSQLiteDatabase(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open SQLite database: %v", err)
+	}
+
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	if err := MigrateSQLDatabase(context.Background(), db, SQLiteDialect); err != nil {
+		t.Fatalf("Failed to migrate SQLite database: %v", err)
+	}
+
+	return db
+}
+
+func setupPostgresDatabase(t *testing.T) *sql.DB {
+	t.Helper()
+
+	dsn := os.Getenv("POSTGRES_DSN")
+	if dsn == "" {
+		t.Fatal("ERROR: POSTGRES_DSN environment variable not set. Please set it in .env_test or your shell.")
+	}
+
+	db, err := sql.Open("pgx", dsn)
+	if err != nil {
+		t.Fatalf("Failed to open PostgreSQL database: %v", err)
+	}
+
+	t.Cleanup(func() {
+		db.Exec("DELETE FROM clothing")
+		db.Exec("DELETE FROM catalog")
+		db.Close()
+	})
+
+	if err := MigrateSQLDatabase(context.Background(), db, PostgresDialect); err != nil {
+		t.Fatalf("Failed to migrate PostgreSQL database: %v", err)
+	}
+
+	return db
+}
+
+func TestSQLiteClothingRepository(t *testing.T) {
+	testSQLClothingRepository(t, SQLiteDialect, setupSQLiteDatabase)
+}
+
+func TestPostgresClothingRepository(t *testing.T) {
+	testSQLClothingRepository(t, PostgresDialect, setupPostgresDatabase)
+}
+
+func TestSQLDialectRebind(t *testing.T) {
+	t.Run("Given PostgreSQL, should number the placeholders", func(t *testing.T) {
+		got := PostgresDialect.rebind("SELECT 1 WHERE a = ? AND b = ?")
+
+		if got != "SELECT 1 WHERE a = $1 AND b = $2" {
+			t.Errorf("Expected numbered placeholders, got %s", got)
+		}
+	})
+
+	t.Run("Given SQLite, should leave the query unchanged", func(t *testing.T) {
+		got := SQLiteDialect.rebind("SELECT 1 WHERE a = ?")
+
+		if got != "SELECT 1 WHERE a = ?" {
+			t.Errorf("Expected the query unchanged, got %s", got)
+		}
+	})
+}
+
+func TestMigrateSQLDatabase(t *testing.T) {
+	t.Run("Given an already migrated database, should apply nothing twice", func(t *testing.T) {
+		db := setupSQLiteDatabase(t)
+
+		if err := MigrateSQLDatabase(context.Background(), db, SQLiteDialect); err != nil {
+			t.Fatalf("Expected migrating twice to succeed, got %v", err)
+		}
+
+		var count int
+		if err := db.QueryRow("SELECT COUNT(*) FROM schema_migrations").Scan(&count); err != nil {
+			t.Fatalf("Failed to count migrations: %v", err)
+		}
+
+		names, _ := migrationFiles.ReadDir("migrations")
+
+		if count != len(names) {
+			t.Errorf("Expected %d recorded migrations, got %d", len(names), count)
+		}
+	})
+}
+
+func testSQLClothingRepository(t *testing.T, dialect SQLDialect, setup func(t *testing.T) *sql.DB) {
+	t.Run("Given a nil db or unknown dialect, constructor should return an error", func(t *testing.T) {
+		if _, err := NewSQLClothingRepository(nil, dialect); err == nil {
+			t.Errorf("Expected an error for a nil db")
+		}
+
+		if _, err := NewSQLClothingRepository(&sql.DB{}, SQLDialect("oracle")); err == nil {
+			t.Errorf("Expected an error for an unknown dialect")
+		}
+	})
+
+	t.Run("Given registered catalog values, should de-duplicate them case-insensitively", func(t *testing.T) {
+		catalog, err := NewSQLCatalogRepository(setup(t), dialect)
+
+		if err != nil {
+			t.Fatalf("Expected no err on NewSQLCatalogRepository, got %v", err)
+		}
+
+		for _, value := range []string{"XYZ", "xyz", "Abc"} {
+			if err := catalog.Register(context.Background(), CatalogBrands, value); err != nil {
+				t.Fatalf("Expected no error registering %q, got %v", value, err)
+			}
+		}
+
+		values, err := catalog.List(context.Background(), CatalogBrands)
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if !slices.Equal(values, []string{"Abc", "XYZ"}) {
+			t.Errorf("Expected [Abc XYZ], got %v", values)
+		}
+	})
+}
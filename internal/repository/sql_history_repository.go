@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"clothes_management/internal/domain"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// SQLHistoryRepository stores history entries in the clothing_history table created by
+// MigrateSQLDatabase, with each entry's changes as a JSON array.
+type SQLHistoryRepository struct {
+	db      *sql.DB
+	dialect SQLDialect
+}
+
+func NewSQLHistoryRepository(db *sql.DB, dialect SQLDialect) (*SQLHistoryRepository, error) {
+	if db == nil {
+		return nil, fmt.Errorf("db should not be nil")
+	}
+
+	if _, ok := ParseSQLDialect(string(dialect)); !ok {
+		return nil, fmt.Errorf("unsupported SQL dialect %q", dialect)
+	}
+
+	return &SQLHistoryRepository{
+		db:      db,
+		dialect: dialect,
+	}, nil
+}
+
+func (s *SQLHistoryRepository) Append(ctx context.Context, entries ...domain.HistoryEntry) error {
+	if err := validateHistoryEntries(entries); err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer tx.Rollback()
+
+	query := s.dialect.rebind("INSERT INTO clothing_history (user_id, item_id, id, action, acted_by, recorded_at, changes) VALUES (?, ?, ?, ?, ?, ?, ?)")
+
+	for _, entry := range entries {
+		if entry.Changes == nil {
+			entry.Changes = []domain.FieldChange{}
+		}
+
+		changes, err := json.Marshal(entry.Changes)
+
+		if err != nil {
+			return fmt.Errorf("failed to marshal history changes: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, query, entry.UserId, entry.ItemId, historyKey(entry),
+			string(entry.Action), entry.ActedBy, entry.Timestamp.UnixNano(), string(changes))
+
+		if err != nil {
+			return fmt.Errorf("failed to insert history entry: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit history entries: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SQLHistoryRepository) List(ctx context.Context, userId, itemId string) ([]domain.HistoryEntry, error) {
+	if err := validateHistoryQuery(userId, itemId); err != nil {
+		return []domain.HistoryEntry{}, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, s.dialect.rebind(
+		"SELECT action, acted_by, recorded_at, changes FROM clothing_history WHERE user_id = ? AND item_id = ? ORDER BY "+s.dialect.binary("id")),
+		userId, itemId)
+
+	if err != nil {
+		return []domain.HistoryEntry{}, fmt.Errorf("failed to query history: %w", err)
+	}
+
+	defer rows.Close()
+
+	entries := []domain.HistoryEntry{}
+
+	for rows.Next() {
+		entry := domain.HistoryEntry{UserId: userId, ItemId: itemId}
+
+		var action, changes string
+		var recordedAt int64
+
+		if err := rows.Scan(&action, &entry.ActedBy, &recordedAt, &changes); err != nil {
+			return []domain.HistoryEntry{}, fmt.Errorf("failed to read history row: %w", err)
+		}
+
+		if err := json.Unmarshal([]byte(changes), &entry.Changes); err != nil {
+			return []domain.HistoryEntry{}, fmt.Errorf("failed to unmarshal history changes: %w", err)
+		}
+
+		entry.Action = domain.HistoryAction(action)
+		entry.Timestamp = time.Unix(0, recordedAt).UTC()
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return []domain.HistoryEntry{}, fmt.Errorf("failed to query history: %w", err)
+	}
+
+	return normaliseHistory(entries), nil
+}
@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// SQLDialect selects the SQL differences between the supported databases.
+type SQLDialect string
+
+const (
+	SQLiteDialect   SQLDialect = "sqlite"
+	PostgresDialect SQLDialect = "postgres"
+)
+
+// ParseSQLDialect returns the SQLDialect named by s, or false if it is not supported.
+func ParseSQLDialect(s string) (SQLDialect, bool) {
+	switch dialect := SQLDialect(s); dialect {
+	case SQLiteDialect, PostgresDialect:
+		return dialect, true
+	default:
+		return "", false
+	}
+}
+
+// rebind rewrites the ? placeholders used throughout this package to $1, $2, ... for PostgreSQL.
+func (d SQLDialect) rebind(query string) string {
+	if d != PostgresDialect {
+		return query
+	}
+
+	var b strings.Builder
+	n := 0
+
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// binary makes comparisons and ordering on column byte-wise, matching the order DynamoDB and the
+// in-memory repository use for ids. SQLite's default collation already is.
+func (d SQLDialect) binary(column string) string {
+	if d == PostgresDialect {
+		return column + ` COLLATE "C"`
+	}
+
+	return column
+}
+
+// jsonArrayContains is a condition that the JSON array of strings in column contains the string bound to
+// its single placeholder.
+func (d SQLDialect) jsonArrayContains(column string) string {
+	if d == PostgresDialect {
+		return "EXISTS (SELECT 1 FROM json_array_elements_text(" + column + "::json) AS element WHERE element = ?)"
+	}
+
+	return "EXISTS (SELECT 1 FROM json_each(" + column + ") WHERE json_each.value = ?)"
+}
+
+// migrationFiles are applied in file name order. Each is named <version>_<description>.sql and holds
+// statements that each end with a semicolon at the end of a line.
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// MigrateSQLDatabase applies any embedded migrations the database has not yet recorded in
+// schema_migrations, each in its own transaction.
+func MigrateSQLDatabase(ctx context.Context, db *sql.DB, dialect SQLDialect) error {
+	if _, err := db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT PRIMARY KEY)"); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	applied := map[int64]bool{}
+
+	rows, err := db.QueryContext(ctx, "SELECT version FROM schema_migrations")
+
+	if err != nil {
+		return fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+
+	for rows.Next() {
+		var version int64
+
+		if err := rows.Scan(&version); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to read schema_migrations: %w", err)
+		}
+
+		applied[version] = true
+	}
+
+	if err := rows.Close(); err != nil {
+		return fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+
+	names, err := fs.Glob(migrationFiles, "migrations/*.sql")
+
+	if err != nil {
+		return fmt.Errorf("failed to list migrations: %w", err)
+	}
+
+	for _, name := range names {
+		prefix, _, _ := strings.Cut(path.Base(name), "_")
+		version, err := strconv.ParseInt(prefix, 10, 64)
+
+		if err != nil {
+			return fmt.Errorf("migration %s does not start with a version number", name)
+		}
+
+		if applied[version] {
+			continue
+		}
+
+		contents, err := migrationFiles.ReadFile(name)
+
+		if err != nil {
+			return fmt.Errorf("failed to read migration %s: %w", name, err)
+		}
+
+		if err := applyMigration(ctx, db, dialect, version, string(contents)); err != nil {
+			return fmt.Errorf("failed to apply migration %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func applyMigration(ctx context.Context, db *sql.DB, dialect SQLDialect, version int64, contents string) error {
+	tx, err := db.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	// Statements are run one at a time because PostgreSQL will not prepare several in one call.
+	for _, statement := range strings.Split(contents, ";\n") {
+		if strings.TrimSpace(statement) == "" {
+			continue
+		}
+
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, dialect.rebind("INSERT INTO schema_migrations (version) VALUES (?)"), version); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"clothes_management/internal/domain"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// SQLOutfitRepository stores outfits in the outfits table created by MigrateSQLDatabase, with each
+// outfit's item ids as a JSON array.
+type SQLOutfitRepository struct {
+	db      *sql.DB
+	dialect SQLDialect
+}
+
+func NewSQLOutfitRepository(db *sql.DB, dialect SQLDialect) (*SQLOutfitRepository, error) {
+	if db == nil {
+		return nil, fmt.Errorf("db should not be nil")
+	}
+
+	if _, ok := ParseSQLDialect(string(dialect)); !ok {
+		return nil, fmt.Errorf("unsupported SQL dialect %q", dialect)
+	}
+
+	return &SQLOutfitRepository{
+		db:      db,
+		dialect: dialect,
+	}, nil
+}
+
+func scanOutfit(row rowScanner) (domain.Outfit, error) {
+	var outfit domain.Outfit
+	var itemIds string
+
+	if err := row.Scan(&outfit.UserId, &outfit.Id, &outfit.Name, &outfit.Occasion, &itemIds); err != nil {
+		return domain.Outfit{}, err
+	}
+
+	if err := json.Unmarshal([]byte(itemIds), &outfit.ItemIds); err != nil {
+		return domain.Outfit{}, fmt.Errorf("failed to unmarshal outfit item ids: %w", err)
+	}
+
+	return outfit, nil
+}
+
+func (s *SQLOutfitRepository) Save(ctx context.Context, userId string, outfit domain.Outfit) (domain.Outfit, error) {
+	if err := validateOutfitWrite(userId, outfit, false); err != nil {
+		return domain.Outfit{}, err
+	}
+
+	outfit.Id = uuid.New().String()
+	outfit.UserId = userId
+
+	itemIds, err := json.Marshal(outfit.ItemIds)
+
+	if err != nil {
+		return domain.Outfit{}, fmt.Errorf("failed to marshal outfit item ids: %w", err)
+	}
+
+	_, err = s.db.ExecContext(ctx, s.dialect.rebind("INSERT INTO outfits (user_id, id, name, occasion, item_ids) VALUES (?, ?, ?, ?, ?)"),
+		outfit.UserId, outfit.Id, outfit.Name, outfit.Occasion, string(itemIds))
+
+	if err != nil {
+		return domain.Outfit{}, fmt.Errorf("failed to insert outfit: %w", err)
+	}
+
+	return outfit, nil
+}
+
+func (s *SQLOutfitRepository) GetAll(ctx context.Context, userId string) ([]domain.Outfit, error) {
+	if strings.TrimSpace(userId) == "" {
+		return []domain.Outfit{}, newValidationError("User ID must not be empty or whitespace")
+	}
+
+	rows, err := s.db.QueryContext(ctx, s.dialect.rebind("SELECT user_id, id, name, occasion, item_ids FROM outfits WHERE user_id = ?"), userId)
+
+	if err != nil {
+		return []domain.Outfit{}, fmt.Errorf("failed to query outfits: %w", err)
+	}
+
+	defer rows.Close()
+
+	outfits := []domain.Outfit{}
+
+	for rows.Next() {
+		outfit, err := scanOutfit(rows)
+
+		if err != nil {
+			return []domain.Outfit{}, fmt.Errorf("failed to read outfit row: %w", err)
+		}
+
+		outfits = append(outfits, outfit)
+	}
+
+	if err := rows.Err(); err != nil {
+		return []domain.Outfit{}, fmt.Errorf("failed to query outfits: %w", err)
+	}
+
+	// Sorted here rather than in SQL, so names compare exactly as they do in the other backends.
+	sortOutfits(outfits)
+
+	return outfits, nil
+}
+
+func (s *SQLOutfitRepository) GetById(ctx context.Context, userId, id string) (domain.Outfit, error) {
+	if strings.TrimSpace(userId) == "" {
+		return domain.Outfit{}, newValidationError("User ID must not be empty or whitespace")
+	}
+
+	if strings.TrimSpace(id) == "" {
+		return domain.Outfit{}, newValidationError("ID must not be empty or whitespace")
+	}
+
+	outfit, err := scanOutfit(s.db.QueryRowContext(ctx, s.dialect.rebind(
+		"SELECT user_id, id, name, occasion, item_ids FROM outfits WHERE user_id = ? AND id = ?"), userId, id))
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Outfit{}, newNotFoundError("No outfit exists for id %s", id)
+	}
+
+	if err != nil {
+		return domain.Outfit{}, fmt.Errorf("failed to get outfit: %w", err)
+	}
+
+	return outfit, nil
+}
+
+func (s *SQLOutfitRepository) Update(ctx context.Context, userId string, outfit domain.Outfit) (domain.Outfit, error) {
+	if err := validateOutfitWrite(userId, outfit, true); err != nil {
+		return domain.Outfit{}, err
+	}
+
+	itemIds, err := json.Marshal(outfit.ItemIds)
+
+	if err != nil {
+		return domain.Outfit{}, fmt.Errorf("failed to marshal outfit item ids: %w", err)
+	}
+
+	result, err := s.db.ExecContext(ctx, s.dialect.rebind("UPDATE outfits SET name = ?, occasion = ?, item_ids = ? WHERE user_id = ? AND id = ?"),
+		outfit.Name, outfit.Occasion, string(itemIds), userId, outfit.Id)
+
+	if err != nil {
+		return domain.Outfit{}, fmt.Errorf("failed to update outfit: %w", err)
+	}
+
+	if updated, err := result.RowsAffected(); err == nil && updated == 0 {
+		return domain.Outfit{}, newNotFoundError("No outfit exists for id %s", outfit.Id)
+	}
+
+	outfit.UserId = userId
+
+	return outfit, nil
+}
+
+func (s *SQLOutfitRepository) Delete(ctx context.Context, userId, id string) error {
+	if strings.TrimSpace(userId) == "" {
+		return newValidationError("User ID must not be empty or whitespace")
+	}
+
+	if strings.TrimSpace(id) == "" {
+		return newValidationError("ID cannot be empty or whitespace")
+	}
+
+	result, err := s.db.ExecContext(ctx, s.dialect.rebind("DELETE FROM outfits WHERE user_id = ? AND id = ?"), userId, id)
+
+	if err != nil {
+		return fmt.Errorf("failed to delete outfit: %w", err)
+	}
+
+	if deleted, err := result.RowsAffected(); err == nil && deleted == 0 {
+		return newNotFoundError("Outfit with id %s does not exist", id)
+	}
+
+	return nil
+}
+
+// ListUsing filters the user's outfits in process, as the item ids are only stored as JSON.
+func (s *SQLOutfitRepository) ListUsing(ctx context.Context, userId, itemId string) ([]domain.Outfit, error) {
+	if strings.TrimSpace(itemId) == "" {
+		return []domain.Outfit{}, newValidationError("Item ID must not be empty or whitespace")
+	}
+
+	outfits, err := s.GetAll(ctx, userId)
+
+	if err != nil {
+		return []domain.Outfit{}, err
+	}
+
+	return outfitsUsing(outfits, itemId), nil
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"clothes_management/internal/domain"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// SQLSettingsRepository stores settings in the user_settings table created by MigrateSQLDatabase, with a
+// row for each user who has saved any.
+type SQLSettingsRepository struct {
+	db      *sql.DB
+	dialect SQLDialect
+}
+
+func NewSQLSettingsRepository(db *sql.DB, dialect SQLDialect) (*SQLSettingsRepository, error) {
+	if db == nil {
+		return nil, fmt.Errorf("db should not be nil")
+	}
+
+	if _, ok := ParseSQLDialect(string(dialect)); !ok {
+		return nil, fmt.Errorf("unsupported SQL dialect %q", dialect)
+	}
+
+	return &SQLSettingsRepository{
+		db:      db,
+		dialect: dialect,
+	}, nil
+}
+
+func (s *SQLSettingsRepository) Get(ctx context.Context, userId string) (domain.Settings, error) {
+	if strings.TrimSpace(userId) == "" {
+		return domain.Settings{}, newValidationError("User ID must not be empty or whitespace")
+	}
+
+	settings := domain.Settings{UserId: userId}
+
+	err := s.db.QueryRowContext(ctx, s.dialect.rebind("SELECT hemisphere FROM user_settings WHERE user_id = ?"), userId).Scan(&settings.Hemisphere)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.DefaultSettings(userId), nil
+	}
+
+	if err != nil {
+		return domain.Settings{}, fmt.Errorf("failed to get settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+func (s *SQLSettingsRepository) Save(ctx context.Context, userId string, settings domain.Settings) (domain.Settings, error) {
+	if err := validateSettingsWrite(userId, settings); err != nil {
+		return domain.Settings{}, err
+	}
+
+	settings.UserId = userId
+
+	_, err := s.db.ExecContext(ctx, s.dialect.rebind(
+		"INSERT INTO user_settings (user_id, hemisphere) VALUES (?, ?) ON CONFLICT (user_id) DO UPDATE SET hemisphere = excluded.hemisphere"),
+		settings.UserId, settings.Hemisphere)
+
+	if err != nil {
+		return domain.Settings{}, fmt.Errorf("failed to save settings: %w", err)
+	}
+
+	return settings, nil
+}